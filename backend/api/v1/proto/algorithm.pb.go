@@ -24,18 +24,32 @@ const (
 )
 
 type ExecuteRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	AlgorithmId    string                 `protobuf:"bytes,1,opt,name=algorithm_id,json=algorithmId,proto3" json:"algorithm_id,omitempty"`
-	Mode           string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
-	IsAsync        bool                   `protobuf:"varint,3,opt,name=is_async,json=isAsync,proto3" json:"is_async,omitempty"`
-	Params         map[string]string      `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	InputSource    *InputSource           `protobuf:"bytes,5,opt,name=input_source,json=inputSource,proto3" json:"input_source,omitempty"`
-	WebhookUrl     string                 `protobuf:"bytes,6,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
-	ForceRefresh   bool                   `protobuf:"varint,7,opt,name=force_refresh,json=forceRefresh,proto3" json:"force_refresh,omitempty"`
-	ResourceConfig *ResourceConfig        `protobuf:"bytes,8,opt,name=resource_config,json=resourceConfig,proto3" json:"resource_config,omitempty"`
-	TimeoutSeconds int32                  `protobuf:"varint,9,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AlgorithmId string                 `protobuf:"bytes,1,opt,name=algorithm_id,json=algorithmId,proto3" json:"algorithm_id,omitempty"`
+	// 执行模式：sync（等待结果）、async（后台执行并回调 webhook_url）、fire_and_forget（后台执行不回调）
+	// 为空时按 is_async 决定；batch、streaming 为旧版本的取值，同样按 is_async 处理
+	Mode string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	// 已废弃，使用 mode
+	//
+	// Deprecated: Marked as deprecated in proto/algorithm.proto.
+	IsAsync        bool              `protobuf:"varint,3,opt,name=is_async,json=isAsync,proto3" json:"is_async,omitempty"`
+	Params         map[string]string `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	InputSource    *InputSource      `protobuf:"bytes,5,opt,name=input_source,json=inputSource,proto3" json:"input_source,omitempty"`
+	WebhookUrl     string            `protobuf:"bytes,6,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
+	ForceRefresh   bool              `protobuf:"varint,7,opt,name=force_refresh,json=forceRefresh,proto3" json:"force_refresh,omitempty"`
+	ResourceConfig *ResourceConfig   `protobuf:"bytes,8,opt,name=resource_config,json=resourceConfig,proto3" json:"resource_config,omitempty"`
+	TimeoutSeconds int32             `protobuf:"varint,9,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// 不读取也不写入结果缓存（force_refresh 只跳过读取，执行后仍会更新缓存）
+	NoCache bool `protobuf:"varint,10,opt,name=no_cache,json=noCache,proto3" json:"no_cache,omitempty"`
+	// 注入容器的密钥：环境变量名 → 服务端配置的密钥名（docker.secrets / docker.secrets_file）。
+	// 任务记录只保存密钥名，密钥值不会出现在保存的日志中
+	Secrets map[string]string `protobuf:"bytes,11,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 多个输入文件，与 input_source 一起下载到任务输入目录，文件名不能重复
+	InputSources []*InputSource `protobuf:"bytes,12,rep,name=input_sources,json=inputSources,proto3" json:"input_sources,omitempty"`
+	// 按标签运行当前的运行镜像，不使用版本固定的镜像摘要（默认按摘要运行，保证结果可复现）
+	UseImageTag   bool `protobuf:"varint,13,opt,name=use_image_tag,json=useImageTag,proto3" json:"use_image_tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ExecuteRequest) Reset() {
@@ -82,6 +96,7 @@ func (x *ExecuteRequest) GetMode() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in proto/algorithm.proto.
 func (x *ExecuteRequest) GetIsAsync() bool {
 	if x != nil {
 		return x.IsAsync
@@ -131,10 +146,41 @@ func (x *ExecuteRequest) GetTimeoutSeconds() int32 {
 	return 0
 }
 
+func (x *ExecuteRequest) GetNoCache() bool {
+	if x != nil {
+		return x.NoCache
+	}
+	return false
+}
+
+func (x *ExecuteRequest) GetSecrets() map[string]string {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+func (x *ExecuteRequest) GetInputSources() []*InputSource {
+	if x != nil {
+		return x.InputSources
+	}
+	return nil
+}
+
+func (x *ExecuteRequest) GetUseImageTag() bool {
+	if x != nil {
+		return x.UseImageTag
+	}
+	return false
+}
+
 type InputSource struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// MinIO 对象路径或完整 URL
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// 预置数据 ID，设置后忽略 url
+	PresetDataId  string `protobuf:"bytes,3,opt,name=preset_data_id,json=presetDataId,proto3" json:"preset_data_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -183,6 +229,13 @@ func (x *InputSource) GetUrl() string {
 	return ""
 }
 
+func (x *InputSource) GetPresetDataId() string {
+	if x != nil {
+		return x.PresetDataId
+	}
+	return ""
+}
+
 type ResourceConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CpuLimit      float32                `protobuf:"fixed32,1,opt,name=cpu_limit,json=cpuLimit,proto3" json:"cpu_limit,omitempty"`
@@ -236,11 +289,14 @@ func (x *ResourceConfig) GetMemoryLimit() string {
 }
 
 type ExecuteResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	ResultUrl     string                 `protobuf:"bytes,3,opt,name=result_url,json=resultUrl,proto3" json:"result_url,omitempty"`
-	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	JobId     string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status    string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ResultUrl string                 `protobuf:"bytes,3,opt,name=result_url,json=resultUrl,proto3" json:"result_url,omitempty"`
+	Message   string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Failure   *FailureDetail         `protobuf:"bytes,5,opt,name=failure,proto3" json:"failure,omitempty"`
+	// 结果来自缓存，job_id 为产生该结果的任务
+	Cached        bool `protobuf:"varint,6,opt,name=cached,proto3" json:"cached,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -303,6 +359,396 @@ func (x *ExecuteResponse) GetMessage() string {
 	return ""
 }
 
+func (x *ExecuteResponse) GetFailure() *FailureDetail {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+func (x *ExecuteResponse) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+// FailureDetail 任务失败的结构化信息，成功时为空
+type FailureDetail struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// validation / pull / timeout / oom / nonzero_exit / infra
+	Category      string `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	ExitCode      int32  `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	LogTail       string `protobuf:"bytes,3,opt,name=log_tail,json=logTail,proto3" json:"log_tail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailureDetail) Reset() {
+	*x = FailureDetail{}
+	mi := &file_proto_algorithm_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailureDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailureDetail) ProtoMessage() {}
+
+func (x *FailureDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_algorithm_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailureDetail.ProtoReflect.Descriptor instead.
+func (*FailureDetail) Descriptor() ([]byte, []int) {
+	return file_proto_algorithm_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FailureDetail) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *FailureDetail) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *FailureDetail) GetLogTail() string {
+	if x != nil {
+		return x.LogTail
+	}
+	return ""
+}
+
+type RetryJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryJobRequest) Reset() {
+	*x = RetryJobRequest{}
+	mi := &file_proto_algorithm_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryJobRequest) ProtoMessage() {}
+
+func (x *RetryJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_algorithm_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryJobRequest.ProtoReflect.Descriptor instead.
+func (*RetryJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_algorithm_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RetryJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type RetryJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	RetriedFrom   string                 `protobuf:"bytes,2,opt,name=retried_from,json=retriedFrom,proto3" json:"retried_from,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryJobResponse) Reset() {
+	*x = RetryJobResponse{}
+	mi := &file_proto_algorithm_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryJobResponse) ProtoMessage() {}
+
+func (x *RetryJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_algorithm_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryJobResponse.ProtoReflect.Descriptor instead.
+func (*RetryJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_algorithm_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RetryJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *RetryJobResponse) GetRetriedFrom() string {
+	if x != nil {
+		return x.RetriedFrom
+	}
+	return ""
+}
+
+func (x *RetryJobResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RetryJobResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CancelJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	mi := &file_proto_algorithm_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_algorithm_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_algorithm_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type CancelJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobResponse) Reset() {
+	*x = CancelJobResponse{}
+	mi := &file_proto_algorithm_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobResponse) ProtoMessage() {}
+
+func (x *CancelJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_algorithm_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobResponse.ProtoReflect.Descriptor instead.
+func (*CancelJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_algorithm_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *CancelJobResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CancelJobResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StreamJobLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamJobLogsRequest) Reset() {
+	*x = StreamJobLogsRequest{}
+	mi := &file_proto_algorithm_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamJobLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamJobLogsRequest) ProtoMessage() {}
+
+func (x *StreamJobLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_algorithm_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamJobLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamJobLogsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_algorithm_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StreamJobLogsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type JobLogChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 日志内容，由完整的行组成
+	Data string `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// container（运行中的容器）或 stored（已保存的日志）
+	Source        string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobLogChunk) Reset() {
+	*x = JobLogChunk{}
+	mi := &file_proto_algorithm_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobLogChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobLogChunk) ProtoMessage() {}
+
+func (x *JobLogChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_algorithm_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobLogChunk.ProtoReflect.Descriptor instead.
+func (*JobLogChunk) Descriptor() ([]byte, []int) {
+	return file_proto_algorithm_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *JobLogChunk) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *JobLogChunk) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
 type GetJobStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
@@ -312,7 +758,7 @@ type GetJobStatusRequest struct {
 
 func (x *GetJobStatusRequest) Reset() {
 	*x = GetJobStatusRequest{}
-	mi := &file_proto_algorithm_proto_msgTypes[4]
+	mi := &file_proto_algorithm_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -324,7 +770,7 @@ func (x *GetJobStatusRequest) String() string {
 func (*GetJobStatusRequest) ProtoMessage() {}
 
 func (x *GetJobStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_algorithm_proto_msgTypes[4]
+	mi := &file_proto_algorithm_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -337,7 +783,7 @@ func (x *GetJobStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetJobStatusRequest.ProtoReflect.Descriptor instead.
 func (*GetJobStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_algorithm_proto_rawDescGZIP(), []int{4}
+	return file_proto_algorithm_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetJobStatusRequest) GetJobId() string {
@@ -348,20 +794,27 @@ func (x *GetJobStatusRequest) GetJobId() string {
 }
 
 type GetJobStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	ResultUrl     string                 `protobuf:"bytes,3,opt,name=result_url,json=resultUrl,proto3" json:"result_url,omitempty"`
-	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
-	CostTimeMs    int32                  `protobuf:"varint,6,opt,name=cost_time_ms,json=costTimeMs,proto3" json:"cost_time_ms,omitempty"`
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	JobId             string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status            string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ResultUrl         string                 `protobuf:"bytes,3,opt,name=result_url,json=resultUrl,proto3" json:"result_url,omitempty"`
+	StartedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	CostTimeMs        int32                  `protobuf:"varint,6,opt,name=cost_time_ms,json=costTimeMs,proto3" json:"cost_time_ms,omitempty"`
+	ArtifactsExpired  bool                   `protobuf:"varint,7,opt,name=artifacts_expired,json=artifactsExpired,proto3" json:"artifacts_expired,omitempty"`
+	ArtifactsExpireAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=artifacts_expire_at,json=artifactsExpireAt,proto3" json:"artifacts_expire_at,omitempty"`
+	LogUrl            string                 `protobuf:"bytes,9,opt,name=log_url,json=logUrl,proto3" json:"log_url,omitempty"`
+	// 不影响任务结果的问题，如日志上传失败
+	Warning string `protobuf:"bytes,10,opt,name=warning,proto3" json:"warning,omitempty"`
+	// 当前等待执行槽位的任务数（server.max_concurrent_jobs 未限制时为 0）
+	QueueDepth    int32 `protobuf:"varint,11,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetJobStatusResponse) Reset() {
 	*x = GetJobStatusResponse{}
-	mi := &file_proto_algorithm_proto_msgTypes[5]
+	mi := &file_proto_algorithm_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -373,7 +826,7 @@ func (x *GetJobStatusResponse) String() string {
 func (*GetJobStatusResponse) ProtoMessage() {}
 
 func (x *GetJobStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_algorithm_proto_msgTypes[5]
+	mi := &file_proto_algorithm_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -386,7 +839,7 @@ func (x *GetJobStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetJobStatusResponse.ProtoReflect.Descriptor instead.
 func (*GetJobStatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_algorithm_proto_rawDescGZIP(), []int{5}
+	return file_proto_algorithm_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetJobStatusResponse) GetJobId() string {
@@ -431,39 +884,107 @@ func (x *GetJobStatusResponse) GetCostTimeMs() int32 {
 	return 0
 }
 
+func (x *GetJobStatusResponse) GetArtifactsExpired() bool {
+	if x != nil {
+		return x.ArtifactsExpired
+	}
+	return false
+}
+
+func (x *GetJobStatusResponse) GetArtifactsExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArtifactsExpireAt
+	}
+	return nil
+}
+
+func (x *GetJobStatusResponse) GetLogUrl() string {
+	if x != nil {
+		return x.LogUrl
+	}
+	return ""
+}
+
+func (x *GetJobStatusResponse) GetWarning() string {
+	if x != nil {
+		return x.Warning
+	}
+	return ""
+}
+
+func (x *GetJobStatusResponse) GetQueueDepth() int32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
 var File_proto_algorithm_proto protoreflect.FileDescriptor
 
 const file_proto_algorithm_proto_rawDesc = "" +
 	"\n" +
-	"\x15proto/algorithm.proto\x12\x06api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc1\x03\n" +
+	"\x15proto/algorithm.proto\x12\x06api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb9\x05\n" +
 	"\x0eExecuteRequest\x12!\n" +
 	"\falgorithm_id\x18\x01 \x01(\tR\valgorithmId\x12\x12\n" +
-	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x19\n" +
-	"\bis_async\x18\x03 \x01(\bR\aisAsync\x12:\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x1d\n" +
+	"\bis_async\x18\x03 \x01(\bB\x02\x18\x01R\aisAsync\x12:\n" +
 	"\x06params\x18\x04 \x03(\v2\".api.v1.ExecuteRequest.ParamsEntryR\x06params\x126\n" +
 	"\finput_source\x18\x05 \x01(\v2\x13.api.v1.InputSourceR\vinputSource\x12\x1f\n" +
 	"\vwebhook_url\x18\x06 \x01(\tR\n" +
 	"webhookUrl\x12#\n" +
 	"\rforce_refresh\x18\a \x01(\bR\fforceRefresh\x12?\n" +
 	"\x0fresource_config\x18\b \x01(\v2\x16.api.v1.ResourceConfigR\x0eresourceConfig\x12'\n" +
-	"\x0ftimeout_seconds\x18\t \x01(\x05R\x0etimeoutSeconds\x1a9\n" +
+	"\x0ftimeout_seconds\x18\t \x01(\x05R\x0etimeoutSeconds\x12\x19\n" +
+	"\bno_cache\x18\n" +
+	" \x01(\bR\anoCache\x12=\n" +
+	"\asecrets\x18\v \x03(\v2#.api.v1.ExecuteRequest.SecretsEntryR\asecrets\x128\n" +
+	"\rinput_sources\x18\f \x03(\v2\x13.api.v1.InputSourceR\finputSources\x12\"\n" +
+	"\ruse_image_tag\x18\r \x01(\bR\vuseImageTag\x1a9\n" +
 	"\vParamsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"3\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fSecretsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Y\n" +
 	"\vInputSource\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x10\n" +
-	"\x03url\x18\x02 \x01(\tR\x03url\"P\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12$\n" +
+	"\x0epreset_data_id\x18\x03 \x01(\tR\fpresetDataId\"P\n" +
 	"\x0eResourceConfig\x12\x1b\n" +
 	"\tcpu_limit\x18\x01 \x01(\x02R\bcpuLimit\x12!\n" +
-	"\fmemory_limit\x18\x02 \x01(\tR\vmemoryLimit\"y\n" +
+	"\fmemory_limit\x18\x02 \x01(\tR\vmemoryLimit\"\xc2\x01\n" +
 	"\x0fExecuteResponse\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
 	"\n" +
 	"result_url\x18\x03 \x01(\tR\tresultUrl\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage\",\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12/\n" +
+	"\afailure\x18\x05 \x01(\v2\x15.api.v1.FailureDetailR\afailure\x12\x16\n" +
+	"\x06cached\x18\x06 \x01(\bR\x06cached\"c\n" +
+	"\rFailureDetail\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x1b\n" +
+	"\texit_code\x18\x02 \x01(\x05R\bexitCode\x12\x19\n" +
+	"\blog_tail\x18\x03 \x01(\tR\alogTail\"(\n" +
+	"\x0fRetryJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"~\n" +
+	"\x10RetryJobResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12!\n" +
+	"\fretried_from\x18\x02 \x01(\tR\vretriedFrom\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\")\n" +
+	"\x10CancelJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\\\n" +
+	"\x11CancelJobResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"-\n" +
+	"\x14StreamJobLogsRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"9\n" +
+	"\vJobLogChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\tR\x04data\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\",\n" +
 	"\x13GetJobStatusRequest\x12\x15\n" +
-	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xfe\x01\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xcb\x03\n" +
 	"\x14GetJobStatusResponse\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
@@ -474,10 +995,20 @@ const file_proto_algorithm_proto_rawDesc = "" +
 	"\vfinished_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"finishedAt\x12 \n" +
 	"\fcost_time_ms\x18\x06 \x01(\x05R\n" +
-	"costTimeMs2\xf7\x01\n" +
+	"costTimeMs\x12+\n" +
+	"\x11artifacts_expired\x18\a \x01(\bR\x10artifactsExpired\x12J\n" +
+	"\x13artifacts_expire_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x11artifactsExpireAt\x12\x17\n" +
+	"\alog_url\x18\t \x01(\tR\x06logUrl\x12\x18\n" +
+	"\awarning\x18\n" +
+	" \x01(\tR\awarning\x12\x1f\n" +
+	"\vqueue_depth\x18\v \x01(\x05R\n" +
+	"queueDepth2\xba\x04\n" +
 	"\x10AlgorithmService\x12y\n" +
 	"\x10ExecuteAlgorithm\x12\x16.api.v1.ExecuteRequest\x1a\x17.api.v1.ExecuteResponse\"4\x82\xd3\xe4\x93\x02.:\x01*\")/api/v1/algorithms/{algorithm_id}/execute\x12h\n" +
-	"\fGetJobStatus\x12\x1b.api.v1.GetJobStatusRequest\x1a\x1c.api.v1.GetJobStatusResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/jobs/{job_id}B$Z\"algorithm-platform/api/v1/proto;v1b\x06proto3"
+	"\fGetJobStatus\x12\x1b.api.v1.GetJobStatusRequest\x1a\x1c.api.v1.GetJobStatusResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/jobs/{job_id}\x12e\n" +
+	"\bRetryJob\x12\x17.api.v1.RetryJobRequest\x1a\x18.api.v1.RetryJobResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/jobs/{job_id}/retry\x12i\n" +
+	"\tCancelJob\x12\x18.api.v1.CancelJobRequest\x1a\x19.api.v1.CancelJobResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/jobs/{job_id}/cancel\x12o\n" +
+	"\rStreamJobLogs\x12\x1c.api.v1.StreamJobLogsRequest\x1a\x13.api.v1.JobLogChunk\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/jobs/{job_id}/logs/stream0\x01B$Z\"algorithm-platform/api/v1/proto;v1b\x06proto3"
 
 var (
 	file_proto_algorithm_proto_rawDescOnce sync.Once
@@ -491,32 +1022,50 @@ func file_proto_algorithm_proto_rawDescGZIP() []byte {
 	return file_proto_algorithm_proto_rawDescData
 }
 
-var file_proto_algorithm_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_algorithm_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_proto_algorithm_proto_goTypes = []any{
 	(*ExecuteRequest)(nil),        // 0: api.v1.ExecuteRequest
 	(*InputSource)(nil),           // 1: api.v1.InputSource
 	(*ResourceConfig)(nil),        // 2: api.v1.ResourceConfig
 	(*ExecuteResponse)(nil),       // 3: api.v1.ExecuteResponse
-	(*GetJobStatusRequest)(nil),   // 4: api.v1.GetJobStatusRequest
-	(*GetJobStatusResponse)(nil),  // 5: api.v1.GetJobStatusResponse
-	nil,                           // 6: api.v1.ExecuteRequest.ParamsEntry
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*FailureDetail)(nil),         // 4: api.v1.FailureDetail
+	(*RetryJobRequest)(nil),       // 5: api.v1.RetryJobRequest
+	(*RetryJobResponse)(nil),      // 6: api.v1.RetryJobResponse
+	(*CancelJobRequest)(nil),      // 7: api.v1.CancelJobRequest
+	(*CancelJobResponse)(nil),     // 8: api.v1.CancelJobResponse
+	(*StreamJobLogsRequest)(nil),  // 9: api.v1.StreamJobLogsRequest
+	(*JobLogChunk)(nil),           // 10: api.v1.JobLogChunk
+	(*GetJobStatusRequest)(nil),   // 11: api.v1.GetJobStatusRequest
+	(*GetJobStatusResponse)(nil),  // 12: api.v1.GetJobStatusResponse
+	nil,                           // 13: api.v1.ExecuteRequest.ParamsEntry
+	nil,                           // 14: api.v1.ExecuteRequest.SecretsEntry
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
 }
 var file_proto_algorithm_proto_depIdxs = []int32{
-	6, // 0: api.v1.ExecuteRequest.params:type_name -> api.v1.ExecuteRequest.ParamsEntry
-	1, // 1: api.v1.ExecuteRequest.input_source:type_name -> api.v1.InputSource
-	2, // 2: api.v1.ExecuteRequest.resource_config:type_name -> api.v1.ResourceConfig
-	7, // 3: api.v1.GetJobStatusResponse.started_at:type_name -> google.protobuf.Timestamp
-	7, // 4: api.v1.GetJobStatusResponse.finished_at:type_name -> google.protobuf.Timestamp
-	0, // 5: api.v1.AlgorithmService.ExecuteAlgorithm:input_type -> api.v1.ExecuteRequest
-	4, // 6: api.v1.AlgorithmService.GetJobStatus:input_type -> api.v1.GetJobStatusRequest
-	3, // 7: api.v1.AlgorithmService.ExecuteAlgorithm:output_type -> api.v1.ExecuteResponse
-	5, // 8: api.v1.AlgorithmService.GetJobStatus:output_type -> api.v1.GetJobStatusResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	13, // 0: api.v1.ExecuteRequest.params:type_name -> api.v1.ExecuteRequest.ParamsEntry
+	1,  // 1: api.v1.ExecuteRequest.input_source:type_name -> api.v1.InputSource
+	2,  // 2: api.v1.ExecuteRequest.resource_config:type_name -> api.v1.ResourceConfig
+	14, // 3: api.v1.ExecuteRequest.secrets:type_name -> api.v1.ExecuteRequest.SecretsEntry
+	1,  // 4: api.v1.ExecuteRequest.input_sources:type_name -> api.v1.InputSource
+	4,  // 5: api.v1.ExecuteResponse.failure:type_name -> api.v1.FailureDetail
+	15, // 6: api.v1.GetJobStatusResponse.started_at:type_name -> google.protobuf.Timestamp
+	15, // 7: api.v1.GetJobStatusResponse.finished_at:type_name -> google.protobuf.Timestamp
+	15, // 8: api.v1.GetJobStatusResponse.artifacts_expire_at:type_name -> google.protobuf.Timestamp
+	0,  // 9: api.v1.AlgorithmService.ExecuteAlgorithm:input_type -> api.v1.ExecuteRequest
+	11, // 10: api.v1.AlgorithmService.GetJobStatus:input_type -> api.v1.GetJobStatusRequest
+	5,  // 11: api.v1.AlgorithmService.RetryJob:input_type -> api.v1.RetryJobRequest
+	7,  // 12: api.v1.AlgorithmService.CancelJob:input_type -> api.v1.CancelJobRequest
+	9,  // 13: api.v1.AlgorithmService.StreamJobLogs:input_type -> api.v1.StreamJobLogsRequest
+	3,  // 14: api.v1.AlgorithmService.ExecuteAlgorithm:output_type -> api.v1.ExecuteResponse
+	12, // 15: api.v1.AlgorithmService.GetJobStatus:output_type -> api.v1.GetJobStatusResponse
+	6,  // 16: api.v1.AlgorithmService.RetryJob:output_type -> api.v1.RetryJobResponse
+	8,  // 17: api.v1.AlgorithmService.CancelJob:output_type -> api.v1.CancelJobResponse
+	10, // 18: api.v1.AlgorithmService.StreamJobLogs:output_type -> api.v1.JobLogChunk
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_proto_algorithm_proto_init() }
@@ -530,7 +1079,7 @@ func file_proto_algorithm_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_algorithm_proto_rawDesc), len(file_proto_algorithm_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,9 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	AlgorithmService_ExecuteAlgorithm_FullMethodName = "/api.v1.AlgorithmService/ExecuteAlgorithm"
 	AlgorithmService_GetJobStatus_FullMethodName     = "/api.v1.AlgorithmService/GetJobStatus"
+	AlgorithmService_RetryJob_FullMethodName         = "/api.v1.AlgorithmService/RetryJob"
+	AlgorithmService_CancelJob_FullMethodName        = "/api.v1.AlgorithmService/CancelJob"
+	AlgorithmService_StreamJobLogs_FullMethodName    = "/api.v1.AlgorithmService/StreamJobLogs"
 )
 
 // AlgorithmServiceClient is the client API for AlgorithmService service.
@@ -29,6 +32,12 @@ const (
 type AlgorithmServiceClient interface {
 	ExecuteAlgorithm(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error)
 	GetJobStatus(ctx context.Context, in *GetJobStatusRequest, opts ...grpc.CallOption) (*GetJobStatusResponse, error)
+	// RetryJob 按原任务的请求创建新任务并放入后台执行
+	RetryJob(ctx context.Context, in *RetryJobRequest, opts ...grpc.CallOption) (*RetryJobResponse, error)
+	// CancelJob 取消尚未结束的任务，停止正在运行的容器
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*CancelJobResponse, error)
+	// StreamJobLogs 持续输出运行中任务的容器日志，已结束的任务输出保存在 MinIO 中的日志
+	StreamJobLogs(ctx context.Context, in *StreamJobLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JobLogChunk], error)
 }
 
 type algorithmServiceClient struct {
@@ -59,12 +68,57 @@ func (c *algorithmServiceClient) GetJobStatus(ctx context.Context, in *GetJobSta
 	return out, nil
 }
 
+func (c *algorithmServiceClient) RetryJob(ctx context.Context, in *RetryJobRequest, opts ...grpc.CallOption) (*RetryJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetryJobResponse)
+	err := c.cc.Invoke(ctx, AlgorithmService_RetryJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *algorithmServiceClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*CancelJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelJobResponse)
+	err := c.cc.Invoke(ctx, AlgorithmService_CancelJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *algorithmServiceClient) StreamJobLogs(ctx context.Context, in *StreamJobLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JobLogChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AlgorithmService_ServiceDesc.Streams[0], AlgorithmService_StreamJobLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamJobLogsRequest, JobLogChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AlgorithmService_StreamJobLogsClient = grpc.ServerStreamingClient[JobLogChunk]
+
 // AlgorithmServiceServer is the server API for AlgorithmService service.
 // All implementations must embed UnimplementedAlgorithmServiceServer
 // for forward compatibility.
 type AlgorithmServiceServer interface {
 	ExecuteAlgorithm(context.Context, *ExecuteRequest) (*ExecuteResponse, error)
 	GetJobStatus(context.Context, *GetJobStatusRequest) (*GetJobStatusResponse, error)
+	// RetryJob 按原任务的请求创建新任务并放入后台执行
+	RetryJob(context.Context, *RetryJobRequest) (*RetryJobResponse, error)
+	// CancelJob 取消尚未结束的任务，停止正在运行的容器
+	CancelJob(context.Context, *CancelJobRequest) (*CancelJobResponse, error)
+	// StreamJobLogs 持续输出运行中任务的容器日志，已结束的任务输出保存在 MinIO 中的日志
+	StreamJobLogs(*StreamJobLogsRequest, grpc.ServerStreamingServer[JobLogChunk]) error
 	mustEmbedUnimplementedAlgorithmServiceServer()
 }
 
@@ -81,6 +135,15 @@ func (UnimplementedAlgorithmServiceServer) ExecuteAlgorithm(context.Context, *Ex
 func (UnimplementedAlgorithmServiceServer) GetJobStatus(context.Context, *GetJobStatusRequest) (*GetJobStatusResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetJobStatus not implemented")
 }
+func (UnimplementedAlgorithmServiceServer) RetryJob(context.Context, *RetryJobRequest) (*RetryJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RetryJob not implemented")
+}
+func (UnimplementedAlgorithmServiceServer) CancelJob(context.Context, *CancelJobRequest) (*CancelJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (UnimplementedAlgorithmServiceServer) StreamJobLogs(*StreamJobLogsRequest, grpc.ServerStreamingServer[JobLogChunk]) error {
+	return status.Error(codes.Unimplemented, "method StreamJobLogs not implemented")
+}
 func (UnimplementedAlgorithmServiceServer) mustEmbedUnimplementedAlgorithmServiceServer() {}
 func (UnimplementedAlgorithmServiceServer) testEmbeddedByValue()                          {}
 
@@ -138,6 +201,53 @@ func _AlgorithmService_GetJobStatus_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AlgorithmService_RetryJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlgorithmServiceServer).RetryJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlgorithmService_RetryJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlgorithmServiceServer).RetryJob(ctx, req.(*RetryJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlgorithmService_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlgorithmServiceServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlgorithmService_CancelJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlgorithmServiceServer).CancelJob(ctx, req.(*CancelJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlgorithmService_StreamJobLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamJobLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AlgorithmServiceServer).StreamJobLogs(m, &grpc.GenericServerStream[StreamJobLogsRequest, JobLogChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AlgorithmService_StreamJobLogsServer = grpc.ServerStreamingServer[JobLogChunk]
+
 // AlgorithmService_ServiceDesc is the grpc.ServiceDesc for AlgorithmService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,7 +263,21 @@ var AlgorithmService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetJobStatus",
 			Handler:    _AlgorithmService_GetJobStatus_Handler,
 		},
+		{
+			MethodName: "RetryJob",
+			Handler:    _AlgorithmService_RetryJob_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _AlgorithmService_CancelJob_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamJobLogs",
+			Handler:       _AlgorithmService_StreamJobLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/algorithm.proto",
 }
@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -79,18 +80,24 @@ func (Platform) EnumDescriptor() ([]byte, []int) {
 }
 
 type CreateAlgorithmRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Language      string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
-	Platform      Platform               `protobuf:"varint,4,opt,name=platform,proto3,enum=api.v1.Platform" json:"platform,omitempty"`
-	Entrypoint    string                 `protobuf:"bytes,5,opt,name=entrypoint,proto3" json:"entrypoint,omitempty"`
-	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
-	PresetDataId  string                 `protobuf:"bytes,7,opt,name=preset_data_id,proto3" json:"preset_data_id,omitempty"`
-	FileData      []byte                 `protobuf:"bytes,8,opt,name=file_data,proto3" json:"file_data,omitempty"`
-	FileName      string                 `protobuf:"bytes,9,opt,name=file_name,proto3" json:"file_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Language        string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	Platform        Platform               `protobuf:"varint,4,opt,name=platform,proto3,enum=api.v1.Platform" json:"platform,omitempty"`
+	Entrypoint      string                 `protobuf:"bytes,5,opt,name=entrypoint,proto3" json:"entrypoint,omitempty"`
+	Tags            []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	PresetDataId    string                 `protobuf:"bytes,7,opt,name=preset_data_id,proto3" json:"preset_data_id,omitempty"`
+	FileData        []byte                 `protobuf:"bytes,8,opt,name=file_data,proto3" json:"file_data,omitempty"`
+	FileName        string                 `protobuf:"bytes,9,opt,name=file_name,proto3" json:"file_name,omitempty"`
+	DefaultCpuLimit float32                `protobuf:"fixed32,10,opt,name=default_cpu_limit,proto3" json:"default_cpu_limit,omitempty"`
+	DefaultMemoryMb int32                  `protobuf:"varint,11,opt,name=default_memory_mb,proto3" json:"default_memory_mb,omitempty"`
+	// JSON Schema of the execution params; empty means params are not validated
+	ParamsSchema string `protobuf:"bytes,12,opt,name=params_schema,proto3" json:"params_schema,omitempty"`
+	// Finished jobs kept for this algorithm; 0 uses server.job_history_limit, -1 keeps all
+	JobHistoryLimit int32 `protobuf:"varint,13,opt,name=job_history_limit,proto3" json:"job_history_limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *CreateAlgorithmRequest) Reset() {
@@ -186,14 +193,46 @@ func (x *CreateAlgorithmRequest) GetFileName() string {
 	return ""
 }
 
+func (x *CreateAlgorithmRequest) GetDefaultCpuLimit() float32 {
+	if x != nil {
+		return x.DefaultCpuLimit
+	}
+	return 0
+}
+
+func (x *CreateAlgorithmRequest) GetDefaultMemoryMb() int32 {
+	if x != nil {
+		return x.DefaultMemoryMb
+	}
+	return 0
+}
+
+func (x *CreateAlgorithmRequest) GetParamsSchema() string {
+	if x != nil {
+		return x.ParamsSchema
+	}
+	return ""
+}
+
+func (x *CreateAlgorithmRequest) GetJobHistoryLimit() int32 {
+	if x != nil {
+		return x.JobHistoryLimit
+	}
+	return 0
+}
+
 type UpdateAlgorithmRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Tags            []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	DefaultCpuLimit float32                `protobuf:"fixed32,5,opt,name=default_cpu_limit,proto3" json:"default_cpu_limit,omitempty"`
+	DefaultMemoryMb int32                  `protobuf:"varint,6,opt,name=default_memory_mb,proto3" json:"default_memory_mb,omitempty"`
+	ParamsSchema    string                 `protobuf:"bytes,7,opt,name=params_schema,proto3" json:"params_schema,omitempty"`
+	JobHistoryLimit int32                  `protobuf:"varint,8,opt,name=job_history_limit,proto3" json:"job_history_limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateAlgorithmRequest) Reset() {
@@ -254,6 +293,34 @@ func (x *UpdateAlgorithmRequest) GetTags() []string {
 	return nil
 }
 
+func (x *UpdateAlgorithmRequest) GetDefaultCpuLimit() float32 {
+	if x != nil {
+		return x.DefaultCpuLimit
+	}
+	return 0
+}
+
+func (x *UpdateAlgorithmRequest) GetDefaultMemoryMb() int32 {
+	if x != nil {
+		return x.DefaultMemoryMb
+	}
+	return 0
+}
+
+func (x *UpdateAlgorithmRequest) GetParamsSchema() string {
+	if x != nil {
+		return x.ParamsSchema
+	}
+	return ""
+}
+
+func (x *UpdateAlgorithmRequest) GetJobHistoryLimit() int32 {
+	if x != nil {
+		return x.JobHistoryLimit
+	}
+	return 0
+}
+
 type Algorithm struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -268,6 +335,14 @@ type Algorithm struct {
 	CurrentVersionId string                 `protobuf:"bytes,10,opt,name=current_version_id,proto3" json:"current_version_id,omitempty"`
 	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,proto3" json:"created_at,omitempty"`
 	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,proto3" json:"updated_at,omitempty"`
+	DefaultCpuLimit  float32                `protobuf:"fixed32,13,opt,name=default_cpu_limit,proto3" json:"default_cpu_limit,omitempty"`
+	DefaultMemoryMb  int32                  `protobuf:"varint,14,opt,name=default_memory_mb,proto3" json:"default_memory_mb,omitempty"`
+	Status           string                 `protobuf:"bytes,15,opt,name=status,proto3" json:"status,omitempty"`
+	DisabledBy       string                 `protobuf:"bytes,16,opt,name=disabled_by,proto3" json:"disabled_by,omitempty"`
+	DisabledReason   string                 `protobuf:"bytes,17,opt,name=disabled_reason,proto3" json:"disabled_reason,omitempty"`
+	DisabledAt       *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=disabled_at,proto3" json:"disabled_at,omitempty"`
+	ParamsSchema     string                 `protobuf:"bytes,19,opt,name=params_schema,proto3" json:"params_schema,omitempty"`
+	JobHistoryLimit  int32                  `protobuf:"varint,20,opt,name=job_history_limit,proto3" json:"job_history_limit,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -386,12 +461,74 @@ func (x *Algorithm) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Algorithm) GetDefaultCpuLimit() float32 {
+	if x != nil {
+		return x.DefaultCpuLimit
+	}
+	return 0
+}
+
+func (x *Algorithm) GetDefaultMemoryMb() int32 {
+	if x != nil {
+		return x.DefaultMemoryMb
+	}
+	return 0
+}
+
+func (x *Algorithm) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Algorithm) GetDisabledBy() string {
+	if x != nil {
+		return x.DisabledBy
+	}
+	return ""
+}
+
+func (x *Algorithm) GetDisabledReason() string {
+	if x != nil {
+		return x.DisabledReason
+	}
+	return ""
+}
+
+func (x *Algorithm) GetDisabledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisabledAt
+	}
+	return nil
+}
+
+func (x *Algorithm) GetParamsSchema() string {
+	if x != nil {
+		return x.ParamsSchema
+	}
+	return ""
+}
+
+func (x *Algorithm) GetJobHistoryLimit() int32 {
+	if x != nil {
+		return x.JobHistoryLimit
+	}
+	return 0
+}
+
 type ListAlgorithmsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
-	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,proto3" json:"page_size,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Category  string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Language  string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Page      int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`           // 已废弃，使用 page_token；未提供令牌时按页码计算偏移
+	PageSize  int32                  `protobuf:"varint,4,opt,name=page_size,proto3" json:"page_size,omitempty"` // 默认 50，最大为 server.max_page_size（默认 500）
+	Status    string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	PageToken string                 `protobuf:"bytes,6,opt,name=page_token,proto3" json:"page_token,omitempty"`
+	// Case-insensitive substring match against name or description
+	Query string `protobuf:"bytes,7,opt,name=query,proto3" json:"query,omitempty"`
+	// Only algorithms having all of these tags (exact, case-insensitive match)
+	Tags          []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -454,10 +591,39 @@ func (x *ListAlgorithmsRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListAlgorithmsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListAlgorithmsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListAlgorithmsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListAlgorithmsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type ListAlgorithmsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Algorithms    []*Algorithm           `protobuf:"bytes,1,rep,name=algorithms,proto3" json:"algorithms,omitempty"`
 	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -506,27 +672,36 @@ func (x *ListAlgorithmsResponse) GetTotal() int32 {
 	return 0
 }
 
-type GetAlgorithmRequest struct {
+func (x *ListAlgorithmsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DisableAlgorithmRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DisabledBy    string                 `protobuf:"bytes,2,opt,name=disabled_by,proto3" json:"disabled_by,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAlgorithmRequest) Reset() {
-	*x = GetAlgorithmRequest{}
+func (x *DisableAlgorithmRequest) Reset() {
+	*x = DisableAlgorithmRequest{}
 	mi := &file_proto_management_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAlgorithmRequest) String() string {
+func (x *DisableAlgorithmRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAlgorithmRequest) ProtoMessage() {}
+func (*DisableAlgorithmRequest) ProtoMessage() {}
 
-func (x *GetAlgorithmRequest) ProtoReflect() protoreflect.Message {
+func (x *DisableAlgorithmRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_management_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -538,40 +713,53 @@ func (x *GetAlgorithmRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAlgorithmRequest.ProtoReflect.Descriptor instead.
-func (*GetAlgorithmRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DisableAlgorithmRequest.ProtoReflect.Descriptor instead.
+func (*DisableAlgorithmRequest) Descriptor() ([]byte, []int) {
 	return file_proto_management_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetAlgorithmRequest) GetId() string {
+func (x *DisableAlgorithmRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type GetAlgorithmResponse struct {
+func (x *DisableAlgorithmRequest) GetDisabledBy() string {
+	if x != nil {
+		return x.DisabledBy
+	}
+	return ""
+}
+
+func (x *DisableAlgorithmRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DeleteAlgorithmRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Algorithm     *Algorithm             `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
-	Versions      []*Version             `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAlgorithmResponse) Reset() {
-	*x = GetAlgorithmResponse{}
+func (x *DeleteAlgorithmRequest) Reset() {
+	*x = DeleteAlgorithmRequest{}
 	mi := &file_proto_management_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAlgorithmResponse) String() string {
+func (x *DeleteAlgorithmRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAlgorithmResponse) ProtoMessage() {}
+func (*DeleteAlgorithmRequest) ProtoMessage() {}
 
-func (x *GetAlgorithmResponse) ProtoReflect() protoreflect.Message {
+func (x *DeleteAlgorithmRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_management_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -583,50 +771,42 @@ func (x *GetAlgorithmResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAlgorithmResponse.ProtoReflect.Descriptor instead.
-func (*GetAlgorithmResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteAlgorithmRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAlgorithmRequest) Descriptor() ([]byte, []int) {
 	return file_proto_management_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetAlgorithmResponse) GetAlgorithm() *Algorithm {
-	if x != nil {
-		return x.Algorithm
-	}
-	return nil
-}
-
-func (x *GetAlgorithmResponse) GetVersions() []*Version {
+func (x *DeleteAlgorithmRequest) GetId() string {
 	if x != nil {
-		return x.Versions
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type CreateVersionRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	AlgorithmId      string                 `protobuf:"bytes,1,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
-	SourceCodeZipUrl string                 `protobuf:"bytes,2,opt,name=source_code_zip_url,proto3" json:"source_code_zip_url,omitempty"`
-	CommitMessage    string                 `protobuf:"bytes,3,opt,name=commit_message,proto3" json:"commit_message,omitempty"`
-	FileData         []byte                 `protobuf:"bytes,4,opt,name=file_data,proto3" json:"file_data,omitempty"`
-	FileName         string                 `protobuf:"bytes,5,opt,name=file_name,proto3" json:"file_name,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type DeleteAlgorithmResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeletedVersions int32                  `protobuf:"varint,2,opt,name=deleted_versions,proto3" json:"deleted_versions,omitempty"`
+	DeletedObjects  int32                  `protobuf:"varint,3,opt,name=deleted_objects,proto3" json:"deleted_objects,omitempty"`
+	FailedObjects   int32                  `protobuf:"varint,4,opt,name=failed_objects,proto3" json:"failed_objects,omitempty"` // 删除失败、需要人工清理的对象数
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *CreateVersionRequest) Reset() {
-	*x = CreateVersionRequest{}
+func (x *DeleteAlgorithmResponse) Reset() {
+	*x = DeleteAlgorithmResponse{}
 	mi := &file_proto_management_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateVersionRequest) String() string {
+func (x *DeleteAlgorithmResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateVersionRequest) ProtoMessage() {}
+func (*DeleteAlgorithmResponse) ProtoMessage() {}
 
-func (x *CreateVersionRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteAlgorithmResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_management_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -638,73 +818,60 @@ func (x *CreateVersionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateVersionRequest.ProtoReflect.Descriptor instead.
-func (*CreateVersionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteAlgorithmResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAlgorithmResponse) Descriptor() ([]byte, []int) {
 	return file_proto_management_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *CreateVersionRequest) GetAlgorithmId() string {
-	if x != nil {
-		return x.AlgorithmId
-	}
-	return ""
-}
-
-func (x *CreateVersionRequest) GetSourceCodeZipUrl() string {
+func (x *DeleteAlgorithmResponse) GetId() string {
 	if x != nil {
-		return x.SourceCodeZipUrl
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CreateVersionRequest) GetCommitMessage() string {
+func (x *DeleteAlgorithmResponse) GetDeletedVersions() int32 {
 	if x != nil {
-		return x.CommitMessage
+		return x.DeletedVersions
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateVersionRequest) GetFileData() []byte {
+func (x *DeleteAlgorithmResponse) GetDeletedObjects() int32 {
 	if x != nil {
-		return x.FileData
+		return x.DeletedObjects
 	}
-	return nil
+	return 0
 }
 
-func (x *CreateVersionRequest) GetFileName() string {
+func (x *DeleteAlgorithmResponse) GetFailedObjects() int32 {
 	if x != nil {
-		return x.FileName
+		return x.FailedObjects
 	}
-	return ""
+	return 0
 }
 
-type Version struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AlgorithmId    string                 `protobuf:"bytes,2,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
-	VersionNumber  int32                  `protobuf:"varint,3,opt,name=version_number,proto3" json:"version_number,omitempty"`
-	MinioPath      string                 `protobuf:"bytes,4,opt,name=minio_path,proto3" json:"minio_path,omitempty"`
-	SourceCodeFile string                 `protobuf:"bytes,5,opt,name=source_code_file,proto3" json:"source_code_file,omitempty"`
-	CommitMessage  string                 `protobuf:"bytes,6,opt,name=commit_message,proto3" json:"commit_message,omitempty"`
-	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,proto3" json:"created_at,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type EnableAlgorithmRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Version) Reset() {
-	*x = Version{}
+func (x *EnableAlgorithmRequest) Reset() {
+	*x = EnableAlgorithmRequest{}
 	mi := &file_proto_management_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Version) String() string {
+func (x *EnableAlgorithmRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Version) ProtoMessage() {}
+func (*EnableAlgorithmRequest) ProtoMessage() {}
 
-func (x *Version) ProtoReflect() protoreflect.Message {
+func (x *EnableAlgorithmRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_management_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -716,83 +883,2390 @@ func (x *Version) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Version.ProtoReflect.Descriptor instead.
-func (*Version) Descriptor() ([]byte, []int) {
+// Deprecated: Use EnableAlgorithmRequest.ProtoReflect.Descriptor instead.
+func (*EnableAlgorithmRequest) Descriptor() ([]byte, []int) {
 	return file_proto_management_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Version) GetId() string {
+func (x *EnableAlgorithmRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Version) GetAlgorithmId() string {
-	if x != nil {
-		return x.AlgorithmId
-	}
-	return ""
+type GetAlgorithmRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Version) GetVersionNumber() int32 {
-	if x != nil {
-		return x.VersionNumber
-	}
-	return 0
+func (x *GetAlgorithmRequest) Reset() {
+	*x = GetAlgorithmRequest{}
+	mi := &file_proto_management_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Version) GetMinioPath() string {
-	if x != nil {
-		return x.MinioPath
-	}
-	return ""
+func (x *GetAlgorithmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Version) GetSourceCodeFile() string {
-	if x != nil {
-		return x.SourceCodeFile
-	}
-	return ""
-}
+func (*GetAlgorithmRequest) ProtoMessage() {}
 
-func (x *Version) GetCommitMessage() string {
+func (x *GetAlgorithmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlgorithmRequest.ProtoReflect.Descriptor instead.
+func (*GetAlgorithmRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetAlgorithmRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetAlgorithmByNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlgorithmByNameRequest) Reset() {
+	*x = GetAlgorithmByNameRequest{}
+	mi := &file_proto_management_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlgorithmByNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlgorithmByNameRequest) ProtoMessage() {}
+
+func (x *GetAlgorithmByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlgorithmByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetAlgorithmByNameRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAlgorithmByNameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetAlgorithmResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm *Algorithm             `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Versions  []*Version             `protobuf:"bytes,2,rep,name=versions,proto3" json:"versions,omitempty"`
+	Image     string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	// unknown, pulling, ready, failed
+	ImageStatus   string `protobuf:"bytes,4,opt,name=image_status,proto3" json:"image_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlgorithmResponse) Reset() {
+	*x = GetAlgorithmResponse{}
+	mi := &file_proto_management_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlgorithmResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlgorithmResponse) ProtoMessage() {}
+
+func (x *GetAlgorithmResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlgorithmResponse.ProtoReflect.Descriptor instead.
+func (*GetAlgorithmResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetAlgorithmResponse) GetAlgorithm() *Algorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return nil
+}
+
+func (x *GetAlgorithmResponse) GetVersions() []*Version {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *GetAlgorithmResponse) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *GetAlgorithmResponse) GetImageStatus() string {
+	if x != nil {
+		return x.ImageStatus
+	}
+	return ""
+}
+
+type CreateVersionRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AlgorithmId      string                 `protobuf:"bytes,1,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
+	SourceCodeZipUrl string                 `protobuf:"bytes,2,opt,name=source_code_zip_url,proto3" json:"source_code_zip_url,omitempty"`
+	CommitMessage    string                 `protobuf:"bytes,3,opt,name=commit_message,proto3" json:"commit_message,omitempty"`
+	FileData         []byte                 `protobuf:"bytes,4,opt,name=file_data,proto3" json:"file_data,omitempty"`
+	FileName         string                 `protobuf:"bytes,5,opt,name=file_name,proto3" json:"file_name,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateVersionRequest) Reset() {
+	*x = CreateVersionRequest{}
+	mi := &file_proto_management_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVersionRequest) ProtoMessage() {}
+
+func (x *CreateVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVersionRequest.ProtoReflect.Descriptor instead.
+func (*CreateVersionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateVersionRequest) GetAlgorithmId() string {
+	if x != nil {
+		return x.AlgorithmId
+	}
+	return ""
+}
+
+func (x *CreateVersionRequest) GetSourceCodeZipUrl() string {
+	if x != nil {
+		return x.SourceCodeZipUrl
+	}
+	return ""
+}
+
+func (x *CreateVersionRequest) GetCommitMessage() string {
 	if x != nil {
 		return x.CommitMessage
 	}
 	return ""
 }
 
-func (x *Version) GetCreatedAt() *timestamppb.Timestamp {
+func (x *CreateVersionRequest) GetFileData() []byte {
+	if x != nil {
+		return x.FileData
+	}
+	return nil
+}
+
+func (x *CreateVersionRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+type Version struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AlgorithmId    string                 `protobuf:"bytes,2,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
+	VersionNumber  int32                  `protobuf:"varint,3,opt,name=version_number,proto3" json:"version_number,omitempty"`
+	MinioPath      string                 `protobuf:"bytes,4,opt,name=minio_path,proto3" json:"minio_path,omitempty"`
+	SourceCodeFile string                 `protobuf:"bytes,5,opt,name=source_code_file,proto3" json:"source_code_file,omitempty"`
+	CommitMessage  string                 `protobuf:"bytes,6,opt,name=commit_message,proto3" json:"commit_message,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,proto3" json:"created_at,omitempty"`
+	DownloadUrl    string                 `protobuf:"bytes,8,opt,name=download_url,proto3" json:"download_url,omitempty"`
+	// 首次执行时固定的运行镜像摘要（repo@sha256:...），之后的任务按摘要运行；为空表示尚未执行过
+	ImageDigest   string `protobuf:"bytes,9,opt,name=image_digest,proto3" json:"image_digest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Version) Reset() {
+	*x = Version{}
+	mi := &file_proto_management_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Version) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Version) ProtoMessage() {}
+
+func (x *Version) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Version.ProtoReflect.Descriptor instead.
+func (*Version) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Version) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Version) GetAlgorithmId() string {
+	if x != nil {
+		return x.AlgorithmId
+	}
+	return ""
+}
+
+func (x *Version) GetVersionNumber() int32 {
+	if x != nil {
+		return x.VersionNumber
+	}
+	return 0
+}
+
+func (x *Version) GetMinioPath() string {
+	if x != nil {
+		return x.MinioPath
+	}
+	return ""
+}
+
+func (x *Version) GetSourceCodeFile() string {
+	if x != nil {
+		return x.SourceCodeFile
+	}
+	return ""
+}
+
+func (x *Version) GetCommitMessage() string {
+	if x != nil {
+		return x.CommitMessage
+	}
+	return ""
+}
+
+func (x *Version) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Version) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+func (x *Version) GetImageDigest() string {
+	if x != nil {
+		return x.ImageDigest
+	}
+	return ""
+}
+
+type RollbackVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AlgorithmId   string                 `protobuf:"bytes,1,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
+	VersionId     string                 `protobuf:"bytes,2,opt,name=version_id,proto3" json:"version_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackVersionRequest) Reset() {
+	*x = RollbackVersionRequest{}
+	mi := &file_proto_management_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackVersionRequest) ProtoMessage() {}
+
+func (x *RollbackVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackVersionRequest.ProtoReflect.Descriptor instead.
+func (*RollbackVersionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RollbackVersionRequest) GetAlgorithmId() string {
+	if x != nil {
+		return x.AlgorithmId
+	}
+	return ""
+}
+
+func (x *RollbackVersionRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+type UploadDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	FileData      []byte                 `protobuf:"bytes,3,opt,name=file_data,proto3" json:"file_data,omitempty"`
+	MinioPath     string                 `protobuf:"bytes,4,opt,name=minio_path,proto3" json:"minio_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadDataRequest) Reset() {
+	*x = UploadDataRequest{}
+	mi := &file_proto_management_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadDataRequest) ProtoMessage() {}
+
+func (x *UploadDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadDataRequest.ProtoReflect.Descriptor instead.
+func (*UploadDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UploadDataRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadDataRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *UploadDataRequest) GetFileData() []byte {
+	if x != nil {
+		return x.FileData
+	}
+	return nil
+}
+
+func (x *UploadDataRequest) GetMinioPath() string {
+	if x != nil {
+		return x.MinioPath
+	}
+	return ""
+}
+
+type UploadDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,proto3" json:"file_id,omitempty"`
+	MinioUrl      string                 `protobuf:"bytes,2,opt,name=minio_url,proto3" json:"minio_url,omitempty"`
+	Checksum      string                 `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadDataResponse) Reset() {
+	*x = UploadDataResponse{}
+	mi := &file_proto_management_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadDataResponse) ProtoMessage() {}
+
+func (x *UploadDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadDataResponse.ProtoReflect.Descriptor instead.
+func (*UploadDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UploadDataResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *UploadDataResponse) GetMinioUrl() string {
+	if x != nil {
+		return x.MinioUrl
+	}
+	return ""
+}
+
+func (x *UploadDataResponse) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+type CreatePresetDataUploadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePresetDataUploadURLRequest) Reset() {
+	*x = CreatePresetDataUploadURLRequest{}
+	mi := &file_proto_management_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePresetDataUploadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePresetDataUploadURLRequest) ProtoMessage() {}
+
+func (x *CreatePresetDataUploadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePresetDataUploadURLRequest.ProtoReflect.Descriptor instead.
+func (*CreatePresetDataUploadURLRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreatePresetDataUploadURLRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+// 客户端用 PUT 将文件上传到 upload_url，再以 minio_path 调用 UploadPresetData 登记
+type CreatePresetDataUploadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadUrl     string                 `protobuf:"bytes,1,opt,name=upload_url,proto3" json:"upload_url,omitempty"`
+	MinioPath     string                 `protobuf:"bytes,2,opt,name=minio_path,proto3" json:"minio_path,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePresetDataUploadURLResponse) Reset() {
+	*x = CreatePresetDataUploadURLResponse{}
+	mi := &file_proto_management_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePresetDataUploadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePresetDataUploadURLResponse) ProtoMessage() {}
+
+func (x *CreatePresetDataUploadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePresetDataUploadURLResponse.ProtoReflect.Descriptor instead.
+func (*CreatePresetDataUploadURLResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreatePresetDataUploadURLResponse) GetUploadUrl() string {
+	if x != nil {
+		return x.UploadUrl
+	}
+	return ""
+}
+
+func (x *CreatePresetDataUploadURLResponse) GetMinioPath() string {
+	if x != nil {
+		return x.MinioPath
+	}
+	return ""
+}
+
+func (x *CreatePresetDataUploadURLResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ListPresetDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"` // 已废弃，使用 page_token
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPresetDataRequest) Reset() {
+	*x = ListPresetDataRequest{}
+	mi := &file_proto_management_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPresetDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPresetDataRequest) ProtoMessage() {}
+
+func (x *ListPresetDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPresetDataRequest.ProtoReflect.Descriptor instead.
+func (*ListPresetDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListPresetDataRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListPresetDataRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPresetDataRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPresetDataRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type PresetData struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename  string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Category  string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	MinioUrl  string                 `protobuf:"bytes,4,opt,name=minio_url,proto3" json:"minio_url,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,proto3" json:"created_at,omitempty"`
+	// 内容的 SHA-256（十六进制），为空表示上传时未计算
+	Checksum string `protobuf:"bytes,6,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// 文件大小（字节），未知时为 0
+	Size int64 `protobuf:"varint,7,opt,name=size,proto3" json:"size,omitempty"`
+	// 上传时检测的内容类型，为空表示未知
+	ContentType   string `protobuf:"bytes,8,opt,name=content_type,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PresetData) Reset() {
+	*x = PresetData{}
+	mi := &file_proto_management_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresetData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresetData) ProtoMessage() {}
+
+func (x *PresetData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresetData.ProtoReflect.Descriptor instead.
+func (*PresetData) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PresetData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PresetData) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *PresetData) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *PresetData) GetMinioUrl() string {
+	if x != nil {
+		return x.MinioUrl
+	}
+	return ""
+}
+
+func (x *PresetData) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PresetData) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *PresetData) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *PresetData) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type GetPresetDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresetDataRequest) Reset() {
+	*x = GetPresetDataRequest{}
+	mi := &file_proto_management_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresetDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresetDataRequest) ProtoMessage() {}
+
+func (x *GetPresetDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresetDataRequest.ProtoReflect.Descriptor instead.
+func (*GetPresetDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetPresetDataRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListPresetDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*PresetData          `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPresetDataResponse) Reset() {
+	*x = ListPresetDataResponse{}
+	mi := &file_proto_management_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPresetDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPresetDataResponse) ProtoMessage() {}
+
+func (x *ListPresetDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPresetDataResponse.ProtoReflect.Descriptor instead.
+func (*ListPresetDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListPresetDataResponse) GetFiles() []*PresetData {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ListPresetDataResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListPresetDataResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DeletePresetDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePresetDataRequest) Reset() {
+	*x = DeletePresetDataRequest{}
+	mi := &file_proto_management_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePresetDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePresetDataRequest) ProtoMessage() {}
+
+func (x *DeletePresetDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePresetDataRequest.ProtoReflect.Descriptor instead.
+func (*DeletePresetDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DeletePresetDataRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeletePresetDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePresetDataResponse) Reset() {
+	*x = DeletePresetDataResponse{}
+	mi := &file_proto_management_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePresetDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePresetDataResponse) ProtoMessage() {}
+
+func (x *DeletePresetDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePresetDataResponse.ProtoReflect.Descriptor instead.
+func (*DeletePresetDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeletePresetDataResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeletePresetDataResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AlgorithmId   string                 `protobuf:"bytes,1,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`           // 已废弃，使用 page_token
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,proto3" json:"page_size,omitempty"` // 默认 100，最大为 server.max_page_size（默认 500）
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_proto_management_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListJobsRequest) GetAlgorithmId() string {
+	if x != nil {
+		return x.AlgorithmId
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListJobsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListJobsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type JobSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,proto3" json:"job_id,omitempty"`
+	AlgorithmId   string                 `protobuf:"bytes,2,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
+	AlgorithmName string                 `protobuf:"bytes,3,opt,name=algorithm_name,proto3" json:"algorithm_name,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,proto3" json:"created_at,omitempty"`
+	CostTimeMs    int32                  `protobuf:"varint,6,opt,name=cost_time_ms,proto3" json:"cost_time_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobSummary) Reset() {
+	*x = JobSummary{}
+	mi := &file_proto_management_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobSummary) ProtoMessage() {}
+
+func (x *JobSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobSummary.ProtoReflect.Descriptor instead.
+func (*JobSummary) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *JobSummary) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobSummary) GetAlgorithmId() string {
+	if x != nil {
+		return x.AlgorithmId
+	}
+	return ""
+}
+
+func (x *JobSummary) GetAlgorithmName() string {
+	if x != nil {
+		return x.AlgorithmName
+	}
+	return ""
+}
+
+func (x *JobSummary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JobSummary) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *JobSummary) GetCostTimeMs() int32 {
+	if x != nil {
+		return x.CostTimeMs
+	}
+	return 0
+}
+
+type ListJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*JobSummary          `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_proto_management_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListJobsResponse) GetJobs() []*JobSummary {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ListJobsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListJobsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DeleteJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobRequest) Reset() {
+	*x = DeleteJobRequest{}
+	mi := &file_proto_management_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobRequest) ProtoMessage() {}
+
+func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobRequest.ProtoReflect.Descriptor instead.
+func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type DeleteJobResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	JobId          string                 `protobuf:"bytes,1,opt,name=job_id,proto3" json:"job_id,omitempty"`
+	DeletedObjects int32                  `protobuf:"varint,2,opt,name=deleted_objects,proto3" json:"deleted_objects,omitempty"`
+	BytesReclaimed int64                  `protobuf:"varint,3,opt,name=bytes_reclaimed,proto3" json:"bytes_reclaimed,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteJobResponse) Reset() {
+	*x = DeleteJobResponse{}
+	mi := &file_proto_management_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobResponse) ProtoMessage() {}
+
+func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobResponse.ProtoReflect.Descriptor instead.
+func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *DeleteJobResponse) GetDeletedObjects() int32 {
+	if x != nil {
+		return x.DeletedObjects
+	}
+	return 0
+}
+
+func (x *DeleteJobResponse) GetBytesReclaimed() int64 {
+	if x != nil {
+		return x.BytesReclaimed
+	}
+	return 0
+}
+
+type PurgeJobsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only jobs created more than older_than_hours ago; 0 means no age limit
+	OlderThanHours int32 `protobuf:"varint,1,opt,name=older_than_hours,proto3" json:"older_than_hours,omitempty"`
+	// Only jobs in these statuses (default: all finished statuses); pending and running jobs are never purged
+	Statuses []string `protobuf:"bytes,2,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	// Optional, restrict to one algorithm
+	AlgorithmId   string `protobuf:"bytes,3,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeJobsRequest) Reset() {
+	*x = PurgeJobsRequest{}
+	mi := &file_proto_management_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeJobsRequest) ProtoMessage() {}
+
+func (x *PurgeJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeJobsRequest.ProtoReflect.Descriptor instead.
+func (*PurgeJobsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PurgeJobsRequest) GetOlderThanHours() int32 {
+	if x != nil {
+		return x.OlderThanHours
+	}
+	return 0
+}
+
+func (x *PurgeJobsRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *PurgeJobsRequest) GetAlgorithmId() string {
+	if x != nil {
+		return x.AlgorithmId
+	}
+	return ""
+}
+
+type PurgeJobsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PurgedJobs     int32                  `protobuf:"varint,1,opt,name=purged_jobs,proto3" json:"purged_jobs,omitempty"`
+	DeletedObjects int32                  `protobuf:"varint,2,opt,name=deleted_objects,proto3" json:"deleted_objects,omitempty"`
+	BytesReclaimed int64                  `protobuf:"varint,3,opt,name=bytes_reclaimed,proto3" json:"bytes_reclaimed,omitempty"`
+	// Jobs kept because their artifacts could not be deleted; retried on the next purge
+	FailedJobs    int32 `protobuf:"varint,4,opt,name=failed_jobs,proto3" json:"failed_jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeJobsResponse) Reset() {
+	*x = PurgeJobsResponse{}
+	mi := &file_proto_management_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeJobsResponse) ProtoMessage() {}
+
+func (x *PurgeJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeJobsResponse.ProtoReflect.Descriptor instead.
+func (*PurgeJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PurgeJobsResponse) GetPurgedJobs() int32 {
+	if x != nil {
+		return x.PurgedJobs
+	}
+	return 0
+}
+
+func (x *PurgeJobsResponse) GetDeletedObjects() int32 {
+	if x != nil {
+		return x.DeletedObjects
+	}
+	return 0
+}
+
+func (x *PurgeJobsResponse) GetBytesReclaimed() int64 {
+	if x != nil {
+		return x.BytesReclaimed
+	}
+	return 0
+}
+
+func (x *PurgeJobsResponse) GetFailedJobs() int32 {
+	if x != nil {
+		return x.FailedJobs
+	}
+	return 0
+}
+
+type GetJobDetailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobDetailRequest) Reset() {
+	*x = GetJobDetailRequest{}
+	mi := &file_proto_management_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobDetailRequest) ProtoMessage() {}
+
+func (x *GetJobDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobDetailRequest.ProtoReflect.Descriptor instead.
+func (*GetJobDetailRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetJobDetailRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type JobDetail struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	JobId             string                 `protobuf:"bytes,1,opt,name=job_id,proto3" json:"job_id,omitempty"`
+	AlgorithmId       string                 `protobuf:"bytes,2,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
+	AlgorithmName     string                 `protobuf:"bytes,3,opt,name=algorithm_name,proto3" json:"algorithm_name,omitempty"`
+	Mode              string                 `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	Status            string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	InputParams       string                 `protobuf:"bytes,6,opt,name=input_params,proto3" json:"input_params,omitempty"`
+	InputUrl          string                 `protobuf:"bytes,7,opt,name=input_url,proto3" json:"input_url,omitempty"`
+	OutputUrl         string                 `protobuf:"bytes,8,opt,name=output_url,proto3" json:"output_url,omitempty"`
+	LogUrl            string                 `protobuf:"bytes,9,opt,name=log_url,proto3" json:"log_url,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,proto3" json:"created_at,omitempty"`
+	StartedAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=started_at,proto3" json:"started_at,omitempty"`
+	FinishedAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=finished_at,proto3" json:"finished_at,omitempty"`
+	CostTimeMs        int32                  `protobuf:"varint,13,opt,name=cost_time_ms,proto3" json:"cost_time_ms,omitempty"`
+	WorkerId          string                 `protobuf:"bytes,14,opt,name=worker_id,proto3" json:"worker_id,omitempty"`
+	ArtifactsExpired  bool                   `protobuf:"varint,15,opt,name=artifacts_expired,proto3" json:"artifacts_expired,omitempty"`
+	ArtifactsExpireAt *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=artifacts_expire_at,proto3" json:"artifacts_expire_at,omitempty"`
+	// Docker 中任务容器的实际状态，找不到容器时为空
+	Container *JobContainer `protobuf:"bytes,17,opt,name=container,proto3" json:"container,omitempty"`
+	// 数据库状态与容器实际状态不一致
+	StatusMismatch bool `protobuf:"varint,18,opt,name=status_mismatch,proto3" json:"status_mismatch,omitempty"`
+	// 由 RetryJob 创建时为原任务 ID
+	RetriedFrom string `protobuf:"bytes,19,opt,name=retried_from,proto3" json:"retried_from,omitempty"`
+	VersionId   string `protobuf:"bytes,20,opt,name=version_id,proto3" json:"version_id,omitempty"`
+	// 不影响任务结果的问题，如日志上传失败
+	Warning string `protobuf:"bytes,21,opt,name=warning,proto3" json:"warning,omitempty"`
+	// webhook 最终投递结果（delivered / failed），未发送时为空
+	WebhookStatus     string `protobuf:"bytes,22,opt,name=webhook_status,proto3" json:"webhook_status,omitempty"`
+	WebhookStatusCode int32  `protobuf:"varint,23,opt,name=webhook_status_code,proto3" json:"webhook_status_code,omitempty"`
+	WebhookError      string `protobuf:"bytes,24,opt,name=webhook_error,proto3" json:"webhook_error,omitempty"`
+	// 任务失败、超时或被服务重启中断的原因
+	FailureReason string `protobuf:"bytes,25,opt,name=failure_reason,proto3" json:"failure_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobDetail) Reset() {
+	*x = JobDetail{}
+	mi := &file_proto_management_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobDetail) ProtoMessage() {}
+
+func (x *JobDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobDetail.ProtoReflect.Descriptor instead.
+func (*JobDetail) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *JobDetail) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobDetail) GetAlgorithmId() string {
+	if x != nil {
+		return x.AlgorithmId
+	}
+	return ""
+}
+
+func (x *JobDetail) GetAlgorithmName() string {
+	if x != nil {
+		return x.AlgorithmName
+	}
+	return ""
+}
+
+func (x *JobDetail) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *JobDetail) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JobDetail) GetInputParams() string {
+	if x != nil {
+		return x.InputParams
+	}
+	return ""
+}
+
+func (x *JobDetail) GetInputUrl() string {
+	if x != nil {
+		return x.InputUrl
+	}
+	return ""
+}
+
+func (x *JobDetail) GetOutputUrl() string {
+	if x != nil {
+		return x.OutputUrl
+	}
+	return ""
+}
+
+func (x *JobDetail) GetLogUrl() string {
+	if x != nil {
+		return x.LogUrl
+	}
+	return ""
+}
+
+func (x *JobDetail) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *JobDetail) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *JobDetail) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *JobDetail) GetCostTimeMs() int32 {
+	if x != nil {
+		return x.CostTimeMs
+	}
+	return 0
+}
+
+func (x *JobDetail) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *JobDetail) GetArtifactsExpired() bool {
+	if x != nil {
+		return x.ArtifactsExpired
+	}
+	return false
+}
+
+func (x *JobDetail) GetArtifactsExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArtifactsExpireAt
+	}
+	return nil
+}
+
+func (x *JobDetail) GetContainer() *JobContainer {
+	if x != nil {
+		return x.Container
+	}
+	return nil
+}
+
+func (x *JobDetail) GetStatusMismatch() bool {
+	if x != nil {
+		return x.StatusMismatch
+	}
+	return false
+}
+
+func (x *JobDetail) GetRetriedFrom() string {
+	if x != nil {
+		return x.RetriedFrom
+	}
+	return ""
+}
+
+func (x *JobDetail) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *JobDetail) GetWarning() string {
+	if x != nil {
+		return x.Warning
+	}
+	return ""
+}
+
+func (x *JobDetail) GetWebhookStatus() string {
+	if x != nil {
+		return x.WebhookStatus
+	}
+	return ""
+}
+
+func (x *JobDetail) GetWebhookStatusCode() int32 {
+	if x != nil {
+		return x.WebhookStatusCode
+	}
+	return 0
+}
+
+func (x *JobDetail) GetWebhookError() string {
+	if x != nil {
+		return x.WebhookError
+	}
+	return ""
+}
+
+func (x *JobDetail) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+type CompareJobsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	LeftJobId  string                 `protobuf:"bytes,1,opt,name=left_job_id,proto3" json:"left_job_id,omitempty"`
+	RightJobId string                 `protobuf:"bytes,2,opt,name=right_job_id,proto3" json:"right_job_id,omitempty"`
+	// 下载比较的最大字节数，0 或超过服务端上限时使用上限
+	MaxBytes      int64 `protobuf:"varint,3,opt,name=max_bytes,proto3" json:"max_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareJobsRequest) Reset() {
+	*x = CompareJobsRequest{}
+	mi := &file_proto_management_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareJobsRequest) ProtoMessage() {}
+
+func (x *CompareJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareJobsRequest.ProtoReflect.Descriptor instead.
+func (*CompareJobsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CompareJobsRequest) GetLeftJobId() string {
+	if x != nil {
+		return x.LeftJobId
+	}
+	return ""
+}
+
+func (x *CompareJobsRequest) GetRightJobId() string {
+	if x != nil {
+		return x.RightJobId
+	}
+	return ""
+}
+
+func (x *CompareJobsRequest) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+type JobOutput struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	JobId       string                 `protobuf:"bytes,1,opt,name=job_id,proto3" json:"job_id,omitempty"`
+	ObjectKey   string                 `protobuf:"bytes,2,opt,name=object_key,proto3" json:"object_key,omitempty"`
+	Size        int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	ContentType string                 `protobuf:"bytes,4,opt,name=content_type,proto3" json:"content_type,omitempty"`
+	// 输出超过大小上限时为空
+	Sha256        string `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobOutput) Reset() {
+	*x = JobOutput{}
+	mi := &file_proto_management_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobOutput) ProtoMessage() {}
+
+func (x *JobOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobOutput.ProtoReflect.Descriptor instead.
+func (*JobOutput) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *JobOutput) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobOutput) GetObjectKey() string {
+	if x != nil {
+		return x.ObjectKey
+	}
+	return ""
+}
+
+func (x *JobOutput) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *JobOutput) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *JobOutput) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type LineDiffSummary struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AddedLines     int32                  `protobuf:"varint,1,opt,name=added_lines,proto3" json:"added_lines,omitempty"`
+	RemovedLines   int32                  `protobuf:"varint,2,opt,name=removed_lines,proto3" json:"removed_lines,omitempty"`
+	UnchangedLines int32                  `protobuf:"varint,3,opt,name=unchanged_lines,proto3" json:"unchanged_lines,omitempty"`
+	// 前若干条差异行，"- " 为左侧独有，"+ " 为右侧独有
+	Preview []string `protobuf:"bytes,4,rep,name=preview,proto3" json:"preview,omitempty"`
+	// 行数过多时按行计数比较，不考虑行的顺序
+	Unordered     bool `protobuf:"varint,5,opt,name=unordered,proto3" json:"unordered,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineDiffSummary) Reset() {
+	*x = LineDiffSummary{}
+	mi := &file_proto_management_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineDiffSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineDiffSummary) ProtoMessage() {}
+
+func (x *LineDiffSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineDiffSummary.ProtoReflect.Descriptor instead.
+func (*LineDiffSummary) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LineDiffSummary) GetAddedLines() int32 {
+	if x != nil {
+		return x.AddedLines
+	}
+	return 0
+}
+
+func (x *LineDiffSummary) GetRemovedLines() int32 {
+	if x != nil {
+		return x.RemovedLines
+	}
+	return 0
+}
+
+func (x *LineDiffSummary) GetUnchangedLines() int32 {
+	if x != nil {
+		return x.UnchangedLines
+	}
+	return 0
+}
+
+func (x *LineDiffSummary) GetPreview() []string {
+	if x != nil {
+		return x.Preview
+	}
+	return nil
+}
+
+func (x *LineDiffSummary) GetUnordered() bool {
+	if x != nil {
+		return x.Unordered
+	}
+	return false
+}
+
+type CompareJobsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Left      *JobOutput             `protobuf:"bytes,1,opt,name=left,proto3" json:"left,omitempty"`
+	Right     *JobOutput             `protobuf:"bytes,2,opt,name=right,proto3" json:"right,omitempty"`
+	Identical bool                   `protobuf:"varint,3,opt,name=identical,proto3" json:"identical,omitempty"`
+	// right.size - left.size
+	SizeDiff int64 `protobuf:"varint,4,opt,name=size_diff,proto3" json:"size_diff,omitempty"`
+	// 两侧都是文本时的行级差异
+	LineDiff *LineDiffSummary `protobuf:"bytes,5,opt,name=line_diff,proto3" json:"line_diff,omitempty"`
+	// 输出超过大小上限，只比较了大小
+	Truncated     bool `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareJobsResponse) Reset() {
+	*x = CompareJobsResponse{}
+	mi := &file_proto_management_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareJobsResponse) ProtoMessage() {}
+
+func (x *CompareJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareJobsResponse.ProtoReflect.Descriptor instead.
+func (*CompareJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CompareJobsResponse) GetLeft() *JobOutput {
+	if x != nil {
+		return x.Left
+	}
+	return nil
+}
+
+func (x *CompareJobsResponse) GetRight() *JobOutput {
+	if x != nil {
+		return x.Right
+	}
+	return nil
+}
+
+func (x *CompareJobsResponse) GetIdentical() bool {
+	if x != nil {
+		return x.Identical
+	}
+	return false
+}
+
+func (x *CompareJobsResponse) GetSizeDiff() int64 {
+	if x != nil {
+		return x.SizeDiff
+	}
+	return 0
+}
+
+func (x *CompareJobsResponse) GetLineDiff() *LineDiffSummary {
+	if x != nil {
+		return x.LineDiff
+	}
+	return nil
+}
+
+func (x *CompareJobsResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type JobContainer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContainerId   string                 `protobuf:"bytes,1,opt,name=container_id,proto3" json:"container_id,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,3,opt,name=exit_code,proto3" json:"exit_code,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=started_at,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=finished_at,proto3" json:"finished_at,omitempty"`
+	OomKilled     bool                   `protobuf:"varint,6,opt,name=oom_killed,proto3" json:"oom_killed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobContainer) Reset() {
+	*x = JobContainer{}
+	mi := &file_proto_management_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobContainer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobContainer) ProtoMessage() {}
+
+func (x *JobContainer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobContainer.ProtoReflect.Descriptor instead.
+func (*JobContainer) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *JobContainer) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *JobContainer) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *JobContainer) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *JobContainer) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *JobContainer) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *JobContainer) GetOomKilled() bool {
+	if x != nil {
+		return x.OomKilled
+	}
+	return false
+}
+
+type GetServerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoRequest) Reset() {
+	*x = GetServerInfoRequest{}
+	mi := &file_proto_management_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoRequest) ProtoMessage() {}
+
+func (x *GetServerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetServerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{39}
+}
+
+type GetServerInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Os            string                 `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
+	Arch          string                 `protobuf:"bytes,2,opt,name=arch,proto3" json:"arch,omitempty"`
+	Platform      Platform               `protobuf:"varint,3,opt,name=platform,proto3,enum=api.v1.Platform" json:"platform,omitempty"`
+	PlatformName  string                 `protobuf:"bytes,4,opt,name=platform_name,proto3" json:"platform_name,omitempty"`
+	Maintenance   *MaintenanceStatus     `protobuf:"bytes,5,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoResponse) Reset() {
+	*x = GetServerInfoResponse{}
+	mi := &file_proto_management_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoResponse) ProtoMessage() {}
+
+func (x *GetServerInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetServerInfoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetServerInfoResponse) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetPlatform() Platform {
+	if x != nil {
+		return x.Platform
+	}
+	return Platform_PLATFORM_DOCKER
+}
+
+func (x *GetServerInfoResponse) GetPlatformName() string {
+	if x != nil {
+		return x.PlatformName
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetMaintenance() *MaintenanceStatus {
+	if x != nil {
+		return x.Maintenance
+	}
+	return nil
+}
+
+type SetMaintenanceModeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReadOnly      bool                   `protobuf:"varint,1,opt,name=read_only,proto3" json:"read_only,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaintenanceModeRequest) Reset() {
+	*x = SetMaintenanceModeRequest{}
+	mi := &file_proto_management_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaintenanceModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaintenanceModeRequest) ProtoMessage() {}
+
+func (x *SetMaintenanceModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaintenanceModeRequest.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceModeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SetMaintenanceModeRequest) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *SetMaintenanceModeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type MaintenanceStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReadOnly      bool                   `protobuf:"varint,1,opt,name=read_only,proto3" json:"read_only,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceStatus) Reset() {
+	*x = MaintenanceStatus{}
+	mi := &file_proto_management_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceStatus) ProtoMessage() {}
+
+func (x *MaintenanceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceStatus.ProtoReflect.Descriptor instead.
+func (*MaintenanceStatus) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *MaintenanceStatus) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *MaintenanceStatus) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *MaintenanceStatus) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		return x.CreatedAt
+		return x.Since
 	}
 	return nil
 }
 
-type RollbackVersionRequest struct {
+type GetConfigRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AlgorithmId   string                 `protobuf:"bytes,1,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
-	VersionId     string                 `protobuf:"bytes,2,opt,name=version_id,proto3" json:"version_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RollbackVersionRequest) Reset() {
-	*x = RollbackVersionRequest{}
-	mi := &file_proto_management_proto_msgTypes[9]
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	mi := &file_proto_management_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RollbackVersionRequest) String() string {
+func (x *GetConfigRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RollbackVersionRequest) ProtoMessage() {}
+func (*GetConfigRequest) ProtoMessage() {}
 
-func (x *RollbackVersionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[9]
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -803,50 +3277,178 @@ func (x *RollbackVersionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RollbackVersionRequest.ProtoReflect.Descriptor instead.
-func (*RollbackVersionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *RollbackVersionRequest) GetAlgorithmId() string {
+type GetConfigResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 生效中的配置（已应用环境变量覆盖和默认值），密钥已脱敏
+	Config        *structpb.Struct `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	ConfigPath    string           `protobuf:"bytes,2,opt,name=config_path,proto3" json:"config_path,omitempty"`
+	LocalMode     bool             `protobuf:"varint,3,opt,name=local_mode,proto3" json:"local_mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigResponse) Reset() {
+	*x = GetConfigResponse{}
+	mi := &file_proto_management_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigResponse) ProtoMessage() {}
+
+func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[44]
 	if x != nil {
-		return x.AlgorithmId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetConfigResponse) GetConfig() *structpb.Struct {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *GetConfigResponse) GetConfigPath() string {
+	if x != nil {
+		return x.ConfigPath
 	}
 	return ""
 }
 
-func (x *RollbackVersionRequest) GetVersionId() string {
+func (x *GetConfigResponse) GetLocalMode() bool {
 	if x != nil {
-		return x.VersionId
+		return x.LocalMode
+	}
+	return false
+}
+
+type MigrateObjectsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 源 bucket 和前缀，bucket 为空时使用当前配置
+	SourceBucket string `protobuf:"bytes,1,opt,name=source_bucket,proto3" json:"source_bucket,omitempty"`
+	SourcePrefix string `protobuf:"bytes,2,opt,name=source_prefix,proto3" json:"source_prefix,omitempty"`
+	// 目标 bucket 和前缀，bucket 为空时使用当前配置
+	TargetBucket string `protobuf:"bytes,3,opt,name=target_bucket,proto3" json:"target_bucket,omitempty"`
+	TargetPrefix string `protobuf:"bytes,4,opt,name=target_prefix,proto3" json:"target_prefix,omitempty"`
+	// 只列出将要迁移的对象，不复制也不修改数据库
+	DryRun        bool `protobuf:"varint,5,opt,name=dry_run,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MigrateObjectsRequest) Reset() {
+	*x = MigrateObjectsRequest{}
+	mi := &file_proto_management_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateObjectsRequest) ProtoMessage() {}
+
+func (x *MigrateObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateObjectsRequest.ProtoReflect.Descriptor instead.
+func (*MigrateObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *MigrateObjectsRequest) GetSourceBucket() string {
+	if x != nil {
+		return x.SourceBucket
 	}
 	return ""
 }
 
-type UploadDataRequest struct {
+func (x *MigrateObjectsRequest) GetSourcePrefix() string {
+	if x != nil {
+		return x.SourcePrefix
+	}
+	return ""
+}
+
+func (x *MigrateObjectsRequest) GetTargetBucket() string {
+	if x != nil {
+		return x.TargetBucket
+	}
+	return ""
+}
+
+func (x *MigrateObjectsRequest) GetTargetPrefix() string {
+	if x != nil {
+		return x.TargetPrefix
+	}
+	return ""
+}
+
+func (x *MigrateObjectsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type MigratedObject struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
-	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
-	FileData      []byte                 `protobuf:"bytes,3,opt,name=file_data,proto3" json:"file_data,omitempty"`
-	MinioPath     string                 `protobuf:"bytes,4,opt,name=minio_path,proto3" json:"minio_path,omitempty"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // version / preset_data / job_result / backup
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SourceKey     string                 `protobuf:"bytes,3,opt,name=source_key,proto3" json:"source_key,omitempty"`
+	TargetKey     string                 `protobuf:"bytes,4,opt,name=target_key,proto3" json:"target_key,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // planned / copied / failed
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadDataRequest) Reset() {
-	*x = UploadDataRequest{}
-	mi := &file_proto_management_proto_msgTypes[10]
+func (x *MigratedObject) Reset() {
+	*x = MigratedObject{}
+	mi := &file_proto_management_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadDataRequest) String() string {
+func (x *MigratedObject) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadDataRequest) ProtoMessage() {}
+func (*MigratedObject) ProtoMessage() {}
 
-func (x *UploadDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[10]
+func (x *MigratedObject) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -857,62 +3459,266 @@ func (x *UploadDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadDataRequest.ProtoReflect.Descriptor instead.
-func (*UploadDataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use MigratedObject.ProtoReflect.Descriptor instead.
+func (*MigratedObject) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *MigratedObject) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *MigratedObject) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MigratedObject) GetSourceKey() string {
+	if x != nil {
+		return x.SourceKey
+	}
+	return ""
+}
+
+func (x *MigratedObject) GetTargetKey() string {
+	if x != nil {
+		return x.TargetKey
+	}
+	return ""
+}
+
+func (x *MigratedObject) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MigratedObject) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type MigrateObjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Objects       []*MigratedObject      `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
+	Copied        int32                  `protobuf:"varint,2,opt,name=copied,proto3" json:"copied,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	RowsUpdated   int32                  `protobuf:"varint,4,opt,name=rows_updated,proto3" json:"rows_updated,omitempty"`
+	DryRun        bool                   `protobuf:"varint,5,opt,name=dry_run,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MigrateObjectsResponse) Reset() {
+	*x = MigrateObjectsResponse{}
+	mi := &file_proto_management_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateObjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateObjectsResponse) ProtoMessage() {}
+
+func (x *MigrateObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateObjectsResponse.ProtoReflect.Descriptor instead.
+func (*MigrateObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *MigrateObjectsResponse) GetObjects() []*MigratedObject {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
+func (x *MigrateObjectsResponse) GetCopied() int32 {
+	if x != nil {
+		return x.Copied
+	}
+	return 0
+}
+
+func (x *MigrateObjectsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *MigrateObjectsResponse) GetRowsUpdated() int32 {
+	if x != nil {
+		return x.RowsUpdated
+	}
+	return 0
+}
+
+func (x *MigrateObjectsResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type GetOverviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOverviewRequest) Reset() {
+	*x = GetOverviewRequest{}
+	mi := &file_proto_management_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOverviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOverviewRequest) ProtoMessage() {}
+
+func (x *GetOverviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOverviewRequest.ProtoReflect.Descriptor instead.
+func (*GetOverviewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{48}
+}
+
+type GetOverviewResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AlgorithmCount  int64                  `protobuf:"varint,1,opt,name=algorithm_count,proto3" json:"algorithm_count,omitempty"`
+	PresetDataCount int64                  `protobuf:"varint,2,opt,name=preset_data_count,proto3" json:"preset_data_count,omitempty"`
+	JobCount        int64                  `protobuf:"varint,3,opt,name=job_count,proto3" json:"job_count,omitempty"`
+	JobsByStatus    map[string]int64       `protobuf:"bytes,4,rep,name=jobs_by_status,proto3" json:"jobs_by_status,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	GeneratedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=generated_at,proto3" json:"generated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetOverviewResponse) Reset() {
+	*x = GetOverviewResponse{}
+	mi := &file_proto_management_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOverviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOverviewResponse) ProtoMessage() {}
+
+func (x *GetOverviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOverviewResponse.ProtoReflect.Descriptor instead.
+func (*GetOverviewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetOverviewResponse) GetAlgorithmCount() int64 {
+	if x != nil {
+		return x.AlgorithmCount
+	}
+	return 0
 }
 
-func (x *UploadDataRequest) GetFilename() string {
+func (x *GetOverviewResponse) GetPresetDataCount() int64 {
 	if x != nil {
-		return x.Filename
+		return x.PresetDataCount
 	}
-	return ""
+	return 0
 }
 
-func (x *UploadDataRequest) GetCategory() string {
+func (x *GetOverviewResponse) GetJobCount() int64 {
 	if x != nil {
-		return x.Category
+		return x.JobCount
 	}
-	return ""
+	return 0
 }
 
-func (x *UploadDataRequest) GetFileData() []byte {
+func (x *GetOverviewResponse) GetJobsByStatus() map[string]int64 {
 	if x != nil {
-		return x.FileData
+		return x.JobsByStatus
 	}
 	return nil
 }
 
-func (x *UploadDataRequest) GetMinioPath() string {
+func (x *GetOverviewResponse) GetGeneratedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.MinioPath
+		return x.GeneratedAt
 	}
-	return ""
+	return nil
 }
 
-type UploadDataResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,proto3" json:"file_id,omitempty"`
-	MinioUrl      string                 `protobuf:"bytes,2,opt,name=minio_url,proto3" json:"minio_url,omitempty"`
+type GetUsageStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only jobs created in the last window_hours are counted (default 168, max 2160)
+	WindowHours int32 `protobuf:"varint,1,opt,name=window_hours,proto3" json:"window_hours,omitempty"`
+	// Number of algorithms returned, ordered by job count (default 10, max 100)
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadDataResponse) Reset() {
-	*x = UploadDataResponse{}
-	mi := &file_proto_management_proto_msgTypes[11]
+func (x *GetUsageStatsRequest) Reset() {
+	*x = GetUsageStatsRequest{}
+	mi := &file_proto_management_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadDataResponse) String() string {
+func (x *GetUsageStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadDataResponse) ProtoMessage() {}
+func (*GetUsageStatsRequest) ProtoMessage() {}
 
-func (x *UploadDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[11]
+func (x *GetUsageStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -923,49 +3729,54 @@ func (x *UploadDataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadDataResponse.ProtoReflect.Descriptor instead.
-func (*UploadDataResponse) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetUsageStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *UploadDataResponse) GetFileId() string {
+func (x *GetUsageStatsRequest) GetWindowHours() int32 {
 	if x != nil {
-		return x.FileId
+		return x.WindowHours
 	}
-	return ""
+	return 0
 }
 
-func (x *UploadDataResponse) GetMinioUrl() string {
+func (x *GetUsageStatsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.MinioUrl
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-type ListPresetDataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,proto3" json:"page_size,omitempty"`
+type AlgorithmUsage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AlgorithmId    string                 `protobuf:"bytes,1,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
+	AlgorithmName  string                 `protobuf:"bytes,2,opt,name=algorithm_name,proto3" json:"algorithm_name,omitempty"`
+	JobCount       int64                  `protobuf:"varint,3,opt,name=job_count,proto3" json:"job_count,omitempty"`
+	CompletedCount int64                  `protobuf:"varint,4,opt,name=completed_count,proto3" json:"completed_count,omitempty"`
+	// Failed and timed-out jobs
+	FailedCount int64 `protobuf:"varint,5,opt,name=failed_count,proto3" json:"failed_count,omitempty"`
+	// completed / (completed + failed); 0 when no job has finished
+	SuccessRate   float64 `protobuf:"fixed64,6,opt,name=success_rate,proto3" json:"success_rate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPresetDataRequest) Reset() {
-	*x = ListPresetDataRequest{}
-	mi := &file_proto_management_proto_msgTypes[12]
+func (x *AlgorithmUsage) Reset() {
+	*x = AlgorithmUsage{}
+	mi := &file_proto_management_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPresetDataRequest) String() string {
+func (x *AlgorithmUsage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPresetDataRequest) ProtoMessage() {}
+func (*AlgorithmUsage) ProtoMessage() {}
 
-func (x *ListPresetDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[12]
+func (x *AlgorithmUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -976,58 +3787,77 @@ func (x *ListPresetDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPresetDataRequest.ProtoReflect.Descriptor instead.
-func (*ListPresetDataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use AlgorithmUsage.ProtoReflect.Descriptor instead.
+func (*AlgorithmUsage) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *ListPresetDataRequest) GetCategory() string {
+func (x *AlgorithmUsage) GetAlgorithmId() string {
 	if x != nil {
-		return x.Category
+		return x.AlgorithmId
 	}
 	return ""
 }
 
-func (x *ListPresetDataRequest) GetPage() int32 {
+func (x *AlgorithmUsage) GetAlgorithmName() string {
 	if x != nil {
-		return x.Page
+		return x.AlgorithmName
+	}
+	return ""
+}
+
+func (x *AlgorithmUsage) GetJobCount() int64 {
+	if x != nil {
+		return x.JobCount
 	}
 	return 0
 }
 
-func (x *ListPresetDataRequest) GetPageSize() int32 {
+func (x *AlgorithmUsage) GetCompletedCount() int64 {
 	if x != nil {
-		return x.PageSize
+		return x.CompletedCount
 	}
 	return 0
 }
 
-type PresetData struct {
+func (x *AlgorithmUsage) GetFailedCount() int64 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *AlgorithmUsage) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+type GetUsageStatsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
-	MinioUrl      string                 `protobuf:"bytes,4,opt,name=minio_url,proto3" json:"minio_url,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,proto3" json:"created_at,omitempty"`
+	Algorithms    []*AlgorithmUsage      `protobuf:"bytes,1,rep,name=algorithms,proto3" json:"algorithms,omitempty"`
+	WindowStart   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=window_start,proto3" json:"window_start,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=generated_at,proto3" json:"generated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PresetData) Reset() {
-	*x = PresetData{}
-	mi := &file_proto_management_proto_msgTypes[13]
+func (x *GetUsageStatsResponse) Reset() {
+	*x = GetUsageStatsResponse{}
+	mi := &file_proto_management_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PresetData) String() string {
+func (x *GetUsageStatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PresetData) ProtoMessage() {}
+func (*GetUsageStatsResponse) ProtoMessage() {}
 
-func (x *PresetData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[13]
+func (x *GetUsageStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1038,69 +3868,110 @@ func (x *PresetData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PresetData.ProtoReflect.Descriptor instead.
-func (*PresetData) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use GetUsageStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *PresetData) GetId() string {
+func (x *GetUsageStatsResponse) GetAlgorithms() []*AlgorithmUsage {
 	if x != nil {
-		return x.Id
+		return x.Algorithms
 	}
-	return ""
+	return nil
 }
 
-func (x *PresetData) GetFilename() string {
+func (x *GetUsageStatsResponse) GetWindowStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Filename
+		return x.WindowStart
 	}
-	return ""
+	return nil
 }
 
-func (x *PresetData) GetCategory() string {
+func (x *GetUsageStatsResponse) GetGeneratedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Category
+		return x.GeneratedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *PresetData) GetMinioUrl() string {
+type GetRelatedAlgorithmsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Number of algorithms returned (default 5, max 50)
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedAlgorithmsRequest) Reset() {
+	*x = GetRelatedAlgorithmsRequest{}
+	mi := &file_proto_management_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedAlgorithmsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedAlgorithmsRequest) ProtoMessage() {}
+
+func (x *GetRelatedAlgorithmsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[53]
 	if x != nil {
-		return x.MinioUrl
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedAlgorithmsRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedAlgorithmsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetRelatedAlgorithmsRequest) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *PresetData) GetCreatedAt() *timestamppb.Timestamp {
+func (x *GetRelatedAlgorithmsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-type ListPresetDataResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Files         []*PresetData          `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+type RelatedAlgorithm struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm *Algorithm             `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// Tags shared with the requested algorithm, compared case-insensitively
+	SharedTags    []string `protobuf:"bytes,2,rep,name=shared_tags,proto3" json:"shared_tags,omitempty"`
+	SameCategory  bool     `protobuf:"varint,3,opt,name=same_category,proto3" json:"same_category,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListPresetDataResponse) Reset() {
-	*x = ListPresetDataResponse{}
-	mi := &file_proto_management_proto_msgTypes[14]
+func (x *RelatedAlgorithm) Reset() {
+	*x = RelatedAlgorithm{}
+	mi := &file_proto_management_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListPresetDataResponse) String() string {
+func (x *RelatedAlgorithm) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPresetDataResponse) ProtoMessage() {}
+func (*RelatedAlgorithm) ProtoMessage() {}
 
-func (x *ListPresetDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[14]
+func (x *RelatedAlgorithm) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1111,47 +3982,55 @@ func (x *ListPresetDataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPresetDataResponse.ProtoReflect.Descriptor instead.
-func (*ListPresetDataResponse) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use RelatedAlgorithm.ProtoReflect.Descriptor instead.
+func (*RelatedAlgorithm) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *ListPresetDataResponse) GetFiles() []*PresetData {
+func (x *RelatedAlgorithm) GetAlgorithm() *Algorithm {
 	if x != nil {
-		return x.Files
+		return x.Algorithm
 	}
 	return nil
 }
 
-func (x *ListPresetDataResponse) GetTotal() int32 {
+func (x *RelatedAlgorithm) GetSharedTags() []string {
 	if x != nil {
-		return x.Total
+		return x.SharedTags
 	}
-	return 0
+	return nil
 }
 
-type DeletePresetDataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *RelatedAlgorithm) GetSameCategory() bool {
+	if x != nil {
+		return x.SameCategory
+	}
+	return false
+}
+
+type GetRelatedAlgorithmsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered by number of shared tags, then same category, then name
+	Algorithms    []*RelatedAlgorithm `protobuf:"bytes,1,rep,name=algorithms,proto3" json:"algorithms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeletePresetDataRequest) Reset() {
-	*x = DeletePresetDataRequest{}
-	mi := &file_proto_management_proto_msgTypes[15]
+func (x *GetRelatedAlgorithmsResponse) Reset() {
+	*x = GetRelatedAlgorithmsResponse{}
+	mi := &file_proto_management_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeletePresetDataRequest) String() string {
+func (x *GetRelatedAlgorithmsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletePresetDataRequest) ProtoMessage() {}
+func (*GetRelatedAlgorithmsResponse) ProtoMessage() {}
 
-func (x *DeletePresetDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[15]
+func (x *GetRelatedAlgorithmsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1162,41 +4041,39 @@ func (x *DeletePresetDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletePresetDataRequest.ProtoReflect.Descriptor instead.
-func (*DeletePresetDataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use GetRelatedAlgorithmsResponse.ProtoReflect.Descriptor instead.
+func (*GetRelatedAlgorithmsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *DeletePresetDataRequest) GetId() string {
+func (x *GetRelatedAlgorithmsResponse) GetAlgorithms() []*RelatedAlgorithm {
 	if x != nil {
-		return x.Id
+		return x.Algorithms
 	}
-	return ""
+	return nil
 }
 
-type DeletePresetDataResponse struct {
+type TriggerBackupRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeletePresetDataResponse) Reset() {
-	*x = DeletePresetDataResponse{}
-	mi := &file_proto_management_proto_msgTypes[16]
+func (x *TriggerBackupRequest) Reset() {
+	*x = TriggerBackupRequest{}
+	mi := &file_proto_management_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeletePresetDataResponse) String() string {
+func (x *TriggerBackupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletePresetDataResponse) ProtoMessage() {}
+func (*TriggerBackupRequest) ProtoMessage() {}
 
-func (x *DeletePresetDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[16]
+func (x *TriggerBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1207,50 +4084,40 @@ func (x *DeletePresetDataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletePresetDataResponse.ProtoReflect.Descriptor instead.
-func (*DeletePresetDataResponse) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *DeletePresetDataResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *DeletePresetDataResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
+// Deprecated: Use TriggerBackupRequest.ProtoReflect.Descriptor instead.
+func (*TriggerBackupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{56}
 }
 
-type ListJobsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AlgorithmId   string                 `protobuf:"bytes,1,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,proto3" json:"page_size,omitempty"`
+type TriggerBackupResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JSON 备份的 MinIO 对象路径，MinIO 不可用时为本地文件路径
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// minio or local
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// 备份时的数据版本号和记录数量
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	RecordCount   int64                  `protobuf:"varint,4,opt,name=record_count,proto3" json:"record_count,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListJobsRequest) Reset() {
-	*x = ListJobsRequest{}
-	mi := &file_proto_management_proto_msgTypes[17]
+func (x *TriggerBackupResponse) Reset() {
+	*x = TriggerBackupResponse{}
+	mi := &file_proto_management_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListJobsRequest) String() string {
+func (x *TriggerBackupResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListJobsRequest) ProtoMessage() {}
+func (*TriggerBackupResponse) ProtoMessage() {}
 
-func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[17]
+func (x *TriggerBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1261,66 +4128,113 @@ func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
-func (*ListJobsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use TriggerBackupResponse.ProtoReflect.Descriptor instead.
+func (*TriggerBackupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *ListJobsRequest) GetAlgorithmId() string {
+func (x *TriggerBackupResponse) GetPath() string {
 	if x != nil {
-		return x.AlgorithmId
+		return x.Path
 	}
 	return ""
 }
 
-func (x *ListJobsRequest) GetStatus() string {
+func (x *TriggerBackupResponse) GetSource() string {
 	if x != nil {
-		return x.Status
+		return x.Source
 	}
 	return ""
 }
 
-func (x *ListJobsRequest) GetPage() int32 {
+func (x *TriggerBackupResponse) GetVersion() int64 {
 	if x != nil {
-		return x.Page
+		return x.Version
 	}
 	return 0
 }
 
-func (x *ListJobsRequest) GetPageSize() int32 {
+func (x *TriggerBackupResponse) GetRecordCount() int64 {
 	if x != nil {
-		return x.PageSize
+		return x.RecordCount
 	}
 	return 0
 }
 
-type JobSummary struct {
+func (x *TriggerBackupResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListBackupsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,proto3" json:"job_id,omitempty"`
-	AlgorithmId   string                 `protobuf:"bytes,2,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
-	AlgorithmName string                 `protobuf:"bytes,3,opt,name=algorithm_name,proto3" json:"algorithm_name,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,proto3" json:"created_at,omitempty"`
-	CostTimeMs    int32                  `protobuf:"varint,6,opt,name=cost_time_ms,proto3" json:"cost_time_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JobSummary) Reset() {
-	*x = JobSummary{}
-	mi := &file_proto_management_proto_msgTypes[18]
+func (x *ListBackupsRequest) Reset() {
+	*x = ListBackupsRequest{}
+	mi := &file_proto_management_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBackupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBackupsRequest) ProtoMessage() {}
+
+func (x *ListBackupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBackupsRequest.ProtoReflect.Descriptor instead.
+func (*ListBackupsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{58}
+}
+
+type BackupEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 恢复时传入的路径：MinIO 对象路径或本地文件路径
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// minio or local
+	Source      string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,proto3" json:"created_at,omitempty"`
+	Version     int64                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	RecordCount int64                  `protobuf:"varint,5,opt,name=record_count,proto3" json:"record_count,omitempty"`
+	// 备份文件大小（字节）
+	Size          int64 `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	SchemaVersion int32 `protobuf:"varint,7,opt,name=schema_version,proto3" json:"schema_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupEntry) Reset() {
+	*x = BackupEntry{}
+	mi := &file_proto_management_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JobSummary) String() string {
+func (x *BackupEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JobSummary) ProtoMessage() {}
+func (*BackupEntry) ProtoMessage() {}
 
-func (x *JobSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[18]
+func (x *BackupEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1331,76 +4245,83 @@ func (x *JobSummary) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JobSummary.ProtoReflect.Descriptor instead.
-func (*JobSummary) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use BackupEntry.ProtoReflect.Descriptor instead.
+func (*BackupEntry) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *JobSummary) GetJobId() string {
+func (x *BackupEntry) GetPath() string {
 	if x != nil {
-		return x.JobId
+		return x.Path
 	}
 	return ""
 }
 
-func (x *JobSummary) GetAlgorithmId() string {
+func (x *BackupEntry) GetSource() string {
 	if x != nil {
-		return x.AlgorithmId
+		return x.Source
 	}
 	return ""
 }
 
-func (x *JobSummary) GetAlgorithmName() string {
+func (x *BackupEntry) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.AlgorithmName
+		return x.CreatedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *JobSummary) GetStatus() string {
+func (x *BackupEntry) GetVersion() int64 {
 	if x != nil {
-		return x.Status
+		return x.Version
 	}
-	return ""
+	return 0
 }
 
-func (x *JobSummary) GetCreatedAt() *timestamppb.Timestamp {
+func (x *BackupEntry) GetRecordCount() int64 {
 	if x != nil {
-		return x.CreatedAt
+		return x.RecordCount
 	}
-	return nil
+	return 0
 }
 
-func (x *JobSummary) GetCostTimeMs() int32 {
+func (x *BackupEntry) GetSize() int64 {
 	if x != nil {
-		return x.CostTimeMs
+		return x.Size
 	}
 	return 0
 }
 
-type ListJobsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Jobs          []*JobSummary          `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+func (x *BackupEntry) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+type ListBackupsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 从新到旧排序
+	Backups       []*BackupEntry `protobuf:"bytes,1,rep,name=backups,proto3" json:"backups,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListJobsResponse) Reset() {
-	*x = ListJobsResponse{}
-	mi := &file_proto_management_proto_msgTypes[19]
+func (x *ListBackupsResponse) Reset() {
+	*x = ListBackupsResponse{}
+	mi := &file_proto_management_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListJobsResponse) String() string {
+func (x *ListBackupsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListJobsResponse) ProtoMessage() {}
+func (*ListBackupsResponse) ProtoMessage() {}
 
-func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[19]
+func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1411,47 +4332,41 @@ func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
-func (*ListJobsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ListBackupsResponse.ProtoReflect.Descriptor instead.
+func (*ListBackupsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *ListJobsResponse) GetJobs() []*JobSummary {
+func (x *ListBackupsResponse) GetBackups() []*BackupEntry {
 	if x != nil {
-		return x.Jobs
+		return x.Backups
 	}
 	return nil
 }
 
-func (x *ListJobsResponse) GetTotal() int32 {
-	if x != nil {
-		return x.Total
-	}
-	return 0
-}
-
-type GetJobDetailRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,proto3" json:"job_id,omitempty"`
+type RestoreBackupRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ListBackups 返回的 path
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobDetailRequest) Reset() {
-	*x = GetJobDetailRequest{}
-	mi := &file_proto_management_proto_msgTypes[20]
+func (x *RestoreBackupRequest) Reset() {
+	*x = RestoreBackupRequest{}
+	mi := &file_proto_management_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobDetailRequest) String() string {
+func (x *RestoreBackupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobDetailRequest) ProtoMessage() {}
+func (*RestoreBackupRequest) ProtoMessage() {}
 
-func (x *GetJobDetailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[20]
+func (x *RestoreBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1462,53 +4377,42 @@ func (x *GetJobDetailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobDetailRequest.ProtoReflect.Descriptor instead.
-func (*GetJobDetailRequest) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use RestoreBackupRequest.ProtoReflect.Descriptor instead.
+func (*RestoreBackupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *GetJobDetailRequest) GetJobId() string {
+func (x *RestoreBackupRequest) GetPath() string {
 	if x != nil {
-		return x.JobId
+		return x.Path
 	}
 	return ""
 }
 
-type JobDetail struct {
+type RestoredTable struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,proto3" json:"job_id,omitempty"`
-	AlgorithmId   string                 `protobuf:"bytes,2,opt,name=algorithm_id,proto3" json:"algorithm_id,omitempty"`
-	AlgorithmName string                 `protobuf:"bytes,3,opt,name=algorithm_name,proto3" json:"algorithm_name,omitempty"`
-	Mode          string                 `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	InputParams   string                 `protobuf:"bytes,6,opt,name=input_params,proto3" json:"input_params,omitempty"`
-	InputUrl      string                 `protobuf:"bytes,7,opt,name=input_url,proto3" json:"input_url,omitempty"`
-	OutputUrl     string                 `protobuf:"bytes,8,opt,name=output_url,proto3" json:"output_url,omitempty"`
-	LogUrl        string                 `protobuf:"bytes,9,opt,name=log_url,proto3" json:"log_url,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,proto3" json:"created_at,omitempty"`
-	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=started_at,proto3" json:"started_at,omitempty"`
-	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=finished_at,proto3" json:"finished_at,omitempty"`
-	CostTimeMs    int32                  `protobuf:"varint,13,opt,name=cost_time_ms,proto3" json:"cost_time_ms,omitempty"`
-	WorkerId      string                 `protobuf:"bytes,14,opt,name=worker_id,proto3" json:"worker_id,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Restored      int32                  `protobuf:"varint,2,opt,name=restored,proto3" json:"restored,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JobDetail) Reset() {
-	*x = JobDetail{}
-	mi := &file_proto_management_proto_msgTypes[21]
+func (x *RestoredTable) Reset() {
+	*x = RestoredTable{}
+	mi := &file_proto_management_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JobDetail) String() string {
+func (x *RestoredTable) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JobDetail) ProtoMessage() {}
+func (*RestoredTable) ProtoMessage() {}
 
-func (x *JobDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[21]
+func (x *RestoredTable) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1519,130 +4423,138 @@ func (x *JobDetail) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JobDetail.ProtoReflect.Descriptor instead.
-func (*JobDetail) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use RestoredTable.ProtoReflect.Descriptor instead.
+func (*RestoredTable) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *JobDetail) GetJobId() string {
+func (x *RestoredTable) GetName() string {
 	if x != nil {
-		return x.JobId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *JobDetail) GetAlgorithmId() string {
+func (x *RestoredTable) GetRestored() int32 {
 	if x != nil {
-		return x.AlgorithmId
+		return x.Restored
 	}
-	return ""
+	return 0
 }
 
-func (x *JobDetail) GetAlgorithmName() string {
+func (x *RestoredTable) GetFailed() int32 {
 	if x != nil {
-		return x.AlgorithmName
+		return x.Failed
 	}
-	return ""
+	return 0
 }
 
-func (x *JobDetail) GetMode() string {
-	if x != nil {
-		return x.Mode
-	}
-	return ""
+type RestoreBackupResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Path   string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Source string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// 有记录恢复失败
+	Partial       bool             `protobuf:"varint,3,opt,name=partial,proto3" json:"partial,omitempty"`
+	Tables        []*RestoredTable `protobuf:"bytes,4,rep,name=tables,proto3" json:"tables,omitempty"`
+	Warnings      []string         `protobuf:"bytes,5,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	DurationMs    int64            `protobuf:"varint,6,opt,name=duration_ms,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JobDetail) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
+func (x *RestoreBackupResponse) Reset() {
+	*x = RestoreBackupResponse{}
+	mi := &file_proto_management_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *JobDetail) GetInputParams() string {
-	if x != nil {
-		return x.InputParams
-	}
-	return ""
+func (x *RestoreBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *JobDetail) GetInputUrl() string {
+func (*RestoreBackupResponse) ProtoMessage() {}
+
+func (x *RestoreBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[63]
 	if x != nil {
-		return x.InputUrl
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *JobDetail) GetOutputUrl() string {
+// Deprecated: Use RestoreBackupResponse.ProtoReflect.Descriptor instead.
+func (*RestoreBackupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *RestoreBackupResponse) GetPath() string {
 	if x != nil {
-		return x.OutputUrl
+		return x.Path
 	}
 	return ""
 }
 
-func (x *JobDetail) GetLogUrl() string {
+func (x *RestoreBackupResponse) GetSource() string {
 	if x != nil {
-		return x.LogUrl
+		return x.Source
 	}
 	return ""
 }
 
-func (x *JobDetail) GetCreatedAt() *timestamppb.Timestamp {
+func (x *RestoreBackupResponse) GetPartial() bool {
 	if x != nil {
-		return x.CreatedAt
+		return x.Partial
 	}
-	return nil
+	return false
 }
 
-func (x *JobDetail) GetStartedAt() *timestamppb.Timestamp {
+func (x *RestoreBackupResponse) GetTables() []*RestoredTable {
 	if x != nil {
-		return x.StartedAt
+		return x.Tables
 	}
 	return nil
 }
 
-func (x *JobDetail) GetFinishedAt() *timestamppb.Timestamp {
+func (x *RestoreBackupResponse) GetWarnings() []string {
 	if x != nil {
-		return x.FinishedAt
+		return x.Warnings
 	}
 	return nil
 }
 
-func (x *JobDetail) GetCostTimeMs() int32 {
+func (x *RestoreBackupResponse) GetDurationMs() int64 {
 	if x != nil {
-		return x.CostTimeMs
+		return x.DurationMs
 	}
 	return 0
 }
 
-func (x *JobDetail) GetWorkerId() string {
-	if x != nil {
-		return x.WorkerId
-	}
-	return ""
-}
-
-type GetServerInfoRequest struct {
+type EnsureStorageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetServerInfoRequest) Reset() {
-	*x = GetServerInfoRequest{}
-	mi := &file_proto_management_proto_msgTypes[22]
+func (x *EnsureStorageRequest) Reset() {
+	*x = EnsureStorageRequest{}
+	mi := &file_proto_management_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetServerInfoRequest) String() string {
+func (x *EnsureStorageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetServerInfoRequest) ProtoMessage() {}
+func (*EnsureStorageRequest) ProtoMessage() {}
 
-func (x *GetServerInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[22]
+func (x *EnsureStorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1653,36 +4565,37 @@ func (x *GetServerInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetServerInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetServerInfoRequest) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use EnsureStorageRequest.ProtoReflect.Descriptor instead.
+func (*EnsureStorageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{64}
 }
 
-type GetServerInfoResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Os            string                 `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
-	Arch          string                 `protobuf:"bytes,2,opt,name=arch,proto3" json:"arch,omitempty"`
-	Platform      Platform               `protobuf:"varint,3,opt,name=platform,proto3,enum=api.v1.Platform" json:"platform,omitempty"`
-	PlatformName  string                 `protobuf:"bytes,4,opt,name=platform_name,proto3" json:"platform_name,omitempty"`
+type StorageCheckStep struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// bucket, write, read or delete
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Ok            bool   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	DurationMs    int64  `protobuf:"varint,4,opt,name=duration_ms,proto3" json:"duration_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetServerInfoResponse) Reset() {
-	*x = GetServerInfoResponse{}
-	mi := &file_proto_management_proto_msgTypes[23]
+func (x *StorageCheckStep) Reset() {
+	*x = StorageCheckStep{}
+	mi := &file_proto_management_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetServerInfoResponse) String() string {
+func (x *StorageCheckStep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetServerInfoResponse) ProtoMessage() {}
+func (*StorageCheckStep) ProtoMessage() {}
 
-func (x *GetServerInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_management_proto_msgTypes[23]
+func (x *StorageCheckStep) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1693,44 +4606,106 @@ func (x *GetServerInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetServerInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetServerInfoResponse) Descriptor() ([]byte, []int) {
-	return file_proto_management_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use StorageCheckStep.ProtoReflect.Descriptor instead.
+func (*StorageCheckStep) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *GetServerInfoResponse) GetOs() string {
+func (x *StorageCheckStep) GetName() string {
 	if x != nil {
-		return x.Os
+		return x.Name
 	}
 	return ""
 }
 
-func (x *GetServerInfoResponse) GetArch() string {
+func (x *StorageCheckStep) GetOk() bool {
 	if x != nil {
-		return x.Arch
+		return x.Ok
+	}
+	return false
+}
+
+func (x *StorageCheckStep) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *GetServerInfoResponse) GetPlatform() Platform {
+func (x *StorageCheckStep) GetDurationMs() int64 {
 	if x != nil {
-		return x.Platform
+		return x.DurationMs
 	}
-	return Platform_PLATFORM_DOCKER
+	return 0
 }
 
-func (x *GetServerInfoResponse) GetPlatformName() string {
+type EnsureStorageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True when every step succeeded
+	Ok     bool   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Bucket string `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Steps in execution order; steps after a failed bucket or write step are skipped
+	Steps         []*StorageCheckStep `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnsureStorageResponse) Reset() {
+	*x = EnsureStorageResponse{}
+	mi := &file_proto_management_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnsureStorageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnsureStorageResponse) ProtoMessage() {}
+
+func (x *EnsureStorageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_management_proto_msgTypes[66]
 	if x != nil {
-		return x.PlatformName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnsureStorageResponse.ProtoReflect.Descriptor instead.
+func (*EnsureStorageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_management_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *EnsureStorageResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *EnsureStorageResponse) GetBucket() string {
+	if x != nil {
+		return x.Bucket
 	}
 	return ""
 }
 
+func (x *EnsureStorageResponse) GetSteps() []*StorageCheckStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
 var File_proto_management_proto protoreflect.FileDescriptor
 
 const file_proto_management_proto_rawDesc = "" +
 	"\n" +
-	"\x16proto/management.proto\x12\x06api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb0\x02\n" +
+	"\x16proto/management.proto\x12\x06api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe0\x03\n" +
 	"\x16CreateAlgorithmRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
@@ -1742,12 +4717,21 @@ const file_proto_management_proto_rawDesc = "" +
 	"\x04tags\x18\x06 \x03(\tR\x04tags\x12&\n" +
 	"\x0epreset_data_id\x18\a \x01(\tR\x0epreset_data_id\x12\x1c\n" +
 	"\tfile_data\x18\b \x01(\fR\tfile_data\x12\x1c\n" +
-	"\tfile_name\x18\t \x01(\tR\tfile_name\"r\n" +
+	"\tfile_name\x18\t \x01(\tR\tfile_name\x12,\n" +
+	"\x11default_cpu_limit\x18\n" +
+	" \x01(\x02R\x11default_cpu_limit\x12,\n" +
+	"\x11default_memory_mb\x18\v \x01(\x05R\x11default_memory_mb\x12$\n" +
+	"\rparams_schema\x18\f \x01(\tR\rparams_schema\x12,\n" +
+	"\x11job_history_limit\x18\r \x01(\x05R\x11job_history_limit\"\xa2\x02\n" +
 	"\x16UpdateAlgorithmRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
-	"\x04tags\x18\x04 \x03(\tR\x04tags\"\xbb\x03\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12,\n" +
+	"\x11default_cpu_limit\x18\x05 \x01(\x02R\x11default_cpu_limit\x12,\n" +
+	"\x11default_memory_mb\x18\x06 \x01(\x05R\x11default_memory_mb\x12$\n" +
+	"\rparams_schema\x18\a \x01(\tR\rparams_schema\x12,\n" +
+	"\x11job_history_limit\x18\b \x01(\x05R\x11job_history_limit\"\x8d\x06\n" +
 	"\tAlgorithm\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1767,28 +4751,60 @@ const file_proto_management_proto_rawDesc = "" +
 	"created_at\x12:\n" +
 	"\n" +
 	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"updated_at\"\x81\x01\n" +
+	"updated_at\x12,\n" +
+	"\x11default_cpu_limit\x18\r \x01(\x02R\x11default_cpu_limit\x12,\n" +
+	"\x11default_memory_mb\x18\x0e \x01(\x05R\x11default_memory_mb\x12\x16\n" +
+	"\x06status\x18\x0f \x01(\tR\x06status\x12 \n" +
+	"\vdisabled_by\x18\x10 \x01(\tR\vdisabled_by\x12(\n" +
+	"\x0fdisabled_reason\x18\x11 \x01(\tR\x0fdisabled_reason\x12<\n" +
+	"\vdisabled_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\vdisabled_at\x12$\n" +
+	"\rparams_schema\x18\x13 \x01(\tR\rparams_schema\x12,\n" +
+	"\x11job_history_limit\x18\x14 \x01(\x05R\x11job_history_limit\"\xe3\x01\n" +
 	"\x15ListAlgorithmsRequest\x12\x1a\n" +
 	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x1a\n" +
 	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1c\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\tpage_size\"a\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\tpage_size\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\n" +
+	"page_token\x12\x14\n" +
+	"\x05query\x18\a \x01(\tR\x05query\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\"\x8b\x01\n" +
 	"\x16ListAlgorithmsResponse\x121\n" +
 	"\n" +
 	"algorithms\x18\x01 \x03(\v2\x11.api.v1.AlgorithmR\n" +
 	"algorithms\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"%\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12(\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\x0fnext_page_token\"c\n" +
+	"\x17DisableAlgorithmRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vdisabled_by\x18\x02 \x01(\tR\vdisabled_by\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"(\n" +
+	"\x16DeleteAlgorithmRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xa7\x01\n" +
+	"\x17DeleteAlgorithmResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x10deleted_versions\x18\x02 \x01(\x05R\x10deleted_versions\x12(\n" +
+	"\x0fdeleted_objects\x18\x03 \x01(\x05R\x0fdeleted_objects\x12&\n" +
+	"\x0efailed_objects\x18\x04 \x01(\x05R\x0efailed_objects\"(\n" +
+	"\x16EnableAlgorithmRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"%\n" +
 	"\x13GetAlgorithmRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"t\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"/\n" +
+	"\x19GetAlgorithmByNameRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xae\x01\n" +
 	"\x14GetAlgorithmResponse\x12/\n" +
 	"\talgorithm\x18\x01 \x01(\v2\x11.api.v1.AlgorithmR\talgorithm\x12+\n" +
-	"\bversions\x18\x02 \x03(\v2\x0f.api.v1.VersionR\bversions\"\xd0\x01\n" +
+	"\bversions\x18\x02 \x03(\v2\x0f.api.v1.VersionR\bversions\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12\"\n" +
+	"\fimage_status\x18\x04 \x01(\tR\fimage_status\"\xd0\x01\n" +
 	"\x14CreateVersionRequest\x12\"\n" +
 	"\falgorithm_id\x18\x01 \x01(\tR\falgorithm_id\x120\n" +
 	"\x13source_code_zip_url\x18\x02 \x01(\tR\x13source_code_zip_url\x12&\n" +
 	"\x0ecommit_message\x18\x03 \x01(\tR\x0ecommit_message\x12\x1c\n" +
 	"\tfile_data\x18\x04 \x01(\fR\tfile_data\x12\x1c\n" +
-	"\tfile_name\x18\x05 \x01(\tR\tfile_name\"\x95\x02\n" +
+	"\tfile_name\x18\x05 \x01(\tR\tfile_name\"\xdd\x02\n" +
 	"\aVersion\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\"\n" +
 	"\falgorithm_id\x18\x02 \x01(\tR\falgorithm_id\x12&\n" +
@@ -1800,7 +4816,9 @@ const file_proto_management_proto_rawDesc = "" +
 	"\x0ecommit_message\x18\x06 \x01(\tR\x0ecommit_message\x12:\n" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"created_at\"\\\n" +
+	"created_at\x12\"\n" +
+	"\fdownload_url\x18\b \x01(\tR\fdownload_url\x12\"\n" +
+	"\fimage_digest\x18\t \x01(\tR\fimage_digest\"\\\n" +
 	"\x16RollbackVersionRequest\x12\"\n" +
 	"\falgorithm_id\x18\x01 \x01(\tR\falgorithm_id\x12\x1e\n" +
 	"\n" +
@@ -1812,14 +4830,30 @@ const file_proto_management_proto_rawDesc = "" +
 	"\tfile_data\x18\x03 \x01(\fR\tfile_data\x12\x1e\n" +
 	"\n" +
 	"minio_path\x18\x04 \x01(\tR\n" +
-	"minio_path\"L\n" +
+	"minio_path\"h\n" +
 	"\x12UploadDataResponse\x12\x18\n" +
 	"\afile_id\x18\x01 \x01(\tR\afile_id\x12\x1c\n" +
-	"\tminio_url\x18\x02 \x01(\tR\tminio_url\"e\n" +
+	"\tminio_url\x18\x02 \x01(\tR\tminio_url\x12\x1a\n" +
+	"\bchecksum\x18\x03 \x01(\tR\bchecksum\">\n" +
+	" CreatePresetDataUploadURLRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\"\x9f\x01\n" +
+	"!CreatePresetDataUploadURLResponse\x12\x1e\n" +
+	"\n" +
+	"upload_url\x18\x01 \x01(\tR\n" +
+	"upload_url\x12\x1e\n" +
+	"\n" +
+	"minio_path\x18\x02 \x01(\tR\n" +
+	"minio_path\x12:\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expires_at\"\x85\x01\n" +
 	"\x15ListPresetDataRequest\x12\x1a\n" +
 	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1c\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\tpage_size\"\xae\x01\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\tpage_size\x12\x1e\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\n" +
+	"page_token\"\x82\x02\n" +
 	"\n" +
 	"PresetData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
@@ -1828,20 +4862,29 @@ const file_proto_management_proto_rawDesc = "" +
 	"\tminio_url\x18\x04 \x01(\tR\tminio_url\x12:\n" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"created_at\"X\n" +
+	"created_at\x12\x1a\n" +
+	"\bchecksum\x18\x06 \x01(\tR\bchecksum\x12\x12\n" +
+	"\x04size\x18\a \x01(\x03R\x04size\x12\"\n" +
+	"\fcontent_type\x18\b \x01(\tR\fcontent_type\"&\n" +
+	"\x14GetPresetDataRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x82\x01\n" +
 	"\x16ListPresetDataResponse\x12(\n" +
 	"\x05files\x18\x01 \x03(\v2\x12.api.v1.PresetDataR\x05files\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\")\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12(\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\x0fnext_page_token\")\n" +
 	"\x17DeletePresetDataRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"N\n" +
 	"\x18DeletePresetDataResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x7f\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9f\x01\n" +
 	"\x0fListJobsRequest\x12\"\n" +
 	"\falgorithm_id\x18\x01 \x01(\tR\falgorithm_id\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1c\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\tpage_size\"\xe8\x01\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\tpage_size\x12\x1e\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\n" +
+	"page_token\"\xe8\x01\n" +
 	"\n" +
 	"JobSummary\x12\x16\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x06job_id\x12\"\n" +
@@ -1851,12 +4894,28 @@ const file_proto_management_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"created_at\x12\"\n" +
-	"\fcost_time_ms\x18\x06 \x01(\x05R\fcost_time_ms\"P\n" +
+	"\fcost_time_ms\x18\x06 \x01(\x05R\fcost_time_ms\"z\n" +
 	"\x10ListJobsResponse\x12&\n" +
 	"\x04jobs\x18\x01 \x03(\v2\x12.api.v1.JobSummaryR\x04jobs\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"-\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12(\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\x0fnext_page_token\"*\n" +
+	"\x10DeleteJobRequest\x12\x16\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x06job_id\"\x7f\n" +
+	"\x11DeleteJobResponse\x12\x16\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x06job_id\x12(\n" +
+	"\x0fdeleted_objects\x18\x02 \x01(\x05R\x0fdeleted_objects\x12(\n" +
+	"\x0fbytes_reclaimed\x18\x03 \x01(\x03R\x0fbytes_reclaimed\"~\n" +
+	"\x10PurgeJobsRequest\x12*\n" +
+	"\x10older_than_hours\x18\x01 \x01(\x05R\x10older_than_hours\x12\x1a\n" +
+	"\bstatuses\x18\x02 \x03(\tR\bstatuses\x12\"\n" +
+	"\falgorithm_id\x18\x03 \x01(\tR\falgorithm_id\"\xab\x01\n" +
+	"\x11PurgeJobsResponse\x12 \n" +
+	"\vpurged_jobs\x18\x01 \x01(\x05R\vpurged_jobs\x12(\n" +
+	"\x0fdeleted_objects\x18\x02 \x01(\x05R\x0fdeleted_objects\x12(\n" +
+	"\x0fbytes_reclaimed\x18\x03 \x01(\x03R\x0fbytes_reclaimed\x12 \n" +
+	"\vfailed_jobs\x18\x04 \x01(\x05R\vfailed_jobs\"-\n" +
 	"\x13GetJobDetailRequest\x12\x16\n" +
-	"\x06job_id\x18\x01 \x01(\tR\x06job_id\"\x8f\x04\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x06job_id\"\xef\a\n" +
 	"\tJobDetail\x12\x16\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x06job_id\x12\"\n" +
 	"\falgorithm_id\x18\x02 \x01(\tR\falgorithm_id\x12&\n" +
@@ -1878,33 +4937,220 @@ const file_proto_management_proto_rawDesc = "" +
 	"started_at\x12<\n" +
 	"\vfinished_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vfinished_at\x12\"\n" +
 	"\fcost_time_ms\x18\r \x01(\x05R\fcost_time_ms\x12\x1c\n" +
-	"\tworker_id\x18\x0e \x01(\tR\tworker_id\"\x16\n" +
-	"\x14GetServerInfoRequest\"\x8f\x01\n" +
+	"\tworker_id\x18\x0e \x01(\tR\tworker_id\x12,\n" +
+	"\x11artifacts_expired\x18\x0f \x01(\bR\x11artifacts_expired\x12L\n" +
+	"\x13artifacts_expire_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x13artifacts_expire_at\x122\n" +
+	"\tcontainer\x18\x11 \x01(\v2\x14.api.v1.JobContainerR\tcontainer\x12(\n" +
+	"\x0fstatus_mismatch\x18\x12 \x01(\bR\x0fstatus_mismatch\x12\"\n" +
+	"\fretried_from\x18\x13 \x01(\tR\fretried_from\x12\x1e\n" +
+	"\n" +
+	"version_id\x18\x14 \x01(\tR\n" +
+	"version_id\x12\x18\n" +
+	"\awarning\x18\x15 \x01(\tR\awarning\x12&\n" +
+	"\x0ewebhook_status\x18\x16 \x01(\tR\x0ewebhook_status\x120\n" +
+	"\x13webhook_status_code\x18\x17 \x01(\x05R\x13webhook_status_code\x12$\n" +
+	"\rwebhook_error\x18\x18 \x01(\tR\rwebhook_error\x12&\n" +
+	"\x0efailure_reason\x18\x19 \x01(\tR\x0efailure_reason\"x\n" +
+	"\x12CompareJobsRequest\x12 \n" +
+	"\vleft_job_id\x18\x01 \x01(\tR\vleft_job_id\x12\"\n" +
+	"\fright_job_id\x18\x02 \x01(\tR\fright_job_id\x12\x1c\n" +
+	"\tmax_bytes\x18\x03 \x01(\x03R\tmax_bytes\"\x93\x01\n" +
+	"\tJobOutput\x12\x16\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x06job_id\x12\x1e\n" +
+	"\n" +
+	"object_key\x18\x02 \x01(\tR\n" +
+	"object_key\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\"\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\fcontent_type\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\"\xbb\x01\n" +
+	"\x0fLineDiffSummary\x12 \n" +
+	"\vadded_lines\x18\x01 \x01(\x05R\vadded_lines\x12$\n" +
+	"\rremoved_lines\x18\x02 \x01(\x05R\rremoved_lines\x12(\n" +
+	"\x0funchanged_lines\x18\x03 \x01(\x05R\x0funchanged_lines\x12\x18\n" +
+	"\apreview\x18\x04 \x03(\tR\apreview\x12\x1c\n" +
+	"\tunordered\x18\x05 \x01(\bR\tunordered\"\xf6\x01\n" +
+	"\x13CompareJobsResponse\x12%\n" +
+	"\x04left\x18\x01 \x01(\v2\x11.api.v1.JobOutputR\x04left\x12'\n" +
+	"\x05right\x18\x02 \x01(\v2\x11.api.v1.JobOutputR\x05right\x12\x1c\n" +
+	"\tidentical\x18\x03 \x01(\bR\tidentical\x12\x1c\n" +
+	"\tsize_diff\x18\x04 \x01(\x03R\tsize_diff\x125\n" +
+	"\tline_diff\x18\x05 \x01(\v2\x17.api.v1.LineDiffSummaryR\tline_diff\x12\x1c\n" +
+	"\ttruncated\x18\x06 \x01(\bR\ttruncated\"\x80\x02\n" +
+	"\fJobContainer\x12\"\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\fcontainer_id\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x1c\n" +
+	"\texit_code\x18\x03 \x01(\x05R\texit_code\x12:\n" +
+	"\n" +
+	"started_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"started_at\x12<\n" +
+	"\vfinished_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vfinished_at\x12\x1e\n" +
+	"\n" +
+	"oom_killed\x18\x06 \x01(\bR\n" +
+	"oom_killed\"\x16\n" +
+	"\x14GetServerInfoRequest\"\xcc\x01\n" +
 	"\x15GetServerInfoResponse\x12\x0e\n" +
 	"\x02os\x18\x01 \x01(\tR\x02os\x12\x12\n" +
 	"\x04arch\x18\x02 \x01(\tR\x04arch\x12,\n" +
 	"\bplatform\x18\x03 \x01(\x0e2\x10.api.v1.PlatformR\bplatform\x12$\n" +
-	"\rplatform_name\x18\x04 \x01(\tR\rplatform_name*\x8b\x01\n" +
+	"\rplatform_name\x18\x04 \x01(\tR\rplatform_name\x12;\n" +
+	"\vmaintenance\x18\x05 \x01(\v2\x19.api.v1.MaintenanceStatusR\vmaintenance\"Q\n" +
+	"\x19SetMaintenanceModeRequest\x12\x1c\n" +
+	"\tread_only\x18\x01 \x01(\bR\tread_only\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"{\n" +
+	"\x11MaintenanceStatus\x12\x1c\n" +
+	"\tread_only\x18\x01 \x01(\bR\tread_only\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"\x12\n" +
+	"\x10GetConfigRequest\"\x86\x01\n" +
+	"\x11GetConfigResponse\x12/\n" +
+	"\x06config\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06config\x12 \n" +
+	"\vconfig_path\x18\x02 \x01(\tR\vconfig_path\x12\x1e\n" +
+	"\n" +
+	"local_mode\x18\x03 \x01(\bR\n" +
+	"local_mode\"\xc9\x01\n" +
+	"\x15MigrateObjectsRequest\x12$\n" +
+	"\rsource_bucket\x18\x01 \x01(\tR\rsource_bucket\x12$\n" +
+	"\rsource_prefix\x18\x02 \x01(\tR\rsource_prefix\x12$\n" +
+	"\rtarget_bucket\x18\x03 \x01(\tR\rtarget_bucket\x12$\n" +
+	"\rtarget_prefix\x18\x04 \x01(\tR\rtarget_prefix\x12\x18\n" +
+	"\adry_run\x18\x05 \x01(\bR\adry_run\"\xa2\x01\n" +
+	"\x0eMigratedObject\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1e\n" +
+	"\n" +
+	"source_key\x18\x03 \x01(\tR\n" +
+	"source_key\x12\x1e\n" +
+	"\n" +
+	"target_key\x18\x04 \x01(\tR\n" +
+	"target_key\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\xb8\x01\n" +
+	"\x16MigrateObjectsResponse\x120\n" +
+	"\aobjects\x18\x01 \x03(\v2\x16.api.v1.MigratedObjectR\aobjects\x12\x16\n" +
+	"\x06copied\x18\x02 \x01(\x05R\x06copied\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12\"\n" +
+	"\frows_updated\x18\x04 \x01(\x05R\frows_updated\x12\x18\n" +
+	"\adry_run\x18\x05 \x01(\bR\adry_run\"\x14\n" +
+	"\x12GetOverviewRequest\"\xe3\x02\n" +
+	"\x13GetOverviewResponse\x12(\n" +
+	"\x0falgorithm_count\x18\x01 \x01(\x03R\x0falgorithm_count\x12,\n" +
+	"\x11preset_data_count\x18\x02 \x01(\x03R\x11preset_data_count\x12\x1c\n" +
+	"\tjob_count\x18\x03 \x01(\x03R\tjob_count\x12U\n" +
+	"\x0ejobs_by_status\x18\x04 \x03(\v2-.api.v1.GetOverviewResponse.JobsByStatusEntryR\x0ejobs_by_status\x12>\n" +
+	"\fgenerated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fgenerated_at\x1a?\n" +
+	"\x11JobsByStatusEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"P\n" +
+	"\x14GetUsageStatsRequest\x12\"\n" +
+	"\fwindow_hours\x18\x01 \x01(\x05R\fwindow_hours\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xec\x01\n" +
+	"\x0eAlgorithmUsage\x12\"\n" +
+	"\falgorithm_id\x18\x01 \x01(\tR\falgorithm_id\x12&\n" +
+	"\x0ealgorithm_name\x18\x02 \x01(\tR\x0ealgorithm_name\x12\x1c\n" +
+	"\tjob_count\x18\x03 \x01(\x03R\tjob_count\x12(\n" +
+	"\x0fcompleted_count\x18\x04 \x01(\x03R\x0fcompleted_count\x12\"\n" +
+	"\ffailed_count\x18\x05 \x01(\x03R\ffailed_count\x12\"\n" +
+	"\fsuccess_rate\x18\x06 \x01(\x01R\fsuccess_rate\"\xcf\x01\n" +
+	"\x15GetUsageStatsResponse\x126\n" +
+	"\n" +
+	"algorithms\x18\x01 \x03(\v2\x16.api.v1.AlgorithmUsageR\n" +
+	"algorithms\x12>\n" +
+	"\fwindow_start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fwindow_start\x12>\n" +
+	"\fgenerated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fgenerated_at\"C\n" +
+	"\x1bGetRelatedAlgorithmsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x8b\x01\n" +
+	"\x10RelatedAlgorithm\x12/\n" +
+	"\talgorithm\x18\x01 \x01(\v2\x11.api.v1.AlgorithmR\talgorithm\x12 \n" +
+	"\vshared_tags\x18\x02 \x03(\tR\vshared_tags\x12$\n" +
+	"\rsame_category\x18\x03 \x01(\bR\rsame_category\"X\n" +
+	"\x1cGetRelatedAlgorithmsResponse\x128\n" +
+	"\n" +
+	"algorithms\x18\x01 \x03(\v2\x18.api.v1.RelatedAlgorithmR\n" +
+	"algorithms\"\x16\n" +
+	"\x14TriggerBackupRequest\"\xbd\x01\n" +
+	"\x15TriggerBackupResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\x12\"\n" +
+	"\frecord_count\x18\x04 \x01(\x03R\frecord_count\x12:\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"created_at\"\x14\n" +
+	"\x12ListBackupsRequest\"\xef\x01\n" +
+	"\vBackupEntry\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12:\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"created_at\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\x12\"\n" +
+	"\frecord_count\x18\x05 \x01(\x03R\frecord_count\x12\x12\n" +
+	"\x04size\x18\x06 \x01(\x03R\x04size\x12&\n" +
+	"\x0eschema_version\x18\a \x01(\x05R\x0eschema_version\"D\n" +
+	"\x13ListBackupsResponse\x12-\n" +
+	"\abackups\x18\x01 \x03(\v2\x13.api.v1.BackupEntryR\abackups\"*\n" +
+	"\x14RestoreBackupRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\"W\n" +
+	"\rRestoredTable\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\brestored\x18\x02 \x01(\x05R\brestored\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"\xca\x01\n" +
+	"\x15RestoreBackupResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x18\n" +
+	"\apartial\x18\x03 \x01(\bR\apartial\x12-\n" +
+	"\x06tables\x18\x04 \x03(\v2\x15.api.v1.RestoredTableR\x06tables\x12\x1a\n" +
+	"\bwarnings\x18\x05 \x03(\tR\bwarnings\x12 \n" +
+	"\vduration_ms\x18\x06 \x01(\x03R\vduration_ms\"\x16\n" +
+	"\x14EnsureStorageRequest\"r\n" +
+	"\x10StorageCheckStep\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\bR\x02ok\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12 \n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\vduration_ms\"o\n" +
+	"\x15EnsureStorageResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x16\n" +
+	"\x06bucket\x18\x02 \x01(\tR\x06bucket\x12.\n" +
+	"\x05steps\x18\x03 \x03(\v2\x18.api.v1.StorageCheckStepR\x05steps*\x8b\x01\n" +
 	"\bPlatform\x12\x13\n" +
 	"\x0fPLATFORM_DOCKER\x10\x00\x12\x19\n" +
 	"\x15PLATFORM_LINUX_X86_64\x10\x01\x12\x18\n" +
 	"\x14PLATFORM_LINUX_ARM64\x10\x02\x12\x1b\n" +
 	"\x17PLATFORM_WINDOWS_X86_64\x10\x03\x12\x18\n" +
-	"\x14PLATFORM_MACOS_ARM64\x10\x042\xb0\n" +
-	"\n" +
+	"\x14PLATFORM_MACOS_ARM64\x10\x042\xb3\x1b\n" +
 	"\x11ManagementService\x12c\n" +
 	"\x0fCreateAlgorithm\x12\x1e.api.v1.CreateAlgorithmRequest\x1a\x11.api.v1.Algorithm\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/api/v1/algorithms\x12h\n" +
 	"\x0fUpdateAlgorithm\x12\x1e.api.v1.UpdateAlgorithmRequest\x1a\x11.api.v1.Algorithm\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\x1a\x17/api/v1/algorithms/{id}\x12k\n" +
-	"\x0eListAlgorithms\x12\x1d.api.v1.ListAlgorithmsRequest\x1a\x1e.api.v1.ListAlgorithmsResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/algorithms\x12j\n" +
-	"\fGetAlgorithm\x12\x1b.api.v1.GetAlgorithmRequest\x1a\x1c.api.v1.GetAlgorithmResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/algorithms/{id}\x12u\n" +
+	"\x0eListAlgorithms\x12\x1d.api.v1.ListAlgorithmsRequest\x1a\x1e.api.v1.ListAlgorithmsResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/algorithms\x12r\n" +
+	"\x10DisableAlgorithm\x12\x1f.api.v1.DisableAlgorithmRequest\x1a\x11.api.v1.Algorithm\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/algorithms/{id}/disable\x12o\n" +
+	"\x0fEnableAlgorithm\x12\x1e.api.v1.EnableAlgorithmRequest\x1a\x11.api.v1.Algorithm\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/api/v1/algorithms/{id}/enable\x12s\n" +
+	"\x0fDeleteAlgorithm\x12\x1e.api.v1.DeleteAlgorithmRequest\x1a\x1f.api.v1.DeleteAlgorithmResponse\"\x1f\x82\xd3\xe4\x93\x02\x19*\x17/api/v1/algorithms/{id}\x12j\n" +
+	"\fGetAlgorithm\x12\x1b.api.v1.GetAlgorithmRequest\x1a\x1c.api.v1.GetAlgorithmResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/algorithms/{id}\x12\x80\x01\n" +
+	"\x12GetAlgorithmByName\x12!.api.v1.GetAlgorithmByNameRequest\x1a\x1c.api.v1.GetAlgorithmResponse\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/algorithms/by-name/{name}\x12\x8a\x01\n" +
+	"\x14GetRelatedAlgorithms\x12#.api.v1.GetRelatedAlgorithmsRequest\x1a$.api.v1.GetRelatedAlgorithmsResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/algorithms/{id}/related\x12u\n" +
 	"\rCreateVersion\x12\x1c.api.v1.CreateVersionRequest\x1a\x0f.api.v1.Version\"5\x82\xd3\xe4\x93\x02/:\x01*\"*/api/v1/algorithms/{algorithm_id}/versions\x12\x91\x01\n" +
 	"\x0fRollbackVersion\x12\x1e.api.v1.RollbackVersionRequest\x1a\x11.api.v1.Algorithm\"K\x82\xd3\xe4\x93\x02E:\x01*\"@/api/v1/algorithms/{algorithm_id}/versions/{version_id}/rollback\x12i\n" +
-	"\x10UploadPresetData\x12\x19.api.v1.UploadDataRequest\x1a\x1a.api.v1.UploadDataResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/api/v1/data/upload\x12e\n" +
-	"\x0eListPresetData\x12\x1d.api.v1.ListPresetDataRequest\x1a\x1e.api.v1.ListPresetDataResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/api/v1/data\x12p\n" +
+	"\x10UploadPresetData\x12\x19.api.v1.UploadDataRequest\x1a\x1a.api.v1.UploadDataResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/api/v1/data/upload\x12\x94\x01\n" +
+	"\x19CreatePresetDataUploadURL\x12(.api.v1.CreatePresetDataUploadURLRequest\x1a).api.v1.CreatePresetDataUploadURLResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/data/upload-url\x12e\n" +
+	"\x0eListPresetData\x12\x1d.api.v1.ListPresetDataRequest\x1a\x1e.api.v1.ListPresetDataResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/api/v1/data\x12\\\n" +
+	"\rGetPresetData\x12\x1c.api.v1.GetPresetDataRequest\x1a\x12.api.v1.PresetData\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/data/{id}\x12p\n" +
 	"\x10DeletePresetData\x12\x1f.api.v1.DeletePresetDataRequest\x1a .api.v1.DeletePresetDataResponse\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/api/v1/data/{id}\x12S\n" +
 	"\bListJobs\x12\x17.api.v1.ListJobsRequest\x1a\x18.api.v1.ListJobsResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/api/v1/jobs\x12d\n" +
-	"\fGetJobDetail\x12\x1b.api.v1.GetJobDetailRequest\x1a\x11.api.v1.JobDetail\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/jobs/{job_id}/detail\x12i\n" +
-	"\rGetServerInfo\x12\x1c.api.v1.GetServerInfoRequest\x1a\x1d.api.v1.GetServerInfoResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/api/v1/server/infoB$Z\"algorithm-platform/api/v1/proto;v1b\x06proto3"
+	"\fGetJobDetail\x12\x1b.api.v1.GetJobDetailRequest\x1a\x11.api.v1.JobDetail\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/jobs/{job_id}/detail\x12_\n" +
+	"\tDeleteJob\x12\x18.api.v1.DeleteJobRequest\x1a\x19.api.v1.DeleteJobResponse\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/api/v1/jobs/{job_id}\x12_\n" +
+	"\tPurgeJobs\x12\x18.api.v1.PurgeJobsRequest\x1a\x19.api.v1.PurgeJobsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/api/v1/jobs/purge\x12g\n" +
+	"\vCompareJobs\x12\x1a.api.v1.CompareJobsRequest\x1a\x1b.api.v1.CompareJobsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/api/v1/jobs/compare\x12i\n" +
+	"\rGetServerInfo\x12\x1c.api.v1.GetServerInfoRequest\x1a\x1d.api.v1.GetServerInfoResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/api/v1/server/info\x12y\n" +
+	"\x12SetMaintenanceMode\x12!.api.v1.SetMaintenanceModeRequest\x1a\x19.api.v1.MaintenanceStatus\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\x1a\x1a/api/v1/server/maintenance\x12_\n" +
+	"\tGetConfig\x12\x18.api.v1.GetConfigRequest\x1a\x19.api.v1.GetConfigResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/server/config\x12v\n" +
+	"\rEnsureStorage\x12\x1c.api.v1.EnsureStorageRequest\x1a\x1d.api.v1.EnsureStorageResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/server/ensure-storage\x12z\n" +
+	"\x0eMigrateObjects\x12\x1d.api.v1.MigrateObjectsRequest\x1a\x1e.api.v1.MigrateObjectsResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/api/v1/server/migrate-objects\x12m\n" +
+	"\rTriggerBackup\x12\x1c.api.v1.TriggerBackupRequest\x1a\x1d.api.v1.TriggerBackupResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/api/v1/admin/backup\x12e\n" +
+	"\vListBackups\x12\x1a.api.v1.ListBackupsRequest\x1a\x1b.api.v1.ListBackupsResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/admin/backups\x12v\n" +
+	"\rRestoreBackup\x12\x1c.api.v1.RestoreBackupRequest\x1a\x1d.api.v1.RestoreBackupResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/admin/backups/restore\x12g\n" +
+	"\vGetOverview\x12\x1a.api.v1.GetOverviewRequest\x1a\x1b.api.v1.GetOverviewResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/server/overview\x12p\n" +
+	"\rGetUsageStats\x12\x1c.api.v1.GetUsageStatsRequest\x1a\x1d.api.v1.GetUsageStatsResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/server/usage-statsB$Z\"algorithm-platform/api/v1/proto;v1b\x06proto3"
 
 var (
 	file_proto_management_proto_rawDescOnce sync.Once
@@ -1919,81 +5165,189 @@ func file_proto_management_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_management_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_management_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_proto_management_proto_msgTypes = make([]protoimpl.MessageInfo, 68)
 var file_proto_management_proto_goTypes = []any{
-	(Platform)(0),                    // 0: api.v1.Platform
-	(*CreateAlgorithmRequest)(nil),   // 1: api.v1.CreateAlgorithmRequest
-	(*UpdateAlgorithmRequest)(nil),   // 2: api.v1.UpdateAlgorithmRequest
-	(*Algorithm)(nil),                // 3: api.v1.Algorithm
-	(*ListAlgorithmsRequest)(nil),    // 4: api.v1.ListAlgorithmsRequest
-	(*ListAlgorithmsResponse)(nil),   // 5: api.v1.ListAlgorithmsResponse
-	(*GetAlgorithmRequest)(nil),      // 6: api.v1.GetAlgorithmRequest
-	(*GetAlgorithmResponse)(nil),     // 7: api.v1.GetAlgorithmResponse
-	(*CreateVersionRequest)(nil),     // 8: api.v1.CreateVersionRequest
-	(*Version)(nil),                  // 9: api.v1.Version
-	(*RollbackVersionRequest)(nil),   // 10: api.v1.RollbackVersionRequest
-	(*UploadDataRequest)(nil),        // 11: api.v1.UploadDataRequest
-	(*UploadDataResponse)(nil),       // 12: api.v1.UploadDataResponse
-	(*ListPresetDataRequest)(nil),    // 13: api.v1.ListPresetDataRequest
-	(*PresetData)(nil),               // 14: api.v1.PresetData
-	(*ListPresetDataResponse)(nil),   // 15: api.v1.ListPresetDataResponse
-	(*DeletePresetDataRequest)(nil),  // 16: api.v1.DeletePresetDataRequest
-	(*DeletePresetDataResponse)(nil), // 17: api.v1.DeletePresetDataResponse
-	(*ListJobsRequest)(nil),          // 18: api.v1.ListJobsRequest
-	(*JobSummary)(nil),               // 19: api.v1.JobSummary
-	(*ListJobsResponse)(nil),         // 20: api.v1.ListJobsResponse
-	(*GetJobDetailRequest)(nil),      // 21: api.v1.GetJobDetailRequest
-	(*JobDetail)(nil),                // 22: api.v1.JobDetail
-	(*GetServerInfoRequest)(nil),     // 23: api.v1.GetServerInfoRequest
-	(*GetServerInfoResponse)(nil),    // 24: api.v1.GetServerInfoResponse
-	(*timestamppb.Timestamp)(nil),    // 25: google.protobuf.Timestamp
+	(Platform)(0),                             // 0: api.v1.Platform
+	(*CreateAlgorithmRequest)(nil),            // 1: api.v1.CreateAlgorithmRequest
+	(*UpdateAlgorithmRequest)(nil),            // 2: api.v1.UpdateAlgorithmRequest
+	(*Algorithm)(nil),                         // 3: api.v1.Algorithm
+	(*ListAlgorithmsRequest)(nil),             // 4: api.v1.ListAlgorithmsRequest
+	(*ListAlgorithmsResponse)(nil),            // 5: api.v1.ListAlgorithmsResponse
+	(*DisableAlgorithmRequest)(nil),           // 6: api.v1.DisableAlgorithmRequest
+	(*DeleteAlgorithmRequest)(nil),            // 7: api.v1.DeleteAlgorithmRequest
+	(*DeleteAlgorithmResponse)(nil),           // 8: api.v1.DeleteAlgorithmResponse
+	(*EnableAlgorithmRequest)(nil),            // 9: api.v1.EnableAlgorithmRequest
+	(*GetAlgorithmRequest)(nil),               // 10: api.v1.GetAlgorithmRequest
+	(*GetAlgorithmByNameRequest)(nil),         // 11: api.v1.GetAlgorithmByNameRequest
+	(*GetAlgorithmResponse)(nil),              // 12: api.v1.GetAlgorithmResponse
+	(*CreateVersionRequest)(nil),              // 13: api.v1.CreateVersionRequest
+	(*Version)(nil),                           // 14: api.v1.Version
+	(*RollbackVersionRequest)(nil),            // 15: api.v1.RollbackVersionRequest
+	(*UploadDataRequest)(nil),                 // 16: api.v1.UploadDataRequest
+	(*UploadDataResponse)(nil),                // 17: api.v1.UploadDataResponse
+	(*CreatePresetDataUploadURLRequest)(nil),  // 18: api.v1.CreatePresetDataUploadURLRequest
+	(*CreatePresetDataUploadURLResponse)(nil), // 19: api.v1.CreatePresetDataUploadURLResponse
+	(*ListPresetDataRequest)(nil),             // 20: api.v1.ListPresetDataRequest
+	(*PresetData)(nil),                        // 21: api.v1.PresetData
+	(*GetPresetDataRequest)(nil),              // 22: api.v1.GetPresetDataRequest
+	(*ListPresetDataResponse)(nil),            // 23: api.v1.ListPresetDataResponse
+	(*DeletePresetDataRequest)(nil),           // 24: api.v1.DeletePresetDataRequest
+	(*DeletePresetDataResponse)(nil),          // 25: api.v1.DeletePresetDataResponse
+	(*ListJobsRequest)(nil),                   // 26: api.v1.ListJobsRequest
+	(*JobSummary)(nil),                        // 27: api.v1.JobSummary
+	(*ListJobsResponse)(nil),                  // 28: api.v1.ListJobsResponse
+	(*DeleteJobRequest)(nil),                  // 29: api.v1.DeleteJobRequest
+	(*DeleteJobResponse)(nil),                 // 30: api.v1.DeleteJobResponse
+	(*PurgeJobsRequest)(nil),                  // 31: api.v1.PurgeJobsRequest
+	(*PurgeJobsResponse)(nil),                 // 32: api.v1.PurgeJobsResponse
+	(*GetJobDetailRequest)(nil),               // 33: api.v1.GetJobDetailRequest
+	(*JobDetail)(nil),                         // 34: api.v1.JobDetail
+	(*CompareJobsRequest)(nil),                // 35: api.v1.CompareJobsRequest
+	(*JobOutput)(nil),                         // 36: api.v1.JobOutput
+	(*LineDiffSummary)(nil),                   // 37: api.v1.LineDiffSummary
+	(*CompareJobsResponse)(nil),               // 38: api.v1.CompareJobsResponse
+	(*JobContainer)(nil),                      // 39: api.v1.JobContainer
+	(*GetServerInfoRequest)(nil),              // 40: api.v1.GetServerInfoRequest
+	(*GetServerInfoResponse)(nil),             // 41: api.v1.GetServerInfoResponse
+	(*SetMaintenanceModeRequest)(nil),         // 42: api.v1.SetMaintenanceModeRequest
+	(*MaintenanceStatus)(nil),                 // 43: api.v1.MaintenanceStatus
+	(*GetConfigRequest)(nil),                  // 44: api.v1.GetConfigRequest
+	(*GetConfigResponse)(nil),                 // 45: api.v1.GetConfigResponse
+	(*MigrateObjectsRequest)(nil),             // 46: api.v1.MigrateObjectsRequest
+	(*MigratedObject)(nil),                    // 47: api.v1.MigratedObject
+	(*MigrateObjectsResponse)(nil),            // 48: api.v1.MigrateObjectsResponse
+	(*GetOverviewRequest)(nil),                // 49: api.v1.GetOverviewRequest
+	(*GetOverviewResponse)(nil),               // 50: api.v1.GetOverviewResponse
+	(*GetUsageStatsRequest)(nil),              // 51: api.v1.GetUsageStatsRequest
+	(*AlgorithmUsage)(nil),                    // 52: api.v1.AlgorithmUsage
+	(*GetUsageStatsResponse)(nil),             // 53: api.v1.GetUsageStatsResponse
+	(*GetRelatedAlgorithmsRequest)(nil),       // 54: api.v1.GetRelatedAlgorithmsRequest
+	(*RelatedAlgorithm)(nil),                  // 55: api.v1.RelatedAlgorithm
+	(*GetRelatedAlgorithmsResponse)(nil),      // 56: api.v1.GetRelatedAlgorithmsResponse
+	(*TriggerBackupRequest)(nil),              // 57: api.v1.TriggerBackupRequest
+	(*TriggerBackupResponse)(nil),             // 58: api.v1.TriggerBackupResponse
+	(*ListBackupsRequest)(nil),                // 59: api.v1.ListBackupsRequest
+	(*BackupEntry)(nil),                       // 60: api.v1.BackupEntry
+	(*ListBackupsResponse)(nil),               // 61: api.v1.ListBackupsResponse
+	(*RestoreBackupRequest)(nil),              // 62: api.v1.RestoreBackupRequest
+	(*RestoredTable)(nil),                     // 63: api.v1.RestoredTable
+	(*RestoreBackupResponse)(nil),             // 64: api.v1.RestoreBackupResponse
+	(*EnsureStorageRequest)(nil),              // 65: api.v1.EnsureStorageRequest
+	(*StorageCheckStep)(nil),                  // 66: api.v1.StorageCheckStep
+	(*EnsureStorageResponse)(nil),             // 67: api.v1.EnsureStorageResponse
+	nil,                                       // 68: api.v1.GetOverviewResponse.JobsByStatusEntry
+	(*timestamppb.Timestamp)(nil),             // 69: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),                   // 70: google.protobuf.Struct
 }
 var file_proto_management_proto_depIdxs = []int32{
 	0,  // 0: api.v1.CreateAlgorithmRequest.platform:type_name -> api.v1.Platform
 	0,  // 1: api.v1.Algorithm.platform:type_name -> api.v1.Platform
-	25, // 2: api.v1.Algorithm.created_at:type_name -> google.protobuf.Timestamp
-	25, // 3: api.v1.Algorithm.updated_at:type_name -> google.protobuf.Timestamp
-	3,  // 4: api.v1.ListAlgorithmsResponse.algorithms:type_name -> api.v1.Algorithm
-	3,  // 5: api.v1.GetAlgorithmResponse.algorithm:type_name -> api.v1.Algorithm
-	9,  // 6: api.v1.GetAlgorithmResponse.versions:type_name -> api.v1.Version
-	25, // 7: api.v1.Version.created_at:type_name -> google.protobuf.Timestamp
-	25, // 8: api.v1.PresetData.created_at:type_name -> google.protobuf.Timestamp
-	14, // 9: api.v1.ListPresetDataResponse.files:type_name -> api.v1.PresetData
-	25, // 10: api.v1.JobSummary.created_at:type_name -> google.protobuf.Timestamp
-	19, // 11: api.v1.ListJobsResponse.jobs:type_name -> api.v1.JobSummary
-	25, // 12: api.v1.JobDetail.created_at:type_name -> google.protobuf.Timestamp
-	25, // 13: api.v1.JobDetail.started_at:type_name -> google.protobuf.Timestamp
-	25, // 14: api.v1.JobDetail.finished_at:type_name -> google.protobuf.Timestamp
-	0,  // 15: api.v1.GetServerInfoResponse.platform:type_name -> api.v1.Platform
-	1,  // 16: api.v1.ManagementService.CreateAlgorithm:input_type -> api.v1.CreateAlgorithmRequest
-	2,  // 17: api.v1.ManagementService.UpdateAlgorithm:input_type -> api.v1.UpdateAlgorithmRequest
-	4,  // 18: api.v1.ManagementService.ListAlgorithms:input_type -> api.v1.ListAlgorithmsRequest
-	6,  // 19: api.v1.ManagementService.GetAlgorithm:input_type -> api.v1.GetAlgorithmRequest
-	8,  // 20: api.v1.ManagementService.CreateVersion:input_type -> api.v1.CreateVersionRequest
-	10, // 21: api.v1.ManagementService.RollbackVersion:input_type -> api.v1.RollbackVersionRequest
-	11, // 22: api.v1.ManagementService.UploadPresetData:input_type -> api.v1.UploadDataRequest
-	13, // 23: api.v1.ManagementService.ListPresetData:input_type -> api.v1.ListPresetDataRequest
-	16, // 24: api.v1.ManagementService.DeletePresetData:input_type -> api.v1.DeletePresetDataRequest
-	18, // 25: api.v1.ManagementService.ListJobs:input_type -> api.v1.ListJobsRequest
-	21, // 26: api.v1.ManagementService.GetJobDetail:input_type -> api.v1.GetJobDetailRequest
-	23, // 27: api.v1.ManagementService.GetServerInfo:input_type -> api.v1.GetServerInfoRequest
-	3,  // 28: api.v1.ManagementService.CreateAlgorithm:output_type -> api.v1.Algorithm
-	3,  // 29: api.v1.ManagementService.UpdateAlgorithm:output_type -> api.v1.Algorithm
-	5,  // 30: api.v1.ManagementService.ListAlgorithms:output_type -> api.v1.ListAlgorithmsResponse
-	7,  // 31: api.v1.ManagementService.GetAlgorithm:output_type -> api.v1.GetAlgorithmResponse
-	9,  // 32: api.v1.ManagementService.CreateVersion:output_type -> api.v1.Version
-	3,  // 33: api.v1.ManagementService.RollbackVersion:output_type -> api.v1.Algorithm
-	12, // 34: api.v1.ManagementService.UploadPresetData:output_type -> api.v1.UploadDataResponse
-	15, // 35: api.v1.ManagementService.ListPresetData:output_type -> api.v1.ListPresetDataResponse
-	17, // 36: api.v1.ManagementService.DeletePresetData:output_type -> api.v1.DeletePresetDataResponse
-	20, // 37: api.v1.ManagementService.ListJobs:output_type -> api.v1.ListJobsResponse
-	22, // 38: api.v1.ManagementService.GetJobDetail:output_type -> api.v1.JobDetail
-	24, // 39: api.v1.ManagementService.GetServerInfo:output_type -> api.v1.GetServerInfoResponse
-	28, // [28:40] is the sub-list for method output_type
-	16, // [16:28] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	69, // 2: api.v1.Algorithm.created_at:type_name -> google.protobuf.Timestamp
+	69, // 3: api.v1.Algorithm.updated_at:type_name -> google.protobuf.Timestamp
+	69, // 4: api.v1.Algorithm.disabled_at:type_name -> google.protobuf.Timestamp
+	3,  // 5: api.v1.ListAlgorithmsResponse.algorithms:type_name -> api.v1.Algorithm
+	3,  // 6: api.v1.GetAlgorithmResponse.algorithm:type_name -> api.v1.Algorithm
+	14, // 7: api.v1.GetAlgorithmResponse.versions:type_name -> api.v1.Version
+	69, // 8: api.v1.Version.created_at:type_name -> google.protobuf.Timestamp
+	69, // 9: api.v1.CreatePresetDataUploadURLResponse.expires_at:type_name -> google.protobuf.Timestamp
+	69, // 10: api.v1.PresetData.created_at:type_name -> google.protobuf.Timestamp
+	21, // 11: api.v1.ListPresetDataResponse.files:type_name -> api.v1.PresetData
+	69, // 12: api.v1.JobSummary.created_at:type_name -> google.protobuf.Timestamp
+	27, // 13: api.v1.ListJobsResponse.jobs:type_name -> api.v1.JobSummary
+	69, // 14: api.v1.JobDetail.created_at:type_name -> google.protobuf.Timestamp
+	69, // 15: api.v1.JobDetail.started_at:type_name -> google.protobuf.Timestamp
+	69, // 16: api.v1.JobDetail.finished_at:type_name -> google.protobuf.Timestamp
+	69, // 17: api.v1.JobDetail.artifacts_expire_at:type_name -> google.protobuf.Timestamp
+	39, // 18: api.v1.JobDetail.container:type_name -> api.v1.JobContainer
+	36, // 19: api.v1.CompareJobsResponse.left:type_name -> api.v1.JobOutput
+	36, // 20: api.v1.CompareJobsResponse.right:type_name -> api.v1.JobOutput
+	37, // 21: api.v1.CompareJobsResponse.line_diff:type_name -> api.v1.LineDiffSummary
+	69, // 22: api.v1.JobContainer.started_at:type_name -> google.protobuf.Timestamp
+	69, // 23: api.v1.JobContainer.finished_at:type_name -> google.protobuf.Timestamp
+	0,  // 24: api.v1.GetServerInfoResponse.platform:type_name -> api.v1.Platform
+	43, // 25: api.v1.GetServerInfoResponse.maintenance:type_name -> api.v1.MaintenanceStatus
+	69, // 26: api.v1.MaintenanceStatus.since:type_name -> google.protobuf.Timestamp
+	70, // 27: api.v1.GetConfigResponse.config:type_name -> google.protobuf.Struct
+	47, // 28: api.v1.MigrateObjectsResponse.objects:type_name -> api.v1.MigratedObject
+	68, // 29: api.v1.GetOverviewResponse.jobs_by_status:type_name -> api.v1.GetOverviewResponse.JobsByStatusEntry
+	69, // 30: api.v1.GetOverviewResponse.generated_at:type_name -> google.protobuf.Timestamp
+	52, // 31: api.v1.GetUsageStatsResponse.algorithms:type_name -> api.v1.AlgorithmUsage
+	69, // 32: api.v1.GetUsageStatsResponse.window_start:type_name -> google.protobuf.Timestamp
+	69, // 33: api.v1.GetUsageStatsResponse.generated_at:type_name -> google.protobuf.Timestamp
+	3,  // 34: api.v1.RelatedAlgorithm.algorithm:type_name -> api.v1.Algorithm
+	55, // 35: api.v1.GetRelatedAlgorithmsResponse.algorithms:type_name -> api.v1.RelatedAlgorithm
+	69, // 36: api.v1.TriggerBackupResponse.created_at:type_name -> google.protobuf.Timestamp
+	69, // 37: api.v1.BackupEntry.created_at:type_name -> google.protobuf.Timestamp
+	60, // 38: api.v1.ListBackupsResponse.backups:type_name -> api.v1.BackupEntry
+	63, // 39: api.v1.RestoreBackupResponse.tables:type_name -> api.v1.RestoredTable
+	66, // 40: api.v1.EnsureStorageResponse.steps:type_name -> api.v1.StorageCheckStep
+	1,  // 41: api.v1.ManagementService.CreateAlgorithm:input_type -> api.v1.CreateAlgorithmRequest
+	2,  // 42: api.v1.ManagementService.UpdateAlgorithm:input_type -> api.v1.UpdateAlgorithmRequest
+	4,  // 43: api.v1.ManagementService.ListAlgorithms:input_type -> api.v1.ListAlgorithmsRequest
+	6,  // 44: api.v1.ManagementService.DisableAlgorithm:input_type -> api.v1.DisableAlgorithmRequest
+	9,  // 45: api.v1.ManagementService.EnableAlgorithm:input_type -> api.v1.EnableAlgorithmRequest
+	7,  // 46: api.v1.ManagementService.DeleteAlgorithm:input_type -> api.v1.DeleteAlgorithmRequest
+	10, // 47: api.v1.ManagementService.GetAlgorithm:input_type -> api.v1.GetAlgorithmRequest
+	11, // 48: api.v1.ManagementService.GetAlgorithmByName:input_type -> api.v1.GetAlgorithmByNameRequest
+	54, // 49: api.v1.ManagementService.GetRelatedAlgorithms:input_type -> api.v1.GetRelatedAlgorithmsRequest
+	13, // 50: api.v1.ManagementService.CreateVersion:input_type -> api.v1.CreateVersionRequest
+	15, // 51: api.v1.ManagementService.RollbackVersion:input_type -> api.v1.RollbackVersionRequest
+	16, // 52: api.v1.ManagementService.UploadPresetData:input_type -> api.v1.UploadDataRequest
+	18, // 53: api.v1.ManagementService.CreatePresetDataUploadURL:input_type -> api.v1.CreatePresetDataUploadURLRequest
+	20, // 54: api.v1.ManagementService.ListPresetData:input_type -> api.v1.ListPresetDataRequest
+	22, // 55: api.v1.ManagementService.GetPresetData:input_type -> api.v1.GetPresetDataRequest
+	24, // 56: api.v1.ManagementService.DeletePresetData:input_type -> api.v1.DeletePresetDataRequest
+	26, // 57: api.v1.ManagementService.ListJobs:input_type -> api.v1.ListJobsRequest
+	33, // 58: api.v1.ManagementService.GetJobDetail:input_type -> api.v1.GetJobDetailRequest
+	29, // 59: api.v1.ManagementService.DeleteJob:input_type -> api.v1.DeleteJobRequest
+	31, // 60: api.v1.ManagementService.PurgeJobs:input_type -> api.v1.PurgeJobsRequest
+	35, // 61: api.v1.ManagementService.CompareJobs:input_type -> api.v1.CompareJobsRequest
+	40, // 62: api.v1.ManagementService.GetServerInfo:input_type -> api.v1.GetServerInfoRequest
+	42, // 63: api.v1.ManagementService.SetMaintenanceMode:input_type -> api.v1.SetMaintenanceModeRequest
+	44, // 64: api.v1.ManagementService.GetConfig:input_type -> api.v1.GetConfigRequest
+	65, // 65: api.v1.ManagementService.EnsureStorage:input_type -> api.v1.EnsureStorageRequest
+	46, // 66: api.v1.ManagementService.MigrateObjects:input_type -> api.v1.MigrateObjectsRequest
+	57, // 67: api.v1.ManagementService.TriggerBackup:input_type -> api.v1.TriggerBackupRequest
+	59, // 68: api.v1.ManagementService.ListBackups:input_type -> api.v1.ListBackupsRequest
+	62, // 69: api.v1.ManagementService.RestoreBackup:input_type -> api.v1.RestoreBackupRequest
+	49, // 70: api.v1.ManagementService.GetOverview:input_type -> api.v1.GetOverviewRequest
+	51, // 71: api.v1.ManagementService.GetUsageStats:input_type -> api.v1.GetUsageStatsRequest
+	3,  // 72: api.v1.ManagementService.CreateAlgorithm:output_type -> api.v1.Algorithm
+	3,  // 73: api.v1.ManagementService.UpdateAlgorithm:output_type -> api.v1.Algorithm
+	5,  // 74: api.v1.ManagementService.ListAlgorithms:output_type -> api.v1.ListAlgorithmsResponse
+	3,  // 75: api.v1.ManagementService.DisableAlgorithm:output_type -> api.v1.Algorithm
+	3,  // 76: api.v1.ManagementService.EnableAlgorithm:output_type -> api.v1.Algorithm
+	8,  // 77: api.v1.ManagementService.DeleteAlgorithm:output_type -> api.v1.DeleteAlgorithmResponse
+	12, // 78: api.v1.ManagementService.GetAlgorithm:output_type -> api.v1.GetAlgorithmResponse
+	12, // 79: api.v1.ManagementService.GetAlgorithmByName:output_type -> api.v1.GetAlgorithmResponse
+	56, // 80: api.v1.ManagementService.GetRelatedAlgorithms:output_type -> api.v1.GetRelatedAlgorithmsResponse
+	14, // 81: api.v1.ManagementService.CreateVersion:output_type -> api.v1.Version
+	3,  // 82: api.v1.ManagementService.RollbackVersion:output_type -> api.v1.Algorithm
+	17, // 83: api.v1.ManagementService.UploadPresetData:output_type -> api.v1.UploadDataResponse
+	19, // 84: api.v1.ManagementService.CreatePresetDataUploadURL:output_type -> api.v1.CreatePresetDataUploadURLResponse
+	23, // 85: api.v1.ManagementService.ListPresetData:output_type -> api.v1.ListPresetDataResponse
+	21, // 86: api.v1.ManagementService.GetPresetData:output_type -> api.v1.PresetData
+	25, // 87: api.v1.ManagementService.DeletePresetData:output_type -> api.v1.DeletePresetDataResponse
+	28, // 88: api.v1.ManagementService.ListJobs:output_type -> api.v1.ListJobsResponse
+	34, // 89: api.v1.ManagementService.GetJobDetail:output_type -> api.v1.JobDetail
+	30, // 90: api.v1.ManagementService.DeleteJob:output_type -> api.v1.DeleteJobResponse
+	32, // 91: api.v1.ManagementService.PurgeJobs:output_type -> api.v1.PurgeJobsResponse
+	38, // 92: api.v1.ManagementService.CompareJobs:output_type -> api.v1.CompareJobsResponse
+	41, // 93: api.v1.ManagementService.GetServerInfo:output_type -> api.v1.GetServerInfoResponse
+	43, // 94: api.v1.ManagementService.SetMaintenanceMode:output_type -> api.v1.MaintenanceStatus
+	45, // 95: api.v1.ManagementService.GetConfig:output_type -> api.v1.GetConfigResponse
+	67, // 96: api.v1.ManagementService.EnsureStorage:output_type -> api.v1.EnsureStorageResponse
+	48, // 97: api.v1.ManagementService.MigrateObjects:output_type -> api.v1.MigrateObjectsResponse
+	58, // 98: api.v1.ManagementService.TriggerBackup:output_type -> api.v1.TriggerBackupResponse
+	61, // 99: api.v1.ManagementService.ListBackups:output_type -> api.v1.ListBackupsResponse
+	64, // 100: api.v1.ManagementService.RestoreBackup:output_type -> api.v1.RestoreBackupResponse
+	50, // 101: api.v1.ManagementService.GetOverview:output_type -> api.v1.GetOverviewResponse
+	53, // 102: api.v1.ManagementService.GetUsageStats:output_type -> api.v1.GetUsageStatsResponse
+	72, // [72:103] is the sub-list for method output_type
+	41, // [41:72] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_proto_management_proto_init() }
@@ -2007,7 +5361,7 @@ func file_proto_management_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_management_proto_rawDesc), len(file_proto_management_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   24,
+			NumMessages:   68,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
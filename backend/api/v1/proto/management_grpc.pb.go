@@ -19,18 +19,37 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ManagementService_CreateAlgorithm_FullMethodName  = "/api.v1.ManagementService/CreateAlgorithm"
-	ManagementService_UpdateAlgorithm_FullMethodName  = "/api.v1.ManagementService/UpdateAlgorithm"
-	ManagementService_ListAlgorithms_FullMethodName   = "/api.v1.ManagementService/ListAlgorithms"
-	ManagementService_GetAlgorithm_FullMethodName     = "/api.v1.ManagementService/GetAlgorithm"
-	ManagementService_CreateVersion_FullMethodName    = "/api.v1.ManagementService/CreateVersion"
-	ManagementService_RollbackVersion_FullMethodName  = "/api.v1.ManagementService/RollbackVersion"
-	ManagementService_UploadPresetData_FullMethodName = "/api.v1.ManagementService/UploadPresetData"
-	ManagementService_ListPresetData_FullMethodName   = "/api.v1.ManagementService/ListPresetData"
-	ManagementService_DeletePresetData_FullMethodName = "/api.v1.ManagementService/DeletePresetData"
-	ManagementService_ListJobs_FullMethodName         = "/api.v1.ManagementService/ListJobs"
-	ManagementService_GetJobDetail_FullMethodName     = "/api.v1.ManagementService/GetJobDetail"
-	ManagementService_GetServerInfo_FullMethodName    = "/api.v1.ManagementService/GetServerInfo"
+	ManagementService_CreateAlgorithm_FullMethodName           = "/api.v1.ManagementService/CreateAlgorithm"
+	ManagementService_UpdateAlgorithm_FullMethodName           = "/api.v1.ManagementService/UpdateAlgorithm"
+	ManagementService_ListAlgorithms_FullMethodName            = "/api.v1.ManagementService/ListAlgorithms"
+	ManagementService_DisableAlgorithm_FullMethodName          = "/api.v1.ManagementService/DisableAlgorithm"
+	ManagementService_EnableAlgorithm_FullMethodName           = "/api.v1.ManagementService/EnableAlgorithm"
+	ManagementService_DeleteAlgorithm_FullMethodName           = "/api.v1.ManagementService/DeleteAlgorithm"
+	ManagementService_GetAlgorithm_FullMethodName              = "/api.v1.ManagementService/GetAlgorithm"
+	ManagementService_GetAlgorithmByName_FullMethodName        = "/api.v1.ManagementService/GetAlgorithmByName"
+	ManagementService_GetRelatedAlgorithms_FullMethodName      = "/api.v1.ManagementService/GetRelatedAlgorithms"
+	ManagementService_CreateVersion_FullMethodName             = "/api.v1.ManagementService/CreateVersion"
+	ManagementService_RollbackVersion_FullMethodName           = "/api.v1.ManagementService/RollbackVersion"
+	ManagementService_UploadPresetData_FullMethodName          = "/api.v1.ManagementService/UploadPresetData"
+	ManagementService_CreatePresetDataUploadURL_FullMethodName = "/api.v1.ManagementService/CreatePresetDataUploadURL"
+	ManagementService_ListPresetData_FullMethodName            = "/api.v1.ManagementService/ListPresetData"
+	ManagementService_GetPresetData_FullMethodName             = "/api.v1.ManagementService/GetPresetData"
+	ManagementService_DeletePresetData_FullMethodName          = "/api.v1.ManagementService/DeletePresetData"
+	ManagementService_ListJobs_FullMethodName                  = "/api.v1.ManagementService/ListJobs"
+	ManagementService_GetJobDetail_FullMethodName              = "/api.v1.ManagementService/GetJobDetail"
+	ManagementService_DeleteJob_FullMethodName                 = "/api.v1.ManagementService/DeleteJob"
+	ManagementService_PurgeJobs_FullMethodName                 = "/api.v1.ManagementService/PurgeJobs"
+	ManagementService_CompareJobs_FullMethodName               = "/api.v1.ManagementService/CompareJobs"
+	ManagementService_GetServerInfo_FullMethodName             = "/api.v1.ManagementService/GetServerInfo"
+	ManagementService_SetMaintenanceMode_FullMethodName        = "/api.v1.ManagementService/SetMaintenanceMode"
+	ManagementService_GetConfig_FullMethodName                 = "/api.v1.ManagementService/GetConfig"
+	ManagementService_EnsureStorage_FullMethodName             = "/api.v1.ManagementService/EnsureStorage"
+	ManagementService_MigrateObjects_FullMethodName            = "/api.v1.ManagementService/MigrateObjects"
+	ManagementService_TriggerBackup_FullMethodName             = "/api.v1.ManagementService/TriggerBackup"
+	ManagementService_ListBackups_FullMethodName               = "/api.v1.ManagementService/ListBackups"
+	ManagementService_RestoreBackup_FullMethodName             = "/api.v1.ManagementService/RestoreBackup"
+	ManagementService_GetOverview_FullMethodName               = "/api.v1.ManagementService/GetOverview"
+	ManagementService_GetUsageStats_FullMethodName             = "/api.v1.ManagementService/GetUsageStats"
 )
 
 // ManagementServiceClient is the client API for ManagementService service.
@@ -40,15 +59,34 @@ type ManagementServiceClient interface {
 	CreateAlgorithm(ctx context.Context, in *CreateAlgorithmRequest, opts ...grpc.CallOption) (*Algorithm, error)
 	UpdateAlgorithm(ctx context.Context, in *UpdateAlgorithmRequest, opts ...grpc.CallOption) (*Algorithm, error)
 	ListAlgorithms(ctx context.Context, in *ListAlgorithmsRequest, opts ...grpc.CallOption) (*ListAlgorithmsResponse, error)
+	DisableAlgorithm(ctx context.Context, in *DisableAlgorithmRequest, opts ...grpc.CallOption) (*Algorithm, error)
+	EnableAlgorithm(ctx context.Context, in *EnableAlgorithmRequest, opts ...grpc.CallOption) (*Algorithm, error)
+	DeleteAlgorithm(ctx context.Context, in *DeleteAlgorithmRequest, opts ...grpc.CallOption) (*DeleteAlgorithmResponse, error)
 	GetAlgorithm(ctx context.Context, in *GetAlgorithmRequest, opts ...grpc.CallOption) (*GetAlgorithmResponse, error)
+	GetAlgorithmByName(ctx context.Context, in *GetAlgorithmByNameRequest, opts ...grpc.CallOption) (*GetAlgorithmResponse, error)
+	GetRelatedAlgorithms(ctx context.Context, in *GetRelatedAlgorithmsRequest, opts ...grpc.CallOption) (*GetRelatedAlgorithmsResponse, error)
 	CreateVersion(ctx context.Context, in *CreateVersionRequest, opts ...grpc.CallOption) (*Version, error)
 	RollbackVersion(ctx context.Context, in *RollbackVersionRequest, opts ...grpc.CallOption) (*Algorithm, error)
 	UploadPresetData(ctx context.Context, in *UploadDataRequest, opts ...grpc.CallOption) (*UploadDataResponse, error)
+	CreatePresetDataUploadURL(ctx context.Context, in *CreatePresetDataUploadURLRequest, opts ...grpc.CallOption) (*CreatePresetDataUploadURLResponse, error)
 	ListPresetData(ctx context.Context, in *ListPresetDataRequest, opts ...grpc.CallOption) (*ListPresetDataResponse, error)
+	GetPresetData(ctx context.Context, in *GetPresetDataRequest, opts ...grpc.CallOption) (*PresetData, error)
 	DeletePresetData(ctx context.Context, in *DeletePresetDataRequest, opts ...grpc.CallOption) (*DeletePresetDataResponse, error)
 	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
 	GetJobDetail(ctx context.Context, in *GetJobDetailRequest, opts ...grpc.CallOption) (*JobDetail, error)
+	DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error)
+	PurgeJobs(ctx context.Context, in *PurgeJobsRequest, opts ...grpc.CallOption) (*PurgeJobsResponse, error)
+	CompareJobs(ctx context.Context, in *CompareJobsRequest, opts ...grpc.CallOption) (*CompareJobsResponse, error)
 	GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error)
+	SetMaintenanceMode(ctx context.Context, in *SetMaintenanceModeRequest, opts ...grpc.CallOption) (*MaintenanceStatus, error)
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
+	EnsureStorage(ctx context.Context, in *EnsureStorageRequest, opts ...grpc.CallOption) (*EnsureStorageResponse, error)
+	MigrateObjects(ctx context.Context, in *MigrateObjectsRequest, opts ...grpc.CallOption) (*MigrateObjectsResponse, error)
+	TriggerBackup(ctx context.Context, in *TriggerBackupRequest, opts ...grpc.CallOption) (*TriggerBackupResponse, error)
+	ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error)
+	RestoreBackup(ctx context.Context, in *RestoreBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error)
+	GetOverview(ctx context.Context, in *GetOverviewRequest, opts ...grpc.CallOption) (*GetOverviewResponse, error)
+	GetUsageStats(ctx context.Context, in *GetUsageStatsRequest, opts ...grpc.CallOption) (*GetUsageStatsResponse, error)
 }
 
 type managementServiceClient struct {
@@ -89,6 +127,36 @@ func (c *managementServiceClient) ListAlgorithms(ctx context.Context, in *ListAl
 	return out, nil
 }
 
+func (c *managementServiceClient) DisableAlgorithm(ctx context.Context, in *DisableAlgorithmRequest, opts ...grpc.CallOption) (*Algorithm, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Algorithm)
+	err := c.cc.Invoke(ctx, ManagementService_DisableAlgorithm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) EnableAlgorithm(ctx context.Context, in *EnableAlgorithmRequest, opts ...grpc.CallOption) (*Algorithm, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Algorithm)
+	err := c.cc.Invoke(ctx, ManagementService_EnableAlgorithm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) DeleteAlgorithm(ctx context.Context, in *DeleteAlgorithmRequest, opts ...grpc.CallOption) (*DeleteAlgorithmResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAlgorithmResponse)
+	err := c.cc.Invoke(ctx, ManagementService_DeleteAlgorithm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *managementServiceClient) GetAlgorithm(ctx context.Context, in *GetAlgorithmRequest, opts ...grpc.CallOption) (*GetAlgorithmResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetAlgorithmResponse)
@@ -99,6 +167,26 @@ func (c *managementServiceClient) GetAlgorithm(ctx context.Context, in *GetAlgor
 	return out, nil
 }
 
+func (c *managementServiceClient) GetAlgorithmByName(ctx context.Context, in *GetAlgorithmByNameRequest, opts ...grpc.CallOption) (*GetAlgorithmResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAlgorithmResponse)
+	err := c.cc.Invoke(ctx, ManagementService_GetAlgorithmByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) GetRelatedAlgorithms(ctx context.Context, in *GetRelatedAlgorithmsRequest, opts ...grpc.CallOption) (*GetRelatedAlgorithmsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelatedAlgorithmsResponse)
+	err := c.cc.Invoke(ctx, ManagementService_GetRelatedAlgorithms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *managementServiceClient) CreateVersion(ctx context.Context, in *CreateVersionRequest, opts ...grpc.CallOption) (*Version, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Version)
@@ -129,6 +217,16 @@ func (c *managementServiceClient) UploadPresetData(ctx context.Context, in *Uplo
 	return out, nil
 }
 
+func (c *managementServiceClient) CreatePresetDataUploadURL(ctx context.Context, in *CreatePresetDataUploadURLRequest, opts ...grpc.CallOption) (*CreatePresetDataUploadURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePresetDataUploadURLResponse)
+	err := c.cc.Invoke(ctx, ManagementService_CreatePresetDataUploadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *managementServiceClient) ListPresetData(ctx context.Context, in *ListPresetDataRequest, opts ...grpc.CallOption) (*ListPresetDataResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListPresetDataResponse)
@@ -139,6 +237,16 @@ func (c *managementServiceClient) ListPresetData(ctx context.Context, in *ListPr
 	return out, nil
 }
 
+func (c *managementServiceClient) GetPresetData(ctx context.Context, in *GetPresetDataRequest, opts ...grpc.CallOption) (*PresetData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PresetData)
+	err := c.cc.Invoke(ctx, ManagementService_GetPresetData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *managementServiceClient) DeletePresetData(ctx context.Context, in *DeletePresetDataRequest, opts ...grpc.CallOption) (*DeletePresetDataResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeletePresetDataResponse)
@@ -169,6 +277,36 @@ func (c *managementServiceClient) GetJobDetail(ctx context.Context, in *GetJobDe
 	return out, nil
 }
 
+func (c *managementServiceClient) DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteJobResponse)
+	err := c.cc.Invoke(ctx, ManagementService_DeleteJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) PurgeJobs(ctx context.Context, in *PurgeJobsRequest, opts ...grpc.CallOption) (*PurgeJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeJobsResponse)
+	err := c.cc.Invoke(ctx, ManagementService_PurgeJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) CompareJobs(ctx context.Context, in *CompareJobsRequest, opts ...grpc.CallOption) (*CompareJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareJobsResponse)
+	err := c.cc.Invoke(ctx, ManagementService_CompareJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *managementServiceClient) GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetServerInfoResponse)
@@ -179,6 +317,96 @@ func (c *managementServiceClient) GetServerInfo(ctx context.Context, in *GetServ
 	return out, nil
 }
 
+func (c *managementServiceClient) SetMaintenanceMode(ctx context.Context, in *SetMaintenanceModeRequest, opts ...grpc.CallOption) (*MaintenanceStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MaintenanceStatus)
+	err := c.cc.Invoke(ctx, ManagementService_SetMaintenanceMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigResponse)
+	err := c.cc.Invoke(ctx, ManagementService_GetConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) EnsureStorage(ctx context.Context, in *EnsureStorageRequest, opts ...grpc.CallOption) (*EnsureStorageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnsureStorageResponse)
+	err := c.cc.Invoke(ctx, ManagementService_EnsureStorage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) MigrateObjects(ctx context.Context, in *MigrateObjectsRequest, opts ...grpc.CallOption) (*MigrateObjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MigrateObjectsResponse)
+	err := c.cc.Invoke(ctx, ManagementService_MigrateObjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) TriggerBackup(ctx context.Context, in *TriggerBackupRequest, opts ...grpc.CallOption) (*TriggerBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriggerBackupResponse)
+	err := c.cc.Invoke(ctx, ManagementService_TriggerBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBackupsResponse)
+	err := c.cc.Invoke(ctx, ManagementService_ListBackups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) RestoreBackup(ctx context.Context, in *RestoreBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreBackupResponse)
+	err := c.cc.Invoke(ctx, ManagementService_RestoreBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) GetOverview(ctx context.Context, in *GetOverviewRequest, opts ...grpc.CallOption) (*GetOverviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOverviewResponse)
+	err := c.cc.Invoke(ctx, ManagementService_GetOverview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementServiceClient) GetUsageStats(ctx context.Context, in *GetUsageStatsRequest, opts ...grpc.CallOption) (*GetUsageStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsageStatsResponse)
+	err := c.cc.Invoke(ctx, ManagementService_GetUsageStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ManagementServiceServer is the server API for ManagementService service.
 // All implementations must embed UnimplementedManagementServiceServer
 // for forward compatibility.
@@ -186,15 +414,34 @@ type ManagementServiceServer interface {
 	CreateAlgorithm(context.Context, *CreateAlgorithmRequest) (*Algorithm, error)
 	UpdateAlgorithm(context.Context, *UpdateAlgorithmRequest) (*Algorithm, error)
 	ListAlgorithms(context.Context, *ListAlgorithmsRequest) (*ListAlgorithmsResponse, error)
+	DisableAlgorithm(context.Context, *DisableAlgorithmRequest) (*Algorithm, error)
+	EnableAlgorithm(context.Context, *EnableAlgorithmRequest) (*Algorithm, error)
+	DeleteAlgorithm(context.Context, *DeleteAlgorithmRequest) (*DeleteAlgorithmResponse, error)
 	GetAlgorithm(context.Context, *GetAlgorithmRequest) (*GetAlgorithmResponse, error)
+	GetAlgorithmByName(context.Context, *GetAlgorithmByNameRequest) (*GetAlgorithmResponse, error)
+	GetRelatedAlgorithms(context.Context, *GetRelatedAlgorithmsRequest) (*GetRelatedAlgorithmsResponse, error)
 	CreateVersion(context.Context, *CreateVersionRequest) (*Version, error)
 	RollbackVersion(context.Context, *RollbackVersionRequest) (*Algorithm, error)
 	UploadPresetData(context.Context, *UploadDataRequest) (*UploadDataResponse, error)
+	CreatePresetDataUploadURL(context.Context, *CreatePresetDataUploadURLRequest) (*CreatePresetDataUploadURLResponse, error)
 	ListPresetData(context.Context, *ListPresetDataRequest) (*ListPresetDataResponse, error)
+	GetPresetData(context.Context, *GetPresetDataRequest) (*PresetData, error)
 	DeletePresetData(context.Context, *DeletePresetDataRequest) (*DeletePresetDataResponse, error)
 	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
 	GetJobDetail(context.Context, *GetJobDetailRequest) (*JobDetail, error)
+	DeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error)
+	PurgeJobs(context.Context, *PurgeJobsRequest) (*PurgeJobsResponse, error)
+	CompareJobs(context.Context, *CompareJobsRequest) (*CompareJobsResponse, error)
 	GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error)
+	SetMaintenanceMode(context.Context, *SetMaintenanceModeRequest) (*MaintenanceStatus, error)
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	EnsureStorage(context.Context, *EnsureStorageRequest) (*EnsureStorageResponse, error)
+	MigrateObjects(context.Context, *MigrateObjectsRequest) (*MigrateObjectsResponse, error)
+	TriggerBackup(context.Context, *TriggerBackupRequest) (*TriggerBackupResponse, error)
+	ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error)
+	RestoreBackup(context.Context, *RestoreBackupRequest) (*RestoreBackupResponse, error)
+	GetOverview(context.Context, *GetOverviewRequest) (*GetOverviewResponse, error)
+	GetUsageStats(context.Context, *GetUsageStatsRequest) (*GetUsageStatsResponse, error)
 	mustEmbedUnimplementedManagementServiceServer()
 }
 
@@ -214,9 +461,24 @@ func (UnimplementedManagementServiceServer) UpdateAlgorithm(context.Context, *Up
 func (UnimplementedManagementServiceServer) ListAlgorithms(context.Context, *ListAlgorithmsRequest) (*ListAlgorithmsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListAlgorithms not implemented")
 }
+func (UnimplementedManagementServiceServer) DisableAlgorithm(context.Context, *DisableAlgorithmRequest) (*Algorithm, error) {
+	return nil, status.Error(codes.Unimplemented, "method DisableAlgorithm not implemented")
+}
+func (UnimplementedManagementServiceServer) EnableAlgorithm(context.Context, *EnableAlgorithmRequest) (*Algorithm, error) {
+	return nil, status.Error(codes.Unimplemented, "method EnableAlgorithm not implemented")
+}
+func (UnimplementedManagementServiceServer) DeleteAlgorithm(context.Context, *DeleteAlgorithmRequest) (*DeleteAlgorithmResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteAlgorithm not implemented")
+}
 func (UnimplementedManagementServiceServer) GetAlgorithm(context.Context, *GetAlgorithmRequest) (*GetAlgorithmResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAlgorithm not implemented")
 }
+func (UnimplementedManagementServiceServer) GetAlgorithmByName(context.Context, *GetAlgorithmByNameRequest) (*GetAlgorithmResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAlgorithmByName not implemented")
+}
+func (UnimplementedManagementServiceServer) GetRelatedAlgorithms(context.Context, *GetRelatedAlgorithmsRequest) (*GetRelatedAlgorithmsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRelatedAlgorithms not implemented")
+}
 func (UnimplementedManagementServiceServer) CreateVersion(context.Context, *CreateVersionRequest) (*Version, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateVersion not implemented")
 }
@@ -226,9 +488,15 @@ func (UnimplementedManagementServiceServer) RollbackVersion(context.Context, *Ro
 func (UnimplementedManagementServiceServer) UploadPresetData(context.Context, *UploadDataRequest) (*UploadDataResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UploadPresetData not implemented")
 }
+func (UnimplementedManagementServiceServer) CreatePresetDataUploadURL(context.Context, *CreatePresetDataUploadURLRequest) (*CreatePresetDataUploadURLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePresetDataUploadURL not implemented")
+}
 func (UnimplementedManagementServiceServer) ListPresetData(context.Context, *ListPresetDataRequest) (*ListPresetDataResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListPresetData not implemented")
 }
+func (UnimplementedManagementServiceServer) GetPresetData(context.Context, *GetPresetDataRequest) (*PresetData, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPresetData not implemented")
+}
 func (UnimplementedManagementServiceServer) DeletePresetData(context.Context, *DeletePresetDataRequest) (*DeletePresetDataResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeletePresetData not implemented")
 }
@@ -238,9 +506,45 @@ func (UnimplementedManagementServiceServer) ListJobs(context.Context, *ListJobsR
 func (UnimplementedManagementServiceServer) GetJobDetail(context.Context, *GetJobDetailRequest) (*JobDetail, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetJobDetail not implemented")
 }
+func (UnimplementedManagementServiceServer) DeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteJob not implemented")
+}
+func (UnimplementedManagementServiceServer) PurgeJobs(context.Context, *PurgeJobsRequest) (*PurgeJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PurgeJobs not implemented")
+}
+func (UnimplementedManagementServiceServer) CompareJobs(context.Context, *CompareJobsRequest) (*CompareJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompareJobs not implemented")
+}
 func (UnimplementedManagementServiceServer) GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetServerInfo not implemented")
 }
+func (UnimplementedManagementServiceServer) SetMaintenanceMode(context.Context, *SetMaintenanceModeRequest) (*MaintenanceStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMaintenanceMode not implemented")
+}
+func (UnimplementedManagementServiceServer) GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedManagementServiceServer) EnsureStorage(context.Context, *EnsureStorageRequest) (*EnsureStorageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EnsureStorage not implemented")
+}
+func (UnimplementedManagementServiceServer) MigrateObjects(context.Context, *MigrateObjectsRequest) (*MigrateObjectsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MigrateObjects not implemented")
+}
+func (UnimplementedManagementServiceServer) TriggerBackup(context.Context, *TriggerBackupRequest) (*TriggerBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TriggerBackup not implemented")
+}
+func (UnimplementedManagementServiceServer) ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBackups not implemented")
+}
+func (UnimplementedManagementServiceServer) RestoreBackup(context.Context, *RestoreBackupRequest) (*RestoreBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreBackup not implemented")
+}
+func (UnimplementedManagementServiceServer) GetOverview(context.Context, *GetOverviewRequest) (*GetOverviewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOverview not implemented")
+}
+func (UnimplementedManagementServiceServer) GetUsageStats(context.Context, *GetUsageStatsRequest) (*GetUsageStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUsageStats not implemented")
+}
 func (UnimplementedManagementServiceServer) mustEmbedUnimplementedManagementServiceServer() {}
 func (UnimplementedManagementServiceServer) testEmbeddedByValue()                           {}
 
@@ -316,6 +620,60 @@ func _ManagementService_ListAlgorithms_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ManagementService_DisableAlgorithm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableAlgorithmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).DisableAlgorithm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_DisableAlgorithm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).DisableAlgorithm(ctx, req.(*DisableAlgorithmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_EnableAlgorithm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableAlgorithmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).EnableAlgorithm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_EnableAlgorithm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).EnableAlgorithm(ctx, req.(*EnableAlgorithmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_DeleteAlgorithm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAlgorithmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).DeleteAlgorithm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_DeleteAlgorithm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).DeleteAlgorithm(ctx, req.(*DeleteAlgorithmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ManagementService_GetAlgorithm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetAlgorithmRequest)
 	if err := dec(in); err != nil {
@@ -334,6 +692,42 @@ func _ManagementService_GetAlgorithm_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ManagementService_GetAlgorithmByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlgorithmByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetAlgorithmByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_GetAlgorithmByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetAlgorithmByName(ctx, req.(*GetAlgorithmByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_GetRelatedAlgorithms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedAlgorithmsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetRelatedAlgorithms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_GetRelatedAlgorithms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetRelatedAlgorithms(ctx, req.(*GetRelatedAlgorithmsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ManagementService_CreateVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateVersionRequest)
 	if err := dec(in); err != nil {
@@ -388,6 +782,24 @@ func _ManagementService_UploadPresetData_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ManagementService_CreatePresetDataUploadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePresetDataUploadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).CreatePresetDataUploadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_CreatePresetDataUploadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).CreatePresetDataUploadURL(ctx, req.(*CreatePresetDataUploadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ManagementService_ListPresetData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListPresetDataRequest)
 	if err := dec(in); err != nil {
@@ -406,6 +818,24 @@ func _ManagementService_ListPresetData_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ManagementService_GetPresetData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPresetDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetPresetData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_GetPresetData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetPresetData(ctx, req.(*GetPresetDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ManagementService_DeletePresetData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeletePresetDataRequest)
 	if err := dec(in); err != nil {
@@ -460,6 +890,60 @@ func _ManagementService_GetJobDetail_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ManagementService_DeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).DeleteJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_DeleteJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).DeleteJob(ctx, req.(*DeleteJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_PurgeJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).PurgeJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_PurgeJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).PurgeJobs(ctx, req.(*PurgeJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_CompareJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).CompareJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_CompareJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).CompareJobs(ctx, req.(*CompareJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ManagementService_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetServerInfoRequest)
 	if err := dec(in); err != nil {
@@ -478,6 +962,168 @@ func _ManagementService_GetServerInfo_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ManagementService_SetMaintenanceMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).SetMaintenanceMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_SetMaintenanceMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).SetMaintenanceMode(ctx, req.(*SetMaintenanceModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_EnsureStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnsureStorageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).EnsureStorage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_EnsureStorage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).EnsureStorage(ctx, req.(*EnsureStorageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_MigrateObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).MigrateObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_MigrateObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).MigrateObjects(ctx, req.(*MigrateObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_TriggerBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).TriggerBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_TriggerBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).TriggerBackup(ctx, req.(*TriggerBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_ListBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).ListBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_ListBackups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).ListBackups(ctx, req.(*ListBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_RestoreBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).RestoreBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_RestoreBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).RestoreBackup(ctx, req.(*RestoreBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_GetOverview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOverviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetOverview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_GetOverview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetOverview(ctx, req.(*GetOverviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagementService_GetUsageStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServiceServer).GetUsageStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManagementService_GetUsageStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServiceServer).GetUsageStats(ctx, req.(*GetUsageStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ManagementService_ServiceDesc is the grpc.ServiceDesc for ManagementService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -497,10 +1143,30 @@ var ManagementService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAlgorithms",
 			Handler:    _ManagementService_ListAlgorithms_Handler,
 		},
+		{
+			MethodName: "DisableAlgorithm",
+			Handler:    _ManagementService_DisableAlgorithm_Handler,
+		},
+		{
+			MethodName: "EnableAlgorithm",
+			Handler:    _ManagementService_EnableAlgorithm_Handler,
+		},
+		{
+			MethodName: "DeleteAlgorithm",
+			Handler:    _ManagementService_DeleteAlgorithm_Handler,
+		},
 		{
 			MethodName: "GetAlgorithm",
 			Handler:    _ManagementService_GetAlgorithm_Handler,
 		},
+		{
+			MethodName: "GetAlgorithmByName",
+			Handler:    _ManagementService_GetAlgorithmByName_Handler,
+		},
+		{
+			MethodName: "GetRelatedAlgorithms",
+			Handler:    _ManagementService_GetRelatedAlgorithms_Handler,
+		},
 		{
 			MethodName: "CreateVersion",
 			Handler:    _ManagementService_CreateVersion_Handler,
@@ -513,10 +1179,18 @@ var ManagementService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UploadPresetData",
 			Handler:    _ManagementService_UploadPresetData_Handler,
 		},
+		{
+			MethodName: "CreatePresetDataUploadURL",
+			Handler:    _ManagementService_CreatePresetDataUploadURL_Handler,
+		},
 		{
 			MethodName: "ListPresetData",
 			Handler:    _ManagementService_ListPresetData_Handler,
 		},
+		{
+			MethodName: "GetPresetData",
+			Handler:    _ManagementService_GetPresetData_Handler,
+		},
 		{
 			MethodName: "DeletePresetData",
 			Handler:    _ManagementService_DeletePresetData_Handler,
@@ -529,10 +1203,58 @@ var ManagementService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetJobDetail",
 			Handler:    _ManagementService_GetJobDetail_Handler,
 		},
+		{
+			MethodName: "DeleteJob",
+			Handler:    _ManagementService_DeleteJob_Handler,
+		},
+		{
+			MethodName: "PurgeJobs",
+			Handler:    _ManagementService_PurgeJobs_Handler,
+		},
+		{
+			MethodName: "CompareJobs",
+			Handler:    _ManagementService_CompareJobs_Handler,
+		},
 		{
 			MethodName: "GetServerInfo",
 			Handler:    _ManagementService_GetServerInfo_Handler,
 		},
+		{
+			MethodName: "SetMaintenanceMode",
+			Handler:    _ManagementService_SetMaintenanceMode_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _ManagementService_GetConfig_Handler,
+		},
+		{
+			MethodName: "EnsureStorage",
+			Handler:    _ManagementService_EnsureStorage_Handler,
+		},
+		{
+			MethodName: "MigrateObjects",
+			Handler:    _ManagementService_MigrateObjects_Handler,
+		},
+		{
+			MethodName: "TriggerBackup",
+			Handler:    _ManagementService_TriggerBackup_Handler,
+		},
+		{
+			MethodName: "ListBackups",
+			Handler:    _ManagementService_ListBackups_Handler,
+		},
+		{
+			MethodName: "RestoreBackup",
+			Handler:    _ManagementService_RestoreBackup_Handler,
+		},
+		{
+			MethodName: "GetOverview",
+			Handler:    _ManagementService_GetOverview_Handler,
+		},
+		{
+			MethodName: "GetUsageStats",
+			Handler:    _ManagementService_GetUsageStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/management.proto",
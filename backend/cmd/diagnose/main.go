@@ -0,0 +1,160 @@
+// Command diagnose checks that a deployment is wired correctly: it loads the config and
+// verifies the database, MinIO, Redis and the Docker daemon, printing a pass/fail report.
+// It exits non-zero when any check fails.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/keys"
+	"algorithm-platform/pkg/docker"
+	"algorithm-platform/pkg/storage"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/redis/go-redis/v9"
+)
+
+// checkTimeout bounds each check so an unreachable service fails instead of hanging
+const checkTimeout = 15 * time.Second
+
+// errSkipped marks a check of a service the configuration does not use
+var errSkipped = errors.New("skipped")
+
+// check is one line of the report; run returns a short description of what was found
+type check struct {
+	name string
+	run  func(ctx context.Context) (string, error)
+}
+
+func main() {
+	cfg, configCheck := loadConfig()
+	checks := append([]check{configCheck}, dependencyChecks(cfg)...)
+
+	failed := 0
+	for _, c := range checks {
+		ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+		start := time.Now()
+		detail, err := c.run(ctx)
+		cancel()
+
+		result := "PASS"
+		if errors.Is(err, errSkipped) {
+			result = "SKIP"
+		} else if err != nil {
+			result, detail = "FAIL", err.Error()
+			failed++
+		}
+		fmt.Printf("[%s] %-10s %s (%v)\n", result, c.name, detail, time.Since(start).Round(time.Millisecond))
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%d of %d checks failed\n", failed, len(checks))
+		os.Exit(1)
+	}
+	fmt.Printf("\nAll %d checks passed\n", len(checks))
+}
+
+// loadConfig loads config.yaml the way the server does. Unlike config.LoadOrDefault, a
+// missing or unparsable file fails the config check; the other checks then use the defaults
+func loadConfig() (*config.Config, check) {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return config.Default(), check{name: "config", run: func(ctx context.Context) (string, error) {
+			return "", fmt.Errorf("%w, the remaining checks use the default configuration", err)
+		}}
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return config.Default(), check{name: "config", run: func(ctx context.Context) (string, error) {
+			return "", fmt.Errorf("%s: %w", configPath, err)
+		}}
+	}
+	config.ApplyEnvOverrides(cfg)
+
+	return cfg, check{name: "config", run: func(ctx context.Context) (string, error) {
+		secrets, err := cfg.Docker.LoadSecrets()
+		if err != nil {
+			return "", err
+		}
+		unusable := 0
+		for _, entry := range secrets {
+			if len(entry.AllowedAlgorithms) == 0 {
+				unusable++
+			}
+		}
+		if unusable > 0 {
+			return fmt.Sprintf("loaded %s (%d secrets, %d without allowed_algorithms cannot be used by any algorithm)", configPath, len(secrets), unusable), nil
+		}
+		return fmt.Sprintf("loaded %s (%d secrets)", configPath, len(secrets)), nil
+	}}
+}
+
+// dependencyChecks lists the checks of the services the server uses, in startup order
+func dependencyChecks(cfg *config.Config) []check {
+	return []check{
+		{name: "database", run: func(ctx context.Context) (string, error) {
+			diagnosis, err := database.Diagnose(ctx, cfg)
+			if err != nil {
+				return "", err
+			}
+			if len(diagnosis.PendingMigrations) > 0 {
+				return "", fmt.Errorf("%s is reachable but not migrated, missing %s; start the server once to migrate",
+					diagnosis.Provider, strings.Join(diagnosis.PendingMigrations, ", "))
+			}
+			return fmt.Sprintf("%s is reachable and migrated", diagnosis.Provider), nil
+		}},
+		{name: "minio", run: func(ctx context.Context) (string, error) {
+			client, err := minio.New(cfg.MinIO.Endpoint, &minio.Options{
+				Creds:  credentials.NewStaticV4(cfg.MinIO.AccessKeyID, cfg.MinIO.SecretAccessKey, ""),
+				Secure: cfg.MinIO.UseSSL,
+			})
+			if err != nil {
+				return "", err
+			}
+			probeKey := cfg.MinIO.ObjectKey(keys.StorageProbe(fmt.Sprintf("diagnose-%d", time.Now().UnixNano())))
+			var steps []string
+			for _, step := range storage.VerifyStorage(ctx, client, cfg.MinIO.Bucket, probeKey) {
+				if !step.OK {
+					return "", fmt.Errorf("%s %s: %s", cfg.MinIO.Endpoint, step.Name, step.Message)
+				}
+				steps = append(steps, step.Name)
+			}
+			return fmt.Sprintf("%s bucket %s: %s ok", cfg.MinIO.Endpoint, cfg.MinIO.Bucket, strings.Join(steps, "/")), nil
+		}},
+		{name: "redis", run: func(ctx context.Context) (string, error) {
+			// Same rule as the server's startup: Redis is only needed for event relaying and result caching
+			if cfg.Redis.JobEventsChannel == "" && cfg.Redis.GetResultCacheTTL() <= 0 {
+				return "not used: job_events_channel and result_cache_ttl are unset", errSkipped
+			}
+			client := redis.NewClient(&redis.Options{
+				Addr:     cfg.Redis.Addr,
+				Password: cfg.Redis.Password,
+				DB:       cfg.Redis.DB,
+			})
+			defer client.Close()
+			if err := client.Ping(ctx).Err(); err != nil {
+				return "", fmt.Errorf("%s: %w", cfg.Redis.Addr, err)
+			}
+			return fmt.Sprintf("%s answered PING", cfg.Redis.Addr), nil
+		}},
+		{name: "docker", run: func(ctx context.Context) (string, error) {
+			client, err := docker.New(cfg.Docker.Host)
+			if err != nil {
+				return "", err
+			}
+			version, apiVersion, err := client.ServerVersion(ctx)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", cfg.Docker.Host, err)
+			}
+			return fmt.Sprintf("%s: Docker %s (API %s)", cfg.Docker.Host, version, apiVersion), nil
+		}},
+	}
+}
@@ -6,11 +6,25 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"algorithm-platform/internal/config"
 	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/events"
+	"algorithm-platform/internal/maintenance"
+	"algorithm-platform/internal/metrics"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/scheduler"
 	"algorithm-platform/internal/server"
 	"algorithm-platform/internal/service"
+	"algorithm-platform/internal/startup"
+	"algorithm-platform/pkg/cache"
+	"algorithm-platform/pkg/docker"
+	"algorithm-platform/pkg/storage"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
@@ -18,24 +32,120 @@ func main() {
 	cfg := config.LoadOrDefault()
 
 	// Override MinIO endpoint if LOCAL_MODE is set
-	localMode := os.Getenv("LOCAL_MODE") == "true"
-	if localMode {
-		cfg.MinIO.Endpoint = "localhost:9000"
-		cfg.MinIO.ExternalEndpoint = "localhost:9000"
+	if config.ApplyEnvOverrides(cfg) {
 		log.Println("LOCAL_MODE enabled: using localhost:9000 for MinIO")
 	}
+	for _, warning := range cfg.DeprecatedSettings() {
+		log.Printf("Warning: %s", warning)
+	}
+
+	// Read-only maintenance mode; also engaged automatically while restoring from backup
+	mode := maintenance.NewMode()
+	if cfg.Server.ReadOnly {
+		mode.SetReadOnly(true, "enabled by config")
+		log.Println("Server starting in read-only maintenance mode")
+	}
+
+	// Operation timings must be enabled before the database and MinIO clients are created
+	if cfg.Server.MetricsEnabled {
+		metrics.Enable()
+		log.Println("Metrics enabled at /metrics")
+	}
 
-	// Initialize database
-	db, err := database.New(cfg)
+	// Wait for the database, MinIO and Redis and recover unfinished jobs before binding the
+	// gRPC/HTTP ports; meanwhile the HTTP port only answers /healthz with "starting"
+	stopStartupHealth, err := server.ServeStartupHealth(cfg.Server.HTTPPort)
 	if err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+		log.Fatalf("Failed to listen on HTTP port %d: %v", cfg.Server.HTTPPort, err)
+	}
+	var db *database.Database
+	startupCtx, cancelStartup := context.WithTimeout(context.Background(), cfg.Server.GetStartupTimeout())
+	err = startup.WaitForDependencies(startupCtx, startupDependencies(cfg, mode, &db), startup.BackoffPolicy())
+	cancelStartup()
+	if err != nil {
+		log.Fatalf("Dependencies not ready after %v: %v", cfg.Server.GetStartupTimeout(), err)
 	}
 	defer db.Close()
 
+	// Job events are published by the executor and pushed to WebSocket/SSE clients
+	jobEvents := events.NewBus()
+	defer jobEvents.Close()
+
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	if cfg.Redis.JobEventsChannel != "" {
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		defer redisClient.Close()
+
+		relay := events.NewRedisRelay(redisClient, cfg.Redis.JobEventsChannel)
+		jobEvents.SetRelay(relay)
+		go func() {
+			if err := relay.Run(relayCtx, jobEvents); err != nil {
+				log.Printf("Job events relay stopped: %v", err)
+			}
+		}()
+		log.Printf("Job events relayed via Redis channel %s", cfg.Redis.JobEventsChannel)
+	}
+
+	// Cache synchronous results in Redis when result_cache_ttl is set
+	var resultCache service.ResultCache
+	if ttl := cfg.Redis.GetResultCacheTTL(); ttl > 0 {
+		c := cache.New(cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB, "algorithm-platform:result")
+		defer c.Close()
+		resultCache = c
+		log.Printf("Result caching enabled (ttl %v)", ttl)
+	}
+
+	// Prewarm algorithm runtime images so the first job doesn't wait for a pull
+	var warmPool *scheduler.WarmPool
+	var sched *scheduler.Scheduler
+	dockerClient, err := docker.New(cfg.Docker.Host)
+	if err != nil {
+		log.Printf("Docker client unavailable, image prewarming and job execution disabled: %v", err)
+	} else {
+		warmPool = scheduler.NewWarmPool(dockerClient)
+		for _, image := range cfg.Docker.PinnedImages {
+			warmPool.Pin(image)
+		}
+		warmPool.Start(5 * time.Minute)
+		defer warmPool.Stop()
+
+		sched = scheduler.New(dockerClient, warmPool)
+
+		// Remove containers left behind by a crash; keep those whose job is still running
+		if cfg.Docker.CleanupOnStartup {
+			removed, err := sched.CleanupStaleContainers(context.Background(), func(jobID string) bool {
+				var count int64
+				db.DB().Model(&models.Job{}).Where("id = ? AND status = ?", jobID, "running").Count(&count)
+				return count > 0
+			})
+			if err != nil {
+				log.Printf("Failed to clean up stale containers: %v", err)
+			} else if removed > 0 {
+				log.Printf("Removed %d stale algorithm containers", removed)
+			}
+		}
+	}
+
 	// Initialize services
-	managementSvc := service.NewManagementService(db, cfg)
-	algorithmSvc := service.NewAlgorithmService(db, cfg)
-	srv := server.New(cfg.Server, managementSvc)
+	// Services read config snapshots from the store; SIGHUP publishes a new snapshot
+	cfgStore := config.NewStore(cfg)
+	managementSvc := service.NewManagementService(db, cfgStore, warmPool, sched, mode)
+	algorithmSvc := service.NewAlgorithmService(db, cfgStore, jobEvents, dockerClient, sched, resultCache)
+	srv := server.New(cfg.Server, managementSvc, jobEvents, mode)
+
+	// Jobs left unfinished by the previous run: requeue pending async jobs, fail the rest
+	recoverCtx, cancelRecover := context.WithTimeout(context.Background(), 2*time.Minute)
+	if recovery, err := algorithmSvc.RecoverJobs(recoverCtx); err != nil {
+		log.Printf("Failed to recover unfinished jobs: %v", err)
+	} else if recovery.Requeued+recovery.Failed > 0 {
+		log.Printf("Recovered unfinished jobs: %d requeued, %d marked failed", recovery.Requeued, recovery.Failed)
+	}
+	cancelRecover()
 
 	srv.RegisterServices(algorithmSvc, managementSvc)
 
@@ -43,12 +153,24 @@ func main() {
 		log.Fatalf("Failed to register gateway: %v", err)
 	}
 
+	// Stop answering "starting" only now that recovery is done, so no request reaches
+	// the services while jobs of the previous run are still being requeued
+	stopStartupHealth()
 	if err := srv.Start(context.Background()); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 
 	log.Printf("Server started. gRPC: %d, HTTP: %d", cfg.Server.GRPCPort, cfg.Server.HTTPPort)
 
+	// SIGHUP reloads config.yaml; only the runtime-safe subset takes effect
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			reloadConfig(cfgStore, db, mode, warmPool)
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -60,3 +182,83 @@ func main() {
 
 	log.Println("Server stopped")
 }
+
+// startupDependencies lists the services that must be usable before the server starts.
+// The database check opens the database and stores it in db; Redis is only required
+// when job event relaying or result caching is configured
+func startupDependencies(cfg *config.Config, mode *maintenance.Mode, db **database.Database) []startup.Dependency {
+	deps := []startup.Dependency{
+		{Name: "database", Check: func(ctx context.Context) error {
+			if *db == nil {
+				opened, err := database.New(cfg, mode)
+				if err != nil {
+					return err
+				}
+				*db = opened
+			}
+			return (*db).Ping(ctx)
+		}},
+		{Name: "MinIO bucket " + cfg.MinIO.Bucket, Check: func(ctx context.Context) error {
+			client, err := minio.New(cfg.MinIO.Endpoint, &minio.Options{
+				Creds:  credentials.NewStaticV4(cfg.MinIO.AccessKeyID, cfg.MinIO.SecretAccessKey, ""),
+				Secure: cfg.MinIO.UseSSL,
+			})
+			if err != nil {
+				return err
+			}
+			_, err = storage.EnsureBucket(ctx, client, cfg.MinIO.Bucket)
+			return err
+		}},
+	}
+
+	if cfg.Redis.JobEventsChannel != "" || cfg.Redis.GetResultCacheTTL() > 0 {
+		deps = append(deps, startup.Dependency{Name: "Redis", Check: func(ctx context.Context) error {
+			client := redis.NewClient(&redis.Options{
+				Addr:     cfg.Redis.Addr,
+				Password: cfg.Redis.Password,
+				DB:       cfg.Redis.DB,
+			})
+			defer client.Close()
+			return client.Ping(ctx).Err()
+		}})
+	}
+	return deps
+}
+
+// reloadConfig re-reads config.yaml and applies the fields that are safe to change at runtime
+func reloadConfig(cfgStore *config.Store, db *database.Database, mode *maintenance.Mode, warmPool *scheduler.WarmPool) {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		log.Printf("Config reload skipped: %v", err)
+		return
+	}
+
+	previous := cfgStore.Load()
+	result, err := cfgStore.Reload(configPath)
+	if err != nil {
+		log.Printf("Config reload failed, keeping current config: %v", err)
+		return
+	}
+
+	cfg := cfgStore.Load()
+	if cfg.Server.ReadOnly != previous.Server.ReadOnly {
+		mode.SetReadOnly(cfg.Server.ReadOnly, "enabled by config")
+	}
+	if warmPool != nil {
+		for _, image := range cfg.Docker.PinnedImages {
+			warmPool.Pin(image)
+		}
+	}
+	if interval := cfg.Database.Backup.GetInterval(); interval != previous.Database.Backup.GetInterval() {
+		db.SetBackupInterval(interval)
+	}
+
+	if len(result.Applied) == 0 {
+		log.Printf("Config reloaded from %s: no runtime changes", configPath)
+	} else {
+		log.Printf("Config reloaded from %s: applied %v", configPath, result.Applied)
+	}
+	if len(result.RestartRequired) > 0 {
+		log.Printf("Warning: config changes require a restart to take effect: %v", result.RestartRequired)
+	}
+}
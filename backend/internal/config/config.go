@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"algorithm-platform/internal/redact"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -18,8 +21,106 @@ type Config struct {
 }
 
 type ServerConfig struct {
-	GRPCPort int `yaml:"grpc_port"`
-	HTTPPort int `yaml:"http_port"`
+	GRPCPort int  `yaml:"grpc_port"`
+	HTTPPort int  `yaml:"http_port"`
+	ReadOnly bool `yaml:"read_only"` // 以只读维护模式启动，运行时可通过 SetMaintenanceMode 关闭
+	// 管理接口（如 GetConfig）的访问令牌，请求需携带 Authorization: Bearer <token>，为空时管理接口不可用
+	AdminToken string `yaml:"admin_token"`
+	// 分页令牌的签名密钥，多副本部署时需配置为相同值；为空时每次启动随机生成
+	PageTokenSecret string `yaml:"page_token_secret"`
+	// 记录 MinIO 和数据库操作耗时并在 /metrics 输出，默认关闭以避免额外开销
+	MetricsEnabled bool `yaml:"metrics_enabled"`
+	// 每个算法保留的已结束任务数量，超出时删除最旧的任务及其结果和日志；算法可单独设置，0 表示不限制
+	JobHistoryLimit int `yaml:"job_history_limit"`
+	// webhook 签名密钥，配置后请求带 X-Webhook-Signature: sha256=<HMAC-SHA256(body)>，为空时不签名
+	WebhookSecret string `yaml:"webhook_secret"`
+	// 结果不超过该字节数时以 base64 放入 webhook 的 result_inline 字段，result_url 仍然保留；0 表示不内嵌
+	WebhookInlineMaxBytes int64 `yaml:"webhook_inline_max_bytes"`
+	// 同时执行的任务数上限，超出时后台任务排队等待，同步任务等待一段时间后返回服务繁忙；0 表示不限制
+	MaxConcurrentJobs int `yaml:"max_concurrent_jobs"`
+	// 列表接口 page_size 的上限，更大的值按上限处理，默认 500
+	MaxPageSize int `yaml:"max_page_size"`
+	// 单个上传文件的大小上限（MB），multipart 上传超出时返回 413，gRPC 请求的 file_data 超出时返回 ResourceExhausted（HTTP 网关返回 413），默认 1024
+	UploadMaxSizeMB int `yaml:"upload_max_size_mb"`
+	// Deprecated: 上传文件直接流式写入 MinIO，不再缓存在内存或 scratch_dir 中，设置后只在启动时打印警告
+	UploadMaxMemoryMB int `yaml:"upload_max_memory_mb"`
+	// Deprecated: 同 UploadMaxMemoryMB
+	ScratchDir string `yaml:"scratch_dir"`
+	// 启动时等待数据库、MinIO 和 Redis 就绪的最长时间，超时后退出，默认 2m
+	StartupTimeoutStr string `yaml:"startup_timeout"`
+	// 预置数据分类允许的文件类型，上传到已配置的分类时校验扩展名和内容，未配置的分类只打印警告
+	PresetDataCategories map[string]PresetDataCategory `yaml:"preset_data_categories"`
+	// 本副本的标识，记录在任务上，启动时只恢复本副本遗留的未结束任务。
+	// 多副本共用数据库时每个副本需配置不同且重启后不变的值，默认为主机名
+	WorkerID string `yaml:"worker_id"`
+}
+
+// maxWorkerIDLength 任务表 worker_id 列的长度
+const maxWorkerIDLength = 36
+
+// GetWorkerID 获取本副本的标识，未配置时使用主机名，超出 worker_id 列长度的部分被截断
+func (c *ServerConfig) GetWorkerID() string {
+	id := c.WorkerID
+	if id == "" {
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			hostname = "default-worker"
+		}
+		id = hostname
+	}
+	if len(id) > maxWorkerIDLength {
+		id = id[:maxWorkerIDLength]
+	}
+	return id
+}
+
+// PresetDataCategory 预置数据分类的内容限制，列表为空表示不限制该项
+type PresetDataCategory struct {
+	Extensions []string `yaml:"extensions"` // 允许的扩展名，如 .png
+	MIMETypes  []string `yaml:"mime_types"` // 允许的内容类型，支持 image/* 形式的通配
+}
+
+// DefaultStartupTimeout 默认的依赖等待时间
+const DefaultStartupTimeout = 2 * time.Minute
+
+// GetStartupTimeout 获取启动时等待依赖就绪的最长时间
+func (c *ServerConfig) GetStartupTimeout() time.Duration {
+	if c.StartupTimeoutStr == "" {
+		return DefaultStartupTimeout
+	}
+
+	duration, err := time.ParseDuration(c.StartupTimeoutStr)
+	if err != nil || duration <= 0 {
+		fmt.Printf("Warning: invalid startup_timeout '%s', using default %s\n", c.StartupTimeoutStr, DefaultStartupTimeout)
+		return DefaultStartupTimeout
+	}
+	return duration
+}
+
+// GetPresetDataCategory 获取分类的内容限制（分类名不区分大小写），未配置时返回 false
+func (c *ServerConfig) GetPresetDataCategory(category string) (PresetDataCategory, bool) {
+	for name, rule := range c.PresetDataCategories {
+		if strings.EqualFold(name, category) {
+			return rule, true
+		}
+	}
+	return PresetDataCategory{}, false
+}
+
+// GetMaxPageSize 列表接口单页的最大条数
+func (c *ServerConfig) GetMaxPageSize() int {
+	if c.MaxPageSize <= 0 {
+		return 500
+	}
+	return c.MaxPageSize
+}
+
+// GetMaxUploadBytes 单个上传文件的字节数上限
+func (c *ServerConfig) GetMaxUploadBytes() int64 {
+	if c.UploadMaxSizeMB <= 0 {
+		return 1024 << 20
+	}
+	return int64(c.UploadMaxSizeMB) << 20
 }
 
 type DockerConfig struct {
@@ -27,12 +128,104 @@ type DockerConfig struct {
 	TLSCert    string `yaml:"tls_cert"`
 	TLSKey     string `yaml:"tls_key"`
 	APIVersion string `yaml:"api_version"`
+	// 平台默认资源限制，请求和算法都未指定时使用（0 表示不限制）
+	DefaultCPULimit float64 `yaml:"default_cpu_limit"`
+	DefaultMemoryMB int     `yaml:"default_memory_mb"`
+	// 单个任务允许申请的资源上限（0 表示不限制）
+	MaxCPULimit float64 `yaml:"max_cpu_limit"`
+	MaxMemoryMB int     `yaml:"max_memory_mb"`
+	// 各语言算法使用的运行镜像
+	RuntimeImages map[string]string `yaml:"runtime_images"`
+	// 固定预热的镜像，启动时拉取并保持在本地
+	PinnedImages []string `yaml:"pinned_images"`
+	// 启动时清理上次运行遗留的算法容器，调试时可关闭以保留现场
+	CleanupOnStartup bool `yaml:"cleanup_on_startup"`
+	// 任务结束后保留算法容器和输出目录，便于调试
+	KeepContainers bool `yaml:"keep_containers"`
+	// 可通过执行请求的 secrets 注入容器的密钥（密钥名 → 密钥），只有 allowed_algorithms 中的算法可以引用
+	Secrets map[string]SecretEntry `yaml:"secrets"`
+	// 密钥文件（YAML，格式与 secrets 相同），每次执行时读取，与 secrets 同名时以文件为准
+	SecretsFile string `yaml:"secrets_file"`
+}
+
+// SecretEntry 可注入容器的密钥
+type SecretEntry struct {
+	Value string `yaml:"value"`
+	// 允许引用该密钥的算法 ID 或名称，"*" 表示所有算法；为空时任何算法都不能引用
+	AllowedAlgorithms []string `yaml:"allowed_algorithms,omitempty"`
+}
+
+// UnmarshalYAML 兼容旧格式：密钥直接写成字符串值时没有允许的算法
+func (e *SecretEntry) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		e.AllowedAlgorithms = nil
+		return node.Decode(&e.Value)
+	}
+	type plain SecretEntry
+	return node.Decode((*plain)(e))
+}
+
+// Allows 算法是否可以引用该密钥
+func (e SecretEntry) Allows(algorithmID, algorithmName string) bool {
+	for _, allowed := range e.AllowedAlgorithms {
+		if allowed == "*" || allowed == algorithmID || (algorithmName != "" && allowed == algorithmName) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadSecrets 合并 secrets 和 secrets_file 中的密钥
+func (c *DockerConfig) LoadSecrets() (map[string]SecretEntry, error) {
+	secrets := make(map[string]SecretEntry, len(c.Secrets))
+	for name, entry := range c.Secrets {
+		secrets[name] = entry
+	}
+	if c.SecretsFile == "" {
+		return secrets, nil
+	}
+
+	data, err := os.ReadFile(c.SecretsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets file: %w", err)
+	}
+	var fromFile map[string]SecretEntry
+	if err := yaml.Unmarshal(data, &fromFile); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets file: %w", err)
+	}
+	for name, value := range fromFile {
+		secrets[name] = value
+	}
+	return secrets, nil
+}
+
+// GetRuntimeImage 获取算法语言对应的运行镜像，未配置时返回空
+func (c *DockerConfig) GetRuntimeImage(language string) string {
+	return c.RuntimeImages[strings.ToLower(language)]
 }
 
 type RedisConfig struct {
-	Addr     string `yaml:"addr"`
-	Password string `yaml:"password"`
-	DB       int    `yaml:"db"`
+	Addr             string `yaml:"addr"`
+	Password         string `yaml:"password"`
+	DB               int    `yaml:"db"`
+	JobEventsChannel string `yaml:"job_events_channel"` // 多副本时通过该频道广播任务事件，为空则只在进程内分发
+	// 同步执行结果的缓存时长，相同算法版本、参数和输入的请求直接返回已完成任务的结果；为空或 0 时不缓存
+	ResultCacheTTLStr string `yaml:"result_cache_ttl"`
+}
+
+// GetResultCacheTTL 获取执行结果缓存时长，0 表示不缓存
+func (c *RedisConfig) GetResultCacheTTL() time.Duration {
+	if c.ResultCacheTTLStr == "" {
+		return 0
+	}
+
+	duration, err := time.ParseDuration(c.ResultCacheTTLStr)
+	if err != nil || duration < 0 {
+		fmt.Printf("Warning: invalid result_cache_ttl '%s', result caching disabled\n", c.ResultCacheTTLStr)
+		return 0
+	}
+
+	return duration
 }
 
 type MinIOConfig struct {
@@ -42,6 +235,40 @@ type MinIOConfig struct {
 	SecretAccessKey  string `yaml:"secret_access_key"`
 	Bucket           string `yaml:"bucket"`
 	UseSSL           bool   `yaml:"use_ssl"`
+	// 任务结果保留时长，超过后视为产物已过期
+	ResultRetentionStr string `yaml:"result_retention"`
+	// 对象路径前缀，多个环境共用一个 bucket 时用于隔离（如 "staging"），为空时不加前缀
+	KeyPrefix string `yaml:"key_prefix"`
+}
+
+// ObjectKey 返回带环境前缀的对象路径
+func (c *MinIOConfig) ObjectKey(key string) string {
+	return JoinObjectKey(c.KeyPrefix, key)
+}
+
+// JoinObjectKey 拼接对象路径前缀，prefix 为空时原样返回 key
+func JoinObjectKey(prefix, key string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return key
+	}
+	return prefix + "/" + strings.TrimPrefix(key, "/")
+}
+
+// GetResultRetention 获取任务结果保留时长
+func (c *MinIOConfig) GetResultRetention() time.Duration {
+	if c.ResultRetentionStr == "" {
+		return 7 * 24 * time.Hour // 默认 7 天
+	}
+
+	duration, err := time.ParseDuration(c.ResultRetentionStr)
+	if err != nil {
+		fmt.Printf("Warning: invalid result_retention '%s', using default 168h: %v\n",
+			c.ResultRetentionStr, err)
+		return 7 * 24 * time.Hour
+	}
+
+	return duration
 }
 
 type DatabaseConfig struct {
@@ -50,21 +277,127 @@ type DatabaseConfig struct {
 	SQLite SQLiteConfig `yaml:"sqlite"`
 	// PostgreSQL 配置
 	PostgreSQL PostgreSQLConfig `yaml:"postgresql"`
+	// 为算法名称创建唯一索引；已有重名数据时只报告，不创建索引
+	UniqueAlgorithmNames bool `yaml:"unique_algorithm_names"`
+	// 执行时间超过该值的语句记录到慢查询日志，0 表示关闭，默认 1s
+	SlowQueryThresholdStr string `yaml:"slow_query_threshold"`
+	// SQLite 数据备份到 MinIO 和本地的配置
+	Backup BackupConfig `yaml:"backup"`
+}
+
+// DefaultSlowQueryThreshold 默认慢查询阈值
+const DefaultSlowQueryThreshold = time.Second
+
+// GetSlowQueryThreshold 获取慢查询阈值，返回 0 表示关闭慢查询日志
+func (c *DatabaseConfig) GetSlowQueryThreshold() time.Duration {
+	if c.SlowQueryThresholdStr == "" {
+		return DefaultSlowQueryThreshold
+	}
+
+	duration, err := time.ParseDuration(c.SlowQueryThresholdStr)
+	if err != nil || duration < 0 {
+		fmt.Printf("Warning: invalid slow_query_threshold '%s', using default %s: %v\n",
+			c.SlowQueryThresholdStr, DefaultSlowQueryThreshold, err)
+		return DefaultSlowQueryThreshold
+	}
+
+	return duration
 }
 
 type SQLiteConfig struct {
 	Path                     string `yaml:"path"`
-	WALCheckpointIntervalStr string `yaml:"wal_checkpoint_interval"`
+	WALCheckpointIntervalStr string `yaml:"wal_checkpoint_interval"` // 定时 TRUNCATE checkpoint 的间隔，0 表示关闭
+	// WAL 达到该页数时由提交事务的连接自动执行 PASSIVE checkpoint，0 表示关闭，未设置时使用 SQLite 默认值 1000
+	WALAutocheckpoint *int `yaml:"wal_autocheckpoint"`
+	// Deprecated: 使用 database.backup.json_retention，未设置新配置时加载为它的值
+	BackupRetention int `yaml:"backup_retention"`
+	// Deprecated: 使用 database.backup.db_retention，未设置新配置时加载为它的值
+	DBBackupRetention int `yaml:"db_backup_retention"`
+}
+
+// BackupConfig SQLite 数据的定时备份配置，未设置的字段使用默认值
+type BackupConfig struct {
+	// 定时备份到 MinIO 的间隔，默认 5m
+	IntervalStr string `yaml:"interval"`
+	// MinIO 和本地备份目录中各自保留的带时间戳的 JSON 备份数量，默认 10
+	JSONRetention int `yaml:"json_retention"`
+	// MinIO 和本地备份目录中各自保留的带时间戳的数据库文件备份数量，默认 5
+	DBRetention int `yaml:"db_retention"`
+	// 本地备份目录，MinIO 不可用时的备份和关闭时的最终备份写到这里，默认 ./data/backups
+	LocalBackupDir string `yaml:"local_backup_dir"`
+	// 从备份恢复时每批插入的记录数，默认 500；过大时可能超过 SQLite 单条语句的参数数量上限
+	RestoreBatchSize int `yaml:"restore_batch_size"`
+}
+
+// 备份配置的默认值
+const (
+	DefaultBackupInterval      = 5 * time.Minute
+	DefaultBackupJSONRetention = 10
+	DefaultBackupDBRetention   = 5
+	DefaultLocalBackupDir      = "./data/backups"
+	DefaultRestoreBatchSize    = 500
+)
+
+// GetInterval 获取定时备份间隔，未设置或无效时使用默认值
+func (c *BackupConfig) GetInterval() time.Duration {
+	if c.IntervalStr == "" {
+		return DefaultBackupInterval
+	}
+
+	duration, err := time.ParseDuration(c.IntervalStr)
+	if err != nil || duration <= 0 {
+		fmt.Printf("Warning: invalid backup interval '%s', using default %s: %v\n",
+			c.IntervalStr, DefaultBackupInterval, err)
+		return DefaultBackupInterval
+	}
+	return duration
+}
+
+// GetJSONRetention 获取 MinIO 和本地目录中保留的 JSON 备份数量，未设置或无效时使用默认值
+func (c *BackupConfig) GetJSONRetention() int {
+	return positiveOrDefault("json_retention", c.JSONRetention, DefaultBackupJSONRetention)
+}
+
+// GetDBRetention 获取 MinIO 和本地目录中保留的数据库文件备份数量，未设置或无效时使用默认值
+func (c *BackupConfig) GetDBRetention() int {
+	return positiveOrDefault("db_retention", c.DBRetention, DefaultBackupDBRetention)
+}
+
+// GetLocalBackupDir 获取本地备份目录
+func (c *BackupConfig) GetLocalBackupDir() string {
+	if c.LocalBackupDir == "" {
+		return DefaultLocalBackupDir
+	}
+	return c.LocalBackupDir
 }
 
-// GetWALCheckpointInterval 获取 WAL checkpoint 间隔
+// GetRestoreBatchSize 获取恢复时每批插入的记录数，未设置或无效时使用默认值
+func (c *BackupConfig) GetRestoreBatchSize() int {
+	return positiveOrDefault("restore_batch_size", c.RestoreBatchSize, DefaultRestoreBatchSize)
+}
+
+func positiveOrDefault(name string, value, def int) int {
+	if value == 0 {
+		return def
+	}
+	if value < 0 {
+		fmt.Printf("Warning: invalid backup %s %d, using default %d\n", name, value, def)
+		return def
+	}
+	return value
+}
+
+// DefaultWALAutocheckpoint SQLite 默认的自动 checkpoint 页数
+const DefaultWALAutocheckpoint = 1000
+
+// GetWALCheckpointInterval 获取 WAL checkpoint 间隔，返回 0 表示关闭定时 checkpoint
 func (c *SQLiteConfig) GetWALCheckpointInterval() time.Duration {
 	if c.WALCheckpointIntervalStr == "" {
 		return 30 * time.Second // 默认 30 秒
 	}
 
 	duration, err := time.ParseDuration(c.WALCheckpointIntervalStr)
-	if err != nil {
+	if err != nil || duration < 0 {
 		fmt.Printf("Warning: invalid wal_checkpoint_interval '%s', using default 30s: %v\n",
 			c.WALCheckpointIntervalStr, err)
 		return 30 * time.Second
@@ -73,6 +406,19 @@ func (c *SQLiteConfig) GetWALCheckpointInterval() time.Duration {
 	return duration
 }
 
+// GetWALAutocheckpoint 获取 WAL 自动 checkpoint 页数，返回 0 表示关闭
+func (c *SQLiteConfig) GetWALAutocheckpoint() int {
+	if c.WALAutocheckpoint == nil {
+		return DefaultWALAutocheckpoint
+	}
+	if *c.WALAutocheckpoint < 0 {
+		fmt.Printf("Warning: invalid wal_autocheckpoint %d, using default %d\n",
+			*c.WALAutocheckpoint, DefaultWALAutocheckpoint)
+		return DefaultWALAutocheckpoint
+	}
+	return *c.WALAutocheckpoint
+}
+
 type PostgreSQLConfig struct {
 	Host     string `yaml:"host"`
 	Port     int    `yaml:"port"`
@@ -94,10 +440,80 @@ func Load(configPath string) (*Config, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	cfg.applyDeprecatedAliases()
 
 	return &cfg, nil
 }
 
+// applyDeprecatedAliases 将已废弃配置项的值迁移到替代它的配置项，两者都设置时以新配置为准
+func (c *Config) applyDeprecatedAliases() {
+	if c.Database.Backup.JSONRetention == 0 {
+		c.Database.Backup.JSONRetention = c.Database.SQLite.BackupRetention
+	}
+	if c.Database.Backup.DBRetention == 0 {
+		c.Database.Backup.DBRetention = c.Database.SQLite.DBBackupRetention
+	}
+}
+
+// DeprecatedSettings 返回配置中仍设置了的已废弃项及说明，启动时作为警告打印
+func (c *Config) DeprecatedSettings() []string {
+	var warnings []string
+	if c.Server.UploadMaxMemoryMB != 0 {
+		warnings = append(warnings, "server.upload_max_memory_mb is deprecated and ignored: uploads are streamed to MinIO without buffering")
+	}
+	if c.Server.ScratchDir != "" {
+		warnings = append(warnings, "server.scratch_dir is deprecated and ignored: uploads are streamed to MinIO without buffering")
+	}
+	if c.Database.SQLite.BackupRetention != 0 {
+		warnings = append(warnings, "database.sqlite.backup_retention is deprecated, use database.backup.json_retention")
+	}
+	if c.Database.SQLite.DBBackupRetention != 0 {
+		warnings = append(warnings, "database.sqlite.db_backup_retention is deprecated, use database.backup.db_retention")
+	}
+	return warnings
+}
+
+// Redacted 返回隐藏了密钥和密码的配置副本，用于展示
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	for _, s := range []*string{
+		&redacted.Server.AdminToken,
+		&redacted.Server.PageTokenSecret,
+		&redacted.Server.WebhookSecret,
+		&redacted.Redis.Password,
+		&redacted.MinIO.SecretAccessKey,
+		&redacted.Database.PostgreSQL.Password,
+	} {
+		*s = redact.String(*s)
+	}
+	if len(c.Docker.Secrets) > 0 {
+		redacted.Docker.Secrets = make(map[string]SecretEntry, len(c.Docker.Secrets))
+		for name, entry := range c.Docker.Secrets {
+			redacted.Docker.Secrets[name] = SecretEntry{Value: redact.Placeholder, AllowedAlgorithms: entry.AllowedAlgorithms}
+		}
+	}
+	return &redacted
+}
+
+// String 以 YAML 输出脱敏后的配置，打印配置时使用，避免密钥出现在日志中
+func (c *Config) String() string {
+	data, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Sprintf("<invalid config: %v>", err)
+	}
+	return string(data)
+}
+
+// ApplyEnvOverrides 应用环境变量覆盖，LOCAL_MODE=true 时使用本机 MinIO，返回是否启用了 LOCAL_MODE
+func ApplyEnvOverrides(cfg *Config) bool {
+	if os.Getenv("LOCAL_MODE") != "true" {
+		return false
+	}
+	cfg.MinIO.Endpoint = "localhost:9000"
+	cfg.MinIO.ExternalEndpoint = "localhost:9000"
+	return true
+}
+
 // LoadOrDefault loads configuration from config.yaml, falls back to default if file not found
 func LoadOrDefault() *Config {
 	configPaths := []string{
@@ -125,20 +541,30 @@ func Default() *Config {
 			HTTPPort: 8080,
 		},
 		Docker: DockerConfig{
-			Host:       "unix:///var/run/docker.sock",
-			APIVersion: "1.45",
+			Host:            "unix:///var/run/docker.sock",
+			APIVersion:      "1.45",
+			DefaultCPULimit: 1,
+			DefaultMemoryMB: 512,
+			MaxCPULimit:     4,
+			MaxMemoryMB:     8192,
+			RuntimeImages: map[string]string{
+				"python": "algorithm-platform/python:latest",
+				"cpp":    "algorithm-platform/cpp:latest",
+			},
+			CleanupOnStartup: true,
 		},
 		Redis: RedisConfig{
 			Addr: "localhost:6379",
 			DB:   0,
 		},
 		MinIO: MinIOConfig{
-			Endpoint:         "minio:9000",
-			ExternalEndpoint: "localhost:9000",
-			AccessKeyID:      "minioadmin",
-			SecretAccessKey:  "minioadmin",
-			Bucket:           "algorithm-platform",
-			UseSSL:           false,
+			Endpoint:           "minio:9000",
+			ExternalEndpoint:   "localhost:9000",
+			AccessKeyID:        "minioadmin",
+			SecretAccessKey:    "minioadmin",
+			Bucket:             "algorithm-platform",
+			UseSSL:             false,
+			ResultRetentionStr: "168h",
 		},
 		Database: DatabaseConfig{
 			Type: "sqlite",
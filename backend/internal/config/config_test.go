@@ -0,0 +1,203 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"algorithm-platform/internal/redact"
+)
+
+func TestJoinObjectKey(t *testing.T) {
+	tests := []struct{ prefix, key, want string }{
+		{"", "algorithms/a/v1/x.zip", "algorithms/a/v1/x.zip"},
+		{"staging", "algorithms/a/v1/x.zip", "staging/algorithms/a/v1/x.zip"},
+		{"/prod/", "/results/job_1", "prod/results/job_1"},
+	}
+	for _, tt := range tests {
+		if got := JoinObjectKey(tt.prefix, tt.key); got != tt.want {
+			t.Errorf("JoinObjectKey(%q, %q) = %q, want %q", tt.prefix, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestGetSlowQueryThreshold(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", DefaultSlowQueryThreshold},
+		{"0", 0},
+		{"250ms", 250 * time.Millisecond},
+		{"-1s", DefaultSlowQueryThreshold},
+		{"soon", DefaultSlowQueryThreshold},
+	}
+	for _, tt := range tests {
+		c := DatabaseConfig{SlowQueryThresholdStr: tt.value}
+		if got := c.GetSlowQueryThreshold(); got != tt.want {
+			t.Errorf("GetSlowQueryThreshold(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestGetResultCacheTTL(t *testing.T) {
+	tests := map[string]time.Duration{"": 0, "0": 0, "10m": 10 * time.Minute, "-1m": 0, "later": 0}
+	for value, want := range tests {
+		c := RedisConfig{ResultCacheTTLStr: value}
+		if got := c.GetResultCacheTTL(); got != want {
+			t.Errorf("GetResultCacheTTL(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestGetResultRetention(t *testing.T) {
+	tests := map[string]time.Duration{"": 7 * 24 * time.Hour, "24h": 24 * time.Hour, "forever": 7 * 24 * time.Hour}
+	for value, want := range tests {
+		c := MinIOConfig{ResultRetentionStr: value}
+		if got := c.GetResultRetention(); got != want {
+			t.Errorf("GetResultRetention(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestBackupConfigDefaults(t *testing.T) {
+	var c BackupConfig
+	if c.GetInterval() != DefaultBackupInterval || c.GetJSONRetention() != DefaultBackupJSONRetention ||
+		c.GetDBRetention() != DefaultBackupDBRetention || c.GetLocalBackupDir() != DefaultLocalBackupDir ||
+		c.GetRestoreBatchSize() != DefaultRestoreBatchSize {
+		t.Errorf("Unset backup config: interval %v, retention %d/%d, dir %q",
+			c.GetInterval(), c.GetJSONRetention(), c.GetDBRetention(), c.GetLocalBackupDir())
+	}
+
+	c = BackupConfig{IntervalStr: "1h", JSONRetention: 3, DBRetention: 2, LocalBackupDir: "/var/backups"}
+	if c.GetInterval() != time.Hour || c.GetJSONRetention() != 3 || c.GetDBRetention() != 2 || c.GetLocalBackupDir() != "/var/backups" {
+		t.Errorf("Configured backup config not applied: %+v", c)
+	}
+
+	c = BackupConfig{IntervalStr: "0s", JSONRetention: -1, RestoreBatchSize: -1}
+	if c.GetInterval() != DefaultBackupInterval || c.GetJSONRetention() != DefaultBackupJSONRetention || c.GetRestoreBatchSize() != DefaultRestoreBatchSize {
+		t.Errorf("Invalid values should fall back to defaults: interval %v, retention %d", c.GetInterval(), c.GetJSONRetention())
+	}
+}
+
+func TestDeprecatedSettings(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	data := "server:\n  upload_max_memory_mb: 64\n  scratch_dir: /var/tmp/uploads\n"
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	warnings := cfg.DeprecatedSettings()
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "upload_max_memory_mb") || !strings.Contains(warnings[1], "scratch_dir") {
+		t.Errorf("DeprecatedSettings = %q", warnings)
+	}
+	if warnings := Default().DeprecatedSettings(); len(warnings) != 0 {
+		t.Errorf("Default config has deprecated settings: %q", warnings)
+	}
+}
+
+func TestLoadAcceptsDeprecatedBackupRetention(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	data := "database:\n  sqlite:\n    backup_retention: 20\n    db_backup_retention: 3\n  backup:\n    db_retention: 7\n"
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	// 只设置旧配置的项沿用旧值，新配置优先
+	if got := cfg.Database.Backup.GetJSONRetention(); got != 20 {
+		t.Errorf("json_retention = %d, want 20", got)
+	}
+	if got := cfg.Database.Backup.GetDBRetention(); got != 7 {
+		t.Errorf("db_retention = %d, want 7", got)
+	}
+	if warnings := cfg.DeprecatedSettings(); len(warnings) != 2 {
+		t.Errorf("DeprecatedSettings = %q, want warnings for both old keys", warnings)
+	}
+}
+
+func TestLoadSecrets(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secrets.yaml")
+	data := "api_key: from-file\ntoken:\n  value: t0k\n  allowed_algorithms: [\"alg_1\", \"weather\"]\n"
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatalf("Failed to write secrets file: %v", err)
+	}
+	cfg := DockerConfig{Secrets: map[string]SecretEntry{"api_key": {Value: "from-config"}, "db": {Value: "pw"}}, SecretsFile: file}
+
+	secrets, err := cfg.LoadSecrets()
+	if err != nil {
+		t.Fatalf("LoadSecrets failed: %v", err)
+	}
+	want := map[string]SecretEntry{
+		"api_key": {Value: "from-file"},
+		"token":   {Value: "t0k", AllowedAlgorithms: []string{"alg_1", "weather"}},
+		"db":      {Value: "pw"},
+	}
+	if !reflect.DeepEqual(secrets, want) {
+		t.Errorf("LoadSecrets = %v, want %v", secrets, want)
+	}
+
+	cfg.SecretsFile = filepath.Join(t.TempDir(), "missing.yaml")
+	if _, err := cfg.LoadSecrets(); err == nil {
+		t.Error("Expected an error for a missing secrets file")
+	}
+}
+
+func TestSecretEntryAllows(t *testing.T) {
+	entry := SecretEntry{Value: "v", AllowedAlgorithms: []string{"alg_1", "weather"}}
+	if !entry.Allows("alg_1", "other") || !entry.Allows("alg_2", "weather") {
+		t.Error("Listed algorithms should be allowed by ID or name")
+	}
+	if entry.Allows("alg_2", "other") || entry.Allows("alg_2", "") {
+		t.Error("Unlisted algorithms should not be allowed")
+	}
+	if (SecretEntry{Value: "v"}).Allows("alg_1", "weather") {
+		t.Error("A secret without allowed_algorithms should not be usable")
+	}
+	if !(SecretEntry{Value: "v", AllowedAlgorithms: []string{"*"}}).Allows("alg_2", "") {
+		t.Error("\"*\" should allow every algorithm")
+	}
+}
+
+func TestRedactedHidesSecrets(t *testing.T) {
+	cfg := &Config{Docker: DockerConfig{Secrets: map[string]SecretEntry{"api_key": {Value: "value", AllowedAlgorithms: []string{"alg_1"}}}}}
+	got := cfg.Redacted().Docker.Secrets["api_key"]
+	if got.Value != redact.Placeholder || !reflect.DeepEqual(got.AllowedAlgorithms, []string{"alg_1"}) {
+		t.Errorf("Redacted secret = %+v", got)
+	}
+	if cfg.Docker.Secrets["api_key"].Value != "value" {
+		t.Error("Redacted modified the original config")
+	}
+}
+
+func TestStringHidesSecrets(t *testing.T) {
+	cfg := &Config{
+		Server:   ServerConfig{AdminToken: "admin-tok", WebhookSecret: "hook-sec"},
+		Redis:    RedisConfig{Password: "redis-pw"},
+		MinIO:    MinIOConfig{AccessKeyID: "minio", SecretAccessKey: "minio-sk"},
+		Database: DatabaseConfig{PostgreSQL: PostgreSQLConfig{Password: "pg-pw"}},
+		Docker:   DockerConfig{Secrets: map[string]SecretEntry{"api_key": {Value: "docker-sec"}}},
+	}
+
+	out := cfg.String()
+	for _, secret := range []string{"admin-tok", "hook-sec", "redis-pw", "minio-sk", "pg-pw", "docker-sec"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("Config output leaks %q:\n%s", secret, out)
+		}
+	}
+	if !strings.Contains(out, "minio") {
+		t.Errorf("Config output should keep non-secret values:\n%s", out)
+	}
+	if out2 := fmt.Sprintf("%v", cfg); out2 != out {
+		t.Error("Formatting the config should use the redacted output")
+	}
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"reflect"
+)
+
+// ReloadResult 一次配置重载的结果
+type ReloadResult struct {
+	Applied         []string // 已生效的字段
+	RestartRequired []string // 已变更但需要重启才能生效的字段
+}
+
+// reloadableField 可在运行时更新的字段，apply 将新值写入当前配置
+type reloadableField struct {
+	name  string
+	get   func(c *Config) interface{}
+	apply func(current, next *Config)
+}
+
+// reloadableFields 可热更新的配置，这些字段在每次请求时从 Store 的快照读取，更新后对新请求生效；
+// 备份间隔由重载方通知备份调度器
+var reloadableFields = []reloadableField{
+	{"server.read_only", func(c *Config) interface{} { return c.Server.ReadOnly }, func(cur, next *Config) { cur.Server.ReadOnly = next.Server.ReadOnly }},
+	{"server.admin_token", func(c *Config) interface{} { return c.Server.AdminToken }, func(cur, next *Config) { cur.Server.AdminToken = next.Server.AdminToken }},
+	{"server.webhook_secret", func(c *Config) interface{} { return c.Server.WebhookSecret }, func(cur, next *Config) { cur.Server.WebhookSecret = next.Server.WebhookSecret }},
+	{"server.webhook_inline_max_bytes", func(c *Config) interface{} { return c.Server.WebhookInlineMaxBytes }, func(cur, next *Config) { cur.Server.WebhookInlineMaxBytes = next.Server.WebhookInlineMaxBytes }},
+	{"server.max_page_size", func(c *Config) interface{} { return c.Server.MaxPageSize }, func(cur, next *Config) { cur.Server.MaxPageSize = next.Server.MaxPageSize }},
+	{"server.job_history_limit", func(c *Config) interface{} { return c.Server.JobHistoryLimit }, func(cur, next *Config) { cur.Server.JobHistoryLimit = next.Server.JobHistoryLimit }},
+	{"server.preset_data_categories", func(c *Config) interface{} { return c.Server.PresetDataCategories }, func(cur, next *Config) { cur.Server.PresetDataCategories = next.Server.PresetDataCategories }},
+	{"docker.default_cpu_limit", func(c *Config) interface{} { return c.Docker.DefaultCPULimit }, func(cur, next *Config) { cur.Docker.DefaultCPULimit = next.Docker.DefaultCPULimit }},
+	{"docker.default_memory_mb", func(c *Config) interface{} { return c.Docker.DefaultMemoryMB }, func(cur, next *Config) { cur.Docker.DefaultMemoryMB = next.Docker.DefaultMemoryMB }},
+	{"docker.max_cpu_limit", func(c *Config) interface{} { return c.Docker.MaxCPULimit }, func(cur, next *Config) { cur.Docker.MaxCPULimit = next.Docker.MaxCPULimit }},
+	{"docker.max_memory_mb", func(c *Config) interface{} { return c.Docker.MaxMemoryMB }, func(cur, next *Config) { cur.Docker.MaxMemoryMB = next.Docker.MaxMemoryMB }},
+	{"docker.runtime_images", func(c *Config) interface{} { return c.Docker.RuntimeImages }, func(cur, next *Config) { cur.Docker.RuntimeImages = next.Docker.RuntimeImages }},
+	{"docker.pinned_images", func(c *Config) interface{} { return c.Docker.PinnedImages }, func(cur, next *Config) { cur.Docker.PinnedImages = next.Docker.PinnedImages }},
+	{"docker.secrets", func(c *Config) interface{} { return c.Docker.Secrets }, func(cur, next *Config) { cur.Docker.Secrets = next.Docker.Secrets }},
+	{"docker.secrets_file", func(c *Config) interface{} { return c.Docker.SecretsFile }, func(cur, next *Config) { cur.Docker.SecretsFile = next.Docker.SecretsFile }},
+	{"docker.keep_containers", func(c *Config) interface{} { return c.Docker.KeepContainers }, func(cur, next *Config) { cur.Docker.KeepContainers = next.Docker.KeepContainers }},
+	{"database.backup.interval", func(c *Config) interface{} { return c.Database.Backup.IntervalStr }, func(cur, next *Config) { cur.Database.Backup.IntervalStr = next.Database.Backup.IntervalStr }},
+	{"minio.external_endpoint", func(c *Config) interface{} { return c.MinIO.ExternalEndpoint }, func(cur, next *Config) { cur.MinIO.ExternalEndpoint = next.MinIO.ExternalEndpoint }},
+	{"minio.result_retention", func(c *Config) interface{} { return c.MinIO.ResultRetentionStr }, func(cur, next *Config) { cur.MinIO.ResultRetentionStr = next.MinIO.ResultRetentionStr }},
+}
+
+// restartOnlyFields 只在启动时读取的配置，变更后需要重启
+var restartOnlyFields = []struct {
+	name string
+	get  func(c *Config) interface{}
+}{
+	{"server.grpc_port", func(c *Config) interface{} { return c.Server.GRPCPort }},
+	{"server.http_port", func(c *Config) interface{} { return c.Server.HTTPPort }},
+	{"server.page_token_secret", func(c *Config) interface{} { return c.Server.PageTokenSecret }}, // 分页令牌编解码器在启动时创建
+	{"server.metrics_enabled", func(c *Config) interface{} { return c.Server.MetricsEnabled }},
+	{"server.max_concurrent_jobs", func(c *Config) interface{} { return c.Server.MaxConcurrentJobs }},
+	{"server.upload_max_size_mb", func(c *Config) interface{} { return c.Server.UploadMaxSizeMB }},
+	{"server.upload_max_memory_mb", func(c *Config) interface{} { return c.Server.UploadMaxMemoryMB }},
+	{"server.scratch_dir", func(c *Config) interface{} { return c.Server.ScratchDir }},
+	{"server.startup_timeout", func(c *Config) interface{} { return c.Server.StartupTimeoutStr }},
+	{"server.worker_id", func(c *Config) interface{} { return c.Server.WorkerID }}, // 任务恢复在启动时按它筛选
+	{"docker.host", func(c *Config) interface{} { return c.Docker.Host }},
+	{"docker.tls_cert", func(c *Config) interface{} { return c.Docker.TLSCert }},
+	{"docker.tls_key", func(c *Config) interface{} { return c.Docker.TLSKey }},
+	{"docker.api_version", func(c *Config) interface{} { return c.Docker.APIVersion }},
+	{"docker.cleanup_on_startup", func(c *Config) interface{} { return c.Docker.CleanupOnStartup }},
+	{"redis", func(c *Config) interface{} { return c.Redis }},
+	{"minio.endpoint", func(c *Config) interface{} { return c.MinIO.Endpoint }},
+	{"minio.access_key_id", func(c *Config) interface{} { return c.MinIO.AccessKeyID }},
+	{"minio.secret_access_key", func(c *Config) interface{} { return c.MinIO.SecretAccessKey }},
+	{"minio.bucket", func(c *Config) interface{} { return c.MinIO.Bucket }},
+	{"minio.use_ssl", func(c *Config) interface{} { return c.MinIO.UseSSL }},
+	{"minio.key_prefix", func(c *Config) interface{} { return c.MinIO.KeyPrefix }}, // 备份调度器启动时读取，运行中切换会使新旧对象分处两个前缀
+	{"database", func(c *Config) interface{} {
+		// 备份间隔可以热更新，不计入需要重启的变更
+		database := c.Database
+		database.Backup.IntervalStr = ""
+		return database
+	}},
+}
+
+// ApplyReloadable 比较两份配置，将可热更新的字段写入 current（current 必须是尚未发布的副本）
+func ApplyReloadable(current, next *Config) ReloadResult {
+	var result ReloadResult
+
+	for _, field := range reloadableFields {
+		if !reflect.DeepEqual(field.get(current), field.get(next)) {
+			field.apply(current, next)
+			result.Applied = append(result.Applied, field.name)
+		}
+	}
+
+	for _, field := range restartOnlyFields {
+		if !reflect.DeepEqual(field.get(current), field.get(next)) {
+			result.RestartRequired = append(result.RestartRequired, field.name)
+		}
+	}
+
+	return result
+}
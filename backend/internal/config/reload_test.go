@@ -0,0 +1,193 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestApplyReloadableKeepsRestartOnlyFields(t *testing.T) {
+	current := Default()
+	next := Default()
+	next.Docker.MaxMemoryMB = 16384
+	next.Docker.RuntimeImages = map[string]string{"python": "python:3.12"}
+	next.Server.HTTPPort = 9999
+
+	result := ApplyReloadable(current, next)
+
+	if current.Docker.MaxMemoryMB != 16384 || current.Docker.GetRuntimeImage("python") != "python:3.12" {
+		t.Errorf("Expected reloadable fields to be applied, got %+v", current.Docker)
+	}
+	if current.Server.HTTPPort != 8080 {
+		t.Errorf("Expected http_port to stay unchanged, got %d", current.Server.HTTPPort)
+	}
+
+	if len(result.Applied) != 2 {
+		t.Errorf("Expected 2 applied fields, got %v", result.Applied)
+	}
+	if len(result.RestartRequired) != 1 || result.RestartRequired[0] != "server.http_port" {
+		t.Errorf("Expected http_port to require restart, got %v", result.RestartRequired)
+	}
+}
+
+func TestReloadRequiresRestartForStartupOnlyFields(t *testing.T) {
+	current := Default()
+	next := Default()
+	next.MinIO.KeyPrefix = "staging"
+	next.Server.PageTokenSecret = "rotated"
+
+	result := ApplyReloadable(current, next)
+
+	if current.MinIO.KeyPrefix != "" || current.Server.PageTokenSecret != "" {
+		t.Errorf("Expected key_prefix and page_token_secret to stay unchanged, got %q %q", current.MinIO.KeyPrefix, current.Server.PageTokenSecret)
+	}
+	want := []string{"server.page_token_secret", "minio.key_prefix"}
+	if len(result.Applied) != 0 || !reflect.DeepEqual(result.RestartRequired, want) {
+		t.Errorf("Expected %v to require restart, got applied=%v restart=%v", want, result.Applied, result.RestartRequired)
+	}
+}
+
+// 新增配置字段时必须归入 reloadableFields 或 restartOnlyFields，否则重载时变更会被静默忽略
+func TestReloadClassifiesEveryField(t *testing.T) {
+	classified := make(map[string]int)
+	for _, field := range reloadableFields {
+		classified[field.name]++
+	}
+	for _, field := range restartOnlyFields {
+		classified[field.name]++
+	}
+
+	sections := reflect.TypeOf(Config{})
+	for i := 0; i < sections.NumField(); i++ {
+		section := sections.Field(i)
+		sectionName := section.Tag.Get("yaml")
+		if classified[sectionName] > 0 {
+			continue // 整节归类，如 redis、database
+		}
+		for j := 0; j < section.Type.NumField(); j++ {
+			name := sectionName + "." + section.Type.Field(j).Tag.Get("yaml")
+			switch classified[name] {
+			case 0:
+				t.Errorf("%s is neither reloadable nor restart-only", name)
+			case 1:
+			default:
+				t.Errorf("%s is classified more than once", name)
+			}
+		}
+	}
+}
+
+func TestReloadAppliesExternalEndpoint(t *testing.T) {
+	current := Default()
+	next := Default()
+	next.MinIO.ExternalEndpoint = "minio.example.com"
+	next.Docker.TLSCert = "/etc/docker/cert.pem"
+
+	result := ApplyReloadable(current, next)
+
+	if current.MinIO.ExternalEndpoint != "minio.example.com" || current.Docker.TLSCert != "" {
+		t.Errorf("Unexpected config after reload: external_endpoint=%q tls_cert=%q", current.MinIO.ExternalEndpoint, current.Docker.TLSCert)
+	}
+	if !reflect.DeepEqual(result.Applied, []string{"minio.external_endpoint"}) || !reflect.DeepEqual(result.RestartRequired, []string{"docker.tls_cert"}) {
+		t.Errorf("Unexpected reload result: applied=%v restart=%v", result.Applied, result.RestartRequired)
+	}
+}
+
+func TestReloadReadsConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("docker:\n  max_cpu_limit: 8\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	initial := &Config{}
+	store := NewStore(initial)
+	result, err := store.Reload(path)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if store.Load().Docker.MaxCPULimit != 8 || len(result.Applied) != 1 {
+		t.Errorf("Unexpected reload result: applied=%v max_cpu_limit=%v", result.Applied, store.Load().Docker.MaxCPULimit)
+	}
+	if initial.Docker.MaxCPULimit != 0 {
+		t.Error("Reload must publish a new snapshot instead of modifying the current one")
+	}
+
+	if _, err := store.Reload(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for missing config file")
+	}
+}
+
+func TestReloadAppliesBackupInterval(t *testing.T) {
+	current := Default()
+	next := Default()
+	next.Database.Backup.IntervalStr = "30m"
+	next.Database.Backup.JSONRetention = 3
+
+	result := ApplyReloadable(current, next)
+
+	if current.Database.Backup.GetInterval() != 30*time.Minute {
+		t.Errorf("Expected backup interval to be applied, got %v", current.Database.Backup.GetInterval())
+	}
+	if len(result.Applied) != 1 || result.Applied[0] != "database.backup.interval" {
+		t.Errorf("Unexpected applied fields: %v", result.Applied)
+	}
+	if len(result.RestartRequired) != 1 || result.RestartRequired[0] != "database" {
+		t.Errorf("Expected other database changes to require restart, got %v", result.RestartRequired)
+	}
+}
+
+// 用 go test -race 运行时检查重载与并发读取之间没有数据竞争
+func TestStoreReloadWhileReading(t *testing.T) {
+	dir := t.TempDir()
+	paths := make([]string, 2)
+	for i, content := range []string{
+		"server:\n  read_only: true\n  preset_data_categories: {images: {extensions: [.png]}}\ndocker:\n  pinned_images: [python:3.11]\n",
+		"server:\n  read_only: false\n  preset_data_categories: {tables: {extensions: [.csv]}}\ndocker:\n  pinned_images: [python:3.12, node:20]\n",
+	} {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("config-%d.yaml", i))
+		if err := os.WriteFile(paths[i], []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+	}
+
+	store := NewStore(Default())
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				cfg := store.Load()
+				_ = cfg.Server.ReadOnly
+				for name, category := range cfg.Server.PresetDataCategories {
+					_, _ = name, len(category.Extensions)
+				}
+				for _, image := range cfg.Docker.PinnedImages {
+					_ = image
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 50; i++ {
+		if _, err := store.Reload(paths[i%2]); err != nil {
+			t.Fatalf("Reload failed: %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	if got := store.Load().Docker.PinnedImages; len(got) != 2 {
+		t.Errorf("Expected the last reload to be published, got %v", got)
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Store 保存当前生效的配置。Load 返回的快照不可修改，
+// 重载时复制当前快照、应用可热更新的字段后整体替换，读取方不需要加锁
+type Store struct {
+	current  atomic.Pointer[Config]
+	reloadMu sync.Mutex // 串行化重载
+}
+
+// NewStore 以 cfg 作为初始快照，之后不应再修改 cfg
+func NewStore(cfg *Config) *Store {
+	s := &Store{}
+	s.current.Store(cfg)
+	return s
+}
+
+// Load 返回当前配置快照
+func (s *Store) Load() *Config {
+	return s.current.Load()
+}
+
+// Reload 重新读取配置文件，发布应用了可热更新字段的新快照，其余变更只记录不生效
+func (s *Store) Reload(configPath string) (*ReloadResult, error) {
+	next, err := Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload config: %w", err)
+	}
+	ApplyEnvOverrides(next)
+
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	// 字段整体替换，切片和 map 不会原地修改，浅拷贝与旧快照共享它们是安全的
+	updated := *s.current.Load()
+	result := ApplyReloadable(&updated, next)
+	s.current.Store(&updated)
+	return &result, nil
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"algorithm-platform/internal/keys"
+)
+
+// ErrBackupNotFound 指定的备份不在可恢复的备份列表中
+var ErrBackupNotFound = errors.New("backup not found")
+
+// ListBackups 列出可以恢复的 JSON 备份（MinIO 和本地目录中带时间戳的备份），按时间从新到旧排序。
+// 版本号和记录数取自备份的摘要，较早的备份没有摘要时读取全部内容，无法读取或解析的备份跳过
+func (m *SQLiteBackupManager) ListBackups(ctx context.Context) ([]*BackupMetadata, error) {
+	var backups []*BackupMetadata
+
+	// MinIO 不可用时只列出本地备份
+	for _, key := range m.listBackupsByPrefix(ctx, m.minio, m.objectKey(keys.BackupJSONPrefix)) {
+		meta, err := m.readMinIOBackupMetadata(ctx, key)
+		if err != nil {
+			fmt.Printf("Warning: skipping MinIO backup %s: %v\n", key, err)
+			continue
+		}
+		backups = append(backups, meta)
+	}
+
+	files, err := filepath.Glob(filepath.Join(m.localBackupDir, localJSONBackupPattern))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local backups: %w", err)
+	}
+	for _, file := range files {
+		meta, err := readLocalBackupMetadata(file)
+		if err != nil {
+			fmt.Printf("Warning: skipping local backup %s: %v\n", file, err)
+			continue
+		}
+		backups = append(backups, meta)
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].Timestamp.After(backups[j].Timestamp)
+	})
+	return backups, nil
+}
+
+// RestoreBackup 恢复 ListBackups 返回的某个备份，path 为其中的 Path。
+// 恢复后数据版本号高于恢复前，并立即备份一次，避免下次启动时按版本号比较又恢复到较新的备份
+func (m *SQLiteBackupManager) RestoreBackup(ctx context.Context, path string) (*RestoreResult, error) {
+	target, err := m.findBackup(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := m.getDatabaseMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database metadata: %w", err)
+	}
+
+	result, err := m.restoreFromBackup(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setDatabaseMetadata(m.db, max(current.Version, target.Version)+1, "manual_restore", time.Now()); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to update database version: %v", err))
+	}
+	if err := m.BackupToMinIO(); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("backup after restore failed: %v", err))
+	}
+	return result, nil
+}
+
+// findBackup 返回 path 指向的可恢复备份的元数据，只检查这一个备份。
+// path 必须是 ListBackups 会列出的路径：本地备份目录中的 JSON 备份，或 MinIO 中带时间戳的 JSON 备份
+func (m *SQLiteBackupManager) findBackup(ctx context.Context, path string) (*BackupMetadata, error) {
+	var (
+		meta *BackupMetadata
+		err  error
+	)
+	if local, _ := filepath.Match(localJSONBackupPattern, filepath.Base(path)); local && filepath.Dir(path) == filepath.Clean(m.localBackupDir) {
+		meta, err = readLocalBackupMetadata(path)
+	} else if strings.HasPrefix(path, m.objectKey(keys.BackupJSONPrefix)) {
+		meta, err = m.readMinIOBackupMetadata(ctx, path)
+	} else {
+		return nil, fmt.Errorf("%w: %s", ErrBackupNotFound, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrBackupNotFound, path, err)
+	}
+	return meta, nil
+}
@@ -0,0 +1,151 @@
+package database
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"algorithm-platform/internal/keys"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+)
+
+// writeLocalBackup 在本地备份目录写入一个 JSON 备份，并设置修改时间作为备份时间
+func writeLocalBackup(t *testing.T, m *SQLiteBackupManager, name, backup string, modTime time.Time) string {
+	t.Helper()
+	if err := os.MkdirAll(m.localBackupDir, 0755); err != nil {
+		t.Fatalf("Failed to create backup directory: %v", err)
+	}
+	backupPath := filepath.Join(m.localBackupDir, name)
+	if err := os.WriteFile(backupPath, []byte(backup), 0644); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+	if err := os.Chtimes(backupPath, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set backup time: %v", err)
+	}
+	return backupPath
+}
+
+func TestListBackupsNewestFirst(t *testing.T) {
+	_, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+	now := time.Now()
+	older := writeLocalBackup(t, m, "backup-20240101-000000.json",
+		`{"algorithms": [{"id": "algo_1"}], "metadata": {"schema_version": 2, "version": 3}}`, now.Add(-time.Hour))
+	newer := writeLocalBackup(t, m, "backup-20240102-000000.json",
+		`{"algorithms": [{"id": "algo_1"}, {"id": "algo_2"}], "metadata": {"schema_version": 2, "version": 5}}`, now)
+	writeLocalBackup(t, m, "backup-20240103-000000.json", `not json`, now)
+
+	backups, err := m.ListBackups(t.Context())
+	if err != nil {
+		t.Fatalf("ListBackups failed: %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("Expected 2 readable backups, got %d", len(backups))
+	}
+	if backups[0].Path != newer || backups[1].Path != older {
+		t.Errorf("Backups not sorted newest first: %s, %s", backups[0].Path, backups[1].Path)
+	}
+	if backups[0].Version != 5 || backups[0].RecordCount != 2 || backups[0].Size == 0 || backups[0].Source != "local" {
+		t.Errorf("Unexpected backup metadata: %+v", backups[0])
+	}
+}
+
+func TestRestoreBackupRollsBackData(t *testing.T) {
+	fake, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+	seedAlgorithms(t, m.db, 3)
+	if err := m.db.Create(&models.DatabaseMetadata{Version: 9, LastUpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to seed metadata: %v", err)
+	}
+	backupPath := writeLocalBackup(t, m, "backup-20240101-000000.json",
+		`{"algorithms": [{"id": "algo_old", "name": "old"}], "metadata": {"schema_version": 2, "version": 4}}`, time.Now())
+
+	if _, err := m.RestoreBackup(t.Context(), filepath.Join(t.TempDir(), "backup.json")); !errors.Is(err, ErrBackupNotFound) {
+		t.Errorf("Expected ErrBackupNotFound for a path outside the backup list, got %v", err)
+	}
+
+	result, err := m.RestoreBackup(t.Context(), backupPath)
+	if err != nil {
+		t.Fatalf("RestoreBackup failed: %v", err)
+	}
+	if result.Partial() || result.Tables["algorithms"].Restored != 1 {
+		t.Errorf("Unexpected restore result: %+v", result.Tables["algorithms"])
+	}
+
+	var ids []string
+	m.db.Model(&models.Algorithm{}).Pluck("id", &ids)
+	if len(ids) != 1 || ids[0] != "algo_old" {
+		t.Errorf("Algorithms after restore = %v, want [algo_old]", ids)
+	}
+
+	// 恢复后的版本号必须高于恢复前，否则下次启动会恢复到较新的 latest.json
+	meta, err := m.getDatabaseMetadata()
+	if err != nil {
+		t.Fatalf("Failed to get metadata: %v", err)
+	}
+	if meta.Version <= 9 {
+		t.Errorf("Version after restore = %d, want > 9", meta.Version)
+	}
+	if _, ok := fake.Object(m.objectKey(keys.BackupLatestJSON)); !ok {
+		t.Error("Expected a backup of the restored data")
+	}
+}
+
+func TestListBackupsReadsSummariesOnly(t *testing.T) {
+	fake, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+	seedAlgorithms(t, m.db, 2)
+	if err := m.db.Create(&models.DatabaseMetadata{Version: 6, LastUpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to seed metadata: %v", err)
+	}
+	result, err := m.RunBackup()
+	if err != nil {
+		t.Fatalf("RunBackup failed: %v", err)
+	}
+
+	// 本地备份只有 gzip 头中的摘要可读，内容无法解析
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Comment = (&backupHeader{Hash: "abc", Info: BackupInfo{SchemaVersion: BackupSchemaVersion, Version: 4, RecordCount: 9}}).comment()
+	zw.Write([]byte("not json"))
+	zw.Close()
+	local := writeLocalBackup(t, m, "backup-20240101-000000.json.gz", buf.String(), time.Now().Add(-time.Hour))
+
+	gets := fake.ObjectGets()
+	backups, err := m.ListBackups(t.Context())
+	if err != nil {
+		t.Fatalf("ListBackups failed: %v", err)
+	}
+	if fake.ObjectGets() != gets {
+		t.Errorf("Expected ListBackups to read only object metadata, got %d downloads", fake.ObjectGets()-gets)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("Expected 2 backups, got %+v", backups)
+	}
+	if b := backups[0]; b.Path != result.Path || b.Version != result.Version || b.RecordCount != 2 || !b.HashRecorded || b.SchemaVersion != BackupSchemaVersion {
+		t.Errorf("Unexpected MinIO backup metadata: %+v", b)
+	}
+	if b := backups[1]; b.Path != local || b.Version != 4 || b.RecordCount != 9 || b.Hash != "abc" {
+		t.Errorf("Unexpected local backup metadata: %+v", b)
+	}
+
+	// 恢复时只检查指定的备份
+	if _, err := m.RestoreBackup(t.Context(), m.objectKey(keys.BackupJSON("20000101-000000"))); !errors.Is(err, ErrBackupNotFound) {
+		t.Errorf("Expected ErrBackupNotFound for a missing MinIO backup, got %v", err)
+	}
+	if _, err := m.RestoreBackup(t.Context(), m.objectKey(keys.BackupLatestJSON)); !errors.Is(err, ErrBackupNotFound) {
+		t.Errorf("Expected ErrBackupNotFound for latest.json.gz, got %v", err)
+	}
+	restored, err := m.RestoreBackup(t.Context(), result.Path)
+	if err != nil {
+		t.Fatalf("RestoreBackup failed: %v", err)
+	}
+	if restored.Tables["algorithms"].Restored != 2 {
+		t.Errorf("Unexpected restore result: %+v", restored.Tables["algorithms"])
+	}
+}
@@ -0,0 +1,251 @@
+package database
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"algorithm-platform/internal/models"
+)
+
+// BackupSchemaVersion 当前 JSON 备份的结构版本，模型变更影响备份内容时递增，并在 backupMigrations 中补充迁移
+//
+//	1: 早期备份，没有 schema_version 字段，算法没有生命周期状态
+//	2: metadata 中记录 schema_version，算法包含 status
+const BackupSchemaVersion = 2
+
+// ErrUnsupportedBackupSchema 备份由更新版本的服务生成，当前版本无法安全恢复
+var ErrUnsupportedBackupSchema = errors.New("unsupported backup schema version")
+
+// Backup JSON 备份的完整内容
+type Backup struct {
+	Algorithms []models.Algorithm  `json:"algorithms"`         // 各算法的 Versions 随算法一起保存
+	Versions   []models.Version    `json:"versions,omitempty"` // 旧备份中重复保存的版本列表，新备份不再写入，恢复时补充算法中没有的版本
+	PresetData []models.PresetData `json:"preset_data"`
+	Jobs       []models.Job        `json:"jobs"`
+	BackupedAt time.Time           `json:"backuped_at"`
+	BackupType string              `json:"backup_type"`
+	Metadata   BackupInfo          `json:"metadata"`
+}
+
+// BackupInfo 备份时的数据库元数据
+type BackupInfo struct {
+	SchemaVersion int       `json:"schema_version"` // 旧备份没有该字段，解析为 0，按版本 1 处理
+	Version       int64     `json:"version"`
+	RecordCount   int64     `json:"record_count"`
+	LastUpdatedAt time.Time `json:"last_updated_at"`
+}
+
+// metadata 生成备份的元数据，缺失的字段使用 fallbackTime 和算法数量估算
+func (b *Backup) metadata(fallbackTime time.Time, algorithms int) *BackupMetadata {
+	meta := &BackupMetadata{
+		Version:       b.Metadata.Version,
+		RecordCount:   b.Metadata.RecordCount,
+		LastUpdatedAt: b.Metadata.LastUpdatedAt,
+		SchemaVersion: b.schemaVersion(),
+	}
+	if meta.LastUpdatedAt.IsZero() {
+		meta.LastUpdatedAt = fallbackTime
+	}
+	if meta.RecordCount == 0 {
+		meta.RecordCount = int64(algorithms)
+	}
+	return meta
+}
+
+// schemaVersion 备份的结构版本，没有该字段的旧备份视为版本 1
+func (b *Backup) schemaVersion() int {
+	if b.Metadata.SchemaVersion == 0 {
+		return 1
+	}
+	return b.Metadata.SchemaVersion
+}
+
+// gzipMagic gzip 数据的文件头
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// hashCommentPrefix gzip 头的注释中记录备份内容（解压后的 JSON）的 SHA-256，恢复前据此校验
+const hashCommentPrefix = "sha256:"
+
+// backupHeaderKeys 摘要中除 SHA-256 外的字段，依次写在 gzip 头注释中
+var backupHeaderKeys = []string{"schema-version", "version", "record-count", "last-updated-at"}
+
+// backupHeader 备份的摘要：内容的 SHA-256 和备份时的元数据。
+// 写在 gzip 头注释和 MinIO 对象的用户元数据中，列出备份时不必解压读取全部内容
+type backupHeader struct {
+	Hash string
+	Info BackupInfo
+}
+
+// fields 摘要的各字段，也是 MinIO 用户元数据的键
+func (h *backupHeader) fields() map[string]string {
+	return map[string]string{
+		"sha256":          h.Hash,
+		"schema-version":  strconv.Itoa(h.Info.SchemaVersion),
+		"version":         strconv.FormatInt(h.Info.Version, 10),
+		"record-count":    strconv.FormatInt(h.Info.RecordCount, 10),
+		"last-updated-at": h.Info.LastUpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// comment gzip 头注释："sha256:<hex>"，之后是空格分隔的 key=value
+func (h *backupHeader) comment() string {
+	fields := h.fields()
+	parts := []string{hashCommentPrefix + h.Hash}
+	for _, key := range backupHeaderKeys {
+		parts = append(parts, key+"="+fields[key])
+	}
+	return strings.Join(parts, " ")
+}
+
+// metadata 由摘要生成备份的元数据（不含来源和路径）
+func (h *backupHeader) metadata(modTime time.Time, size int64) *BackupMetadata {
+	meta := &BackupMetadata{
+		Timestamp:     modTime,
+		Hash:          h.Hash,
+		HashRecorded:  true,
+		Version:       h.Info.Version,
+		RecordCount:   h.Info.RecordCount,
+		LastUpdatedAt: h.Info.LastUpdatedAt,
+		SchemaVersion: h.Info.SchemaVersion,
+		Size:          size,
+	}
+	if meta.LastUpdatedAt.IsZero() {
+		meta.LastUpdatedAt = modTime
+	}
+	return meta
+}
+
+// parseBackupHeader 按字段解析摘要，缺少字段（较早的备份）时返回 nil
+func parseBackupHeader(field func(key string) string) *backupHeader {
+	h := &backupHeader{Hash: field("sha256")}
+	if h.Hash == "" {
+		return nil
+	}
+	var err error
+	if h.Info.SchemaVersion, err = strconv.Atoi(field("schema-version")); err != nil {
+		return nil
+	}
+	if h.Info.Version, err = strconv.ParseInt(field("version"), 10, 64); err != nil {
+		return nil
+	}
+	if h.Info.RecordCount, err = strconv.ParseInt(field("record-count"), 10, 64); err != nil {
+		return nil
+	}
+	if h.Info.LastUpdatedAt, err = time.Parse(time.RFC3339Nano, field("last-updated-at")); err != nil {
+		return nil
+	}
+	return h
+}
+
+// parseBackupComment 解析 gzip 头注释，返回记录的 SHA-256 和摘要（只记录了 SHA-256 时为 nil）
+func parseBackupComment(comment string) (string, *backupHeader) {
+	hashField, rest, _ := strings.Cut(comment, " ")
+	fields := map[string]string{"sha256": strings.TrimPrefix(hashField, hashCommentPrefix)}
+	for _, field := range strings.Fields(rest) {
+		if key, value, ok := strings.Cut(field, "="); ok {
+			fields[key] = value
+		}
+	}
+	return fields["sha256"], parseBackupHeader(func(key string) string { return fields[key] })
+}
+
+// compressBackup 用 gzip 压缩 JSON 备份，并在 gzip 头中记录内容的 SHA-256 和 info，
+// 返回压缩后的内容（对象和文件以 .json.gz 结尾）和写入的摘要
+func compressBackup(backupJSON []byte, info BackupInfo) ([]byte, *backupHeader, error) {
+	hash := sha256.Sum256(backupJSON)
+	header := &backupHeader{Hash: hex.EncodeToString(hash[:]), Info: info}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Comment = header.comment()
+	if _, err := zw.Write(backupJSON); err != nil {
+		return nil, nil, fmt.Errorf("failed to compress backup: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to compress backup: %w", err)
+	}
+	return buf.Bytes(), header, nil
+}
+
+// backupContent 返回备份的 JSON 内容和备份时记录的 SHA-256。
+// 按文件头识别 gzip，压缩前的 .json 备份原样返回，没有记录的 hash
+func backupContent(r io.Reader) (io.ReadCloser, string, error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to decompress backup: %w", err)
+		}
+		hash, _ := parseBackupComment(zr.Comment)
+		return zr, hash, nil
+	}
+	return io.NopCloser(br), "", nil
+}
+
+// readBackupHeader 只读取 gzip 头中的摘要，未压缩或没有完整摘要的备份返回 nil
+func readBackupHeader(r io.Reader) *backupHeader {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(len(gzipMagic)); !bytes.Equal(magic, gzipMagic) {
+		return nil
+	}
+	zr, err := gzip.NewReader(br)
+	if err != nil {
+		return nil
+	}
+	_, header := parseBackupComment(zr.Comment)
+	return header
+}
+
+// backupMigrations 将 key 版本的备份原地升级到 key+1 版本
+var backupMigrations = map[int]func(backup *Backup){
+	1: migrateBackupV1,
+}
+
+// upgradeBackup 将备份升级到当前结构版本，返回备份原本的版本
+func upgradeBackup(backup *Backup) (int, error) {
+	from := backup.schemaVersion()
+	if from > BackupSchemaVersion {
+		return from, fmt.Errorf("%w: backup uses schema v%d but this server supports up to v%d, upgrade the server before restoring",
+			ErrUnsupportedBackupSchema, from, BackupSchemaVersion)
+	}
+	if from < 1 {
+		return from, fmt.Errorf("%w: invalid schema version %d", ErrUnsupportedBackupSchema, from)
+	}
+
+	if err := migrateBackup(backup, from); err != nil {
+		return from, err
+	}
+	backup.Metadata.SchemaVersion = BackupSchemaVersion
+	return from, nil
+}
+
+// migrateBackup 将 from 版本的记录升级到当前结构版本。
+// 迁移只能逐条修改记录，流式恢复时对每批记录分别调用
+func migrateBackup(backup *Backup, from int) error {
+	for v := from; v < BackupSchemaVersion; v++ {
+		migrate, ok := backupMigrations[v]
+		if !ok {
+			return fmt.Errorf("%w: no migration from schema v%d", ErrUnsupportedBackupSchema, v)
+		}
+		migrate(backup)
+	}
+	return nil
+}
+
+// migrateBackupV1 生命周期状态之前的算法都可以执行，恢复为 ready
+func migrateBackupV1(backup *Backup) {
+	for i := range backup.Algorithms {
+		if backup.Algorithms[i].Status == "" {
+			backup.Algorithms[i].Status = models.AlgorithmStatusReady
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"algorithm-platform/internal/models"
+
+	"github.com/minio/minio-go/v7"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// backupCounts 备份中各数组的记录数
+type backupCounts struct {
+	Algorithms int
+	Versions   int
+	PresetData int
+	Jobs       int
+}
+
+// backupSummary 流式读取备份的结果
+type backupSummary struct {
+	Header       Backup       // 顶层字段，不含数组
+	Counts       backupCounts // 各数组的记录数
+	Hash         string       // 读取到的内容（解压后的 JSON）的 SHA-256
+	RecordedHash string       // 备份时记录在 gzip 头中的 SHA-256，旧备份为空
+}
+
+// expectedHash 备份应有的 hash：优先使用备份时记录的值，旧备份使用读取时计算的值（未经校验）
+func (s *backupSummary) expectedHash() string {
+	if s.RecordedHash != "" {
+		return s.RecordedHash
+	}
+	return s.Hash
+}
+
+// backupHandlers 流式读取备份时各数组的批处理函数，为 nil 时只计数不解析
+type backupHandlers struct {
+	algorithms func([]models.Algorithm) error
+	versions   func([]models.Version) error
+	presetData func([]models.PresetData) error
+	jobs       func([]models.Job) error
+}
+
+// streamBackup 流式读取备份：数组逐条解析，每 batchSize 条交给 handlers 处理一次，
+// 其余顶层字段解析到 Header 中，同时计算内容的 hash，内存占用只与批大小有关
+func streamBackup(r io.Reader, batchSize int, handlers backupHandlers) (*backupSummary, error) {
+	summary := &backupSummary{}
+	header, counts := &summary.Header, &summary.Counts
+
+	content, recordedHash, err := backupContent(r)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	summary.RecordedHash = recordedHash
+
+	hasher := sha256.New()
+	hashed := io.TeeReader(content, hasher)
+	dec := json.NewDecoder(hashed)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse backup: %w", err)
+		}
+		field, _ := token.(string)
+		switch field {
+		case "algorithms":
+			counts.Algorithms, err = streamArray(dec, batchSize, handlers.algorithms)
+		case "versions":
+			counts.Versions, err = streamArray(dec, batchSize, handlers.versions)
+		case "preset_data":
+			counts.PresetData, err = streamArray(dec, batchSize, handlers.presetData)
+		case "jobs":
+			counts.Jobs, err = streamArray(dec, batchSize, handlers.jobs)
+		case "backuped_at":
+			err = dec.Decode(&header.BackupedAt)
+		case "backup_type":
+			err = dec.Decode(&header.BackupType)
+		case "metadata":
+			err = dec.Decode(&header.Metadata)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse backup field %q: %w", field, err)
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	// 解码器没有读到的结尾部分（如换行）也计入 hash
+	if _, err := io.Copy(io.Discard, hashed); err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	summary.Hash = hex.EncodeToString(hasher.Sum(nil))
+	return summary, nil
+}
+
+// expectDelim 读取下一个 token，必须是指定的分隔符
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to parse backup: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("failed to parse backup: expected %v, got %v", want, token)
+	}
+	return nil
+}
+
+// streamArray 逐条解析数组并按批调用 handle，handle 为 nil 时跳过记录只计数，null 视为空数组
+func streamArray[T any](dec *json.Decoder, batchSize int, handle func([]T) error) (int, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return 0, err
+	}
+	if token == nil {
+		return 0, nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return 0, fmt.Errorf("expected an array, got %v", token)
+	}
+
+	count := 0
+	batch := make([]T, 0, batchSize)
+	for dec.More() {
+		count++
+		if handle == nil {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return count, err
+			}
+			continue
+		}
+
+		var record T
+		if err := dec.Decode(&record); err != nil {
+			return count, err
+		}
+		batch = append(batch, record)
+		if len(batch) == batchSize {
+			if err := handle(batch); err != nil {
+				return count, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := handle(batch); err != nil {
+			return count, err
+		}
+	}
+
+	// 结束的 ]
+	if _, err := dec.Token(); err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// readBackupStream 打开备份并流式读取，恢复时读取两遍：先扫描元数据和记录数，再逐批导入
+func (m *SQLiteBackupManager) readBackupStream(ctx context.Context, metadata *BackupMetadata, handlers backupHandlers) (*backupSummary, error) {
+	var r io.ReadCloser
+	if metadata.Source == "minio" {
+		obj, err := m.minio.GetObject(ctx, m.bucketName, metadata.Path, minio.GetObjectOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get MinIO backup: %w", err)
+		}
+		r = obj
+	} else {
+		file, err := os.Open(metadata.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read local backup: %w", err)
+		}
+		r = file
+	}
+	defer r.Close()
+
+	return streamBackup(r, m.restoreBatch, handlers)
+}
+
+// readBackupMetadata 流式读取备份并返回其元数据（不含来源和路径），不保留记录
+func readBackupMetadata(r io.Reader, modTime time.Time, size int64) (*BackupMetadata, error) {
+	summary, err := streamBackup(r, 0, backupHandlers{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse backup: %w", err)
+	}
+
+	meta := summary.Header.metadata(modTime, summary.Counts.Algorithms)
+	meta.Timestamp = modTime
+	meta.Hash = summary.expectedHash()
+	meta.HashRecorded = summary.RecordedHash != ""
+	meta.Size = size
+	return meta, nil
+}
+
+// createBatch 在保存点中按 batchSize 批量创建记录（包括关联），批次失败时回滚到保存点并逐条创建，找出失败的记录
+func createBatch[T any](tx *gorm.DB, batchSize int, records []T, id func(*T) string, kind string, result *RestoreResult) (restored, failed int) {
+	// 只有一条记录时直接逐条创建，不需要保存点
+	if len(records) > 1 {
+		if err := tx.Transaction(func(tx *gorm.DB) error {
+			return tx.CreateInBatches(&records, batchSize).Error
+		}); err == nil {
+			return len(records), 0
+		}
+	}
+
+	for i := range records {
+		record := &records[i]
+		if err := tx.Create(record).Error; err != nil {
+			fmt.Printf("   ⚠️  %s %s failed: %v\n", kind, id(record), err)
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s %s: %v", kind, id(record), err))
+			failed++
+		} else {
+			restored++
+		}
+	}
+	return restored, failed
+}
+
+// firstOrCreateBatch 批量创建数据库中还没有的记录，不保存关联，批次失败时回滚到保存点并逐条处理。
+// 返回新建和失败的数量，已存在的记录不计入
+func firstOrCreateBatch[T any](tx *gorm.DB, batchSize int, records []T, id func(*T) string, kind string, result *RestoreResult) (restored, failed int) {
+	if len(records) > 1 {
+		var created int64
+		if err := tx.Transaction(func(tx *gorm.DB) error {
+			res := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&records, batchSize)
+			created = res.RowsAffected
+			return res.Error
+		}); err == nil {
+			return int(created), 0
+		}
+	}
+
+	return firstOrCreateAll(tx, records, id, kind, result)
+}
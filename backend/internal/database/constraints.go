@@ -0,0 +1,158 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"algorithm-platform/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// algorithmNameIndex 算法名称唯一索引
+const algorithmNameIndex = "idx_algorithms_name_unique"
+
+// legacyJobIndexes 旧版本的单列任务索引，已被 idx_jobs_status_created 和 idx_jobs_algorithm_created 取代
+var legacyJobIndexes = []string{"idx_jobs_status", "idx_jobs_algorithm_id"}
+
+// jobStatuses、jobModes 与 jobs 表 chk_jobs_status、chk_jobs_mode 约束接受的取值一致
+var (
+	jobStatuses = []string{
+		models.JobStatusPending, models.JobStatusRunning, models.JobStatusCompleted,
+		models.JobStatusFailed, models.JobStatusTimeout, models.JobStatusCancelled,
+	}
+	jobModes = []string{
+		"", models.ExecutionModeSync, models.ExecutionModeAsync, models.ExecutionModeFireAndForget,
+		"batch", "streaming",
+	}
+)
+
+// DuplicateName 使用相同名称的算法
+type DuplicateName struct {
+	Name string
+	IDs  []string
+}
+
+// findDuplicateAlgorithmNames 查找被多个算法使用的名称
+func findDuplicateAlgorithmNames(db *gorm.DB) ([]DuplicateName, error) {
+	var names []string
+	if err := db.Model(&models.Algorithm{}).
+		Group("name").
+		Having("COUNT(*) > 1").
+		Order("name").
+		Pluck("name", &names).Error; err != nil {
+		return nil, fmt.Errorf("failed to find duplicate names: %w", err)
+	}
+
+	duplicates := make([]DuplicateName, 0, len(names))
+	for _, name := range names {
+		dup := DuplicateName{Name: name}
+		if err := db.Model(&models.Algorithm{}).Where("name = ?", name).Order("created_at").Pluck("id", &dup.IDs).Error; err != nil {
+			return nil, fmt.Errorf("failed to list algorithms named %q: %w", name, err)
+		}
+		duplicates = append(duplicates, dup)
+	}
+	return duplicates, nil
+}
+
+// ensureAlgorithmNameIndex 按配置创建或删除算法名称唯一索引。
+// 已有重名数据时不创建索引（否则迁移会失败），返回重名列表供人工处理
+func ensureAlgorithmNameIndex(db *gorm.DB, enabled bool) ([]DuplicateName, error) {
+	migrator := db.Migrator()
+	exists := migrator.HasIndex(&models.Algorithm{}, algorithmNameIndex)
+
+	if !enabled {
+		if exists {
+			if err := migrator.DropIndex(&models.Algorithm{}, algorithmNameIndex); err != nil {
+				return nil, fmt.Errorf("failed to drop %s: %w", algorithmNameIndex, err)
+			}
+			fmt.Println("Algorithm name unique index dropped")
+		}
+		return nil, nil
+	}
+	if exists {
+		return nil, nil
+	}
+
+	duplicates, err := findDuplicateAlgorithmNames(db)
+	if err != nil {
+		return nil, err
+	}
+	if len(duplicates) > 0 {
+		fmt.Printf("Warning: %d algorithm names are used more than once, unique index not created:\n", len(duplicates))
+		for _, dup := range duplicates {
+			fmt.Printf("   %q: %v\n", dup.Name, dup.IDs)
+		}
+		return duplicates, nil
+	}
+
+	if err := db.Exec(fmt.Sprintf("CREATE UNIQUE INDEX %s ON algorithms (name)", algorithmNameIndex)).Error; err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", algorithmNameIndex, err)
+	}
+	fmt.Println("Algorithm name unique index created")
+	return nil, nil
+}
+
+// dropLegacyJobIndexes 删除旧版本创建的单列任务索引。AutoMigrate 只新增索引，不会删除模型中已移除的索引
+func dropLegacyJobIndexes(db *gorm.DB) error {
+	migrator := db.Migrator()
+	for _, name := range legacyJobIndexes {
+		if !migrator.HasIndex(&models.Job{}, name) {
+			continue
+		}
+		if err := migrator.DropIndex(&models.Job{}, name); err != nil {
+			return fmt.Errorf("failed to drop %s: %w", name, err)
+		}
+		fmt.Printf("Legacy job index %s dropped\n", name)
+	}
+	return nil
+}
+
+// normalizeLegacyJobs 在 AutoMigrate 添加状态和模式约束之前修正旧任务的取值。
+// 旧版本接受任意字符串，SQLite 添加约束时会重建表并复制所有行，任何越界的值都会让迁移失败。
+// 大小写和空白不同的值统一为小写，仍无法识别的模式置空（按旧任务处理），状态记为 failed
+func normalizeLegacyJobs(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.Job{}) {
+		return nil
+	}
+	for _, column := range []struct {
+		name     string
+		allowed  []string
+		fallback string
+	}{
+		{"mode", jobModes, ""},
+		{"status", jobStatuses, models.JobStatusFailed},
+	} {
+		if !db.Migrator().HasColumn(&models.Job{}, column.name) {
+			continue
+		}
+		lowered := db.Model(&models.Job{}).
+			Where(fmt.Sprintf("%s <> LOWER(TRIM(%s))", column.name, column.name)).
+			UpdateColumn(column.name, gorm.Expr(fmt.Sprintf("LOWER(TRIM(%s))", column.name)))
+		if lowered.Error != nil {
+			return fmt.Errorf("failed to normalize job %s: %w", column.name, lowered.Error)
+		}
+
+		var unknown []string
+		if err := db.Model(&models.Job{}).
+			Where(fmt.Sprintf("%s NOT IN ?", column.name), column.allowed).
+			Distinct().Pluck(column.name, &unknown).Error; err != nil {
+			return fmt.Errorf("failed to find unknown job %s values: %w", column.name, err)
+		}
+		replaced := db.Model(&models.Job{}).
+			Where(fmt.Sprintf("%s NOT IN ?", column.name), column.allowed).
+			UpdateColumn(column.name, column.fallback)
+		if replaced.Error != nil {
+			return fmt.Errorf("failed to replace unknown job %s values: %w", column.name, replaced.Error)
+		}
+
+		if lowered.RowsAffected > 0 {
+			fmt.Printf("Normalized %s of %d legacy jobs to lower case\n", column.name, lowered.RowsAffected)
+		}
+		if replaced.RowsAffected > 0 {
+			fmt.Printf("Warning: %d legacy jobs had an unknown %s (%s), set to %q\n",
+				replaced.RowsAffected, column.name, strings.Join(unknown, ", "), column.fallback)
+		}
+	}
+	return nil
+}
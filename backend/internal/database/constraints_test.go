@@ -0,0 +1,245 @@
+package database
+
+import (
+	"testing"
+
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"gorm.io/gorm"
+)
+
+func newConstraintTestDB(t *testing.T, algorithms ...models.Algorithm) *gorm.DB {
+	t.Helper()
+	db := testutil.NewDB(t)
+	for i := range algorithms {
+		if err := db.Create(&algorithms[i]).Error; err != nil {
+			t.Fatalf("Failed to create algorithm: %v", err)
+		}
+	}
+	return db
+}
+
+func TestAlgorithmNameIndexReportsDuplicates(t *testing.T) {
+	db := newConstraintTestDB(t,
+		models.Algorithm{ID: "alg_1", Name: "detector"},
+		models.Algorithm{ID: "alg_2", Name: "detector"},
+		models.Algorithm{ID: "alg_3", Name: "classifier"},
+	)
+
+	duplicates, err := ensureAlgorithmNameIndex(db, true)
+	if err != nil {
+		t.Fatalf("ensureAlgorithmNameIndex failed: %v", err)
+	}
+	if len(duplicates) != 1 || duplicates[0].Name != "detector" || len(duplicates[0].IDs) != 2 {
+		t.Errorf("Unexpected duplicates: %+v", duplicates)
+	}
+	if db.Migrator().HasIndex(&models.Algorithm{}, algorithmNameIndex) {
+		t.Error("Index should not be created while duplicates exist")
+	}
+}
+
+func TestAlgorithmNameIndexEnforcesUniqueness(t *testing.T) {
+	db := newConstraintTestDB(t, models.Algorithm{ID: "alg_1", Name: "detector"})
+
+	if _, err := ensureAlgorithmNameIndex(db, true); err != nil {
+		t.Fatalf("ensureAlgorithmNameIndex failed: %v", err)
+	}
+	if err := db.Create(&models.Algorithm{ID: "alg_2", Name: "detector"}).Error; err == nil {
+		t.Fatal("Expected duplicate name to be rejected by the index")
+	}
+
+	// 关闭配置后删除索引
+	if _, err := ensureAlgorithmNameIndex(db, false); err != nil {
+		t.Fatalf("ensureAlgorithmNameIndex failed: %v", err)
+	}
+	if err := db.Create(&models.Algorithm{ID: "alg_2", Name: "detector"}).Error; err != nil {
+		t.Errorf("Expected duplicate name to be allowed after dropping the index, got %v", err)
+	}
+}
+
+func TestJobIndexesAndChecks(t *testing.T) {
+	db := newConstraintTestDB(t)
+
+	// PRAGMA index_list 返回 seq, name, unique, origin, partial
+	type indexEntry struct {
+		Name string
+	}
+	var indexes []indexEntry
+	if err := db.Raw("PRAGMA index_list(jobs)").Scan(&indexes).Error; err != nil {
+		t.Fatalf("Failed to list indexes: %v", err)
+	}
+	columns := map[string][]string{}
+	for _, index := range indexes {
+		var info []struct{ Name string }
+		if err := db.Raw("PRAGMA index_info(" + index.Name + ")").Scan(&info).Error; err != nil {
+			t.Fatalf("Failed to read index %s: %v", index.Name, err)
+		}
+		for _, column := range info {
+			columns[index.Name] = append(columns[index.Name], column.Name)
+		}
+	}
+	for name, want := range map[string][]string{
+		"idx_jobs_status_created":    {"status", "created_at"},
+		"idx_jobs_algorithm_created": {"algorithm_id", "created_at"},
+	} {
+		if got := columns[name]; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("Index %s columns = %v, want %v", name, got, want)
+		}
+	}
+
+	valid := []models.Job{
+		{ID: "job_1", Status: models.JobStatusPending, Mode: models.ExecutionModeAsync},
+		{ID: "job_2", Status: models.JobStatusCompleted, Mode: "batch"},
+		{ID: "job_3", Status: models.JobStatusFailed},
+	}
+	for _, job := range valid {
+		if err := db.Create(&job).Error; err != nil {
+			t.Errorf("Job %s rejected: %v", job.ID, err)
+		}
+	}
+	invalid := []models.Job{
+		{ID: "job_4", Status: "done"},
+		{ID: "job_5"},
+		{ID: "job_6", Status: models.JobStatusRunning, Mode: "realtime"},
+	}
+	for _, job := range invalid {
+		if err := db.Create(&job).Error; err == nil {
+			t.Errorf("Job %s with status %q and mode %q should be rejected", job.ID, job.Status, job.Mode)
+		}
+	}
+}
+
+func TestJobChecksMigrateExistingTable(t *testing.T) {
+	db := testutil.OpenDB(t, nil)
+	// 旧版本创建的表没有约束和组合索引，只有单列索引
+	for _, stmt := range []string{
+		`CREATE TABLE jobs (id varchar(36) PRIMARY KEY, algorithm_id varchar(36), mode varchar(50), status varchar(50), created_at datetime)`,
+		`CREATE INDEX idx_jobs_status ON jobs (status)`,
+		`CREATE INDEX idx_jobs_algorithm_id ON jobs (algorithm_id)`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("Failed to create legacy table: %v", err)
+		}
+	}
+	if err := db.Exec(`INSERT INTO jobs (id, algorithm_id, mode, status) VALUES ('job_1', 'alg_1', 'streaming', 'completed'), ('job_2', 'alg_1', '', 'failed')`).Error; err != nil {
+		t.Fatalf("Failed to seed legacy jobs: %v", err)
+	}
+
+	if err := models.AutoMigrate(db); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if err := dropLegacyJobIndexes(db); err != nil {
+		t.Fatalf("dropLegacyJobIndexes failed: %v", err)
+	}
+	var count int64
+	db.Model(&models.Job{}).Count(&count)
+	if count != 2 {
+		t.Errorf("Expected legacy jobs to be kept, got %d", count)
+	}
+	if !db.Migrator().HasIndex(&models.Job{}, "idx_jobs_status_created") {
+		t.Error("Expected the status index to be added")
+	}
+	for _, name := range legacyJobIndexes {
+		if db.Migrator().HasIndex(&models.Job{}, name) {
+			t.Errorf("Expected legacy index %s to be removed", name)
+		}
+	}
+	if err := db.Create(&models.Job{ID: "job_3", Status: "done"}).Error; err == nil {
+		t.Error("Expected the migrated table to reject unknown statuses")
+	}
+}
+
+func TestJobChecksMigrateOutOfRangeValues(t *testing.T) {
+	db := testutil.OpenDB(t, nil)
+	// 旧版本接受任意字符串，直接添加约束时 SQLite 重建表复制这些行会失败
+	if err := db.Exec(`CREATE TABLE jobs (id varchar(36) PRIMARY KEY, algorithm_id varchar(36), mode varchar(50), status varchar(50), created_at datetime)`).Error; err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+	if err := db.Exec(`INSERT INTO jobs (id, algorithm_id, mode, status) VALUES
+		('job_1', 'alg_1', 'Batch', 'Completed'),
+		('job_2', 'alg_1', 'realtime', 'done'),
+		('job_3', 'alg_1', ' async ', 'running'),
+		('job_4', 'alg_1', NULL, NULL)`).Error; err != nil {
+		t.Fatalf("Failed to seed legacy jobs: %v", err)
+	}
+
+	if err := normalizeLegacyJobs(db); err != nil {
+		t.Fatalf("normalizeLegacyJobs failed: %v", err)
+	}
+	if err := models.AutoMigrate(db); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	want := map[string][2]string{
+		"job_1": {"batch", models.JobStatusCompleted},
+		"job_2": {"", models.JobStatusFailed},
+		"job_3": {models.ExecutionModeAsync, models.JobStatusRunning},
+	}
+	var jobs []models.Job
+	if err := db.Order("id").Find(&jobs).Error; err != nil {
+		t.Fatalf("Failed to load jobs: %v", err)
+	}
+	if len(jobs) != 4 {
+		t.Fatalf("Expected 4 migrated jobs, got %d", len(jobs))
+	}
+	for _, job := range jobs {
+		expected, ok := want[job.ID]
+		if !ok {
+			continue
+		}
+		if job.Mode != expected[0] || job.Status != expected[1] {
+			t.Errorf("Job %s = (%q, %q), want (%q, %q)", job.ID, job.Mode, job.Status, expected[0], expected[1])
+		}
+	}
+	if err := db.Create(&models.Job{ID: "job_5", Status: models.JobStatusRunning, Mode: "Batch"}).Error; err == nil {
+		t.Error("Expected the migrated table to enforce the mode constraint")
+	}
+
+	// 再次执行不做任何修改
+	if err := normalizeLegacyJobs(db); err != nil {
+		t.Fatalf("normalizeLegacyJobs failed on a migrated table: %v", err)
+	}
+}
+
+func TestDropLegacyJobIndexes(t *testing.T) {
+	db := newConstraintTestDB(t)
+	// 旧版本留下的单列索引。SQLite 添加约束时重建表会顺带删除它们，
+	// 表已有约束（或使用 PostgreSQL）时 AutoMigrate 不会删除
+	for _, stmt := range []string{
+		`CREATE INDEX idx_jobs_status ON jobs (status)`,
+		`CREATE INDEX idx_jobs_algorithm_id ON jobs (algorithm_id)`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("Failed to create legacy index: %v", err)
+		}
+	}
+
+	if err := models.AutoMigrate(db); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	migrator := db.Migrator()
+	for _, name := range legacyJobIndexes {
+		if !migrator.HasIndex(&models.Job{}, name) {
+			t.Fatalf("Expected AutoMigrate to keep legacy index %s", name)
+		}
+	}
+	if err := dropLegacyJobIndexes(db); err != nil {
+		t.Fatalf("dropLegacyJobIndexes failed: %v", err)
+	}
+	for _, name := range legacyJobIndexes {
+		if migrator.HasIndex(&models.Job{}, name) {
+			t.Errorf("Expected legacy index %s to be dropped", name)
+		}
+	}
+	for _, name := range []string{"idx_jobs_status_created", "idx_jobs_algorithm_created"} {
+		if !migrator.HasIndex(&models.Job{}, name) {
+			t.Errorf("Expected index %s to exist", name)
+		}
+	}
+
+	// 再次执行不做任何修改
+	if err := dropLegacyJobIndexes(db); err != nil {
+		t.Errorf("Expected a second run to succeed, got %v", err)
+	}
+}
@@ -1,11 +1,19 @@
 package database
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
+	"os"
 	"strings"
+	"time"
 
 	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/maintenance"
+	"algorithm-platform/internal/metrics"
 	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/retry"
 
 	"gorm.io/gorm"
 )
@@ -16,13 +24,15 @@ type Database struct {
 	cfg      *config.Config
 }
 
-func New(cfg *config.Config) (*Database, error) {
-	// 根据配置创建数据库提供者
+// newProvider 根据配置创建数据库提供者
+func newProvider(cfg *config.Config, mode *maintenance.Mode) (DBProvider, error) {
 	var provider DBProvider
 	dbType := strings.ToLower(cfg.Database.Type)
 	switch dbType {
 	case "sqlite", "":
-		provider = NewSQLiteProvider(cfg)
+		sqliteProvider := NewSQLiteProvider(cfg)
+		sqliteProvider.maintenance = mode
+		provider = sqliteProvider
 	case "postgres", "postgresql":
 		// 使用 PostgreSQL
 		provider = NewPostgreSQLProvider(PostgreSQLConfig{
@@ -37,6 +47,16 @@ func New(cfg *config.Config) (*Database, error) {
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", cfg.Database.Type)
 	}
+	return provider, nil
+}
+
+// New 创建数据库，mode 用于在启动恢复期间开启只读模式，可为 nil
+func New(cfg *config.Config, mode *maintenance.Mode) (*Database, error) {
+	// 根据配置创建数据库提供者
+	provider, err := newProvider(cfg, mode)
+	if err != nil {
+		return nil, err
+	}
 
 	// 打开数据库连接
 	db, err := provider.Open()
@@ -44,20 +64,47 @@ func New(cfg *config.Config) (*Database, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
+	// 初始化失败时释放已打开的连接，启动时等待依赖会反复调用 New
+	initialized := false
+	defer func() {
+		if !initialized {
+			closeUninitialized(provider, db)
+		}
+	}()
+
+	// 慢查询日志
+	db.Logger = newQueryLogger(log.New(os.Stdout, "\r\n", log.LstdFlags), cfg.Database.GetSlowQueryThreshold())
+
 	// 配置数据库连接参数
 	if err := provider.Configure(db); err != nil {
 		return nil, fmt.Errorf("failed to configure database: %w", err)
 	}
 
+	// 开启指标时记录每条语句的耗时
+	if metrics.Enabled() {
+		if err := InstallMetrics(db); err != nil {
+			return nil, fmt.Errorf("failed to install metrics: %w", err)
+		}
+	}
+
 	// 测试数据库连接
 	if err := provider.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// 自动迁移数据库表结构
+	// 自动迁移数据库表结构，先修正旧任务中约束不接受的取值
+	if err := normalizeLegacyJobs(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate legacy jobs: %w", err)
+	}
 	if err := models.AutoMigrate(db); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
+	if err := collapseDatabaseMetadata(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate database metadata: %w", err)
+	}
+	if err := dropLegacyJobIndexes(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate job indexes: %w", err)
+	}
 
 	// 执行迁移后的操作（如数据恢复）
 	if postMigrator, ok := provider.(interface{ PostMigrate() error }); ok {
@@ -66,6 +113,12 @@ func New(cfg *config.Config) (*Database, error) {
 		}
 	}
 
+	// 算法名称唯一索引在恢复之后创建，避免恢复的数据与索引冲突
+	if _, err := ensureAlgorithmNameIndex(db, cfg.Database.UniqueAlgorithmNames); err != nil {
+		fmt.Printf("Warning: failed to apply algorithm name constraint: %v\n", err)
+	}
+
+	initialized = true
 	database := &Database{
 		db:       db,
 		provider: provider,
@@ -82,10 +135,85 @@ func New(cfg *config.Config) (*Database, error) {
 	return database, nil
 }
 
+// closeUninitialized 关闭初始化失败的数据库，不执行 Close 中的最终备份
+func closeUninitialized(provider DBProvider, db *gorm.DB) {
+	var err error
+	if aborter, ok := provider.(interface{ abort() error }); ok {
+		err = aborter.abort()
+	} else if sqlDB, dbErr := db.DB(); dbErr != nil {
+		err = dbErr
+	} else {
+		err = sqlDB.Close()
+	}
+	if err != nil {
+		fmt.Printf("Warning: failed to close database after failed initialization: %v\n", err)
+	}
+}
+
+// NewWithDB 使用已打开的连接创建 Database，不执行迁移和恢复，用于测试
+func NewWithDB(db *gorm.DB, cfg *config.Config) *Database {
+	return &Database{db: db, cfg: cfg}
+}
+
 func (d *Database) DB() *gorm.DB {
 	return d.db
 }
 
+// Ping 检查数据库连接是否可用
+func (d *Database) Ping(ctx context.Context) error {
+	sqlDB, err := d.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// ErrBackupUnsupported 当前数据库没有启用备份（只有 SQLite 会备份到 MinIO）
+var ErrBackupUnsupported = errors.New("backups are only available for the SQLite database")
+
+// backupManager 返回 SQLite 的备份管理器，未启用备份时返回 ErrBackupUnsupported
+func (d *Database) backupManager() (*SQLiteBackupManager, error) {
+	sqliteProvider, ok := d.provider.(*SQLiteProvider)
+	if !ok || sqliteProvider.backupManager == nil {
+		return nil, ErrBackupUnsupported
+	}
+	return sqliteProvider.backupManager, nil
+}
+
+// Backup 立即执行一次备份，已有备份或恢复在进行时返回 ErrBackupBusy
+func (d *Database) Backup() (*BackupResult, error) {
+	manager, err := d.backupManager()
+	if err != nil {
+		return nil, err
+	}
+	return manager.RunBackup()
+}
+
+// SetBackupInterval 修改定时备份间隔，未启用备份时忽略
+func (d *Database) SetBackupInterval(interval time.Duration) {
+	if manager, err := d.backupManager(); err == nil {
+		manager.SetBackupInterval(interval)
+	}
+}
+
+// ListBackups 列出可以恢复的备份，从新到旧排序
+func (d *Database) ListBackups(ctx context.Context) ([]*BackupMetadata, error) {
+	manager, err := d.backupManager()
+	if err != nil {
+		return nil, err
+	}
+	return manager.ListBackups(ctx)
+}
+
+// RestoreBackup 用指定的备份替换当前数据，path 必须是 ListBackups 返回的路径
+func (d *Database) RestoreBackup(ctx context.Context, path string) (*RestoreResult, error) {
+	manager, err := d.backupManager()
+	if err != nil {
+		return nil, err
+	}
+	return manager.RestoreBackup(ctx, path)
+}
+
 func (d *Database) Close() error {
 	// 关闭数据库连接
 	if d.provider != nil {
@@ -120,6 +248,17 @@ func (d *Database) GetStats() (map[string]interface{}, error) {
 	return nil, fmt.Errorf("stats not available for this database type")
 }
 
+// writeRetries 是 WithRetry 在数据库忙碌时的最大重试次数
+const writeRetries = 5
+
+// WithRetry 执行写操作，SQLite 返回 SQLITE_BUSY/SQLITE_LOCKED 时退避重试；ctx 取消后停止重试。
+// fn 可能被执行多次，只应包含数据库操作
+func (d *Database) WithRetry(ctx context.Context, fn func(*gorm.DB) error) error {
+	return retry.Do(ctx, busyRetryPolicy(writeRetries), func() error {
+		return fn(d.db.WithContext(ctx))
+	})
+}
+
 // Transaction 执行带重试的事务
 func (d *Database) Transaction(fn func(*gorm.DB) error) error {
 	return d.TransactionWithRetry(fn, 3)
@@ -127,32 +266,24 @@ func (d *Database) Transaction(fn func(*gorm.DB) error) error {
 
 // TransactionWithRetry 执行带重试的事务
 func (d *Database) TransactionWithRetry(fn func(*gorm.DB) error, maxRetries int) error {
-	var lastErr error
-
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		err := d.db.Transaction(fn)
-		if err == nil {
-			return nil
-		}
-
-		lastErr = err
-
-		// 检查是否是可重试的错误
-		if !isRetryableError(err) {
-			return err
-		}
-
-		if attempt < maxRetries {
-			// 指数退避
-			backoff := (1 << uint(attempt)) * 100 * 1000000 // 纳秒
-			fmt.Printf("Transaction failed, retrying in %dms (attempt %d/%d): %v\n",
-				backoff/1000000, attempt+1, maxRetries, err)
-			// time.Sleep 需要 time.Duration
-			// 这里简化处理，实际使用时需要 import "time"
-		}
+	policy := retry.Policy{
+		MaxAttempts: maxRetries + 1,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    2 * time.Second,
+		Jitter:      0.2,
+		Retryable:   isRetryableError,
+		OnRetry: func(attempt int, delay time.Duration, err error) {
+			fmt.Printf("Transaction failed, retrying in %v (attempt %d/%d): %v\n",
+				delay.Round(time.Millisecond), attempt, maxRetries, err)
+		},
 	}
 
-	return fmt.Errorf("transaction failed after %d retries: %w", maxRetries, lastErr)
+	if err := retry.Do(context.Background(), policy, func() error {
+		return d.db.Transaction(fn)
+	}); err != nil {
+		return fmt.Errorf("transaction failed: %w", err)
+	}
+	return nil
 }
 
 // isRetryableError 检查错误是否可重试
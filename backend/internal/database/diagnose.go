@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Diagnosis 数据库自检结果
+type Diagnosis struct {
+	Provider          string
+	PendingMigrations []string // 数据库中缺少的表和列，如 "table jobs"、"column versions.image_digest"
+}
+
+// Diagnose 打开数据库并检查连接和表结构，不执行迁移、恢复和备份，用于部署后的自检
+func Diagnose(ctx context.Context, cfg *config.Config) (*Diagnosis, error) {
+	provider, err := newProvider(cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+	db, err := provider.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer provider.Close()
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database instance: %w", err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	pending, err := PendingMigrations(db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &Diagnosis{Provider: provider.Name(), PendingMigrations: pending}, nil
+}
+
+// PendingMigrations 返回 models.AutoMigrate 会创建、但数据库中还没有的表和列
+func PendingMigrations(db *gorm.DB) ([]string, error) {
+	var pending []string
+	migrator := db.Migrator()
+	for _, model := range models.All() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+
+		table := stmt.Schema.Table
+		if !migrator.HasTable(table) {
+			pending = append(pending, "table "+table)
+			continue
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || field.IgnoreMigration {
+				continue
+			}
+			if !migrator.HasColumn(model, field.DBName) {
+				pending = append(pending, fmt.Sprintf("column %s.%s", table, field.DBName))
+			}
+		}
+	}
+	return pending, nil
+}
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"slices"
+	"testing"
+
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+)
+
+func TestPendingMigrations(t *testing.T) {
+	db := testutil.NewDB(t)
+
+	pending, err := PendingMigrations(db)
+	if err != nil || len(pending) != 0 {
+		t.Fatalf("Migrated database: pending = %v, err = %v", pending, err)
+	}
+
+	// 模拟升级前的数据库：缺少新增的列和表
+	if err := db.Migrator().DropColumn(&models.Version{}, "image_digest"); err != nil {
+		t.Fatalf("DropColumn failed: %v", err)
+	}
+	if err := db.Migrator().DropTable(&models.PresetData{}); err != nil {
+		t.Fatalf("DropTable failed: %v", err)
+	}
+
+	pending, err = PendingMigrations(db)
+	if err != nil {
+		t.Fatalf("PendingMigrations failed: %v", err)
+	}
+	want := []string{"column versions.image_digest", "table preset_data"}
+	if !slices.Equal(pending, want) {
+		t.Errorf("pending = %v, want %v", pending, want)
+	}
+}
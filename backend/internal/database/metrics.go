@@ -0,0 +1,66 @@
+package database
+
+import (
+	"time"
+
+	"algorithm-platform/internal/metrics"
+
+	"gorm.io/gorm"
+)
+
+// metricsStartKey 语句开始时间在 Statement 中的键
+const metricsStartKey = "metrics:start"
+
+// MetricsPlugin GORM 插件，按操作类型记录语句耗时
+type MetricsPlugin struct{}
+
+// Name 插件名称
+func (p *MetricsPlugin) Name() string {
+	return "MetricsPlugin"
+}
+
+// Initialize 在每类操作前后注册计时回调
+func (p *MetricsPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	processors := []struct {
+		operation string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+
+	for _, proc := range processors {
+		if err := proc.before("metrics:before_"+proc.operation, startTimer); err != nil {
+			return err
+		}
+		if err := proc.after("metrics:after_"+proc.operation, observeDuration(proc.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startTimer(db *gorm.DB) {
+	db.InstanceSet(metricsStartKey, time.Now())
+}
+
+func observeDuration(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if v, ok := db.InstanceGet(metricsStartKey); ok {
+			if start, ok := v.(time.Time); ok {
+				metrics.DBDuration.Since(operation, start)
+			}
+		}
+	}
+}
+
+// InstallMetrics 安装语句计时插件
+func InstallMetrics(db *gorm.DB) error {
+	return db.Use(&MetricsPlugin{})
+}
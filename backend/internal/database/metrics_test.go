@@ -0,0 +1,33 @@
+package database
+
+import (
+	"testing"
+
+	"algorithm-platform/internal/metrics"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+)
+
+func TestMetricsPluginRecordsQueries(t *testing.T) {
+	db := testutil.OpenDB(t, nil)
+	if err := InstallMetrics(db); err != nil {
+		t.Fatalf("InstallMetrics failed: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Algorithm{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	creates := metrics.DBDuration.Count("create")
+	queries := metrics.DBDuration.Count("query")
+
+	db.Create(&models.Algorithm{ID: "alg_1", Name: "detector"})
+	var alg models.Algorithm
+	db.First(&alg, "id = ?", "alg_1")
+
+	if got := metrics.DBDuration.Count("create") - creates; got != 1 {
+		t.Errorf("Recorded %d creates, want 1", got)
+	}
+	if got := metrics.DBDuration.Count("query") - queries; got != 1 {
+		t.Errorf("Recorded %d queries, want 1", got)
+	}
+}
@@ -1,11 +1,20 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"algorithm-platform/internal/config"
 	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
 )
 
 func TestSQLiteProvider(t *testing.T) {
@@ -21,6 +30,7 @@ func TestSQLiteProvider(t *testing.T) {
 				Path:                     dbPath,
 				WALCheckpointIntervalStr: "30s",
 			},
+			Backup: config.BackupConfig{LocalBackupDir: tmpDir},
 		},
 		MinIO: config.MinIOConfig{
 			Endpoint:        "test:9000",
@@ -165,3 +175,123 @@ func TestPostgreSQLProvider(t *testing.T) {
 		}
 	*/
 }
+
+func TestSQLiteWALAutocheckpointAppliesToAllConnections(t *testing.T) {
+	autocheckpoint := 250
+	testCfg := &config.Config{
+		Database: config.DatabaseConfig{
+			Type: "sqlite",
+			SQLite: config.SQLiteConfig{
+				Path:                     filepath.Join(t.TempDir(), "test.db"),
+				WALCheckpointIntervalStr: "0",
+				WALAutocheckpoint:        &autocheckpoint,
+			},
+		},
+	}
+
+	provider := NewSQLiteProvider(testCfg)
+	db, err := provider.Open()
+	if err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+	defer provider.Close()
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get database instance: %v", err)
+	}
+
+	// 同时占用多个连接，确认连接池中每个连接都应用了设置
+	ctx := t.Context()
+	for i := 0; i < 3; i++ {
+		conn, err := sqlDB.Conn(ctx)
+		if err != nil {
+			t.Fatalf("Failed to get connection: %v", err)
+		}
+		defer conn.Close()
+
+		var pages int
+		if err := conn.QueryRowContext(ctx, "PRAGMA wal_autocheckpoint").Scan(&pages); err != nil {
+			t.Fatalf("Failed to read wal_autocheckpoint: %v", err)
+		}
+		if pages != autocheckpoint {
+			t.Errorf("Connection %d: wal_autocheckpoint = %d, want %d", i, pages, autocheckpoint)
+		}
+	}
+
+	stats, err := provider.GetStats()
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if stats["wal_autocheckpoint"] != autocheckpoint || stats["wal_checkpoint_interval"] != "0s" {
+		t.Errorf("Unexpected WAL stats: autocheckpoint=%v interval=%v", stats["wal_autocheckpoint"], stats["wal_checkpoint_interval"])
+	}
+}
+
+func TestResolveWALCheckpointingKeepsOneDriver(t *testing.T) {
+	if pages, interval := resolveWALCheckpointing(0, 0); pages != config.DefaultWALAutocheckpoint || interval != 0 {
+		t.Errorf("Expected default autocheckpoint when both are disabled, got %d/%v", pages, interval)
+	}
+	if pages, interval := resolveWALCheckpointing(0, time.Minute); pages != 0 || interval != time.Minute {
+		t.Errorf("Expected ticker-only checkpointing to be kept, got %d/%v", pages, interval)
+	}
+}
+
+func TestIsSQLiteBusyErrorUnwraps(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{sqlite3.Error{Code: sqlite3.ErrBusy}, true},
+		{fmt.Errorf("failed to update job: %w", sqlite3.Error{Code: sqlite3.ErrLocked}), true},
+		{fmt.Errorf("failed to update job: %w", sqlite3.Error{Code: sqlite3.ErrConstraint}), false},
+		{errors.New("commit failed: database is locked (5) (SQLITE_BUSY)"), true},
+		{errors.New("no such table: jobs"), false},
+	}
+	for _, tt := range tests {
+		if got := isSQLiteBusyError(tt.err); got != tt.want {
+			t.Errorf("isSQLiteBusyError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestExecuteWithRetryRetriesWrappedBusyErrors(t *testing.T) {
+	provider := &SQLiteProvider{db: testutil.OpenDB(t, nil)}
+
+	attempts := 0
+	err := provider.ExecuteWithRetry(func(*sql.DB) error {
+		attempts++
+		if attempts < 3 {
+			return fmt.Errorf("failed to save: %w", sqlite3.Error{Code: sqlite3.ErrBusy})
+		}
+		return nil
+	}, 5)
+	if err != nil || attempts != 3 {
+		t.Errorf("Expected success after 3 attempts, got %d attempts, err %v", attempts, err)
+	}
+
+	attempts = 0
+	err = provider.ExecuteWithRetry(func(*sql.DB) error {
+		attempts++
+		return fmt.Errorf("failed to save: %w", sqlite3.Error{Code: sqlite3.ErrConstraint})
+	}, 5)
+	if err == nil || attempts != 1 {
+		t.Errorf("Non-busy errors should not be retried, got %d attempts, err %v", attempts, err)
+	}
+}
+
+func TestWithRetryStopsWhenContextCancelled(t *testing.T) {
+	d := NewWithDB(testutil.OpenDB(t, nil), &config.Config{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	err := d.WithRetry(ctx, func(*gorm.DB) error {
+		attempts++
+		cancel()
+		return fmt.Errorf("failed to save: %w", sqlite3.Error{Code: sqlite3.ErrBusy})
+	})
+	if !errors.Is(err, context.Canceled) || attempts != 1 {
+		t.Errorf("Expected to stop after the context was cancelled, got %d attempts, err %v", attempts, err)
+	}
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// restoredTables 恢复时替换的表，按删除顺序排列：先删除引用算法的表，开启外键约束时才能删除算法
+var restoredTables = []string{"jobs", "versions", "algorithms", "preset_data"}
+
+// ErrRestoreVerificationFailed 恢复提交后校验发现数据丢失，已从恢复前的快照还原
+var ErrRestoreVerificationFailed = errors.New("restore verification failed")
+
+// takePreRestoreSnapshot 恢复前用 VACUUM INTO 保存当前数据，返回快照路径；数据库为空时不需要快照，返回空字符串。
+// 进程在恢复过程中被终止时快照保留在本地备份目录，可以手动还原
+func (m *SQLiteBackupManager) takePreRestoreSnapshot() (string, error) {
+	var total int64
+	for _, table := range restoredTables {
+		var count int64
+		if err := m.db.Table(table).Count(&count).Error; err != nil {
+			return "", fmt.Errorf("failed to count %s: %w", table, err)
+		}
+		total += count
+	}
+	if total == 0 {
+		return "", nil
+	}
+
+	snapshotPath := filepath.Join(m.localBackupDir, fmt.Sprintf("pre-restore-%s.db", time.Now().Format("20060102-150405")))
+	if err := snapshotDBFile(m.db, snapshotPath); err != nil {
+		return "", fmt.Errorf("failed to snapshot database before restore: %w", err)
+	}
+	return snapshotPath, nil
+}
+
+// restoreFromSnapshot 用快照中的数据替换恢复涉及的表。
+// ATTACH 只对当前连接有效，因此在同一连接上附加快照并在事务中复制
+func (m *SQLiteBackupManager) restoreFromSnapshot(snapshotPath string) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("ATTACH DATABASE ? AS pre_restore", snapshotPath).Error; err != nil {
+			return fmt.Errorf("failed to attach snapshot: %w", err)
+		}
+		defer conn.Exec("DETACH DATABASE pre_restore")
+
+		return conn.Transaction(func(tx *gorm.DB) error {
+			for _, table := range restoredTables {
+				if err := tx.Exec("DELETE FROM main." + table).Error; err != nil {
+					return fmt.Errorf("failed to clear %s: %w", table, err)
+				}
+			}
+			// 按删除的相反顺序写回，先写被引用的表
+			for i := len(restoredTables) - 1; i >= 0; i-- {
+				table := restoredTables[i]
+				if err := tx.Exec("INSERT INTO main." + table + " SELECT * FROM pre_restore." + table).Error; err != nil {
+					return fmt.Errorf("failed to copy %s from snapshot: %w", table, err)
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// rollbackToSnapshot 恢复失败后从快照还原并删除快照，还原失败时保留快照文件供手动处理
+func (m *SQLiteBackupManager) rollbackToSnapshot(snapshotPath string) {
+	if snapshotPath == "" {
+		return
+	}
+	fmt.Print("🔙 Restoring pre-restore snapshot... ")
+	if err := m.restoreFromSnapshot(snapshotPath); err != nil {
+		fmt.Println("❌ FAILED")
+		fmt.Printf("Warning: failed to restore pre-restore snapshot, it is kept at %s: %v\n", snapshotPath, err)
+		return
+	}
+	fmt.Println("✅")
+	removeSnapshot(snapshotPath)
+}
+
+// removeSnapshot 删除恢复前的快照
+func removeSnapshot(snapshotPath string) {
+	if snapshotPath == "" {
+		return
+	}
+	if err := os.Remove(snapshotPath); err != nil {
+		fmt.Printf("Warning: failed to remove pre-restore snapshot %s: %v\n", snapshotPath, err)
+	}
+}
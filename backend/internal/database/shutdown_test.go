@@ -0,0 +1,155 @@
+package database
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/keys"
+	"algorithm-platform/internal/testutil"
+
+	"go.uber.org/goleak"
+)
+
+// MinIO 客户端的 HTTP 连接池会保留空闲连接（客户端和模拟服务端各一个 goroutine），
+// 它们属于共享的 http.Transport 和测试服务器，不随数据库关闭，检查时忽略
+var httpKeepAliveGoroutines = []goleak.Option{
+	goleak.IgnoreAnyFunction("net/http.(*persistConn).readLoop"),
+	goleak.IgnoreAnyFunction("net/http.(*persistConn).writeLoop"),
+	goleak.IgnoreAnyFunction("net/http.(*conn).serve"),
+}
+
+// gorm 开启 PrepareStmt 时为语句缓存启动的过期清理 goroutine 没有停止方式（gorm 有意不关闭），
+// 每个连接只有一个，不会随运行时间增长
+var gormStmtCacheGoroutine = goleak.IgnoreAnyFunction("gorm.io/gorm/internal/lru.NewLRU[...].func1")
+
+func TestBackupManagerStopWaitsForBackgroundWork(t *testing.T) {
+	_, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+	m.SetBackupInterval(10 * time.Millisecond)
+	defer goleak.VerifyNone(t, append(httpKeepAliveGoroutines, goleak.IgnoreCurrent())...)
+
+	if err := m.StartBackupScheduler(); err != nil {
+		t.Fatalf("StartBackupScheduler failed: %v", err)
+	}
+	// 等待至少一次定时备份，它会启动旧备份清理
+	time.Sleep(50 * time.Millisecond)
+
+	m.Stop()
+	m.Stop() // 重复调用不应 panic
+}
+
+func TestSetBackupIntervalResetsScheduler(t *testing.T) {
+	fake, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+	if err := m.StartBackupScheduler(); err != nil {
+		t.Fatalf("StartBackupScheduler failed: %v", err)
+	}
+	defer m.Stop()
+
+	// 调度器按一分钟的间隔启动，缩短间隔后应很快执行定时备份
+	m.SetBackupInterval(10 * time.Millisecond)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, ok := fake.Object(m.objectKey(keys.BackupLatestJSON)); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected a scheduled backup after shortening the interval")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSQLiteProviderCloseStopsCheckpointWorker(t *testing.T) {
+	defer goleak.VerifyNone(t, gormStmtCacheGoroutine, goleak.IgnoreCurrent())
+
+	provider := NewSQLiteProvider(&config.Config{
+		Database: config.DatabaseConfig{
+			SQLite: config.SQLiteConfig{
+				Path:                     filepath.Join(t.TempDir(), "test.db"),
+				WALCheckpointIntervalStr: "10ms",
+			},
+		},
+	})
+	if _, err := provider.Open(); err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+	if provider.checkpointDone == nil {
+		t.Fatal("Checkpoint worker did not start")
+	}
+
+	if err := provider.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+}
+
+// 完整的 New/Close 周期：checkpoint worker、备份调度器和旧备份清理都应在 Close 返回前退出
+func TestDatabaseCloseLeavesNoGoroutines(t *testing.T) {
+	fake, client := testutil.NewS3(t)
+	defer goleak.VerifyNone(t, append(httpKeepAliveGoroutines, gormStmtCacheGoroutine, goleak.IgnoreCurrent())...)
+
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{
+			Type: "sqlite",
+			SQLite: config.SQLiteConfig{
+				Path:                     filepath.Join(t.TempDir(), "test.db"),
+				WALCheckpointIntervalStr: "10ms",
+			},
+			Backup: config.BackupConfig{LocalBackupDir: t.TempDir()},
+		},
+		MinIO: config.MinIOConfig{
+			Endpoint:        client.EndpointURL().Host,
+			Bucket:          "test",
+			AccessKeyID:     "test",
+			SecretAccessKey: "test",
+		},
+	}
+
+	db, err := New(cfg, nil)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Close 会执行一次最终备份
+	if len(fake.Keys()) == 0 {
+		t.Error("No backup uploaded on Close")
+	}
+}
+
+// New 在打开连接之后失败时应关闭连接并停止 checkpoint worker，启动重试不会累积连接池
+func TestNewReleasesConnectionOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "test.db")
+	// 与 jobs 表同名的视图让迁移失败
+	seed, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := seed.Exec("CREATE VIEW jobs AS SELECT 1 AS id"); err != nil {
+		t.Fatalf("Failed to create view: %v", err)
+	}
+	seed.Close()
+
+	defer goleak.VerifyNone(t, gormStmtCacheGoroutine, goleak.IgnoreCurrent())
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{
+			Type: "sqlite",
+			SQLite: config.SQLiteConfig{
+				Path:                     dbPath,
+				WALCheckpointIntervalStr: "10ms",
+			},
+			Backup: config.BackupConfig{LocalBackupDir: dir},
+		},
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := New(cfg, nil); err == nil {
+			t.Fatal("Expected New to fail to migrate")
+		}
+	}
+}
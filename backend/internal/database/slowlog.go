@@ -0,0 +1,22 @@
+package database
+
+import (
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+// newQueryLogger 创建 GORM 日志：记录错误和执行时间超过 threshold 的语句（含 SQL 和耗时），
+// threshold 为 0 时只记录错误
+func newQueryLogger(w logger.Writer, threshold time.Duration) logger.Interface {
+	cfg := logger.Config{
+		LogLevel:                  logger.Warn,
+		IgnoreRecordNotFoundError: true,
+	}
+	if threshold > 0 {
+		cfg.SlowThreshold = threshold
+	} else {
+		cfg.LogLevel = logger.Error
+	}
+	return logger.New(w, cfg)
+}
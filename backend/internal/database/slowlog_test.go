@@ -0,0 +1,61 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"gorm.io/gorm"
+)
+
+// bufferWriter 收集 GORM 日志输出
+type bufferWriter struct {
+	strings.Builder
+}
+
+func (w *bufferWriter) Printf(format string, args ...interface{}) {
+	fmt.Fprintf(&w.Builder, format+"\n", args...)
+}
+
+func openLoggedDB(t *testing.T, threshold time.Duration) (*gorm.DB, *bufferWriter) {
+	t.Helper()
+	out := &bufferWriter{}
+	db := testutil.OpenDB(t, newQueryLogger(out, threshold))
+	if err := db.AutoMigrate(&models.Algorithm{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	out.Reset()
+	return db, out
+}
+
+func TestSlowQueryLogIncludesSQL(t *testing.T) {
+	db, out := openLoggedDB(t, time.Nanosecond)
+
+	var algorithms []models.Algorithm
+	db.Where("name = ?", "detector").Find(&algorithms)
+
+	log := out.String()
+	if !strings.Contains(log, "SLOW SQL") || !strings.Contains(log, `name = "detector"`) {
+		t.Errorf("Expected slow query with SQL in log, got:\n%s", log)
+	}
+}
+
+func TestSlowQueryLogDisabled(t *testing.T) {
+	db, out := openLoggedDB(t, 0)
+
+	var algorithms []models.Algorithm
+	db.Find(&algorithms)
+	if out.Len() != 0 {
+		t.Errorf("Expected no log output, got:\n%s", out.String())
+	}
+
+	// 错误仍然记录
+	db.Exec("SELECT * FROM missing_table")
+	if !strings.Contains(out.String(), "missing_table") {
+		t.Errorf("Expected error to be logged, got:\n%s", out.String())
+	}
+}
@@ -1,14 +1,23 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/maintenance"
+	"algorithm-platform/internal/retry"
 
+	"github.com/mattn/go-sqlite3"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -18,9 +27,13 @@ type SQLiteProvider struct {
 	dbPath                string
 	db                    *gorm.DB
 	walCheckpointInterval time.Duration
+	walAutocheckpoint     int // 每个连接的 PRAGMA wal_autocheckpoint，0 表示关闭
 	stopCheckpoint        chan struct{}
+	checkpointDone        chan struct{} // checkpoint worker 退出后关闭，未启动 worker 时为 nil
+	closeOnce             sync.Once
 	backupManager         *SQLiteBackupManager
 	cfg                   *config.Config
+	maintenance           *maintenance.Mode
 }
 
 // SQLiteConfig SQLite 配置选项
@@ -33,7 +46,10 @@ type SQLiteConfig struct {
 // NewSQLiteProvider 创建 SQLite 数据库提供者
 func NewSQLiteProvider(cfg *config.Config) *SQLiteProvider {
 	// 设置默认值
-	walInterval := cfg.Database.SQLite.GetWALCheckpointInterval()
+	walAutocheckpoint, walInterval := resolveWALCheckpointing(
+		cfg.Database.SQLite.GetWALAutocheckpoint(),
+		cfg.Database.SQLite.GetWALCheckpointInterval(),
+	)
 
 	dbPath := cfg.Database.SQLite.Path
 	if dbPath == "" {
@@ -43,11 +59,29 @@ func NewSQLiteProvider(cfg *config.Config) *SQLiteProvider {
 	return &SQLiteProvider{
 		dbPath:                dbPath,
 		walCheckpointInterval: walInterval,
+		walAutocheckpoint:     walAutocheckpoint,
 		stopCheckpoint:        make(chan struct{}),
 		cfg:                   cfg,
 	}
 }
 
+// resolveWALCheckpointing 协调自动 checkpoint 和定时 checkpoint 的配置
+//
+// 两者作用不同，可以同时开启：
+//   - wal_autocheckpoint：WAL 超过指定页数时，由提交事务的连接执行 PASSIVE checkpoint，
+//     不阻塞读写，但不会缩小 WAL 文件，用于限制写入高峰时 WAL 的增长
+//   - 定时 checkpoint：后台定期执行 TRUNCATE checkpoint，会短暂等待读写完成并把 WAL 文件截断为 0
+//
+// 两者都关闭时 WAL 会无限增长，此时恢复默认的自动 checkpoint。
+func resolveWALCheckpointing(autocheckpoint int, interval time.Duration) (int, time.Duration) {
+	if autocheckpoint <= 0 && interval <= 0 {
+		fmt.Printf("Warning: both wal_autocheckpoint and wal_checkpoint_interval are disabled, using wal_autocheckpoint = %d\n",
+			config.DefaultWALAutocheckpoint)
+		return config.DefaultWALAutocheckpoint, 0
+	}
+	return autocheckpoint, interval
+}
+
 // Open 打开 SQLite 数据库连接
 func (p *SQLiteProvider) Open() (*gorm.DB, error) {
 	// 确保数据目录存在
@@ -59,8 +93,20 @@ func (p *SQLiteProvider) Open() (*gorm.DB, error) {
 	// 打开数据库，启用共享缓存和扩展结果代码
 	dsn := fmt.Sprintf("%s?_journal_mode=WAL&_synchronous=FULL&_busy_timeout=5000&_foreign_keys=ON", p.dbPath)
 
+	// PRAGMA wal_autocheckpoint 只对当前连接生效，通过连接钩子在连接池的每个新连接上设置
+	sqlDB := sql.OpenDB(&sqliteConnector{
+		dsn: dsn,
+		driver: &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				_, err := conn.Exec(fmt.Sprintf("PRAGMA wal_autocheckpoint = %d", p.walAutocheckpoint), nil)
+				return err
+			},
+		},
+	})
+
 	db, err := gorm.Open(sqlite.Dialector{
-		DSN: dsn,
+		DSN:  dsn,
+		Conn: sqlDB,
 	}, &gorm.Config{
 		// 预处理语句缓存
 		PrepareStmt: true,
@@ -68,6 +114,7 @@ func (p *SQLiteProvider) Open() (*gorm.DB, error) {
 		DisableAutomaticPing: false,
 	})
 	if err != nil {
+		sqlDB.Close()
 		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
 	}
 
@@ -75,11 +122,16 @@ func (p *SQLiteProvider) Open() (*gorm.DB, error) {
 
 	// 初始化数据库优化设置
 	if err := p.optimizeDatabase(); err != nil {
+		p.abort()
 		return nil, fmt.Errorf("failed to optimize database: %w", err)
 	}
 
-	// 启动 WAL checkpoint 定时任务
-	go p.walCheckpointWorker()
+	// 启动 WAL checkpoint 定时任务（间隔为 0 时只依赖 wal_autocheckpoint）
+	if p.walCheckpointInterval > 0 {
+		p.checkpointDone = make(chan struct{})
+		go p.walCheckpointWorker()
+	}
+	fmt.Printf("SQLite WAL checkpointing: autocheckpoint=%d pages, interval=%v\n", p.walAutocheckpoint, p.walCheckpointInterval)
 
 	return db, nil
 }
@@ -116,6 +168,9 @@ func (p *SQLiteProvider) optimizeDatabase() error {
 
 		// 自动清理
 		{"auto_vacuum", "INCREMENTAL", "启用增量自动清理"},
+
+		// 自动 checkpoint 页数（新连接由连接钩子设置，这里输出当前值）
+		{"wal_autocheckpoint", strconv.Itoa(p.walAutocheckpoint), "WAL 自动 checkpoint 页数"},
 	}
 
 	for _, pragma := range pragmas {
@@ -182,6 +237,7 @@ func (p *SQLiteProvider) initBackupManager() error {
 		return fmt.Errorf("failed to create backup manager: %w", err)
 	}
 
+	backupManager.maintenance = p.maintenance
 	p.backupManager = backupManager
 
 	// 注意：不在这里LoadFromMinIO，而是在PostMigrate中执行
@@ -214,6 +270,7 @@ func (p *SQLiteProvider) SetConfig(cfg *config.Config) {
 
 // walCheckpointWorker 定期执行 WAL checkpoint
 func (p *SQLiteProvider) walCheckpointWorker() {
+	defer close(p.checkpointDone)
 	ticker := time.NewTicker(p.walCheckpointInterval)
 	defer ticker.Stop()
 
@@ -263,7 +320,7 @@ func (p *SQLiteProvider) Close() error {
 		}
 
 		// 备份数据库文件到本地和 MinIO
-		backupPath := "./data/backup-final.db"
+		backupPath := filepath.Join(p.backupManager.localBackupDir, "backup-final.db")
 		if err := p.backupManager.BackupDBFile(backupPath); err != nil {
 			fmt.Printf("Warning: SQLite file backup failed: %v\n", err)
 		} else {
@@ -273,11 +330,11 @@ func (p *SQLiteProvider) Close() error {
 		p.backupManager.Stop()
 	}
 
-	// 停止 checkpoint worker
-	close(p.stopCheckpoint)
-
-	// 等待一小段时间让 checkpoint 完成
-	time.Sleep(100 * time.Millisecond)
+	// 停止 checkpoint worker 并等待最后一次 checkpoint 完成
+	p.closeOnce.Do(func() { close(p.stopCheckpoint) })
+	if p.checkpointDone != nil {
+		<-p.checkpointDone
+	}
 
 	if p.db == nil {
 		return nil
@@ -301,6 +358,28 @@ func (p *SQLiteProvider) Close() error {
 	return sqlDB.Close()
 }
 
+// abort 释放初始化失败时已经打开的资源：停止后台任务并关闭连接。
+// 与 Close 不同，不执行最终备份：此时数据可能还没有从备份恢复，备份会覆盖 MinIO 中较新的数据
+func (p *SQLiteProvider) abort() error {
+	if p.backupManager != nil {
+		p.backupManager.Stop()
+	}
+
+	p.closeOnce.Do(func() { close(p.stopCheckpoint) })
+	if p.checkpointDone != nil {
+		<-p.checkpointDone
+	}
+
+	if p.db == nil {
+		return nil
+	}
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %w", err)
+	}
+	return sqlDB.Close()
+}
+
 // Name 返回数据库提供者名称
 func (p *SQLiteProvider) Name() string {
 	return "SQLite"
@@ -384,6 +463,13 @@ func (p *SQLiteProvider) GetStats() (map[string]interface{}, error) {
 		stats["freelist_count"] = freelistCount
 	}
 
+	// WAL checkpoint 设置
+	var walAutocheckpoint int
+	if err := sqlDB.QueryRow("PRAGMA wal_autocheckpoint").Scan(&walAutocheckpoint); err == nil {
+		stats["wal_autocheckpoint"] = walAutocheckpoint
+	}
+	stats["wal_checkpoint_interval"] = p.walCheckpointInterval.String()
+
 	// 连接池统计
 	dbStats := sqlDB.Stats()
 	stats["open_connections"] = dbStats.OpenConnections
@@ -421,19 +507,7 @@ func (p *SQLiteProvider) Backup(destPath string) error {
 		return fmt.Errorf("database not initialized")
 	}
 
-	// 执行 checkpoint 确保数据完整性
-	if err := p.checkpoint(); err != nil {
-		return fmt.Errorf("checkpoint before backup failed: %w", err)
-	}
-
-	sqlDB, err := p.db.DB()
-	if err != nil {
-		return err
-	}
-
-	// 使用 SQLite backup API
-	query := fmt.Sprintf("VACUUM INTO '%s'", destPath)
-	if _, err := sqlDB.Exec(query); err != nil {
+	if err := snapshotDBFile(p.db, destPath); err != nil {
 		return fmt.Errorf("backup failed: %w", err)
 	}
 
@@ -452,38 +526,53 @@ func (p *SQLiteProvider) ExecuteWithRetry(fn func(*sql.DB) error, maxRetries int
 		return err
 	}
 
-	var lastErr error
-	for i := 0; i <= maxRetries; i++ {
-		err := fn(sqlDB)
-		if err == nil {
-			return nil
-		}
-
-		lastErr = err
-
-		// 检查是否是可重试的错误（如数据库锁定）
-		if !isSQLiteBusyError(err) {
-			return err
-		}
+	return retry.Do(context.Background(), busyRetryPolicy(maxRetries), func() error {
+		return fn(sqlDB)
+	})
+}
 
-		// 指数退避
-		if i < maxRetries {
-			backoff := time.Duration(1<<uint(i)) * 10 * time.Millisecond
-			fmt.Printf("SQLite busy, retrying in %v (attempt %d/%d)...\n", backoff, i+1, maxRetries)
-			time.Sleep(backoff)
-		}
+// busyRetryPolicy 只重试数据库锁定类错误，指数退避
+func busyRetryPolicy(maxRetries int) retry.Policy {
+	return retry.Policy{
+		MaxAttempts: maxRetries + 1,
+		BaseDelay:   10 * time.Millisecond,
+		MaxDelay:    time.Second,
+		Jitter:      0.2,
+		Retryable:   isSQLiteBusyError,
+		OnRetry: func(attempt int, delay time.Duration, err error) {
+			fmt.Printf("SQLite busy, retrying in %v (attempt %d/%d)...\n", delay.Round(time.Millisecond), attempt, maxRetries)
+		},
 	}
-
-	return fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
-// isSQLiteBusyError 检查是否是 SQLite 忙碌错误
+// isSQLiteBusyError 检查是否是 SQLite 忙碌错误，GORM 和调用方包装过的错误同样识别
 func isSQLiteBusyError(err error) bool {
 	if err == nil {
 		return false
 	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+
+	// 只保留了错误信息的错误（如被格式化成字符串）按内容判断
 	errStr := err.Error()
-	return errStr == "database is locked" ||
-		errStr == "database table is locked" ||
-		errStr == "SQLITE_BUSY"
+	return strings.Contains(errStr, "database is locked") ||
+		strings.Contains(errStr, "database table is locked") ||
+		strings.Contains(errStr, "SQLITE_BUSY")
+}
+
+// sqliteConnector 使用带连接钩子的驱动创建连接
+type sqliteConnector struct {
+	dsn    string
+	driver *sqlite3.SQLiteDriver
+}
+
+func (c *sqliteConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c *sqliteConnector) Driver() driver.Driver {
+	return c.driver
 }
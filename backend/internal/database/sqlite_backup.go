@@ -6,21 +6,36 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/keys"
+	"algorithm-platform/internal/maintenance"
+	"algorithm-platform/internal/metrics"
 	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/retry"
+	"algorithm-platform/pkg/storage"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrBackupBusy 已有备份或恢复操作正在进行
+var ErrBackupBusy = errors.New("backup or restore already in progress")
+
 // SQLiteBackupManager SQLite 专用的备份管理器
 // 负责将 SQLite 数据备份到 MinIO 和本地文件系统
 type SQLiteBackupManager struct {
@@ -28,29 +43,53 @@ type SQLiteBackupManager struct {
 	minio          *minio.Client
 	bucketName     string
 	stopBackup     chan struct{}
-	backupInterval time.Duration
-	dbPath         string // 数据库文件路径
+	stopOnce       sync.Once
+	background     sync.WaitGroup // 调度器和旧备份清理的 goroutine，Stop 时等待退出
+	backupInterval atomic.Int64   // 定时备份间隔（纳秒），可通过 SetBackupInterval 在运行时修改
+	intervalReset  chan struct{}  // 通知调度器按新间隔重置定时器
+	dbPath         string         // 数据库文件路径
+	opMu           sync.Mutex     // 备份与恢复互斥，避免并发写 latest.json 或在清表过程中读取数据
+	lastRestore    atomic.Pointer[RestoreResult]
+	maintenance    *maintenance.Mode // 恢复期间开启只读模式，为 nil 时不处理
+	keyPrefix      string            // 对象路径前缀，见 MinIOConfig.KeyPrefix
+	jsonRetention  int               // MinIO 和本地目录中各自保留的 JSON 备份数量
+	dbRetention    int               // MinIO 和本地目录中各自保留的数据库文件备份数量
+	localBackupDir string            // 本地备份目录
+	restoreBatch   int               // 恢复时每批插入的记录数
 }
 
 // NewSQLiteBackupManager 创建 SQLite 备份管理器
 func NewSQLiteBackupManager(db *gorm.DB, cfg *config.Config) (*SQLiteBackupManager, error) {
 	// 初始化 MinIO 客户端
 	minioClient, err := minio.New(cfg.MinIO.Endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(cfg.MinIO.AccessKeyID, cfg.MinIO.SecretAccessKey, ""),
-		Secure: cfg.MinIO.UseSSL,
+		Creds:     credentials.NewStaticV4(cfg.MinIO.AccessKeyID, cfg.MinIO.SecretAccessKey, ""),
+		Secure:    cfg.MinIO.UseSSL,
+		Transport: metrics.MinIOTransport(cfg.MinIO.UseSSL),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize MinIO client: %w", err)
 	}
 
-	return &SQLiteBackupManager{
+	manager := &SQLiteBackupManager{
 		db:             db,
 		minio:          minioClient,
 		bucketName:     cfg.MinIO.Bucket,
 		stopBackup:     make(chan struct{}),
-		backupInterval: 5 * time.Minute,
+		intervalReset:  make(chan struct{}, 1),
 		dbPath:         cfg.Database.SQLite.Path,
-	}, nil
+		keyPrefix:      cfg.MinIO.KeyPrefix,
+		jsonRetention:  cfg.Database.Backup.GetJSONRetention(),
+		dbRetention:    cfg.Database.Backup.GetDBRetention(),
+		localBackupDir: cfg.Database.Backup.GetLocalBackupDir(),
+		restoreBatch:   cfg.Database.Backup.GetRestoreBatchSize(),
+	}
+	manager.backupInterval.Store(int64(cfg.Database.Backup.GetInterval()))
+	return manager, nil
+}
+
+// objectKey 返回带环境前缀的备份对象路径
+func (m *SQLiteBackupManager) objectKey(key string) string {
+	return config.JoinObjectKey(m.keyPrefix, key)
 }
 
 // BackupMetadata 备份元数据
@@ -62,6 +101,47 @@ type BackupMetadata struct {
 	Version       int64     `json:"version"`         // 数据版本号
 	RecordCount   int64     `json:"record_count"`    // 记录数量
 	LastUpdatedAt time.Time `json:"last_updated_at"` // 数据最后更新时间
+	SchemaVersion int       `json:"schema_version"`  // 备份结构版本，见 BackupSchemaVersion
+	Size          int64     `json:"size"`            // 备份文件大小（字节）
+	HashRecorded  bool      `json:"hash_recorded"`   // 备份时记录了 SHA-256；旧备份为 false，恢复时无法校验完整性
+}
+
+// TableRestoreResult 单张表的恢复统计
+type TableRestoreResult struct {
+	Restored int `json:"restored"`
+	Failed   int `json:"failed"`
+}
+
+// RestoreResult 一次恢复的结构化结果，部分记录失败时 Partial 返回 true
+type RestoreResult struct {
+	Source   string                         `json:"source"`
+	Path     string                         `json:"path"`
+	Tables   map[string]*TableRestoreResult `json:"tables"`
+	Duration time.Duration                  `json:"duration"`
+	Warnings []string                       `json:"warnings,omitempty"`
+}
+
+// Partial 是否有记录恢复失败
+func (r *RestoreResult) Partial() bool {
+	for _, table := range r.Tables {
+		if table.Failed > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// table 获取表的统计项，不存在时创建
+func (r *RestoreResult) table(name string) *TableRestoreResult {
+	if r.Tables[name] == nil {
+		r.Tables[name] = &TableRestoreResult{}
+	}
+	return r.Tables[name]
+}
+
+// LastRestoreResult 返回最近一次恢复的结果，未执行过恢复时返回 nil
+func (m *SQLiteBackupManager) LastRestoreResult() *RestoreResult {
+	return m.lastRestore.Load()
 }
 
 // LoadFromMinIO 智能恢复策略：使用版本号比对，选择最新数据
@@ -180,8 +260,11 @@ func (m *SQLiteBackupManager) LoadFromMinIO() error {
 
 	// 执行恢复
 	restoreChan := make(chan error, 1)
+	var result *RestoreResult
 	go func() {
-		restoreChan <- m.restoreFromBackup(ctx, newestBackup)
+		var err error
+		result, err = m.restoreFromBackup(ctx, newestBackup)
+		restoreChan <- err
 	}()
 
 	select {
@@ -192,7 +275,11 @@ func (m *SQLiteBackupManager) LoadFromMinIO() error {
 			fmt.Println("   ⚠️  Keeping current database")
 			return nil // 不中断启动，保留当前数据
 		}
-		fmt.Println("✅ Database restored successfully")
+		if result.Partial() {
+			fmt.Printf("⚠️  Database partially restored (%d warnings)\n", len(result.Warnings))
+		} else {
+			fmt.Println("✅ Database restored successfully")
+		}
 	case <-ctx.Done():
 		fmt.Println("\n❌ RESTORE TIMEOUT (exceeded 5 minutes)")
 		fmt.Println("   ⚠️  Keeping current database")
@@ -206,8 +293,8 @@ func (m *SQLiteBackupManager) LoadFromMinIO() error {
 func (m *SQLiteBackupManager) getDatabaseMetadata() (*BackupMetadata, error) {
 	var meta models.DatabaseMetadata
 
-	// 尝试获取最新的元数据记录
-	if err := m.db.Order("version DESC").First(&meta).Error; err != nil {
+	// 获取唯一的元数据记录
+	if err := m.db.First(&meta, models.DatabaseMetadataID).Error; err != nil {
 		// 如果表不存在或没有记录，返回默认值
 		if err == gorm.ErrRecordNotFound || isTableNotExistError(err) {
 			// 统计实际记录数
@@ -259,44 +346,18 @@ func isTableNotExistError(err error) bool {
 	return strings.Contains(errStr, "no such table") || strings.Contains(errStr, "doesn't exist")
 }
 
-// updateDatabaseMetadata 更新数据库元数据（每次写操作后调用）
-func (m *SQLiteBackupManager) updateDatabaseMetadata(updatedBy string) error {
-	var count int64
-	if err := m.db.Model(&models.Algorithm{}).Count(&count).Error; err != nil {
-		return err
-	}
-
-	// 获取当前最大版本号
-	var currentMeta models.DatabaseMetadata
-	m.db.Order("version DESC").First(&currentMeta)
-
-	newMeta := models.DatabaseMetadata{
-		Version:       currentMeta.Version + 1, // 版本号递增
-		LastUpdatedAt: time.Now(),
-		UpdatedBy:     updatedBy,
-		CheckpointAt:  time.Now(),
-		RecordCount:   count,
-	}
-
-	return m.db.Create(&newMeta).Error
-}
-
 // attemptRestore 尝试从备份恢复（仅在数据库损坏时调用）
 func (m *SQLiteBackupManager) attemptRestore(ctx context.Context) error {
 	fmt.Println("🔍 Looking for backups to restore...")
 
-	// 确保 bucket 存在
-	exists, err := m.minio.BucketExists(ctx, m.bucketName)
+	// 确保 bucket 存在，新建的 bucket 中不会有备份
+	created, err := storage.EnsureBucket(ctx, m.minio, m.bucketName)
 	if err != nil {
-		fmt.Printf("❌ Failed to check MinIO bucket: %v\n", err)
+		fmt.Printf("❌ Failed to prepare MinIO bucket: %v\n", err)
 		return err
 	}
-	if !exists {
-		if err := m.minio.MakeBucket(ctx, m.bucketName, minio.MakeBucketOptions{}); err != nil {
-			fmt.Printf("⚠️  Failed to create bucket: %v\n", err)
-		} else {
-			fmt.Printf("✅ Created MinIO bucket: %s\n", m.bucketName)
-		}
+	if created {
+		fmt.Printf("✅ Created MinIO bucket: %s\n", m.bucketName)
 		return fmt.Errorf("no backup available")
 	}
 
@@ -336,8 +397,11 @@ func (m *SQLiteBackupManager) attemptRestore(ctx context.Context) error {
 	fmt.Printf("   Hash: %s\n", newestBackup.Hash[:16])
 
 	restoreChan := make(chan error, 1)
+	var result *RestoreResult
 	go func() {
-		restoreChan <- m.restoreFromBackup(ctx, newestBackup)
+		var err error
+		result, err = m.restoreFromBackup(ctx, newestBackup)
+		restoreChan <- err
 	}()
 
 	select {
@@ -345,6 +409,9 @@ func (m *SQLiteBackupManager) attemptRestore(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		if result.Partial() {
+			fmt.Printf("⚠️  Database partially restored (%d warnings)\n", len(result.Warnings))
+		}
 	case <-ctx.Done():
 		return fmt.Errorf("restore timeout exceeded 5 minutes")
 	}
@@ -382,14 +449,30 @@ func (m *SQLiteBackupManager) calculateDatabaseHash() (string, error) {
 
 // getMinIOBackupMetadata 获取MinIO备份的元数据
 func (m *SQLiteBackupManager) getMinIOBackupMetadata(ctx context.Context) (*BackupMetadata, error) {
-	backupPath := "database-backup/latest.json"
+	meta, err := m.readMinIOBackupMetadata(ctx, m.objectKey(keys.BackupLatestJSON))
+	if err != nil && minio.ToErrorResponse(errors.Unwrap(err)).Code == "NoSuchKey" {
+		// 升级前只有未压缩的 latest.json
+		return m.readMinIOBackupMetadata(ctx, m.objectKey(keys.BackupLegacyLatestJSON))
+	}
+	return meta, err
+}
 
+// readMinIOBackupMetadata 返回 MinIO 中指定的 JSON 备份的元数据。
+// 优先使用对象用户元数据中的摘要，较早的备份没有摘要时读取全部内容
+func (m *SQLiteBackupManager) readMinIOBackupMetadata(ctx context.Context, backupPath string) (*BackupMetadata, error) {
 	// 检查对象是否存在
 	stat, err := m.minio.StatObject(ctx, m.bucketName, backupPath, minio.StatObjectOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("backup not found: %w", err)
 	}
 
+	if header := parseBackupHeader(func(key string) string { return stat.Metadata.Get("X-Amz-Meta-" + key) }); header != nil {
+		meta := header.metadata(stat.LastModified, stat.Size)
+		meta.Source = "minio"
+		meta.Path = backupPath
+		return meta, nil
+	}
+
 	// 获取备份内容
 	obj, err := m.minio.GetObject(ctx, m.bucketName, backupPath, minio.GetObjectOptions{})
 	if err != nil {
@@ -397,60 +480,18 @@ func (m *SQLiteBackupManager) getMinIOBackupMetadata(ctx context.Context) (*Back
 	}
 	defer obj.Close()
 
-	// 读取内容并计算hash
-	var buf bytes.Buffer
-	if _, err := buf.ReadFrom(obj); err != nil {
-		return nil, fmt.Errorf("failed to read backup: %w", err)
-	}
-
-	hash := sha256.Sum256(buf.Bytes())
-
-	// 解析备份内容以获取元数据
-	var backupData map[string]interface{}
-	if err := json.Unmarshal(buf.Bytes(), &backupData); err != nil {
-		return nil, fmt.Errorf("failed to parse backup: %w", err)
-	}
-
-	// 提取元数据
-	version := int64(0)
-	recordCount := int64(0)
-	lastUpdatedAt := stat.LastModified
-
-	if metadata, ok := backupData["metadata"].(map[string]interface{}); ok {
-		if v, ok := metadata["version"].(float64); ok {
-			version = int64(v)
-		}
-		if rc, ok := metadata["record_count"].(float64); ok {
-			recordCount = int64(rc)
-		}
-		if luat, ok := metadata["last_updated_at"].(string); ok {
-			if t, err := time.Parse(time.RFC3339, luat); err == nil {
-				lastUpdatedAt = t
-			}
-		}
-	}
-
-	// 如果没有元数据，尝试从算法数量估算
-	if recordCount == 0 {
-		if algorithms, ok := backupData["algorithms"].([]interface{}); ok {
-			recordCount = int64(len(algorithms))
-		}
+	meta, err := readBackupMetadata(obj, stat.LastModified, stat.Size)
+	if err != nil {
+		return nil, err
 	}
-
-	return &BackupMetadata{
-		Timestamp:     stat.LastModified,
-		Hash:          hex.EncodeToString(hash[:]),
-		Source:        "minio",
-		Path:          backupPath,
-		Version:       version,
-		RecordCount:   recordCount,
-		LastUpdatedAt: lastUpdatedAt,
-	}, nil
+	meta.Source = "minio"
+	meta.Path = backupPath
+	return meta, nil
 }
 
 // getLocalBackupMetadata 获取本地最新备份的元数据
 func (m *SQLiteBackupManager) getLocalBackupMetadata() (*BackupMetadata, error) {
-	backupDir := "./data/backups"
+	backupDir := m.localBackupDir
 
 	// 检查目录是否存在
 	if _, err := os.Stat(backupDir); os.IsNotExist(err) {
@@ -458,7 +499,7 @@ func (m *SQLiteBackupManager) getLocalBackupMetadata() (*BackupMetadata, error)
 	}
 
 	// 列出所有备份文件
-	files, err := filepath.Glob(filepath.Join(backupDir, "backup-*.json"))
+	files, err := filepath.Glob(filepath.Join(backupDir, localJSONBackupPattern))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list backups: %w", err)
 	}
@@ -474,125 +515,124 @@ func (m *SQLiteBackupManager) getLocalBackupMetadata() (*BackupMetadata, error)
 		return infoI.ModTime().After(infoJ.ModTime())
 	})
 
-	latestFile := files[0]
-	info, err := os.Stat(latestFile)
+	return readLocalBackupMetadata(files[0])
+}
+
+// readLocalBackupMetadata 返回本地 JSON 备份文件的元数据。
+// 优先使用 gzip 头中的摘要，较早的备份没有摘要时读取全部内容
+func readLocalBackupMetadata(backupPath string) (*BackupMetadata, error) {
+	info, err := os.Stat(backupPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to stat backup file: %w", err)
 	}
 
-	// 读取文件并计算hash
-	data, err := os.ReadFile(latestFile)
+	file, err := os.Open(backupPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read backup file: %w", err)
 	}
+	defer file.Close()
 
-	hash := sha256.Sum256(data)
-
-	// 解析备份内容以获取元数据
-	var backupData map[string]interface{}
-	if err := json.Unmarshal(data, &backupData); err != nil {
-		return nil, fmt.Errorf("failed to parse backup: %w", err)
-	}
-
-	// 提取元数据
-	version := int64(0)
-	recordCount := int64(0)
-	lastUpdatedAt := info.ModTime()
-
-	if metadata, ok := backupData["metadata"].(map[string]interface{}); ok {
-		if v, ok := metadata["version"].(float64); ok {
-			version = int64(v)
-		}
-		if rc, ok := metadata["record_count"].(float64); ok {
-			recordCount = int64(rc)
+	var meta *BackupMetadata
+	if header := readBackupHeader(file); header != nil {
+		meta = header.metadata(info.ModTime(), info.Size())
+	} else {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to read backup file: %w", err)
 		}
-		if luat, ok := metadata["last_updated_at"].(string); ok {
-			if t, err := time.Parse(time.RFC3339, luat); err == nil {
-				lastUpdatedAt = t
-			}
+		if meta, err = readBackupMetadata(file, info.ModTime(), info.Size()); err != nil {
+			return nil, err
 		}
 	}
+	meta.Source = "local"
+	meta.Path = backupPath
+	return meta, nil
+}
 
-	// 如果没有元数据，尝试从算法数量估算
-	if recordCount == 0 {
-		if algorithms, ok := backupData["algorithms"].([]interface{}); ok {
-			recordCount = int64(len(algorithms))
-		}
+// restoreFromBackup 从备份恢复数据（带事务和完整性验证）
+// 部分记录失败不会返回 error（避免中断启动），调用方通过 RestoreResult.Partial 判断
+func (m *SQLiteBackupManager) restoreFromBackup(ctx context.Context, metadata *BackupMetadata) (*RestoreResult, error) {
+	if !m.opMu.TryLock() {
+		return nil, ErrBackupBusy
 	}
+	defer m.opMu.Unlock()
 
-	return &BackupMetadata{
-		Timestamp:     info.ModTime(),
-		Hash:          hex.EncodeToString(hash[:]),
-		Source:        "local",
-		Path:          latestFile,
-		Version:       version,
-		RecordCount:   recordCount,
-		LastUpdatedAt: lastUpdatedAt,
-	}, nil
-}
+	// 恢复期间拒绝写请求，避免与清表和重新导入竞争
+	if m.maintenance != nil {
+		release := m.maintenance.Engage("restoring database from backup")
+		defer release()
+	}
 
-// restoreFromBackup 从备份恢复数据（带事务和完整性验证）
-func (m *SQLiteBackupManager) restoreFromBackup(ctx context.Context, metadata *BackupMetadata) error {
 	startTime := time.Now()
+	result := &RestoreResult{
+		Source: metadata.Source,
+		Path:   metadata.Path,
+		Tables: make(map[string]*TableRestoreResult),
+	}
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("🔄 Starting database restore from %s backup\n", metadata.Source)
 	fmt.Printf("   Backup time: %s\n", metadata.Timestamp.Format("2006-01-02 15:04:05"))
 	fmt.Printf("   Backup hash: %s\n", metadata.Hash[:16])
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	var backupData map[string]interface{}
-
-	// Step 1: 加载备份数据
-	fmt.Print("📥 [1/5] Loading backup data... ")
+	// Step 1: 扫描备份，只读取元数据和记录数，记录在导入时再逐批解析
+	fmt.Print("📥 [1/5] Scanning backup data... ")
 	loadStart := time.Now()
 
-	if metadata.Source == "minio" {
-		// 从MinIO恢复
-		obj, err := m.minio.GetObject(ctx, m.bucketName, metadata.Path, minio.GetObjectOptions{})
-		if err != nil {
-			fmt.Println("❌ FAILED")
-			return fmt.Errorf("failed to get MinIO backup: %w", err)
-		}
-		defer obj.Close()
+	summary, err := m.readBackupStream(ctx, metadata, backupHandlers{})
+	if err != nil {
+		fmt.Println("❌ FAILED")
+		return nil, fmt.Errorf("failed to decode %s backup: %w", metadata.Source, err)
+	}
+	fmt.Printf("✅ (%.2fs)\n", time.Since(loadStart).Seconds())
 
-		if err := json.NewDecoder(obj).Decode(&backupData); err != nil {
-			fmt.Println("❌ FAILED")
-			return fmt.Errorf("failed to decode MinIO backup: %w", err)
-		}
+	// 内容与备份时记录的 hash 不一致说明备份已损坏，保留现有数据
+	if err := verifyBackupHash(summary, metadata); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return nil, err
+	}
+	// 旧备份没有记录 hash，无从校验，照常恢复但明确标记为未校验
+	if summary.RecordedHash == "" {
+		fmt.Println("   ⚠️  Backup has no recorded SHA-256, integrity not verified")
+		result.Warnings = append(result.Warnings, "backup has no recorded sha256, integrity not verified")
 	} else {
-		// 从本地恢复
-		data, err := os.ReadFile(metadata.Path)
-		if err != nil {
-			fmt.Println("❌ FAILED")
-			return fmt.Errorf("failed to read local backup: %w", err)
-		}
+		fmt.Println("   SHA-256 matches the recorded hash")
+	}
+	header, counts := &summary.Header, summary.Counts
 
-		if err := json.Unmarshal(data, &backupData); err != nil {
-			fmt.Println("❌ FAILED")
-			return fmt.Errorf("failed to decode local backup: %w", err)
-		}
+	// 旧结构的备份在导入时逐批升级到当前模型，未来版本的备份直接拒绝
+	schemaVersion, err := upgradeBackup(header)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return nil, err
+	}
+	if schemaVersion < BackupSchemaVersion {
+		fmt.Printf("   Migrating backup from schema v%d to v%d\n", schemaVersion, BackupSchemaVersion)
+		result.Warnings = append(result.Warnings, fmt.Sprintf("backup migrated from schema v%d to v%d", schemaVersion, BackupSchemaVersion))
 	}
-	fmt.Printf("✅ (%.2fs)\n", time.Since(loadStart).Seconds())
 
 	// Step 2: 验证备份完整性
 	fmt.Print("🔍 [2/5] Validating backup integrity... ")
 	validateStart := time.Now()
 
-	algorithmCount := 0
-	if algorithms, ok := backupData["algorithms"].([]interface{}); ok {
-		algorithmCount = len(algorithms)
+	if counts.Algorithms == 0 && counts.PresetData == 0 {
+		fmt.Println("⚠️  WARNING: Backup is empty")
+		result.Warnings = append(result.Warnings, "backup is empty")
+	} else {
+		fmt.Printf("✅ (%.2fs)\n", time.Since(validateStart).Seconds())
+		fmt.Printf("   Found: %d algorithms, %d preset data, %d jobs\n", counts.Algorithms, counts.PresetData, counts.Jobs)
 	}
 
-	presetDataCount := 0
-	if presetData, ok := backupData["preset_data"].([]interface{}); ok {
-		presetDataCount = len(presetData)
+	// 清空数据前保存快照，事务失败或恢复后数据丢失时据此还原
+	fmt.Print("📸 Snapshotting current data... ")
+	snapshotPath, err := m.takePreRestoreSnapshot()
+	if err != nil {
+		fmt.Println("❌ FAILED")
+		return nil, err
 	}
-
-	if algorithmCount == 0 && presetDataCount == 0 {
-		fmt.Println("⚠️  WARNING: Backup is empty")
+	if snapshotPath == "" {
+		fmt.Println("skipped (database is empty)")
 	} else {
-		fmt.Printf("✅ (%.2fs)\n", time.Since(validateStart).Seconds())
-		fmt.Printf("   Found: %d algorithms, %d preset data\n", algorithmCount, presetDataCount)
+		fmt.Printf("✅ %s\n", snapshotPath)
 	}
 
 	// Step 3: 开始事务恢复（确保原子性）
@@ -602,16 +642,18 @@ func (m *SQLiteBackupManager) restoreFromBackup(ctx context.Context, metadata *B
 	tx := m.db.Begin()
 	if tx.Error != nil {
 		fmt.Println("❌ FAILED")
-		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
+		removeSnapshot(snapshotPath)
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
 	}
 
-	// 使用defer确保出错时回滚
+	// 使用defer确保出错时回滚，并从快照还原
 	var restoreErr error
 	defer func() {
 		if restoreErr != nil {
 			fmt.Print("🔙 Rolling back transaction... ")
 			tx.Rollback()
 			fmt.Println("✅")
+			m.rollbackToSnapshot(snapshotPath)
 		}
 	}()
 
@@ -621,75 +663,84 @@ func (m *SQLiteBackupManager) restoreFromBackup(ctx context.Context, metadata *B
 	fmt.Print("🗑️  [4/5] Clearing existing data... ")
 	clearStart := time.Now()
 
-	if err := tx.Exec("DELETE FROM algorithms").Error; err != nil {
-		fmt.Println("❌ FAILED")
-		restoreErr = fmt.Errorf("failed to clear algorithms: %w", err)
-		return restoreErr
-	}
-	if err := tx.Exec("DELETE FROM preset_data").Error; err != nil {
-		fmt.Println("❌ FAILED")
-		restoreErr = fmt.Errorf("failed to clear preset data: %w", err)
-		return restoreErr
+	for _, table := range restoredTables {
+		if err := tx.Exec("DELETE FROM " + table).Error; err != nil {
+			fmt.Println("❌ FAILED")
+			restoreErr = fmt.Errorf("failed to clear %s: %w", table, err)
+			return nil, restoreErr
+		}
 	}
 	fmt.Printf("✅ (%.2fs)\n", time.Since(clearStart).Seconds())
 
-	// 恢复算法数据（带进度）
+	// 再次读取备份并逐批导入。算法在备份中位于版本和任务之前，版本随算法一起创建；
+	// 旧备份在顶层重复保存了版本列表，补充算法中没有的版本，算法未恢复的版本因外键约束失败
 	fmt.Printf("📝 [5/5] Restoring data:\n")
 	restoreStart := time.Now()
 
-	restoredAlgorithms := 0
-	failedAlgorithms := 0
-	if algorithms, ok := backupData["algorithms"].([]interface{}); ok {
-		totalAlgorithms := len(algorithms)
-		lastProgress := 0
-
-		for i, alg := range algorithms {
-			if algMap, ok := alg.(map[string]interface{}); ok {
-				var algorithm models.Algorithm
-				algorithmData, _ := json.Marshal(algMap)
-				json.Unmarshal(algorithmData, &algorithm)
-
-				if result := tx.Create(&algorithm); result.Error != nil {
-					fmt.Printf("   ⚠️  Algorithm %s failed: %v\n", algorithm.ID, result.Error)
-					failedAlgorithms++
-				} else {
-					restoredAlgorithms++
-				}
-
-				// 显示进度（每10%或最后一条）
-				progress := (i + 1) * 100 / totalAlgorithms
-				if progress >= lastProgress+10 || i == totalAlgorithms-1 {
-					fmt.Printf("   Algorithms: %d/%d (%d%%)\n", i+1, totalAlgorithms, progress)
-					lastProgress = progress
-				}
+	var restoredAlgorithms, failedAlgorithms, failedVersions int
+	var restoredPresetData, failedPresetData, restoredJobs, failedJobs int
+	processedAlgorithms, lastProgress := 0, 0
+	imported, err := m.readBackupStream(ctx, metadata, backupHandlers{
+		algorithms: func(batch []models.Algorithm) error {
+			if err := migrateBackup(&Backup{Algorithms: batch}, schemaVersion); err != nil {
+				return err
 			}
-		}
-	}
-
-	// 恢复预设数据
-	restoredPresetData := 0
-	failedPresetData := 0
-	if presetData, ok := backupData["preset_data"].([]interface{}); ok {
-		totalPresetData := len(presetData)
-		for i, data := range presetData {
-			if dataMap, ok := data.(map[string]interface{}); ok {
-				var presetData models.PresetData
-				dataData, _ := json.Marshal(dataMap)
-				json.Unmarshal(dataData, &presetData)
-
-				if result := tx.Create(&presetData); result.Error != nil {
-					fmt.Printf("   ⚠️  PresetData %s failed: %v\n", presetData.ID, result.Error)
-					failedPresetData++
-				} else {
-					restoredPresetData++
-				}
+			restored, failed := createBatch(tx, m.restoreBatch, batch, func(a *models.Algorithm) string { return a.ID }, "algorithm", result)
+			restoredAlgorithms += restored
+			failedAlgorithms += failed
+
+			// 显示进度（每10%或最后一批）
+			processedAlgorithms += len(batch)
+			progress := processedAlgorithms * 100 / counts.Algorithms
+			if progress >= lastProgress+10 || processedAlgorithms == counts.Algorithms {
+				fmt.Printf("   Algorithms: %d/%d (%d%%)\n", processedAlgorithms, counts.Algorithms, progress)
+				lastProgress = progress
 			}
+			return nil
+		},
+		versions: func(batch []models.Version) error {
+			_, failed := firstOrCreateBatch(tx, m.restoreBatch, batch, func(v *models.Version) string { return v.ID }, "version", result)
+			failedVersions += failed
+			return nil
+		},
+		presetData: func(batch []models.PresetData) error {
+			restored, failed := createBatch(tx, m.restoreBatch, batch, func(p *models.PresetData) string { return p.ID }, "preset data", result)
+			restoredPresetData += restored
+			failedPresetData += failed
+			fmt.Printf("   Preset data: %d/%d\n", restoredPresetData+failedPresetData, counts.PresetData)
+			return nil
+		},
+		jobs: func(batch []models.Job) error {
+			restored, failed := firstOrCreateBatch(tx, m.restoreBatch, batch, func(j *models.Job) string { return j.ID }, "job", result)
+			restoredJobs += restored
+			failedJobs += failed
+			return nil
+		},
+	})
+	if err != nil {
+		fmt.Println("   ❌ FAILED")
+		restoreErr = fmt.Errorf("failed to restore backup: %w", err)
+		return nil, restoreErr
+	}
+	// 两次读取之间备份被替换时回滚
+	if err := verifyBackupHash(imported, metadata); err != nil {
+		fmt.Printf("   ❌ %v\n", err)
+		restoreErr = err
+		return nil, restoreErr
+	}
 
-			// 显示进度
-			if (i+1)%100 == 0 || i == totalPresetData-1 {
-				fmt.Printf("   Preset data: %d/%d\n", i+1, totalPresetData)
-			}
-		}
+	// 版本表已清空，导入后的行数即恢复的版本数（随算法创建的和旧备份补充的）
+	var versionCount int64
+	if err := tx.Model(&models.Version{}).Count(&versionCount).Error; err != nil {
+		restoreErr = fmt.Errorf("failed to count restored versions: %w", err)
+		return nil, restoreErr
+	}
+	restoredVersions := int(versionCount)
+	if counts.Versions > 0 {
+		fmt.Printf("   Versions: %d restored\n", restoredVersions)
+	}
+	if counts.Jobs > 0 {
+		fmt.Printf("   Jobs: %d/%d\n", restoredJobs, counts.Jobs)
 	}
 
 	fmt.Printf("   ✅ Restore completed (%.2fs)\n", time.Since(restoreStart).Seconds())
@@ -701,7 +752,7 @@ func (m *SQLiteBackupManager) restoreFromBackup(ctx context.Context, metadata *B
 	if err := tx.Commit().Error; err != nil {
 		fmt.Println("❌ FAILED")
 		restoreErr = fmt.Errorf("failed to commit transaction: %w", err)
-		return restoreErr
+		return nil, restoreErr
 	}
 	fmt.Printf("✅ (%.2fs)\n", time.Since(commitStart).Seconds())
 
@@ -709,15 +760,30 @@ func (m *SQLiteBackupManager) restoreFromBackup(ctx context.Context, metadata *B
 	fmt.Print("🔍 Verifying restored data... ")
 	verifyStart := time.Now()
 
-	var finalAlgCount, finalPresetCount int64
+	var finalAlgCount, finalVersionCount, finalPresetCount, finalJobCount int64
+	verified := false
 	if err := m.db.Model(&models.Algorithm{}).Count(&finalAlgCount).Error; err != nil {
 		fmt.Printf("⚠️  Warning: failed to verify: %v\n", err)
+	} else if err := m.db.Model(&models.Version{}).Count(&finalVersionCount).Error; err != nil {
+		fmt.Printf("⚠️  Warning: failed to verify: %v\n", err)
 	} else if err := m.db.Model(&models.PresetData{}).Count(&finalPresetCount).Error; err != nil {
 		fmt.Printf("⚠️  Warning: failed to verify: %v\n", err)
+	} else if err := m.db.Model(&models.Job{}).Count(&finalJobCount).Error; err != nil {
+		fmt.Printf("⚠️  Warning: failed to verify: %v\n", err)
 	} else {
+		verified = true
 		fmt.Printf("✅ (%.2fs)\n", time.Since(verifyStart).Seconds())
-		fmt.Printf("   Verified: %d algorithms, %d preset data in database\n", finalAlgCount, finalPresetCount)
+		fmt.Printf("   Verified: %d algorithms, %d versions, %d preset data, %d jobs in database\n",
+			finalAlgCount, finalVersionCount, finalPresetCount, finalJobCount)
+	}
+
+	// 备份中有算法但一个都没有恢复，保留恢复前的数据
+	if verified && counts.Algorithms > 0 && finalAlgCount == 0 {
+		fmt.Println("   ❌ No algorithms were restored")
+		m.rollbackToSnapshot(snapshotPath)
+		return nil, fmt.Errorf("%w: none of the %d algorithms in the backup were restored", ErrRestoreVerificationFailed, counts.Algorithms)
 	}
+	removeSnapshot(snapshotPath)
 
 	// 最终报告
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -727,15 +793,25 @@ func (m *SQLiteBackupManager) restoreFromBackup(ctx context.Context, metadata *B
 		fmt.Printf(", ⚠️  %d failed", failedAlgorithms)
 	}
 	fmt.Println()
+	fmt.Printf("   ✅ Versions: %d restored", restoredVersions)
+	if failedVersions > 0 {
+		fmt.Printf(", ⚠️  %d failed", failedVersions)
+	}
+	fmt.Println()
 	fmt.Printf("   ✅ Preset Data: %d restored", restoredPresetData)
 	if failedPresetData > 0 {
 		fmt.Printf(", ⚠️  %d failed", failedPresetData)
 	}
 	fmt.Println()
+	fmt.Printf("   ✅ Jobs: %d restored", restoredJobs)
+	if failedJobs > 0 {
+		fmt.Printf(", ⚠️  %d failed", failedJobs)
+	}
+	fmt.Println()
 	fmt.Printf("   ⏱️  Total time: %.2fs\n", time.Since(startTime).Seconds())
 
 	// 如果有失败项，警告但不中断启动
-	if failedAlgorithms > 0 || failedPresetData > 0 {
+	if failedAlgorithms > 0 || failedVersions > 0 || failedPresetData > 0 || failedJobs > 0 {
 		fmt.Println("   ⚠️  WARNING: Some items failed to restore")
 		fmt.Println("   ℹ️  Service will continue with successfully restored data")
 	}
@@ -745,98 +821,156 @@ func (m *SQLiteBackupManager) restoreFromBackup(ctx context.Context, metadata *B
 	// 更新数据库元数据为备份的版本
 	if err := m.restoreMetadataFromBackup(metadata); err != nil {
 		fmt.Printf("Warning: failed to update database metadata: %v\n", err)
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to update database metadata: %v", err))
 	}
 
+	result.table("algorithms").Restored = restoredAlgorithms
+	result.table("algorithms").Failed = failedAlgorithms
+	result.table("versions").Restored = restoredVersions
+	result.table("versions").Failed = failedVersions
+	result.table("preset_data").Restored = restoredPresetData
+	result.table("preset_data").Failed = failedPresetData
+	result.table("jobs").Restored = restoredJobs
+	result.table("jobs").Failed = failedJobs
+	result.Duration = time.Since(startTime)
+	m.lastRestore.Store(result)
+
+	return result, nil
+}
+
+// ErrBackupHashMismatch 备份内容与记录的 SHA-256 不一致（损坏或被截断），拒绝恢复
+var ErrBackupHashMismatch = errors.New("backup hash mismatch")
+
+// verifyBackupHash 校验读取到的备份内容与元数据中的 hash 一致。
+// 旧备份的元数据 hash 是列出备份时计算的，只能发现两次读取之间的改动，不能证明备份完好
+func verifyBackupHash(summary *backupSummary, metadata *BackupMetadata) error {
+	if summary.Hash != metadata.Hash {
+		return fmt.Errorf("%w: %s has sha256 %s, expected %s", ErrBackupHashMismatch, metadata.Path, summary.Hash, metadata.Hash)
+	}
 	return nil
 }
 
+// firstOrCreateAll 逐条创建数据库中还没有的记录，不保存关联，失败的记录写入 result 的警告。
+// 返回新建和失败的数量，已存在的记录不计入
+func firstOrCreateAll[T any](tx *gorm.DB, records []T, id func(*T) string, kind string, result *RestoreResult) (restored, failed int) {
+	for i := range records {
+		record := &records[i]
+		res := tx.Omit(clause.Associations).FirstOrCreate(record, "id = ?", id(record))
+		switch {
+		case res.Error != nil:
+			fmt.Printf("   ⚠️  %s %s failed: %v\n", kind, id(record), res.Error)
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s %s: %v", kind, id(record), res.Error))
+			failed++
+		case res.RowsAffected > 0:
+			restored++
+		}
+	}
+	return restored, failed
+}
+
 // restoreMetadataFromBackup 从备份恢复元数据
 func (m *SQLiteBackupManager) restoreMetadataFromBackup(backupMeta *BackupMetadata) error {
-	newMeta := models.DatabaseMetadata{
-		Version:       backupMeta.Version,
-		LastUpdatedAt: backupMeta.LastUpdatedAt,
-		UpdatedBy:     "backup_restore",
-		CheckpointAt:  time.Now(),
-		RecordCount:   backupMeta.RecordCount,
-	}
+	return setDatabaseMetadata(m.db, backupMeta.Version, "backup_restore", backupMeta.LastUpdatedAt)
+}
 
-	return m.db.Create(&newMeta).Error
+// BackupResult 一次 JSON 备份的结果
+type BackupResult struct {
+	Source      string    // "minio" or "local"
+	Path        string    // MinIO 对象路径或本地文件路径
+	Version     int64     // 备份时的数据版本号
+	RecordCount int64     // 备份时的记录数量
+	CreatedAt   time.Time // 备份时间
 }
 
 // BackupToMinIO 备份数据到 MinIO（优先）或本地（fallback）
 func (m *SQLiteBackupManager) BackupToMinIO() error {
+	_, err := m.RunBackup()
+	return err
+}
+
+// RunBackup 立即执行一次备份并返回 JSON 备份的位置，已有备份或恢复在进行时返回 ErrBackupBusy
+func (m *SQLiteBackupManager) RunBackup() (*BackupResult, error) {
+	if !m.opMu.TryLock() {
+		return nil, ErrBackupBusy
+	}
+	defer m.opMu.Unlock()
+
 	ctx := context.Background()
 
 	// 获取当前数据库元数据
 	meta, err := m.getDatabaseMetadata()
 	if err != nil {
-		return fmt.Errorf("failed to get database metadata: %w", err)
+		return nil, fmt.Errorf("failed to get database metadata: %w", err)
 	}
 
-	// 获取所有数据
+	// 获取所有数据，版本随算法一次预加载（恢复时也是随算法一起创建）
 	var algorithms []models.Algorithm
-	if err := m.db.Find(&algorithms).Error; err != nil {
-		return fmt.Errorf("failed to fetch algorithms: %w", err)
-	}
-
-	var versions []models.Version
-	if err := m.db.Find(&versions).Error; err != nil {
-		return fmt.Errorf("failed to fetch versions: %w", err)
-	}
-
-	for i := range algorithms {
-		if err := m.db.Model(&algorithms[i]).Association("Versions").Find(&algorithms[i].Versions); err != nil {
-			fmt.Printf("Failed to load versions for algorithm %s: %v\n", algorithms[i].ID, err)
-		}
+	if err := m.db.Preload("Versions").Find(&algorithms).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch algorithms: %w", err)
 	}
 
 	var presetData []models.PresetData
 	if err := m.db.Find(&presetData).Error; err != nil {
-		return fmt.Errorf("failed to fetch preset data: %w", err)
+		return nil, fmt.Errorf("failed to fetch preset data: %w", err)
 	}
 
 	var jobs []models.Job
 	if err := m.db.Find(&jobs).Error; err != nil {
-		return fmt.Errorf("failed to fetch jobs: %w", err)
+		return nil, fmt.Errorf("failed to fetch jobs: %w", err)
 	}
 
 	// 包含元数据的备份
-	backupData := map[string]interface{}{
-		"algorithms":  algorithms,
-		"versions":    versions,
-		"preset_data": presetData,
-		"jobs":        jobs,
-		"backuped_at": time.Now(),
-		"backup_type": "sqlite",
-		"metadata": map[string]interface{}{
-			"version":         meta.Version,
-			"record_count":    meta.RecordCount,
-			"last_updated_at": meta.LastUpdatedAt,
+	backup := Backup{
+		Algorithms: algorithms,
+		PresetData: presetData,
+		Jobs:       jobs,
+		BackupedAt: time.Now(),
+		BackupType: "sqlite",
+		Metadata: BackupInfo{
+			SchemaVersion: BackupSchemaVersion,
+			Version:       meta.Version,
+			RecordCount:   meta.RecordCount,
+			LastUpdatedAt: meta.LastUpdatedAt,
 		},
 	}
 
-	backupJSON, err := json.MarshalIndent(backupData, "", "  ")
+	backupJSON, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal backup data: %w", err)
+	}
+	compressed, header, err := compressBackup(backupJSON, backup.Metadata)
 	if err != nil {
-		return fmt.Errorf("failed to marshal backup data: %w", err)
+		return nil, err
 	}
 
-	timestamp := time.Now().Format("20060102-150405")
+	timestamp := backup.BackupedAt.Format("20060102-150405")
+	result := &BackupResult{
+		Source:      "minio",
+		Path:        m.objectKey(keys.BackupJSON(timestamp)),
+		Version:     meta.Version,
+		RecordCount: meta.RecordCount,
+		CreatedAt:   backup.BackupedAt,
+	}
 
 	// 优先备份到 MinIO
 	minioSuccess := false
-	if err := m.backupJSONToMinIO(ctx, backupJSON, timestamp); err != nil {
+	if err := m.backupJSONToMinIO(ctx, compressed, header, timestamp); err != nil {
 		fmt.Printf("Warning: MinIO JSON backup failed, falling back to local: %v\n", err)
 	} else {
 		minioSuccess = true
-		fmt.Printf("JSON backup saved to MinIO: backup-%s.json (version: %d)\n", timestamp, meta.Version)
+		fmt.Printf("JSON backup saved to MinIO: backup-%s.json.gz (version: %d, %d bytes compressed to %d)\n",
+			timestamp, meta.Version, len(backupJSON), len(compressed))
 	}
 
 	// MinIO 失败时才备份到本地
 	if !minioSuccess {
-		if err := m.saveLocalBackup(backupJSON, timestamp); err != nil {
-			return fmt.Errorf("both MinIO and local JSON backup failed: %w", err)
+		path, err := m.saveLocalBackup(compressed, timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("both MinIO and local JSON backup failed: %w", err)
 		}
-		fmt.Printf("JSON backup saved to local (fallback): backup-%s.json (version: %d)\n", timestamp, meta.Version)
+		result.Source, result.Path = "local", path
+		fmt.Printf("JSON backup saved to local (fallback): backup-%s.json.gz (version: %d, %d bytes compressed to %d)\n",
+			timestamp, meta.Version, len(backupJSON), len(compressed))
 	}
 
 	// 备份数据库文件（同样优先 MinIO）
@@ -858,110 +992,132 @@ func (m *SQLiteBackupManager) BackupToMinIO() error {
 	}
 
 	// 异步清理旧备份
-	go m.cleanupOldBackups()
+	m.background.Add(1)
+	go func() {
+		defer m.background.Done()
+		m.cleanupOldBackups()
+	}()
 
-	return nil
+	return result, nil
 }
 
-// backupJSONToMinIO 将 JSON 备份上传到 MinIO
-func (m *SQLiteBackupManager) backupJSONToMinIO(ctx context.Context, backupJSON []byte, timestamp string) error {
-	// 上传带时间戳的备份
-	backupPath := fmt.Sprintf("database-backup/backup-%s.json", timestamp)
-	_, err := m.minio.PutObject(ctx, m.bucketName, backupPath,
-		bytes.NewReader(backupJSON), int64(len(backupJSON)),
-		minio.PutObjectOptions{
-			ContentType: "application/json",
+// backupJSONToMinIO 将压缩后的 JSON 备份上传到 MinIO，摘要同时写入对象的用户元数据
+func (m *SQLiteBackupManager) backupJSONToMinIO(ctx context.Context, compressed []byte, header *backupHeader, timestamp string) error {
+	putJSON := func(objectPath string) error {
+		return retry.Do(ctx, minioRetryPolicy(objectPath), func() error {
+			_, err := m.minio.PutObject(ctx, m.bucketName, objectPath,
+				bytes.NewReader(compressed), int64(len(compressed)),
+				minio.PutObjectOptions{
+					ContentType:  "application/gzip",
+					UserMetadata: header.fields(),
+				})
+			return err
 		})
-	if err != nil {
+	}
+
+	// 上传带时间戳的备份
+	backupPath := m.objectKey(keys.BackupJSON(timestamp))
+	if err := putJSON(backupPath); err != nil {
 		return fmt.Errorf("failed to upload backup to MinIO: %w", err)
 	}
 
 	// 更新 latest 备份
-	latestPath := "database-backup/latest.json"
-	_, err = m.minio.PutObject(ctx, m.bucketName, latestPath,
-		bytes.NewReader(backupJSON), int64(len(backupJSON)),
-		minio.PutObjectOptions{
-			ContentType: "application/json",
-		})
-	if err != nil {
+	if err := putJSON(m.objectKey(keys.BackupLatestJSON)); err != nil {
 		return fmt.Errorf("failed to update latest backup: %w", err)
 	}
 
 	return nil
 }
 
-// saveLocalBackup 保存本地 JSON 备份
-func (m *SQLiteBackupManager) saveLocalBackup(data []byte, timestamp string) error {
-	backupDir := "./data/backups"
+// isRetryableMinIOError 地址无法解析或 4xx（权限、参数错误）重试也不会成功，其余错误视为暂时性故障
+func isRetryableMinIOError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+
+	code := minio.ToErrorResponse(err).StatusCode
+	if code >= 400 && code < 500 && code != http.StatusRequestTimeout && code != http.StatusTooManyRequests {
+		return false
+	}
+	return true
+}
+
+// minioRetryPolicy 备份上传的重试策略，应对 MinIO 短暂不可用
+func minioRetryPolicy(objectPath string) retry.Policy {
+	policy := retry.Default()
+	policy.BaseDelay = 500 * time.Millisecond
+	policy.Retryable = isRetryableMinIOError
+	policy.OnRetry = func(attempt int, delay time.Duration, err error) {
+		fmt.Printf("Warning: upload of %s failed, retrying in %v: %v\n", objectPath, delay.Round(time.Millisecond), err)
+	}
+	return policy
+}
+
+// localJSONBackupPattern 本地 JSON 备份文件，包括压缩的 .json.gz 和压缩前的 .json
+const localJSONBackupPattern = "backup-*.json*"
+
+// saveLocalBackup 保存本地 JSON 备份，返回备份文件路径
+func (m *SQLiteBackupManager) saveLocalBackup(data []byte, timestamp string) (string, error) {
+	backupDir := m.localBackupDir
 	if err := os.MkdirAll(backupDir, 0755); err != nil {
-		return fmt.Errorf("failed to create backup directory: %w", err)
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
 	}
 
-	backupFile := filepath.Join(backupDir, fmt.Sprintf("backup-%s.json", timestamp))
+	backupFile := filepath.Join(backupDir, fmt.Sprintf("backup-%s.json.gz", timestamp))
 	if err := os.WriteFile(backupFile, data, 0644); err != nil {
-		return fmt.Errorf("failed to write backup file: %w", err)
+		return "", fmt.Errorf("failed to write backup file: %w", err)
 	}
 
-	return nil
+	return backupFile, nil
 }
 
 // saveLocalDBBackup 保存本地数据库文件备份
 func (m *SQLiteBackupManager) saveLocalDBBackup(timestamp string) error {
-	backupDir := "./data/backups"
+	backupDir := m.localBackupDir
 	if err := os.MkdirAll(backupDir, 0755); err != nil {
 		return fmt.Errorf("failed to create backup directory: %w", err)
 	}
 
-	// 读取数据库文件
-	data, err := os.ReadFile(m.dbPath)
-	if err != nil {
-		return fmt.Errorf("failed to read database file: %w", err)
-	}
-
-	// 保存到本地
 	backupFile := filepath.Join(backupDir, fmt.Sprintf("db-backup-%s.db", timestamp))
-	if err := os.WriteFile(backupFile, data, 0644); err != nil {
-		return fmt.Errorf("failed to write database backup file: %w", err)
-	}
-
-	return nil
+	return snapshotDBFile(m.db, backupFile)
 }
 
 // cleanupOldBackups 清理旧备份（MinIO 和本地）
 func (m *SQLiteBackupManager) cleanupOldBackups() {
-	// ctx := context.Background()
-
-	// // 清理 MinIO 旧的 JSON 备份（保留最近 10 个）
-	// jsonBackups := m.listBackupsByPrefix(ctx, "database-backup/backup-")
-	// if len(jsonBackups) > 10 {
-	// 	for _, key := range jsonBackups[:len(jsonBackups)-10] {
-	// 		if err := m.minio.RemoveObject(ctx, m.bucketName, key, minio.RemoveObjectOptions{}); err != nil {
-	// 			fmt.Printf("Failed to delete old MinIO JSON backup %s: %v\n", key, err)
-	// 		} else {
-	// 			fmt.Printf("Deleted old MinIO JSON backup: %s\n", key)
-	// 		}
-	// 	}
-	// }
-
-	// // 清理 MinIO 旧的数据库文件备份（保留最近 5 个）
-	// dbBackups := m.listBackupsByPrefix(ctx, "database-backup/db-backup-")
-	// if len(dbBackups) > 5 {
-	// 	for _, key := range dbBackups[:len(dbBackups)-5] {
-	// 		if err := m.minio.RemoveObject(ctx, m.bucketName, key, minio.RemoveObjectOptions{}); err != nil {
-	// 			fmt.Printf("Failed to delete old MinIO DB backup %s: %v\n", key, err)
-	// 		} else {
-	// 			fmt.Printf("Deleted old MinIO DB backup: %s\n", key)
-	// 		}
-	// 	}
-	// }
+	m.cleanupMinIOBackups(context.Background(), m.minio)
 
 	// 清理本地旧备份
 	m.cleanupLocalBackups()
 }
 
-// listBackupsByPrefix 列出指定前缀的备份文件
-func (m *SQLiteBackupManager) listBackupsByPrefix(ctx context.Context, prefix string) []string {
-	objectCh := m.minio.ListObjects(ctx, m.bucketName, minio.ListObjectsOptions{
+// backupObjectStore 清理 MinIO 备份所需的操作，*minio.Client 实现了该接口
+type backupObjectStore interface {
+	ListObjects(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo
+	storage.ObjectRemover
+}
+
+// cleanupMinIOBackups 删除 MinIO 中超出保留数量的最旧的 JSON 和数据库文件备份，一次批量删除
+func (m *SQLiteBackupManager) cleanupMinIOBackups(ctx context.Context, store backupObjectStore) {
+	var stale []string
+	if jsonBackups := m.listBackupsByPrefix(ctx, store, m.objectKey(keys.BackupJSONPrefix)); len(jsonBackups) > m.jsonRetention {
+		stale = append(stale, jsonBackups[:len(jsonBackups)-m.jsonRetention]...)
+	}
+	if dbBackups := m.listBackupsByPrefix(ctx, store, m.objectKey(keys.BackupDBPrefix)); len(dbBackups) > m.dbRetention {
+		stale = append(stale, dbBackups[:len(dbBackups)-m.dbRetention]...)
+	}
+	if len(stale) > 0 {
+		failed := storage.RemoveObjects(ctx, store, m.bucketName, stale)
+		for key, err := range failed {
+			fmt.Printf("Failed to delete old MinIO backup %s: %v\n", key, err)
+		}
+		fmt.Printf("Deleted %d old MinIO backups\n", len(stale)-len(failed))
+	}
+}
+
+// listBackupsByPrefix 列出指定前缀的带时间戳备份，按时间从旧到新排序，不包含 latest 和 final 备份
+func (m *SQLiteBackupManager) listBackupsByPrefix(ctx context.Context, store backupObjectStore, prefix string) []string {
+	objectCh := store.ListObjects(ctx, m.bucketName, minio.ListObjectsOptions{
 		Prefix:    prefix,
 		Recursive: true,
 	})
@@ -969,11 +1125,12 @@ func (m *SQLiteBackupManager) listBackupsByPrefix(ctx context.Context, prefix st
 	var backups []string
 	for object := range objectCh {
 		if object.Err != nil {
+			// 列表不完整时不删除，避免误删较新的备份
 			fmt.Printf("Error listing backups: %v\n", object.Err)
-			return backups
+			return nil
 		}
 		// 排除 latest 文件
-		if object.Key != "database-backup/latest.json" && object.Key != "database-backup/latest.db" && object.Key != "database-backup/final-backup.db" {
+		if object.Key != m.objectKey(keys.BackupLatestJSON) && object.Key != m.objectKey(keys.BackupLatestDB) && object.Key != m.objectKey(keys.BackupFinalDB) {
 			backups = append(backups, object.Key)
 		}
 	}
@@ -982,16 +1139,16 @@ func (m *SQLiteBackupManager) listBackupsByPrefix(ctx context.Context, prefix st
 	return backups
 }
 
-// cleanupLocalBackups 清理本地旧备份（JSON 和数据库文件）
+// cleanupLocalBackups 清理本地旧备份（JSON 和数据库文件），保留数量与 MinIO 中相同
 func (m *SQLiteBackupManager) cleanupLocalBackups() {
-	backupDir := "./data/backups"
+	backupDir := m.localBackupDir
 
-	// 清理 JSON 备份（保留最近 5 个）
-	jsonFiles, err := filepath.Glob(filepath.Join(backupDir, "backup-*.json"))
+	// 清理 JSON 备份（保留最近 jsonRetention 个）
+	jsonFiles, err := filepath.Glob(filepath.Join(backupDir, localJSONBackupPattern))
 	if err == nil {
 		sort.Strings(jsonFiles)
-		if len(jsonFiles) > 5 {
-			for _, file := range jsonFiles[:len(jsonFiles)-5] {
+		if len(jsonFiles) > m.jsonRetention {
+			for _, file := range jsonFiles[:len(jsonFiles)-m.jsonRetention] {
 				if err := os.Remove(file); err != nil {
 					fmt.Printf("Failed to delete local JSON backup %s: %v\n", file, err)
 				} else {
@@ -1001,12 +1158,12 @@ func (m *SQLiteBackupManager) cleanupLocalBackups() {
 		}
 	}
 
-	// 清理数据库文件备份（保留最近 3 个）
+	// 清理数据库文件备份（保留最近 dbRetention 个）
 	dbFiles, err := filepath.Glob(filepath.Join(backupDir, "db-backup-*.db"))
 	if err == nil {
 		sort.Strings(dbFiles)
-		if len(dbFiles) > 3 {
-			for _, file := range dbFiles[:len(dbFiles)-3] {
+		if len(dbFiles) > m.dbRetention {
+			for _, file := range dbFiles[:len(dbFiles)-m.dbRetention] {
 				if err := os.Remove(file); err != nil {
 					fmt.Printf("Failed to delete local DB backup %s: %v\n", file, err)
 				} else {
@@ -1019,123 +1176,155 @@ func (m *SQLiteBackupManager) cleanupLocalBackups() {
 
 // StartBackupScheduler 启动备份调度器
 func (m *SQLiteBackupManager) StartBackupScheduler() error {
-	ticker := time.NewTicker(m.backupInterval)
+	ticker := time.NewTicker(m.interval())
 
+	m.background.Add(1)
 	go func() {
+		defer m.background.Done()
 		defer ticker.Stop()
 		for {
 			select {
 			case <-m.stopBackup:
 				return
+			case <-m.intervalReset:
+				ticker.Reset(m.interval())
 			case <-ticker.C:
-				if err := m.BackupToMinIO(); err != nil {
+				if err := m.BackupToMinIO(); errors.Is(err, ErrBackupBusy) {
+					fmt.Println("SQLite backup skipped: another backup or restore is in progress")
+				} else if err != nil {
 					fmt.Printf("SQLite backup failed: %v\n", err)
 				}
 			}
 		}
 	}()
 
-	fmt.Printf("SQLite backup scheduler started (interval: %v)\n", m.backupInterval)
+	fmt.Printf("SQLite backup scheduler started (interval: %v)\n", m.interval())
 	return nil
 }
 
-// Stop 停止备份调度器
+// Stop 停止备份调度器，等待进行中的定时备份和旧备份清理结束，可重复调用
 func (m *SQLiteBackupManager) Stop() {
-	close(m.stopBackup)
-	fmt.Println("SQLite backup scheduler stopped")
+	m.stopOnce.Do(func() {
+		close(m.stopBackup)
+		fmt.Println("SQLite backup scheduler stopped")
+	})
+	m.background.Wait()
 }
 
-// SetBackupInterval 设置备份间隔
+// SetBackupInterval 设置备份间隔，调度器已启动时从现在起按新间隔计时
 func (m *SQLiteBackupManager) SetBackupInterval(interval time.Duration) {
-	m.backupInterval = interval
+	if interval <= 0 || time.Duration(m.backupInterval.Swap(int64(interval))) == interval {
+		return
+	}
+	select {
+	case m.intervalReset <- struct{}{}:
+	default:
+	}
+}
+
+// interval 返回当前的定时备份间隔
+func (m *SQLiteBackupManager) interval() time.Duration {
+	return time.Duration(m.backupInterval.Load())
 }
 
 // backupDBFileToMinIO 备份数据库文件到 MinIO
 func (m *SQLiteBackupManager) backupDBFileToMinIO(timestamp string) error {
 	ctx := context.Background()
 
-	// 读取数据库文件
-	dbFile, err := os.Open(m.dbPath)
-	if err != nil {
-		return fmt.Errorf("failed to open database file: %w", err)
-	}
-	defer dbFile.Close()
-
-	// 获取文件大小
-	fileInfo, err := dbFile.Stat()
-	if err != nil {
-		return fmt.Errorf("failed to stat database file: %w", err)
+	// 先生成一致性快照，再上传快照文件
+	snapshotPath := filepath.Join(os.TempDir(), fmt.Sprintf("db-backup-%s-%d.db", timestamp, time.Now().UnixNano()))
+	if err := snapshotDBFile(m.db, snapshotPath); err != nil {
+		return err
 	}
+	defer os.Remove(snapshotPath)
 
 	// 上传到 MinIO（带时间戳）
-	dbBackupPath := fmt.Sprintf("database-backup/db-backup-%s.db", timestamp)
-	_, err = m.minio.PutObject(ctx, m.bucketName, dbBackupPath,
-		dbFile, fileInfo.Size(),
-		minio.PutObjectOptions{
-			ContentType: "application/octet-stream",
-		})
-	if err != nil {
+	dbBackupPath := m.objectKey(keys.BackupDB(timestamp))
+	if err := m.uploadDBFile(ctx, dbBackupPath, snapshotPath); err != nil {
 		return fmt.Errorf("failed to upload database file to MinIO: %w", err)
 	}
 
-	// 重新打开文件用于 latest 备份
-	dbFile.Seek(0, 0)
-
 	// 更新 latest 数据库文件
-	latestDBPath := "database-backup/latest.db"
-	_, err = m.minio.PutObject(ctx, m.bucketName, latestDBPath,
-		dbFile, fileInfo.Size(),
-		minio.PutObjectOptions{
-			ContentType: "application/octet-stream",
-		})
-	if err != nil {
+	if err := m.uploadDBFile(ctx, m.objectKey(keys.BackupLatestDB), snapshotPath); err != nil {
 		return fmt.Errorf("failed to update latest database file: %w", err)
 	}
 
-	// 只打印简短的文件名，避免重复
 	return nil
 }
 
-// BackupDBFile 手动备份数据库文件到 MinIO（给 sqlite.go 调用）
-func (m *SQLiteBackupManager) BackupDBFile(destPath string) error {
-	// 删除已存在的备份文件（如果存在）
-	if _, err := os.Stat(destPath); err == nil {
-		if err := os.Remove(destPath); err != nil {
-			return fmt.Errorf("failed to remove existing backup file: %w", err)
+// uploadDBFile 上传本地数据库文件到 MinIO
+func (m *SQLiteBackupManager) uploadDBFile(ctx context.Context, objectPath, filePath string) error {
+	return retry.Do(ctx, minioRetryPolicy(objectPath), func() error {
+		dbFile, err := os.Open(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to open database file: %w", err)
 		}
-	}
+		defer dbFile.Close()
 
-	// 执行 VACUUM INTO 创建本地备份
-	sqlDB, err := m.db.DB()
+		fileInfo, err := dbFile.Stat()
+		if err != nil {
+			return fmt.Errorf("failed to stat database file: %w", err)
+		}
+
+		_, err = m.minio.PutObject(ctx, m.bucketName, objectPath,
+			dbFile, fileInfo.Size(),
+			minio.PutObjectOptions{
+				ContentType: "application/octet-stream",
+			})
+		return err
+	})
+}
+
+// snapshotDBFile 生成数据库文件的一致性快照，所有数据库文件备份都经由此处
+// 先 checkpoint 将 WAL 合并回主文件，再通过 VACUUM INTO 复制，保证最近提交的事务包含在备份中
+func snapshotDBFile(db *gorm.DB, destPath string) error {
+	sqlDB, err := db.DB()
 	if err != nil {
 		return fmt.Errorf("failed to get database instance: %w", err)
 	}
 
-	query := fmt.Sprintf("VACUUM INTO '%s'", destPath)
-	if _, err := sqlDB.Exec(query); err != nil {
+	var busy, logFrames, checkpointed int
+	if err := sqlDB.QueryRow("PRAGMA wal_checkpoint(TRUNCATE)").Scan(&busy, &logFrames, &checkpointed); err != nil {
+		return fmt.Errorf("checkpoint before database file backup failed: %w", err)
+	}
+	if busy != 0 {
+		// VACUUM INTO 读取的是一致性视图，checkpoint 未完成也不会丢数据，这里只提示
+		fmt.Printf("Warning: checkpoint incomplete before backup (%d of %d WAL frames)\n", checkpointed, logFrames)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	// VACUUM INTO 要求目标文件不存在
+	if _, err := os.Stat(destPath); err == nil {
+		if err := os.Remove(destPath); err != nil {
+			return fmt.Errorf("failed to remove existing backup file: %w", err)
+		}
+	}
+
+	if _, err := sqlDB.Exec("VACUUM INTO ?", destPath); err != nil {
 		return fmt.Errorf("VACUUM INTO failed: %w", err)
 	}
 
-	// 上传到 MinIO
-	ctx := context.Background()
-	dbFile, err := os.Open(destPath)
-	if err != nil {
-		return fmt.Errorf("failed to open backup file: %w", err)
+	return nil
+}
+
+// BackupDBFile 手动备份数据库文件到 MinIO（给 sqlite.go 调用）
+func (m *SQLiteBackupManager) BackupDBFile(destPath string) error {
+	if !m.opMu.TryLock() {
+		return ErrBackupBusy
 	}
-	defer dbFile.Close()
+	defer m.opMu.Unlock()
 
-	fileInfo, err := dbFile.Stat()
-	if err != nil {
-		return fmt.Errorf("failed to stat backup file: %w", err)
+	// 创建本地快照
+	if err := snapshotDBFile(m.db, destPath); err != nil {
+		return err
 	}
 
-	backupPath := "database-backup/final-backup.db"
-	_, err = m.minio.PutObject(ctx, m.bucketName, backupPath,
-		dbFile, fileInfo.Size(),
-		minio.PutObjectOptions{
-			ContentType: "application/octet-stream",
-		})
-	if err != nil {
+	// 上传到 MinIO
+	backupPath := m.objectKey(keys.BackupFinalDB)
+	if err := m.uploadDBFile(context.Background(), backupPath, destPath); err != nil {
 		return fmt.Errorf("failed to upload final backup to MinIO: %w", err)
 	}
 
@@ -0,0 +1,859 @@
+package database
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/keys"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"github.com/minio/minio-go/v7"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestBackupManager 创建使用临时 SQLite 文件和模拟 MinIO 的备份管理器
+func newTestBackupManager(t testing.TB, client *minio.Client) *SQLiteBackupManager {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	db, err := gorm.Open(sqlite.Open(dbPath+"?_journal_mode=WAL"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := models.AutoMigrate(db); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	m := &SQLiteBackupManager{
+		db:             db,
+		minio:          client,
+		bucketName:     "test",
+		stopBackup:     make(chan struct{}),
+		intervalReset:  make(chan struct{}, 1),
+		dbPath:         dbPath,
+		jsonRetention:  config.DefaultBackupJSONRetention,
+		dbRetention:    config.DefaultBackupDBRetention,
+		localBackupDir: filepath.Join(filepath.Dir(dbPath), "backups"),
+		restoreBatch:   config.DefaultRestoreBatchSize,
+	}
+	m.backupInterval.Store(int64(time.Minute))
+	return m
+}
+
+func TestBackupOperationsAreMutuallyExclusive(t *testing.T) {
+	fake, client := testutil.NewS3(t)
+	started, release := fake.BlockPuts()
+	m := newTestBackupManager(t, client)
+
+	tmpDir := t.TempDir()
+	firstDone := make(chan error, 1)
+	go func() {
+		firstDone <- m.BackupDBFile(filepath.Join(tmpDir, "first.db"))
+	}()
+
+	// 等待第一次备份进入上传阶段
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for first backup to start uploading")
+	}
+
+	if err := m.BackupDBFile(filepath.Join(tmpDir, "second.db")); !errors.Is(err, ErrBackupBusy) {
+		t.Errorf("Expected ErrBackupBusy for concurrent file backup, got %v", err)
+	}
+	if err := m.BackupToMinIO(); !errors.Is(err, ErrBackupBusy) {
+		t.Errorf("Expected ErrBackupBusy for concurrent JSON backup, got %v", err)
+	}
+	if _, err := m.restoreFromBackup(t.Context(), &BackupMetadata{Source: "local", Hash: strings.Repeat("0", 16)}); !errors.Is(err, ErrBackupBusy) {
+		t.Errorf("Expected ErrBackupBusy for concurrent restore, got %v", err)
+	}
+
+	release()
+	if err := <-firstDone; err != nil {
+		t.Fatalf("First backup failed: %v", err)
+	}
+
+	// 第一次备份结束后应可以再次备份
+	if err := m.BackupDBFile(filepath.Join(tmpDir, "third.db")); err != nil {
+		t.Errorf("Expected backup to succeed after previous one finished, got %v", err)
+	}
+}
+
+func TestDBFileBackupIncludesUncheckpointedWrites(t *testing.T) {
+	fake, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+
+	// 写入后立即备份，此时数据仍在 WAL 文件中
+	for i := 0; i < 3; i++ {
+		algo := models.Algorithm{ID: fmt.Sprintf("algo_%d", i), Name: "test"}
+		if err := m.db.Create(&algo).Error; err != nil {
+			t.Fatalf("Failed to create algorithm: %v", err)
+		}
+	}
+
+	if err := m.backupDBFileToMinIO("20260101-000000"); err != nil {
+		t.Fatalf("Database file backup failed: %v", err)
+	}
+
+	data, _ := fake.Object("database-backup/db-backup-20260101-000000.db")
+	if len(data) == 0 {
+		t.Fatal("Expected database file to be uploaded")
+	}
+
+	// 用上传的文件恢复出一个新数据库，确认数据没有丢失
+	restoredPath := filepath.Join(t.TempDir(), "restored.db")
+	if err := os.WriteFile(restoredPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write restored database: %v", err)
+	}
+	restored, err := gorm.Open(sqlite.Open(restoredPath), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("Failed to open restored database: %v", err)
+	}
+	defer func() {
+		if sqlDB, err := restored.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}()
+
+	var count int64
+	if err := restored.Model(&models.Algorithm{}).Count(&count).Error; err != nil {
+		t.Fatalf("Failed to count restored algorithms: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 algorithms in restored backup, got %d", count)
+	}
+}
+
+func TestFileBackupsContainRecentWrites(t *testing.T) {
+	fake, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+
+	if err := m.db.Create(&models.Algorithm{ID: "algo_recent", Name: "recent"}).Error; err != nil {
+		t.Fatalf("Failed to create algorithm: %v", err)
+	}
+
+	destPath := filepath.Join(t.TempDir(), "final.db")
+	if err := m.BackupDBFile(destPath); err != nil {
+		t.Fatalf("BackupDBFile failed: %v", err)
+	}
+
+	uploadedPath := filepath.Join(t.TempDir(), "uploaded.db")
+	uploaded, _ := fake.Object("database-backup/final-backup.db")
+	if err := os.WriteFile(uploadedPath, uploaded, 0644); err != nil {
+		t.Fatalf("Failed to write uploaded backup: %v", err)
+	}
+
+	for _, path := range []string{destPath, uploadedPath} {
+		backup, err := gorm.Open(sqlite.Open(path), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		if err != nil {
+			t.Fatalf("Failed to open backup %s: %v", path, err)
+		}
+
+		var algo models.Algorithm
+		if err := backup.First(&algo, "id = ?", "algo_recent").Error; err != nil {
+			t.Errorf("Recent write missing from backup %s: %v", path, err)
+		}
+
+		if sqlDB, err := backup.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
+}
+
+func TestRestoreReportsPartialFailures(t *testing.T) {
+	_, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+
+	// 重复的算法 ID 会导致第二条记录插入失败
+	backup := `{
+		"algorithms": [
+			{"id": "algo_dup", "name": "first"},
+			{"id": "algo_dup", "name": "second"},
+			{"id": "algo_ok", "name": "ok"}
+		],
+		"preset_data": []
+	}`
+	result, err := m.restoreFromBackup(t.Context(), writeTestBackup(t, backup))
+	if err != nil {
+		t.Fatalf("Partial restore should not return an error, got %v", err)
+	}
+
+	if !result.Partial() {
+		t.Error("Expected restore to be reported as partial")
+	}
+	algorithms := result.Tables["algorithms"]
+	if algorithms == nil || algorithms.Restored != 2 || algorithms.Failed != 1 {
+		t.Errorf("Unexpected algorithms result: %+v", algorithms)
+	}
+	if len(result.Warnings) == 0 {
+		t.Error("Expected a warning for the failed record")
+	}
+	if m.LastRestoreResult() != result {
+		t.Error("Expected LastRestoreResult to return the latest result")
+	}
+}
+
+func TestBackupObjectsUseKeyPrefix(t *testing.T) {
+	fake, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+	m.keyPrefix = "staging/"
+
+	if err := m.BackupDBFile(filepath.Join(t.TempDir(), "final.db")); err != nil {
+		t.Fatalf("BackupDBFile failed: %v", err)
+	}
+
+	if _, ok := fake.Object("staging/database-backup/final-backup.db"); !ok {
+		t.Error("Expected backup to be stored under the key prefix")
+	}
+	if _, ok := fake.Object("database-backup/final-backup.db"); ok {
+		t.Error("Expected no backup outside the key prefix")
+	}
+}
+
+func writeTestBackup(t *testing.T, backup string) *BackupMetadata {
+	t.Helper()
+	backupPath := filepath.Join(t.TempDir(), "backup.json")
+	if err := os.WriteFile(backupPath, []byte(backup), 0644); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+	meta, err := readLocalBackupMetadata(backupPath)
+	if err != nil {
+		t.Fatalf("Failed to read backup metadata: %v", err)
+	}
+	return meta
+}
+
+// decodeBackup 完整解析备份内容，用于检查备份
+func decodeBackup(r io.Reader, backup *Backup) error {
+	content, _, err := backupContent(r)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	return json.NewDecoder(content).Decode(backup)
+}
+
+func TestUpgradeLegacyBackupSchema(t *testing.T) {
+	// 没有 schema_version 的旧备份，算法没有 status 字段
+	var backup Backup
+	legacy := `{"algorithms": [{"id": "algo_old"}, {"id": "algo_disabled", "status": "disabled"}]}`
+	if err := json.Unmarshal([]byte(legacy), &backup); err != nil {
+		t.Fatalf("Failed to parse backup: %v", err)
+	}
+
+	from, err := upgradeBackup(&backup)
+	if err != nil {
+		t.Fatalf("upgradeBackup failed: %v", err)
+	}
+	if from != 1 {
+		t.Errorf("from = %d, want 1", from)
+	}
+	if backup.Algorithms[0].Status != models.AlgorithmStatusReady {
+		t.Errorf("legacy algorithm status = %q, want %q", backup.Algorithms[0].Status, models.AlgorithmStatusReady)
+	}
+	if backup.Algorithms[1].Status != models.AlgorithmStatusDisabled {
+		t.Errorf("existing status was overwritten: %q", backup.Algorithms[1].Status)
+	}
+}
+
+func TestDecodeBackupAcceptsCompressedAndLegacyJSON(t *testing.T) {
+	plain := []byte(`{"algorithms": [{"id": "algo_1"}], "metadata": {"version": 3}}`)
+	compressed, _, err := compressBackup(plain, BackupInfo{})
+	if err != nil {
+		t.Fatalf("compressBackup failed: %v", err)
+	}
+	if len(compressed) == 0 || !bytes.HasPrefix(compressed, gzipMagic) {
+		t.Fatal("Expected gzip output")
+	}
+
+	for name, data := range map[string][]byte{"gzip": compressed, "plain": plain} {
+		var backup Backup
+		if err := decodeBackup(bytes.NewReader(data), &backup); err != nil {
+			t.Errorf("%s: decodeBackup failed: %v", name, err)
+			continue
+		}
+		if len(backup.Algorithms) != 1 || backup.Metadata.Version != 3 {
+			t.Errorf("%s: unexpected backup %+v", name, backup)
+		}
+	}
+}
+
+func TestRestoreRefusesCorruptBackup(t *testing.T) {
+	_, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+	seedAlgorithms(t, m.db, 3)
+
+	original := []byte(`{"algorithms": [{"id": "algo_old", "name": "old"}], "metadata": {"schema_version": 2}}`)
+	corrupted := bytes.Replace(original, []byte(`"old"}`), []byte(`"olc"}`), 1)
+
+	// gzip 头中记录的是原始内容的 hash，内容在备份后被改动
+	hash := sha256.Sum256(original)
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Comment = hashCommentPrefix + hex.EncodeToString(hash[:])
+	zw.Write(corrupted)
+	zw.Close()
+	tampered := writeTestBackup(t, buf.String())
+
+	// 未压缩的旧备份在读取元数据之后被改动
+	legacy := writeTestBackup(t, string(original))
+	if err := os.WriteFile(legacy.Path, corrupted, 0644); err != nil {
+		t.Fatalf("Failed to corrupt backup: %v", err)
+	}
+
+	for name, meta := range map[string]*BackupMetadata{"gzip": tampered, "legacy": legacy} {
+		if _, err := m.restoreFromBackup(t.Context(), meta); !errors.Is(err, ErrBackupHashMismatch) {
+			t.Errorf("%s: expected ErrBackupHashMismatch, got %v", name, err)
+		}
+	}
+
+	var count int64
+	m.db.Model(&models.Algorithm{}).Count(&count)
+	if count != 3 {
+		t.Errorf("Refused restore should keep the current data, found %d algorithms", count)
+	}
+}
+
+func TestRestoreReportsLegacyBackupUnverified(t *testing.T) {
+	_, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+
+	content := `{"algorithms": [{"id": "algo_1", "name": "one"}], "metadata": {"schema_version": 2}}`
+	compressed, _, err := compressBackup([]byte(content), BackupInfo{SchemaVersion: BackupSchemaVersion})
+	if err != nil {
+		t.Fatalf("compressBackup failed: %v", err)
+	}
+	const unverified = "backup has no recorded sha256, integrity not verified"
+
+	for name, tt := range map[string]struct {
+		backup   string
+		recorded bool
+	}{
+		"gzip":   {string(compressed), true},
+		"legacy": {content, false},
+	} {
+		meta := writeTestBackup(t, tt.backup)
+		if meta.HashRecorded != tt.recorded {
+			t.Errorf("%s: HashRecorded = %v, want %v", name, meta.HashRecorded, tt.recorded)
+		}
+		result, err := m.restoreFromBackup(t.Context(), meta)
+		if err != nil {
+			t.Fatalf("%s: restore failed: %v", name, err)
+		}
+		if slices.Contains(result.Warnings, unverified) == tt.recorded {
+			t.Errorf("%s: unexpected warnings %v", name, result.Warnings)
+		}
+	}
+}
+
+func TestRestoreRollsBackToSnapshot(t *testing.T) {
+	_, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+	seedAlgorithms(t, m.db, 3)
+	backup := writeTestBackup(t, `{"algorithms": [{"id": "algo_new", "name": "new"}], "metadata": {"schema_version": 2}}`)
+
+	// 模拟所有算法都插入失败：事务本身提交成功，但恢复后没有算法
+	if err := m.db.Callback().Create().Before("gorm:create").Register("test:fail_algorithms", func(db *gorm.DB) {
+		if db.Statement.Table == "algorithms" {
+			db.AddError(errors.New("disk I/O error"))
+		}
+	}); err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+
+	if _, err := m.restoreFromBackup(t.Context(), backup); !errors.Is(err, ErrRestoreVerificationFailed) {
+		t.Fatalf("Expected ErrRestoreVerificationFailed, got %v", err)
+	}
+	var algorithms, versions int64
+	m.db.Model(&models.Algorithm{}).Count(&algorithms)
+	m.db.Model(&models.Version{}).Count(&versions)
+	if algorithms != 3 || versions != 6 {
+		t.Errorf("Expected the pre-restore data back, found %d algorithms and %d versions", algorithms, versions)
+	}
+
+	// 成功的恢复删除快照
+	m.db.Callback().Create().Remove("test:fail_algorithms")
+	if _, err := m.restoreFromBackup(t.Context(), backup); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if snapshots, _ := filepath.Glob(filepath.Join(m.localBackupDir, "pre-restore-*.db")); len(snapshots) != 0 {
+		t.Errorf("Snapshots left after restore: %v", snapshots)
+	}
+}
+
+func TestRestoreRejectsFutureBackupSchema(t *testing.T) {
+	_, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+
+	backup := fmt.Sprintf(`{"algorithms": [{"id": "algo_new", "name": "new"}], "metadata": {"schema_version": %d}}`, BackupSchemaVersion+1)
+	_, err := m.restoreFromBackup(t.Context(), writeTestBackup(t, backup))
+	if !errors.Is(err, ErrUnsupportedBackupSchema) {
+		t.Fatalf("Expected ErrUnsupportedBackupSchema, got %v", err)
+	}
+
+	var count int64
+	m.db.Model(&models.Algorithm{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Rejected backup should not modify the database, found %d algorithms", count)
+	}
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	fake, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+
+	disabledAt := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	algorithm := models.Algorithm{
+		ID:              "algo_1",
+		Name:            "detector",
+		DefaultCPULimit: 1.5,
+		DefaultMemoryMB: 2048,
+		Status:          models.AlgorithmStatusDisabled,
+		DisabledReason:  "broken model",
+		DisabledAt:      &disabledAt,
+		Versions:        []models.Version{{ID: "ver_1", VersionNumber: 3, MinioPath: "algorithms/algo_1/v3.zip"}},
+	}
+	if err := m.db.Create(&algorithm).Error; err != nil {
+		t.Fatalf("Failed to seed algorithm: %v", err)
+	}
+	if err := m.db.Create(&models.PresetData{ID: "data_1", Filename: "input.csv", MinioPath: "preset-data/input.csv"}).Error; err != nil {
+		t.Fatalf("Failed to seed preset data: %v", err)
+	}
+	if err := m.db.Create(&models.Job{ID: "job_1", AlgorithmID: "algo_1", Status: models.JobStatusCompleted, CostTimeMs: 1 << 53}).Error; err != nil {
+		t.Fatalf("Failed to seed job: %v", err)
+	}
+
+	if err := m.BackupToMinIO(); err != nil {
+		t.Fatalf("BackupToMinIO failed: %v", err)
+	}
+	data, _ := fake.Object(keys.BackupLatestJSON)
+	if data == nil {
+		t.Fatal("Expected latest.json.gz to be uploaded")
+	}
+	if !bytes.HasPrefix(data, gzipMagic) {
+		t.Error("Expected the backup to be gzip-compressed")
+	}
+
+	var backup Backup
+	if err := decodeBackup(bytes.NewReader(data), &backup); err != nil {
+		t.Fatalf("Failed to parse backup: %v", err)
+	}
+	if backup.Metadata.SchemaVersion != BackupSchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", backup.Metadata.SchemaVersion, BackupSchemaVersion)
+	}
+	if len(backup.Algorithms) != 1 || len(backup.Algorithms[0].Versions) != 1 || len(backup.PresetData) != 1 || len(backup.Jobs) != 1 {
+		t.Fatalf("Unexpected backup contents: %d algorithms, %d preset data, %d jobs",
+			len(backup.Algorithms), len(backup.PresetData), len(backup.Jobs))
+	}
+	if backup.Versions != nil {
+		t.Errorf("Versions should only be stored under their algorithm, got %d top-level versions", len(backup.Versions))
+	}
+	if backup.Jobs[0].CostTimeMs != 1<<53 {
+		t.Errorf("CostTimeMs = %d, want %d", backup.Jobs[0].CostTimeMs, int64(1<<53))
+	}
+
+	// 带时间戳的备份与 latest.json.gz 内容相同
+	var timestamped []byte
+	for _, key := range fake.Keys() {
+		if strings.HasPrefix(key, "database-backup/backup-") && strings.HasSuffix(key, ".json.gz") {
+			timestamped, _ = fake.Object(key)
+		}
+	}
+	if !bytes.Equal(timestamped, data) {
+		t.Errorf("Timestamped backup has %d bytes, latest.json.gz has %d", len(timestamped), len(data))
+	}
+
+	// 恢复到新的数据库，字段应保持不变
+	_, restoreClient := testutil.NewS3(t)
+	restored := newTestBackupManager(t, restoreClient)
+	if _, err := restored.restoreFromBackup(t.Context(), writeTestBackup(t, string(data))); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	var got models.Algorithm
+	if err := restored.db.Preload("Versions").First(&got, "id = ?", "algo_1").Error; err != nil {
+		t.Fatalf("Algorithm not restored: %v", err)
+	}
+	if got.DefaultCPULimit != 1.5 || got.DefaultMemoryMB != 2048 || got.Status != models.AlgorithmStatusDisabled || got.DisabledReason != "broken model" {
+		t.Errorf("Restored algorithm differs: %+v", got)
+	}
+	if got.DisabledAt == nil || !got.DisabledAt.Equal(disabledAt) {
+		t.Errorf("DisabledAt = %v, want %v", got.DisabledAt, disabledAt)
+	}
+	if len(got.Versions) != 1 || got.Versions[0].VersionNumber != 3 {
+		t.Errorf("Restored versions differ: %+v", got.Versions)
+	}
+	var job models.Job
+	if err := restored.db.First(&job, "id = ?", "job_1").Error; err != nil {
+		t.Fatalf("Job not restored: %v", err)
+	}
+	if job.AlgorithmID != "algo_1" || job.Status != models.JobStatusCompleted || job.CostTimeMs != 1<<53 {
+		t.Errorf("Restored job differs: %+v", job)
+	}
+}
+
+func TestRestoreReplacesVersionsAndJobs(t *testing.T) {
+	_, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+
+	// 与生产环境一致开启外键约束，单连接保证 PRAGMA 对后续语句生效
+	sqlDB, _ := m.db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := m.db.Exec("PRAGMA foreign_keys = ON").Error; err != nil {
+		t.Fatalf("Failed to enable foreign keys: %v", err)
+	}
+
+	// 恢复前已有的数据应被备份内容替换
+	if err := m.db.Create(&models.Algorithm{ID: "algo_stale", Name: "stale", Versions: []models.Version{{ID: "ver_stale", VersionNumber: 1}}}).Error; err != nil {
+		t.Fatalf("Failed to seed algorithm: %v", err)
+	}
+	if err := m.db.Create(&models.Job{ID: "job_stale", AlgorithmID: "algo_stale", Status: models.JobStatusCompleted}).Error; err != nil {
+		t.Fatalf("Failed to seed job: %v", err)
+	}
+
+	// 旧备份：版本同时保存在算法下和顶层列表中，顶层列表还有算法下缺少的版本和算法不存在的版本
+	backup := `{
+		"algorithms": [{"id": "algo_1", "name": "detector", "versions": [{"id": "ver_1", "algorithm_id": "algo_1", "version_number": 1}]}],
+		"versions": [
+			{"id": "ver_1", "algorithm_id": "algo_1", "version_number": 1},
+			{"id": "ver_2", "algorithm_id": "algo_1", "version_number": 2, "image_digest": "python@sha256:abc"},
+			{"id": "ver_orphan", "algorithm_id": "algo_missing", "version_number": 1}
+		],
+		"preset_data": [],
+		"jobs": [{"job_id": "job_1", "algorithm_id": "algo_1", "version_id": "ver_2", "status": "completed"}]
+	}`
+	result, err := m.restoreFromBackup(t.Context(), writeTestBackup(t, backup))
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	var versions []models.Version
+	m.db.Order("id").Find(&versions)
+	if len(versions) != 2 || versions[0].ID != "ver_1" || versions[1].ID != "ver_2" || versions[1].ImageDigest != "python@sha256:abc" {
+		t.Errorf("Restored versions = %+v", versions)
+	}
+	var jobs []models.Job
+	m.db.Find(&jobs)
+	if len(jobs) != 1 || jobs[0].ID != "job_1" || jobs[0].VersionID != "ver_2" {
+		t.Errorf("Restored jobs = %+v", jobs)
+	}
+
+	if v := result.Tables["versions"]; v == nil || v.Restored != 2 || v.Failed != 1 {
+		t.Errorf("Unexpected versions result: %+v", v)
+	}
+	if j := result.Tables["jobs"]; j == nil || j.Restored != 1 || j.Failed != 0 {
+		t.Errorf("Unexpected jobs result: %+v", j)
+	}
+	if !result.Partial() {
+		t.Error("Expected the orphaned version to make the restore partial")
+	}
+}
+
+// seedAlgorithms 创建 n 个各带两个版本的算法
+func seedAlgorithms(tb testing.TB, db *gorm.DB, n int) {
+	tb.Helper()
+	algorithms := make([]models.Algorithm, n)
+	for i := range algorithms {
+		id := fmt.Sprintf("algo_%d", i)
+		algorithms[i] = models.Algorithm{
+			ID:   id,
+			Name: id,
+			Versions: []models.Version{
+				{ID: id + "_v1", VersionNumber: 1},
+				{ID: id + "_v2", VersionNumber: 2},
+			},
+		}
+	}
+	if err := db.CreateInBatches(algorithms, 100).Error; err != nil {
+		tb.Fatalf("Failed to seed algorithms: %v", err)
+	}
+}
+
+func TestBackupLoadsVersionsWithoutPerAlgorithmQueries(t *testing.T) {
+	fake, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+	seedAlgorithms(t, m.db, 50)
+
+	queries := 0
+	if err := m.db.Callback().Query().Before("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		queries++
+	}); err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+
+	if err := m.BackupToMinIO(); err != nil {
+		t.Fatalf("BackupToMinIO failed: %v", err)
+	}
+	// 元数据、算法、版本预加载、预设数据、任务各一次，不随算法数量增长
+	if queries > 10 {
+		t.Errorf("Backup ran %d queries for 50 algorithms", queries)
+	}
+
+	var backup Backup
+	latest, _ := fake.Object(keys.BackupLatestJSON)
+	if err := decodeBackup(bytes.NewReader(latest), &backup); err != nil {
+		t.Fatalf("Failed to parse backup: %v", err)
+	}
+	if len(backup.Algorithms) != 50 {
+		t.Fatalf("Expected 50 algorithms, got %d", len(backup.Algorithms))
+	}
+	for _, alg := range backup.Algorithms {
+		if len(alg.Versions) != 2 {
+			t.Errorf("Algorithm %s has %d versions, want 2", alg.ID, len(alg.Versions))
+		}
+	}
+}
+
+// legacyBackupJSON 生成包含 n 个算法（各一个版本）和 n 个任务的旧结构备份，预设数据为 null
+func legacyBackupJSON(tb testing.TB, n int) []byte {
+	tb.Helper()
+	backup := Backup{Metadata: BackupInfo{SchemaVersion: 1}}
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("algo_%d", i)
+		backup.Algorithms = append(backup.Algorithms, models.Algorithm{
+			ID:       id,
+			Name:     id,
+			Versions: []models.Version{{ID: id + "_v1", AlgorithmID: id, VersionNumber: 1}},
+		})
+		backup.Jobs = append(backup.Jobs, models.Job{ID: fmt.Sprintf("job_%d", i), AlgorithmID: id, Status: models.JobStatusCompleted})
+	}
+	data, err := json.Marshal(backup)
+	if err != nil {
+		tb.Fatalf("Failed to marshal backup: %v", err)
+	}
+	return data
+}
+
+func TestRestoreStreamsAcrossBatches(t *testing.T) {
+	_, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+	m.restoreBatch = 100
+
+	// 第二批中有一条重复的算法，只有这一条失败
+	n := m.restoreBatch*2 + 1
+	data := legacyBackupJSON(t, n)
+	data = bytes.Replace(data, []byte(`"id":"algo_150"`), []byte(`"id":"algo_149"`), 1)
+	data = bytes.Replace(data, []byte(`"id":"algo_149_v1"`), []byte(`"id":"algo_149_v2"`), 1)
+	compressed, _, err := compressBackup(data, BackupInfo{SchemaVersion: 1})
+	if err != nil {
+		t.Fatalf("compressBackup failed: %v", err)
+	}
+
+	result, err := m.restoreFromBackup(t.Context(), writeTestBackup(t, string(compressed)))
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if algorithms := result.Tables["algorithms"]; algorithms.Restored != n-1 || algorithms.Failed != 1 {
+		t.Errorf("Unexpected algorithms result: %+v", algorithms)
+	}
+	if jobs := result.Tables["jobs"]; jobs.Restored != n {
+		t.Errorf("Unexpected jobs result: %+v", jobs)
+	}
+	if result.Tables["preset_data"].Restored != 0 {
+		t.Errorf("Unexpected preset data result: %+v", result.Tables["preset_data"])
+	}
+
+	// 每一批都按旧结构升级
+	var notReady int64
+	m.db.Model(&models.Algorithm{}).Where("status <> ?", models.AlgorithmStatusReady).Count(&notReady)
+	if notReady != 0 {
+		t.Errorf("%d algorithms were not migrated to ready", notReady)
+	}
+}
+
+// BenchmarkRestoreFromBackup 对比逐条插入（batch=1）和默认批大小
+func BenchmarkRestoreFromBackup(b *testing.B) {
+	// 1 万条记录：5000 个算法（各一个版本）和 5000 个任务
+	compressed, _, err := compressBackup(legacyBackupJSON(b, 5000), BackupInfo{SchemaVersion: 1})
+	if err != nil {
+		b.Fatalf("compressBackup failed: %v", err)
+	}
+	backupPath := filepath.Join(b.TempDir(), "backup.json.gz")
+	if err := os.WriteFile(backupPath, compressed, 0644); err != nil {
+		b.Fatalf("Failed to write backup: %v", err)
+	}
+	meta, err := readLocalBackupMetadata(backupPath)
+	if err != nil {
+		b.Fatalf("Failed to read backup metadata: %v", err)
+	}
+
+	for _, batchSize := range []int{1, config.DefaultRestoreBatchSize} {
+		b.Run(fmt.Sprintf("batch=%d", batchSize), func(b *testing.B) {
+			_, client := testutil.NewS3(b)
+			m := newTestBackupManager(b, client)
+			m.restoreBatch = batchSize
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := m.restoreFromBackup(context.Background(), meta); err != nil {
+					b.Fatalf("Restore failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBackupToMinIO(b *testing.B) {
+	_, client := testutil.NewS3(b)
+	m := newTestBackupManager(b, client)
+	seedAlgorithms(b, m.db, 1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := m.BackupToMinIO(); err != nil {
+			b.Fatalf("BackupToMinIO failed: %v", err)
+		}
+	}
+}
+
+// fakeBackupStore 模拟 ListObjects 和 RemoveObjects，记录删除的对象
+type fakeBackupStore struct {
+	keys    []string
+	removed []string
+}
+
+func (f *fakeBackupStore) ListObjects(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+	ch := make(chan minio.ObjectInfo, len(f.keys))
+	for _, key := range f.keys {
+		if strings.HasPrefix(key, opts.Prefix) {
+			ch <- minio.ObjectInfo{Key: key}
+		}
+	}
+	close(ch)
+	return ch
+}
+
+func (f *fakeBackupStore) RemoveObjects(ctx context.Context, bucketName string, objectsCh <-chan minio.ObjectInfo, opts minio.RemoveObjectsOptions) <-chan minio.RemoveObjectError {
+	for object := range objectsCh {
+		f.removed = append(f.removed, object.Key)
+	}
+	errCh := make(chan minio.RemoveObjectError)
+	close(errCh)
+	return errCh
+}
+
+func TestCleanupMinIOBackupsKeepsNewest(t *testing.T) {
+	m := &SQLiteBackupManager{bucketName: "test", keyPrefix: "staging/", jsonRetention: 2, dbRetention: 1}
+	store := &fakeBackupStore{keys: []string{
+		"staging/database-backup/latest.json.gz",
+		"staging/database-backup/latest.json",
+		"staging/database-backup/latest.db",
+		"staging/database-backup/final-backup.db",
+		"staging/database-backup/backup-20240103-000000.json",
+		"staging/database-backup/backup-20240101-000000.json",
+		"staging/database-backup/backup-20240102-000000.json",
+		"staging/database-backup/backup-20240104-000000.json",
+		"staging/database-backup/db-backup-20240102-000000.db",
+		"staging/database-backup/db-backup-20240101-000000.db",
+		"database-backup/backup-20230101-000000.json", // 其他环境的备份
+	}}
+
+	m.cleanupMinIOBackups(context.Background(), store)
+
+	want := []string{
+		"staging/database-backup/backup-20240101-000000.json",
+		"staging/database-backup/backup-20240102-000000.json",
+		"staging/database-backup/db-backup-20240101-000000.db",
+	}
+	slices.Sort(store.removed)
+	if !slices.Equal(store.removed, want) {
+		t.Errorf("removed = %v, want %v", store.removed, want)
+	}
+}
+
+func TestCleanupLocalBackupsUsesRetention(t *testing.T) {
+	dir := t.TempDir()
+	m := &SQLiteBackupManager{localBackupDir: dir, jsonRetention: 2, dbRetention: 1}
+	for _, name := range []string{
+		"backup-20240101-000000.json.gz",
+		"backup-20240102-000000.json.gz",
+		"backup-20240103-000000.json.gz",
+		"db-backup-20240101-000000.db",
+		"db-backup-20240102-000000.db",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	m.cleanupLocalBackups()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	var remaining []string
+	for _, entry := range entries {
+		remaining = append(remaining, entry.Name())
+	}
+	want := []string{"backup-20240102-000000.json.gz", "backup-20240103-000000.json.gz", "db-backup-20240102-000000.db"}
+	if !slices.Equal(remaining, want) {
+		t.Errorf("remaining = %v, want %v", remaining, want)
+	}
+}
+
+func TestNewSQLiteBackupManagerUsesBackupConfig(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Backup = config.BackupConfig{IntervalStr: "1h", JSONRetention: 20, DBRetention: 2, LocalBackupDir: "/var/backups/platform", RestoreBatchSize: 50}
+
+	m, err := NewSQLiteBackupManager(nil, cfg)
+	if err != nil {
+		t.Fatalf("NewSQLiteBackupManager failed: %v", err)
+	}
+	if m.interval() != time.Hour || m.jsonRetention != 20 || m.dbRetention != 2 || m.localBackupDir != "/var/backups/platform" || m.restoreBatch != 50 {
+		t.Errorf("Backup config not applied: interval %v, retention %d/%d, dir %q", m.interval(), m.jsonRetention, m.dbRetention, m.localBackupDir)
+	}
+}
+
+func TestRunBackupReportsLocation(t *testing.T) {
+	fake, client := testutil.NewS3(t)
+	m := newTestBackupManager(t, client)
+	if err := m.db.Create(&models.DatabaseMetadata{Version: 7, LastUpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to seed metadata: %v", err)
+	}
+	seedAlgorithms(t, m.db, 3)
+
+	result, err := m.RunBackup()
+	if err != nil {
+		t.Fatalf("RunBackup failed: %v", err)
+	}
+	if result.Source != "minio" || result.Version != 7 || result.RecordCount != 3 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if _, ok := fake.Object(result.Path); !ok {
+		t.Errorf("Backup not found at reported path %s", result.Path)
+	}
+
+	m.opMu.Lock()
+	defer m.opMu.Unlock()
+	if _, err := m.RunBackup(); !errors.Is(err, ErrBackupBusy) {
+		t.Errorf("Expected ErrBackupBusy while another backup runs, got %v", err)
+	}
+}
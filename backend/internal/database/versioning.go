@@ -1,16 +1,31 @@
 package database
 
 import (
-	"algorithm-platform/internal/models"
+	"database/sql"
+	"errors"
 	"fmt"
+	"runtime"
+	"sync"
 	"time"
+	"weak"
+
+	"algorithm-platform/internal/models"
 
 	"gorm.io/gorm"
 )
 
+// versionedTables 写入后需要递增数据版本号的表
+var versionedTables = map[string]bool{
+	"algorithms":  true,
+	"preset_data": true,
+	"versions":    true,
+}
+
 // VersioningPlugin GORM插件，用于自动更新数据库版本号
 type VersioningPlugin struct {
-	db *gorm.DB
+	// bumped 记录已经递增过版本号的事务，同一事务内的多次写入只递增一次。
+	// 使用弱引用，事务对象被回收后自动移除
+	bumped sync.Map // weak.Pointer[sql.Tx] -> struct{}
 }
 
 // Name 插件名称
@@ -20,9 +35,7 @@ func (p *VersioningPlugin) Name() string {
 
 // Initialize 初始化插件
 func (p *VersioningPlugin) Initialize(db *gorm.DB) error {
-	p.db = db
-	
-	// 注册回调：在创建、更新、删除后更新版本号
+	// 注册回调：在创建、更新、删除后、事务提交前更新版本号
 	if err := db.Callback().Create().After("gorm:after_create").Register("versioning:after_create", p.afterWrite); err != nil {
 		return err
 	}
@@ -32,41 +45,95 @@ func (p *VersioningPlugin) Initialize(db *gorm.DB) error {
 	if err := db.Callback().Delete().After("gorm:after_delete").Register("versioning:after_delete", p.afterWrite); err != nil {
 		return err
 	}
-	
+
 	return nil
 }
 
-// afterWrite 写操作后的回调
+// afterWrite 写操作后的回调，在写操作所在的事务中同步递增版本号，失败时写操作一起回滚
 func (p *VersioningPlugin) afterWrite(db *gorm.DB) {
 	// 只在主要表变更时更新版本
-	tableName := db.Statement.Table
-	if tableName == "algorithms" || tableName == "preset_data" || tableName == "versions" {
-		// 异步更新版本号，避免影响主操作性能
-		go p.incrementVersion()
+	if db.Error != nil || db.Statement.RowsAffected == 0 || !versionedTables[db.Statement.Table] {
+		return
 	}
-}
 
-// incrementVersion 递增数据库版本号
-func (p *VersioningPlugin) incrementVersion() {
-	// 获取当前最大版本号
-	var currentMeta models.DatabaseMetadata
-	p.db.Order("version DESC").First(&currentMeta)
-
-	// 统计记录数
-	var count int64
-	p.db.Model(&models.Algorithm{}).Count(&count)
-
-	newMeta := models.DatabaseMetadata{
-		Version:       currentMeta.Version + 1,
-		LastUpdatedAt: time.Now(),
-		UpdatedBy:     "auto",
-		CheckpointAt:  time.Now(),
-		RecordCount:   count,
+	if tx := statementTx(db.Statement.ConnPool); tx != nil {
+		key := weak.Make(tx)
+		if _, loaded := p.bumped.LoadOrStore(key, struct{}{}); loaded {
+			return
+		}
+		runtime.AddCleanup(tx, func(key weak.Pointer[sql.Tx]) { p.bumped.Delete(key) }, key)
 	}
 
-	if err := p.db.Create(&newMeta).Error; err != nil {
-		fmt.Printf("Warning: failed to update database version: %v\n", err)
+	if err := updateDatabaseMetadata(db.Session(&gorm.Session{NewDB: true}), "auto"); err != nil {
+		db.AddError(fmt.Errorf("failed to update database version: %w", err))
+	}
+}
+
+// statementTx 返回语句所在的事务，不在事务中时返回 nil
+func statementTx(pool gorm.ConnPool) *sql.Tx {
+	switch pool := pool.(type) {
+	case *sql.Tx:
+		return pool
+	case *gorm.PreparedStmtTX:
+		tx, _ := pool.Tx.(*sql.Tx)
+		return tx
 	}
+	return nil
+}
+
+// updateDatabaseMetadata 递增数据版本号（每次写操作后在写操作的事务中调用）
+func updateDatabaseMetadata(db *gorm.DB, updatedBy string) error {
+	return upsertDatabaseMetadata(db, 0, 1, updatedBy, time.Now())
+}
+
+// setDatabaseMetadata 将数据版本号设为 version（恢复备份时使用），不低于当前版本号
+func setDatabaseMetadata(db *gorm.DB, version int64, updatedBy string, lastUpdatedAt time.Time) error {
+	return upsertDatabaseMetadata(db, version, 0, updatedBy, lastUpdatedAt)
+}
+
+// upsertDatabaseMetadata 原地更新唯一的元数据行（不存在时创建），
+// 新版本号为 max(version, 当前版本号 + increment)，保证单调递增
+func upsertDatabaseMetadata(db *gorm.DB, version, increment int64, updatedBy string, lastUpdatedAt time.Time) error {
+	return db.Exec(`INSERT INTO database_metadata (id, version, last_updated_at, updated_by, checkpoint_at, record_count)
+VALUES (?, MAX(?, (SELECT COALESCE(MAX(version), 0) FROM database_metadata) + ?), ?, ?, ?, (SELECT COUNT(*) FROM algorithms))
+ON CONFLICT (id) DO UPDATE SET
+	version = excluded.version,
+	last_updated_at = excluded.last_updated_at,
+	updated_by = excluded.updated_by,
+	checkpoint_at = excluded.checkpoint_at,
+	record_count = excluded.record_count`,
+		models.DatabaseMetadataID, version, increment, lastUpdatedAt, updatedBy, time.Now()).Error
+}
+
+// collapseDatabaseMetadata 将旧版本追加写入的多行元数据合并为版本号最大的一行，
+// 保存在 models.DatabaseMetadataID 上
+func collapseDatabaseMetadata(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var rows int64
+		if err := tx.Model(&models.DatabaseMetadata{}).Count(&rows).Error; err != nil {
+			return err
+		}
+		var latest models.DatabaseMetadata
+		if err := tx.Order("version DESC, id DESC").First(&latest).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		if rows == 1 && latest.ID == models.DatabaseMetadataID {
+			return nil
+		}
+
+		if err := tx.Where("1 = 1").Delete(&models.DatabaseMetadata{}).Error; err != nil {
+			return err
+		}
+		latest.ID = models.DatabaseMetadataID
+		if err := tx.Create(&latest).Error; err != nil {
+			return err
+		}
+		fmt.Printf("Collapsed %d database metadata rows into one (version %d)\n", rows, latest.Version)
+		return nil
+	})
 }
 
 // InstallVersioning 安装版本控制插件
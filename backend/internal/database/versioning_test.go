@@ -0,0 +1,174 @@
+package database
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// openVersioningTestDB 通过 SQLiteProvider 打开数据库，安装版本控制插件
+func openVersioningTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	provider := NewSQLiteProvider(&config.Config{
+		Database: config.DatabaseConfig{
+			Type:   "sqlite",
+			SQLite: config.SQLiteConfig{Path: filepath.Join(t.TempDir(), "test.db"), WALCheckpointIntervalStr: "0"},
+		},
+	})
+	db, err := provider.Open()
+	if err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+	t.Cleanup(func() { provider.Close() })
+	if err := provider.Configure(db); err != nil {
+		t.Fatalf("Failed to configure SQLite database: %v", err)
+	}
+	if err := models.AutoMigrate(db); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	return db
+}
+
+func currentVersion(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var version int64
+	if err := db.Raw("SELECT COALESCE(MAX(version), 0) FROM database_metadata").Scan(&version).Error; err != nil {
+		t.Fatalf("Failed to read version: %v", err)
+	}
+	return version
+}
+
+func TestVersioningIncrementsOncePerWrite(t *testing.T) {
+	db := openVersioningTestDB(t)
+
+	// 并发写入，每次写入在自己的事务中读取递增后的版本号
+	const writes = 100
+	versions := make([]int64, writes)
+	var wg sync.WaitGroup
+	for i := 0; i < writes; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Create(&models.Algorithm{ID: fmt.Sprintf("alg_%d", i), Name: fmt.Sprintf("alg-%d", i)}).Error; err != nil {
+					return err
+				}
+				return tx.Raw("SELECT version FROM database_metadata WHERE id = 1").Scan(&versions[i]).Error
+			})
+			if err != nil {
+				t.Errorf("Write %d failed: %v", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	slices.Sort(versions)
+	for i, version := range versions {
+		if version != int64(i+1) {
+			t.Fatalf("Expected versions 1..%d without gaps or duplicates, got %v", writes, versions)
+		}
+	}
+
+	var meta models.DatabaseMetadata
+	if err := db.First(&meta, models.DatabaseMetadataID).Error; err != nil {
+		t.Fatalf("Failed to load metadata: %v", err)
+	}
+	if meta.Version != writes || meta.RecordCount != writes || meta.UpdatedBy != "auto" {
+		t.Errorf("Unexpected metadata: %+v", meta)
+	}
+	var rows int64
+	db.Model(&models.DatabaseMetadata{}).Count(&rows)
+	if rows != 1 {
+		t.Errorf("Expected a single metadata row, got %d", rows)
+	}
+}
+
+func TestVersioningIncrementsOncePerTransaction(t *testing.T) {
+	db := openVersioningTestDB(t)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		algorithm := &models.Algorithm{ID: "alg_1", Name: "alg"}
+		if err := tx.Create(algorithm).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.Version{ID: "ver_1", AlgorithmID: "alg_1", VersionNumber: 1}).Error; err != nil {
+			return err
+		}
+		return tx.Model(algorithm).Update("current_version_id", "ver_1").Error
+	})
+	if err != nil {
+		t.Fatalf("Transaction failed: %v", err)
+	}
+	if version := currentVersion(t, db); version != 1 {
+		t.Errorf("Expected one increment for the transaction, got version %d", version)
+	}
+
+	// 回滚的事务不递增版本号，未变更任何行的写入也不递增
+	db.Transaction(func(tx *gorm.DB) error {
+		tx.Create(&models.Algorithm{ID: "alg_2", Name: "rolled back"})
+		return fmt.Errorf("rollback")
+	})
+	db.Model(&models.Algorithm{}).Where("id = ?", "missing").Update("name", "none")
+	if version := currentVersion(t, db); version != 1 {
+		t.Errorf("Expected version to stay at 1, got %d", version)
+	}
+
+	// 恢复备份设置的版本号不会让版本倒退
+	if err := setDatabaseMetadata(db, 10, "backup_restore", time.Now()); err != nil {
+		t.Fatalf("Failed to set metadata: %v", err)
+	}
+	if err := setDatabaseMetadata(db, 5, "backup_restore", time.Now()); err != nil {
+		t.Fatalf("Failed to set metadata: %v", err)
+	}
+	if version := currentVersion(t, db); version != 10 {
+		t.Errorf("Expected version 10 after restores, got %d", version)
+	}
+	if err := db.Delete(&models.Version{}, "id = ?", "ver_1").Error; err != nil {
+		t.Fatalf("Failed to delete version: %v", err)
+	}
+	if version := currentVersion(t, db); version != 11 {
+		t.Errorf("Expected version 11 after a write, got %d", version)
+	}
+}
+
+func TestCollapseDatabaseMetadata(t *testing.T) {
+	db := openVersioningTestDB(t)
+
+	// 旧版本每次写入追加一行
+	now := time.Now()
+	for _, version := range []int64{1, 2, 7, 3} {
+		meta := models.DatabaseMetadata{Version: version, LastUpdatedAt: now, UpdatedBy: fmt.Sprintf("v%d", version)}
+		if err := db.Create(&meta).Error; err != nil {
+			t.Fatalf("Failed to seed metadata: %v", err)
+		}
+	}
+
+	if err := collapseDatabaseMetadata(db); err != nil {
+		t.Fatalf("collapseDatabaseMetadata failed: %v", err)
+	}
+	var rows []models.DatabaseMetadata
+	db.Find(&rows)
+	if len(rows) != 1 || rows[0].ID != models.DatabaseMetadataID || rows[0].Version != 7 || rows[0].UpdatedBy != "v7" {
+		t.Fatalf("Expected the latest row kept as id %d, got %+v", models.DatabaseMetadataID, rows)
+	}
+
+	// 再次执行不做任何修改，之后的写入原地更新
+	if err := collapseDatabaseMetadata(db); err != nil {
+		t.Fatalf("collapseDatabaseMetadata failed: %v", err)
+	}
+	if err := db.Create(&models.Algorithm{ID: "alg_1", Name: "alg"}).Error; err != nil {
+		t.Fatalf("Failed to create algorithm: %v", err)
+	}
+	db.Find(&rows)
+	if len(rows) != 1 || rows[0].Version != 8 || rows[0].RecordCount != 1 {
+		t.Errorf("Expected the single row updated to version 8, got %+v", rows)
+	}
+}
@@ -0,0 +1,161 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// JobEvent 任务生命周期事件
+type JobEvent struct {
+	JobID     string    `json:"job_id"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message,omitempty"`
+	ResultURL string    `json:"result_url,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Terminal 是否为终止状态（之后不会再有新的事件）
+func (e JobEvent) Terminal() bool {
+	return IsTerminalStatus(e.Status)
+}
+
+// IsTerminalStatus 判断任务状态是否为终止状态
+func IsTerminalStatus(status string) bool {
+	switch status {
+	case "completed", "failed", "timeout", "cancelled":
+		return true
+	}
+	return false
+}
+
+// subscriberBuffer 每个订阅者的缓冲区大小，消费过慢时丢弃事件而不是阻塞执行器
+const subscriberBuffer = 16
+
+// relayPublishTimeout 单次转发的超时时间，Relay 不可用时不阻塞执行器
+const relayPublishTimeout = 2 * time.Second
+
+// Relay 跨进程转发事件（如 Redis pub/sub），用于多副本部署
+type Relay interface {
+	Publish(ctx context.Context, event JobEvent) error
+}
+
+// Bus 按任务ID划分主题的事件发布/订阅
+// 事件总是直接分发给本进程的订阅者；配置 Relay 后同时经 Relay 广播，由其他副本回调 Deliver 分发给它们的订阅者，
+// Relay 需要丢弃本副本自己发出的事件，避免重复分发
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[<-chan JobEvent]chan JobEvent
+	relay       Relay
+	closed      bool
+}
+
+// NewBus 创建事件总线
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[string]map[<-chan JobEvent]chan JobEvent),
+	}
+}
+
+// SetRelay 设置跨进程转发器，需在发布事件前调用
+func (b *Bus) SetRelay(relay Relay) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.relay = relay
+}
+
+// Subscribe 订阅指定任务的事件，使用完毕后需调用 Unsubscribe
+func (b *Bus) Subscribe(jobID string) <-chan JobEvent {
+	ch := make(chan JobEvent, subscriberBuffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(ch)
+		return ch
+	}
+
+	if b.subscribers[jobID] == nil {
+		b.subscribers[jobID] = make(map[<-chan JobEvent]chan JobEvent)
+	}
+	b.subscribers[jobID][ch] = ch
+	return ch
+}
+
+// Unsubscribe 取消订阅并关闭通道，重复调用是安全的
+func (b *Bus) Unsubscribe(jobID string, sub <-chan JobEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subs, ok := b.subscribers[jobID]
+	if !ok {
+		return
+	}
+
+	if ch, ok := subs[sub]; ok {
+		delete(subs, sub)
+		close(ch)
+	}
+	if len(subs) == 0 {
+		delete(b.subscribers, jobID)
+	}
+}
+
+// Publish 发布任务事件
+func (b *Bus) Publish(event JobEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	// 先分发给本副本的订阅者，不依赖 Relay 的订阅是否正常
+	b.Deliver(event)
+
+	b.mu.RLock()
+	relay := b.relay
+	b.mu.RUnlock()
+	if relay == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), relayPublishTimeout)
+	defer cancel()
+	if err := relay.Publish(ctx, event); err != nil {
+		fmt.Printf("Warning: failed to relay event of job %s: %v\n", event.JobID, err)
+	}
+}
+
+// Deliver 将事件分发给本进程内的订阅者，消费过慢的订阅者会丢弃该事件
+func (b *Bus) Deliver(event JobEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, ch := range b.subscribers[event.JobID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// SubscriberCount 返回指定任务当前的订阅者数量
+func (b *Bus) SubscriberCount(jobID string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers[jobID])
+}
+
+// Close 关闭所有订阅通道，之后的订阅会立即得到已关闭的通道
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for jobID, subs := range b.subscribers {
+		for _, ch := range subs {
+			close(ch)
+		}
+		delete(b.subscribers, jobID)
+	}
+	b.closed = true
+}
@@ -0,0 +1,119 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBusPublishSubscribe(t *testing.T) {
+	bus := NewBus()
+
+	sub := bus.Subscribe("job_1")
+	other := bus.Subscribe("job_2")
+	defer bus.Unsubscribe("job_2", other)
+
+	bus.Publish(JobEvent{JobID: "job_1", Status: "running"})
+
+	select {
+	case event := <-sub:
+		if event.Status != "running" || event.Timestamp.IsZero() {
+			t.Errorf("Unexpected event: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for event")
+	}
+
+	select {
+	case event := <-other:
+		t.Errorf("Subscriber of another job received event: %+v", event)
+	default:
+	}
+
+	bus.Unsubscribe("job_1", sub)
+	bus.Unsubscribe("job_1", sub)
+	if _, ok := <-sub; ok {
+		t.Error("Expected channel to be closed after unsubscribe")
+	}
+	if n := bus.SubscriberCount("job_1"); n != 0 {
+		t.Errorf("Expected no subscribers left, got %d", n)
+	}
+}
+
+func TestBusDropsEventsForSlowSubscriber(t *testing.T) {
+	bus := NewBus()
+	sub := bus.Subscribe("job_1")
+	defer bus.Unsubscribe("job_1", sub)
+
+	// 超出缓冲区的事件会被丢弃，Publish 不应阻塞
+	for i := 0; i < subscriberBuffer*2; i++ {
+		bus.Publish(JobEvent{JobID: "job_1", Status: "running"})
+	}
+
+	if len(sub) != subscriberBuffer {
+		t.Errorf("Expected %d buffered events, got %d", subscriberBuffer, len(sub))
+	}
+}
+
+type failingRelay struct{}
+
+func (failingRelay) Publish(ctx context.Context, event JobEvent) error {
+	return errors.New("relay unavailable")
+}
+
+func TestBusFallsBackToLocalDeliveryWhenRelayFails(t *testing.T) {
+	bus := NewBus()
+	bus.SetRelay(failingRelay{})
+
+	sub := bus.Subscribe("job_1")
+	defer bus.Unsubscribe("job_1", sub)
+
+	bus.Publish(JobEvent{JobID: "job_1", Status: "completed"})
+
+	select {
+	case event := <-sub:
+		if !event.Terminal() {
+			t.Errorf("Expected terminal event, got %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for event")
+	}
+}
+
+type recordingRelay struct {
+	events      []JobEvent
+	hasDeadline bool
+}
+
+func (r *recordingRelay) Publish(ctx context.Context, event JobEvent) error {
+	_, r.hasDeadline = ctx.Deadline()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestBusDeliversLocallyWhenRelaySucceeds(t *testing.T) {
+	bus := NewBus()
+	relay := &recordingRelay{}
+	bus.SetRelay(relay)
+
+	sub := bus.Subscribe("job_1")
+	defer bus.Unsubscribe("job_1", sub)
+
+	bus.Publish(JobEvent{JobID: "job_1", Status: "running"})
+
+	select {
+	case event := <-sub:
+		if event.Status != "running" {
+			t.Errorf("Unexpected event %+v", event)
+		}
+	default:
+		t.Fatal("Local subscribers should get the event without waiting for the relay")
+	}
+	if len(relay.events) != 1 {
+		t.Errorf("Expected the event to be relayed once, got %d", len(relay.events))
+	}
+	if !relay.hasDeadline {
+		t.Error("Relay publish should use a bounded context")
+	}
+}
@@ -0,0 +1,83 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisRelay 基于 Redis pub/sub 在多个副本之间广播任务事件
+type RedisRelay struct {
+	client  *redis.Client
+	channel string
+	origin  string // 本副本的标识，用于丢弃自己发出的事件
+}
+
+// relayMessage Redis 频道中的消息
+type relayMessage struct {
+	Origin string   `json:"origin"`
+	Event  JobEvent `json:"event"`
+}
+
+// NewRedisRelay 创建 Redis 事件转发器
+func NewRedisRelay(client *redis.Client, channel string) *RedisRelay {
+	return &RedisRelay{
+		client:  client,
+		channel: channel,
+		origin:  rand.Text(),
+	}
+}
+
+// Publish 将事件发布到 Redis 频道
+func (r *RedisRelay) Publish(ctx context.Context, event JobEvent) error {
+	data, err := json.Marshal(relayMessage{Origin: r.origin, Event: event})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job event: %w", err)
+	}
+
+	if err := r.client.Publish(ctx, r.channel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish job event: %w", err)
+	}
+	return nil
+}
+
+// Run 订阅 Redis 频道并把收到的事件分发给本地订阅者，直到 ctx 取消
+func (r *RedisRelay) Run(ctx context.Context, bus *Bus) error {
+	pubsub := r.client.Subscribe(ctx, r.channel)
+	defer pubsub.Close()
+
+	// 等待订阅确认，确保之后发布的事件不会丢失
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe job events channel: %w", err)
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			r.deliver(bus, msg.Payload)
+		}
+	}
+}
+
+// deliver 将其他副本发出的事件分发给本地订阅者，本副本发出的事件已在发布时分发过
+func (r *RedisRelay) deliver(bus *Bus, payload string) {
+	var msg relayMessage
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		fmt.Printf("Warning: invalid job event payload: %v\n", err)
+		return
+	}
+	if msg.Origin == r.origin {
+		return
+	}
+	bus.Deliver(msg.Event)
+}
@@ -0,0 +1,27 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRedisRelaySkipsOwnEvents(t *testing.T) {
+	local := NewRedisRelay(nil, "job_events")
+	remote := NewRedisRelay(nil, "job_events")
+	bus := NewBus()
+
+	sub := bus.Subscribe("job_1")
+	defer bus.Unsubscribe("job_1", sub)
+
+	for _, origin := range []*RedisRelay{local, remote} {
+		payload, err := json.Marshal(relayMessage{Origin: origin.origin, Event: JobEvent{JobID: "job_1", Status: "running"}})
+		if err != nil {
+			t.Fatalf("Failed to marshal message: %v", err)
+		}
+		local.deliver(bus, string(payload))
+	}
+
+	if len(sub) != 1 {
+		t.Errorf("Expected only the other replica's event to be delivered, got %d events", len(sub))
+	}
+}
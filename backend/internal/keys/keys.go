@@ -0,0 +1,124 @@
+// Package keys 集中生成 MinIO 对象路径，用户提供的文件名在拼接前统一清洗
+// 返回的路径不含环境前缀，调用方通过 MinIOConfig.ObjectKey 加上前缀
+package keys
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxFilenameBytes 文件名的最大字节数，超出时截断主文件名并保留扩展名
+const maxFilenameBytes = 200
+
+// fallbackFilename 清洗后为空时使用的文件名
+const fallbackFilename = "file"
+
+// 备份对象路径
+const (
+	BackupDir              = "database-backup/"
+	BackupLatestJSON       = BackupDir + "latest.json.gz"
+	BackupLegacyLatestJSON = BackupDir + "latest.json" // 压缩前的 latest 备份，仅用于恢复
+	BackupLatestDB         = BackupDir + "latest.db"
+	BackupFinalDB          = BackupDir + "final-backup.db"
+	BackupJSONPrefix       = BackupDir + "backup-"
+	BackupDBPrefix         = BackupDir + "db-backup-"
+)
+
+// unsafeChars 在对象路径或 URL 中容易出问题的字符，替换为下划线
+const unsafeChars = `/\:*?"<>|#%{}^[]` + "`~"
+
+// SanitizeFilename 清洗用户提供的文件名：去掉目录部分、控制字符和不安全字符，
+// 统一为 NFC 形式，不允许 "." 和 ".." 这样的路径段
+func SanitizeFilename(name string) string {
+	name = norm.NFC.String(strings.ToValidUTF8(name, ""))
+
+	// 同时按 / 和 \ 去掉目录部分
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsControl(r):
+			return -1
+		case strings.ContainsRune(unsafeChars, r):
+			return '_'
+		case unicode.IsSpace(r):
+			return ' '
+		}
+		return r
+	}, name)
+
+	// 首尾的空格和点在部分系统上会被忽略，去掉后避免出现 ".." 或隐藏文件
+	name = strings.Trim(name, " .")
+	if name == "" {
+		return fallbackFilename
+	}
+	return truncateFilename(name, maxFilenameBytes)
+}
+
+// truncateFilename 按字节截断文件名，保留扩展名且不截断多字节字符
+func truncateFilename(name string, limit int) string {
+	if len(name) <= limit {
+		return name
+	}
+	ext := path.Ext(name)
+	if len(ext) > limit/2 {
+		ext = ""
+	}
+	base := name[:limit-len(ext)]
+	for !utf8.ValidString(base) {
+		base = base[:len(base)-1]
+	}
+	return strings.TrimRight(base, " .") + ext
+}
+
+// segment 清洗由服务端生成的 ID 等路径段，防止意外包含分隔符
+func segment(s string) string {
+	return SanitizeFilename(s)
+}
+
+// AlgorithmPrefix 算法所有代码版本所在的目录
+func AlgorithmPrefix(algorithmID string) string {
+	return fmt.Sprintf("algorithms/%s/", segment(algorithmID))
+}
+
+// AlgorithmCode 算法某个版本的代码文件
+func AlgorithmCode(algorithmID string, version int, filename string) string {
+	return fmt.Sprintf("%sv%d/%s", AlgorithmPrefix(algorithmID), version, SanitizeFilename(filename))
+}
+
+// PresetData 预置数据文件，按数据 ID 分目录，同名文件不会互相覆盖
+func PresetData(id, filename string) string {
+	return fmt.Sprintf("preset-data/%s/%s", segment(id), SanitizeFilename(filename))
+}
+
+// JobResult 任务结果
+func JobResult(jobID string) string {
+	return "results/" + segment(jobID)
+}
+
+// JobLog 任务容器日志
+func JobLog(jobID string) string {
+	return fmt.Sprintf("logs/%s.log", segment(jobID))
+}
+
+// StorageProbe 存储自检写入后立即删除的探测对象
+func StorageProbe(id string) string {
+	return "storage-check/" + segment(id)
+}
+
+// BackupJSON 某次 JSON 备份（gzip 压缩）
+func BackupJSON(timestamp string) string {
+	return BackupJSONPrefix + segment(timestamp) + ".json.gz"
+}
+
+// BackupDB 某次数据库文件备份
+func BackupDB(timestamp string) string {
+	return BackupDBPrefix + segment(timestamp) + ".db"
+}
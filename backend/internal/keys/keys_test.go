@@ -0,0 +1,67 @@
+package keys
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"data.csv", "data.csv"},
+		{"销售数据 2024.csv", "销售数据 2024.csv"},
+		{"../../etc/passwd", "passwd"},
+		{`..\..\windows\system32\config`, "config"},
+		{"/absolute/path.txt", "path.txt"},
+		{"..", "file"},
+		{".", "file"},
+		{"", "file"},
+		{"   ", "file"},
+		{"dir/", "file"},
+		{".hidden", "hidden"},
+		{"trailing. ", "trailing"},
+		{"a\x00b\nc.txt", "abc.txt"},
+		{"tab\tname.txt", "tabname.txt"},
+		{"no\u00a0break.txt", "no break.txt"},
+		{"what?#%.csv", "what___.csv"},
+		{"report<1>|2.txt", "report_1__2.txt"},
+		{"café.txt", "café.txt"},
+		{"bad\xffutf8.txt", "badutf8.txt"},
+	}
+	for _, tt := range tests {
+		if got := SanitizeFilename(tt.name); got != tt.want {
+			t.Errorf("SanitizeFilename(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSanitizeFilenameTruncates(t *testing.T) {
+	long := strings.Repeat("数", 100) + ".csv"
+	got := SanitizeFilename(long)
+	if len(got) > maxFilenameBytes || !strings.HasSuffix(got, ".csv") || !utf8.ValidString(got) {
+		t.Errorf("SanitizeFilename(long) = %q (%d bytes)", got, len(got))
+	}
+}
+
+func TestKeysStayInTheirDirectory(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{AlgorithmCode("alg_1", 2, "../../../database-backup/latest.json"), "algorithms/alg_1/v2/latest.json"},
+		{AlgorithmPrefix("alg_1"), "algorithms/alg_1/"},
+		{PresetData("data_1", "../alg_2/v1/main.py"), "preset-data/data_1/main.py"},
+		{PresetData("../x", "a.csv"), "preset-data/x/a.csv"},
+		{JobResult("job_1"), "results/job_1"},
+		{JobLog("job_1"), "logs/job_1.log"},
+		{BackupJSON("20240101-000000"), "database-backup/backup-20240101-000000.json.gz"},
+		{BackupDB("20240101-000000"), "database-backup/db-backup-20240101-000000.db"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package maintenance
+
+import (
+	"sync"
+	"time"
+)
+
+// Mode 维护（只读）模式开关，开启后服务拒绝所有写操作，读操作不受影响
+//
+// 只读可以由运维手动开启，也可以由恢复等内部流程临时开启（Engage），
+// 两者互不覆盖：手动关闭不会解除仍在进行中的恢复所持有的只读状态。
+type Mode struct {
+	mu           sync.Mutex
+	manual       bool
+	manualReason string
+	manualSince  time.Time
+	holds        map[int]hold
+	nextHoldID   int
+}
+
+type hold struct {
+	reason string
+	since  time.Time
+}
+
+// Status 当前的只读状态
+type Status struct {
+	ReadOnly bool
+	Reason   string
+	Since    time.Time
+}
+
+// NewMode 创建维护模式开关，默认可写
+func NewMode() *Mode {
+	return &Mode{holds: make(map[int]hold)}
+}
+
+// SetReadOnly 手动开启或关闭只读模式
+func (m *Mode) SetReadOnly(readOnly bool, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if readOnly && !m.manual {
+		m.manualSince = time.Now()
+	}
+	m.manual = readOnly
+	m.manualReason = reason
+	if !readOnly {
+		m.manualReason = ""
+		m.manualSince = time.Time{}
+	}
+}
+
+// Engage 临时开启只读模式，返回的函数用于解除，可安全多次调用
+func (m *Mode) Engage(reason string) (release func()) {
+	m.mu.Lock()
+	id := m.nextHoldID
+	m.nextHoldID++
+	m.holds[id] = hold{reason: reason, since: time.Now()}
+	m.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.mu.Lock()
+			delete(m.holds, id)
+			m.mu.Unlock()
+		})
+	}
+}
+
+// Status 返回当前的只读状态，手动开启优先于内部流程
+func (m *Mode) Status() Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.manual {
+		return Status{ReadOnly: true, Reason: m.manualReason, Since: m.manualSince}
+	}
+
+	return m.engagedLocked()
+}
+
+// EngagedStatus 返回内部流程（Engage）持有的只读状态，不含手动开启，用于避免维护操作并发执行
+func (m *Mode) EngagedStatus() Status {
+	if m == nil {
+		return Status{}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.engagedLocked()
+}
+
+// engagedLocked 有多个内部流程时返回最早开启的一个，调用方需持有 mu
+func (m *Mode) engagedLocked() Status {
+	var status Status
+	for _, h := range m.holds {
+		if !status.ReadOnly || h.since.Before(status.Since) {
+			status = Status{ReadOnly: true, Reason: h.reason, Since: h.since}
+		}
+	}
+	return status
+}
+
+// ReadOnly 是否处于只读模式，m 为 nil 时视为可写
+func (m *Mode) ReadOnly() bool {
+	if m == nil {
+		return false
+	}
+	return m.Status().ReadOnly
+}
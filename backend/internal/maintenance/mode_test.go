@@ -0,0 +1,60 @@
+package maintenance
+
+import "testing"
+
+func TestManualReadOnly(t *testing.T) {
+	m := NewMode()
+	if m.ReadOnly() {
+		t.Fatal("Expected new mode to be writable")
+	}
+
+	m.SetReadOnly(true, "migration")
+	if s := m.Status(); !s.ReadOnly || s.Reason != "migration" || s.Since.IsZero() {
+		t.Errorf("Unexpected status after enabling: %+v", s)
+	}
+
+	m.SetReadOnly(false, "")
+	if m.ReadOnly() {
+		t.Error("Expected mode to be writable after disabling")
+	}
+}
+
+func TestEngageIsIndependentOfManualToggle(t *testing.T) {
+	m := NewMode()
+
+	release := m.Engage("restore")
+	m.SetReadOnly(true, "operator")
+	m.SetReadOnly(false, "")
+
+	// 手动关闭不影响恢复流程持有的只读状态
+	if s := m.Status(); !s.ReadOnly || s.Reason != "restore" {
+		t.Errorf("Expected restore hold to keep read-only, got %+v", s)
+	}
+
+	release()
+	release()
+	if m.ReadOnly() {
+		t.Error("Expected mode to be writable after release")
+	}
+}
+
+func TestEngagedStatusIgnoresManualReadOnly(t *testing.T) {
+	m := NewMode()
+	m.SetReadOnly(true, "operator")
+	if s := m.EngagedStatus(); s.ReadOnly {
+		t.Errorf("Manual read-only should not count as engaged, got %+v", s)
+	}
+
+	release := m.Engage("restore")
+	if s := m.EngagedStatus(); !s.ReadOnly || s.Reason != "restore" {
+		t.Errorf("Expected the restore hold, got %+v", s)
+	}
+	release()
+}
+
+func TestNilModeIsWritable(t *testing.T) {
+	var m *Mode
+	if m.ReadOnly() || m.EngagedStatus().ReadOnly {
+		t.Error("Expected nil mode to be writable")
+	}
+}
@@ -0,0 +1,164 @@
+// Package metrics 记录 MinIO 和数据库操作的耗时直方图，并以 Prometheus 文本格式输出。
+// 默认关闭，调用 Enable 后各组件才会挂载计时逻辑。
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultBuckets 耗时直方图的默认分桶（秒）
+var DefaultBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Histogram 按一个标签区分的耗时直方图
+type Histogram struct {
+	name    string
+	help    string
+	label   string
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	counts []uint64 // 每个分桶的计数（非累计），最后一项为 +Inf
+	sum    float64
+	count  uint64
+}
+
+// NewHistogram 创建直方图，buckets 需按升序排列
+func NewHistogram(name, help, label string, buckets []float64) *Histogram {
+	return &Histogram{
+		name:    name,
+		help:    help,
+		label:   label,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+}
+
+// Observe 记录一次耗时（秒）
+func (h *Histogram) Observe(labelValue string, seconds float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := h.series[labelValue]
+	if s == nil {
+		s = &series{counts: make([]uint64, len(h.buckets)+1)}
+		h.series[labelValue] = s
+	}
+	s.counts[sort.SearchFloat64s(h.buckets, seconds)]++
+	s.sum += seconds
+	s.count++
+}
+
+// Since 记录从 start 到现在的耗时
+func (h *Histogram) Since(labelValue string, start time.Time) {
+	h.Observe(labelValue, time.Since(start).Seconds())
+}
+
+// Count 返回某个标签值的观测次数
+func (h *Histogram) Count(labelValue string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if s := h.series[labelValue]; s != nil {
+		return s.count
+	}
+	return 0
+}
+
+// write 按 Prometheus 文本格式输出
+func (h *Histogram) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n", h.name, h.help)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", h.name)
+
+	values := make([]string, 0, len(h.series))
+	for v := range h.series {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+
+	for _, v := range values {
+		s := h.series[v]
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket{%s=%q,le=%q} %d\n", h.name, h.label, v, strconv.FormatFloat(upper, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket{%s=%q,le=\"+Inf\"} %d\n", h.name, h.label, v, s.count)
+		fmt.Fprintf(w, "%s_sum{%s=%q} %g\n", h.name, h.label, v, s.sum)
+		fmt.Fprintf(w, "%s_count{%s=%q} %d\n", h.name, h.label, v, s.count)
+	}
+}
+
+// Registry 一组需要输出的直方图
+type Registry struct {
+	mu         sync.Mutex
+	histograms []*Histogram
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register 注册直方图并返回它
+func (r *Registry) Register(h *Histogram) *Histogram {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.histograms = append(r.histograms, h)
+	return h
+}
+
+// Write 输出所有直方图
+func (r *Registry) Write(w io.Writer) {
+	r.mu.Lock()
+	histograms := append([]*Histogram(nil), r.histograms...)
+	r.mu.Unlock()
+
+	for _, h := range histograms {
+		h.write(w)
+	}
+}
+
+// Handler 返回 /metrics 的 HTTP 处理函数
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.Write(w)
+	})
+}
+
+var (
+	// Default 默认注册表，/metrics 输出其中的内容
+	Default = NewRegistry()
+
+	// MinIODuration MinIO 请求耗时，按操作区分
+	MinIODuration = Default.Register(NewHistogram(
+		"minio_operation_duration_seconds", "Duration of MinIO requests by operation.", "operation", DefaultBuckets))
+
+	// DBDuration 数据库语句耗时，按操作区分
+	DBDuration = Default.Register(NewHistogram(
+		"db_query_duration_seconds", "Duration of database statements by operation.", "operation", DefaultBuckets))
+)
+
+var enabled atomic.Bool
+
+// Enable 开启计时，需在创建数据库和 MinIO 客户端之前调用
+func Enable() {
+	enabled.Store(true)
+}
+
+// Enabled 是否开启了计时
+func Enabled() bool {
+	return enabled.Load()
+}
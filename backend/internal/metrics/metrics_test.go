@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHistogramExposition(t *testing.T) {
+	r := NewRegistry()
+	h := r.Register(NewHistogram("test_duration_seconds", "Test.", "operation", []float64{0.1, 1}))
+	h.Observe("get", 0.05)
+	h.Observe("get", 0.5)
+	h.Observe("get", 3)
+
+	var b strings.Builder
+	r.Write(&b)
+	out := b.String()
+
+	for _, want := range []string{
+		"# TYPE test_duration_seconds histogram",
+		`test_duration_seconds_bucket{operation="get",le="0.1"} 1`,
+		`test_duration_seconds_bucket{operation="get",le="1"} 2`,
+		`test_duration_seconds_bucket{operation="get",le="+Inf"} 3`,
+		`test_duration_seconds_sum{operation="get"} 3.55`,
+		`test_duration_seconds_count{operation="get"} 3`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMinIOOperation(t *testing.T) {
+	tests := []struct {
+		method, url string
+		header      string
+		want        string
+	}{
+		{http.MethodPut, "/bucket/results/job_1", "", "put_object"},
+		{http.MethodPut, "/bucket/results/job_1?partNumber=1&uploadId=x", "", "put_object_part"},
+		{http.MethodPut, "/bucket/new/key", "/bucket/old/key", "copy_object"},
+		{http.MethodPut, "/bucket/", "", "make_bucket"},
+		{http.MethodGet, "/bucket/results/job_1", "", "get_object"},
+		{http.MethodGet, "/bucket/?list-type=2&prefix=results/", "", "list_objects"},
+		{http.MethodGet, "/bucket/?location=", "", "get_bucket_location"},
+		{http.MethodHead, "/bucket/results/job_1", "", "stat_object"},
+		{http.MethodHead, "/bucket/", "", "bucket_exists"},
+		{http.MethodPost, "/bucket/?delete=", "", "remove_objects"},
+		{http.MethodDelete, "/bucket/results/job_1", "", "remove_object"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "http://minio:9000"+tt.url, nil)
+		if tt.header != "" {
+			req.Header.Set("X-Amz-Copy-Source", tt.header)
+		}
+		if got := minioOperation(req); got != tt.want {
+			t.Errorf("%s %s = %q, want %q", tt.method, tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// MinIOTransport 返回记录请求耗时的 MinIO Transport，未开启计时时返回 nil（使用 minio-go 默认 Transport）
+func MinIOTransport(secure bool) http.RoundTripper {
+	if !Enabled() {
+		return nil
+	}
+	base, err := minio.DefaultTransport(secure)
+	if err != nil {
+		return nil
+	}
+	return &timedTransport{base: base, histogram: MinIODuration}
+}
+
+type timedTransport struct {
+	base      http.RoundTripper
+	histogram *Histogram
+}
+
+func (t *timedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	t.histogram.Since(minioOperation(req), start)
+	return resp, err
+}
+
+// minioOperation 根据 S3 请求的方法、路径和参数推断操作名
+func minioOperation(req *http.Request) string {
+	query := req.URL.Query()
+	bucketOnly := !strings.Contains(strings.Trim(req.URL.Path, "/"), "/")
+
+	switch req.Method {
+	case http.MethodHead:
+		if bucketOnly {
+			return "bucket_exists"
+		}
+		return "stat_object"
+	case http.MethodGet:
+		switch {
+		case query.Has("location"):
+			return "get_bucket_location"
+		case bucketOnly:
+			return "list_objects"
+		}
+		return "get_object"
+	case http.MethodPut:
+		switch {
+		case query.Has("uploadId"):
+			return "put_object_part"
+		case req.Header.Get("X-Amz-Copy-Source") != "":
+			return "copy_object"
+		case bucketOnly:
+			return "make_bucket"
+		}
+		return "put_object"
+	case http.MethodPost:
+		switch {
+		case query.Has("delete"):
+			return "remove_objects"
+		case query.Has("uploads"), query.Has("uploadId"):
+			return "multipart_upload"
+		}
+		return "post"
+	case http.MethodDelete:
+		return "remove_object"
+	}
+	return strings.ToLower(req.Method)
+}
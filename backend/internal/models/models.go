@@ -6,6 +6,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// DatabaseMetadataID 数据库元数据只有一行，写入时原地更新
+const DatabaseMetadataID uint = 1
+
 // DatabaseMetadata 数据库元数据，用于版本控制和数据同步
 type DatabaseMetadata struct {
 	ID            uint      `gorm:"primaryKey" json:"id"`
@@ -16,19 +19,34 @@ type DatabaseMetadata struct {
 	RecordCount   int64     `json:"record_count"`                          // 总记录数
 }
 
+// 算法生命周期状态
+const (
+	AlgorithmStatusDraft    = "draft"    // 已创建但尚无可执行的代码版本
+	AlgorithmStatusReady    = "ready"    // 可执行
+	AlgorithmStatusDisabled = "disabled" // 已停用，拒绝新的执行请求
+)
+
 type Algorithm struct {
-	ID               string    `gorm:"primaryKey;type:varchar(36)" json:"id"`
-	Name             string    `gorm:"type:varchar(255);not null" json:"name"`
-	Description      string    `gorm:"type:text" json:"description"`
-	Language         string    `gorm:"type:varchar(50)" json:"language"`
-	Platform         string    `gorm:"type:varchar(50)" json:"platform"`
-	Category         string    `gorm:"type:varchar(255)" json:"category"`
-	Entrypoint       string    `gorm:"type:varchar(255)" json:"entrypoint"`
-	Tags             string    `gorm:"type:text" json:"tags"`
-	PresetDataID     string    `gorm:"type:varchar(36)" json:"preset_data_id"`
-	CurrentVersionID string    `gorm:"type:varchar(36)" json:"current_version_id"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID               string     `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	Name             string     `gorm:"type:varchar(255);not null;index" json:"name"`
+	Description      string     `gorm:"type:text" json:"description"`
+	Language         string     `gorm:"type:varchar(50)" json:"language"`
+	Platform         string     `gorm:"type:varchar(50)" json:"platform"`
+	Category         string     `gorm:"type:varchar(255)" json:"category"`
+	Entrypoint       string     `gorm:"type:varchar(255)" json:"entrypoint"`
+	Tags             string     `gorm:"type:text" json:"tags"`
+	PresetDataID     string     `gorm:"type:varchar(36)" json:"preset_data_id"`
+	CurrentVersionID string     `gorm:"type:varchar(36)" json:"current_version_id"`
+	DefaultCPULimit  float64    `json:"default_cpu_limit"`              // 默认CPU核数，执行请求未指定时使用
+	DefaultMemoryMB  int        `json:"default_memory_mb"`              // 默认内存（MB），执行请求未指定时使用
+	ParamsSchema     string     `gorm:"type:text" json:"params_schema"` // 执行参数的 JSON Schema，为空时不校验
+	JobHistoryLimit  int        `json:"job_history_limit"`              // 保留的已结束任务数量，0 表示使用全局配置，JobHistoryUnlimited 表示不限制
+	Status           string     `gorm:"type:varchar(20);default:ready;index" json:"status"`
+	DisabledBy       string     `gorm:"type:varchar(100)" json:"disabled_by"`
+	DisabledReason   string     `gorm:"type:text" json:"disabled_reason"`
+	DisabledAt       *time.Time `json:"disabled_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 
 	Versions []Version `gorm:"foreignKey:AlgorithmID" json:"versions,omitempty"`
 }
@@ -40,45 +58,90 @@ type Version struct {
 	MinioPath      string    `gorm:"type:text" json:"minio_path"`
 	SourceCodeFile string    `gorm:"type:text" json:"source_code_file"`
 	CommitMessage  string    `gorm:"type:text" json:"commit_message"`
+	ImageDigest    string    `gorm:"type:varchar(255)" json:"image_digest"` // 首次执行时解析的运行镜像摘要（repo@sha256:...），之后按摘要运行
+	ImageTag       string    `gorm:"type:varchar(255)" json:"image_tag"`    // 解析摘要时的镜像标签，配置的运行镜像变更后重新解析
 	CreatedAt      time.Time `json:"created_at"`
 
 	Algorithm Algorithm `gorm:"foreignKey:AlgorithmID" json:"algorithm,omitempty"`
 }
 
+// JobHistoryUnlimited 算法的 JobHistoryLimit 取该值时不清理任务，不受全局配置影响
+const JobHistoryUnlimited = -1
+
+// 任务状态，jobs 表的 chk_jobs_status 约束只接受这些值
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+	JobStatusTimeout   = "timeout"
+	JobStatusCancelled = "cancelled"
+)
+
+// 执行模式，jobs 表的 chk_jobs_mode 约束另外接受旧任务的空值和旧版本的 batch/streaming
+const (
+	ExecutionModeSync          = "sync"            // 阻塞等待结果
+	ExecutionModeAsync         = "async"           // 后台执行，结束后回调 webhook
+	ExecutionModeFireAndForget = "fire_and_forget" // 后台执行，不回调
+)
+
+// webhook 投递结果
+const (
+	WebhookStatusDelivered = "delivered"
+	WebhookStatusFailed    = "failed"
+)
+
 type Job struct {
-	ID            string     `gorm:"primaryKey;type:varchar(36)" json:"job_id"`
-	AlgorithmID   string     `gorm:"type:varchar(36);index" json:"algorithm_id"`
-	AlgorithmName string     `gorm:"type:varchar(255)" json:"algorithm_name"`
-	Mode          string     `gorm:"type:varchar(50)" json:"mode"`
-	Status        string     `gorm:"type:varchar(50);index" json:"status"`
-	InputParams   string     `gorm:"type:text" json:"input_params"`
-	InputURL      string     `gorm:"type:text" json:"input_url"`
-	OutputURL     string     `gorm:"type:text" json:"output_url"`
-	LogURL        string     `gorm:"type:text" json:"log_url"`
-	StartedAt     *time.Time `json:"started_at"`
-	FinishedAt    *time.Time `json:"finished_at"`
-	CostTimeMs    int64      `json:"cost_time_ms"`
-	WorkerID      string     `gorm:"type:varchar(36)" json:"worker_id"`
-	CreatedAt     time.Time  `json:"created_at"`
+	ID                string     `gorm:"primaryKey;type:varchar(36)" json:"job_id"`
+	AlgorithmID       string     `gorm:"type:varchar(36);index:idx_jobs_algorithm_created,priority:1" json:"algorithm_id"`
+	AlgorithmName     string     `gorm:"type:varchar(255)" json:"algorithm_name"`
+	Mode              string     `gorm:"type:varchar(50);check:chk_jobs_mode,mode IN ('', 'sync', 'async', 'fire_and_forget', 'batch', 'streaming')" json:"mode"`
+	Status            string     `gorm:"type:varchar(50);index:idx_jobs_status_created,priority:1;check:chk_jobs_status,status IN ('pending', 'running', 'completed', 'failed', 'timeout', 'cancelled')" json:"status"`
+	InputParams       string     `gorm:"type:text" json:"input_params"`
+	InputURL          string     `gorm:"type:text" json:"input_url"`
+	OutputURL         string     `gorm:"type:text" json:"output_url"`
+	LogURL            string     `gorm:"type:text" json:"log_url"`
+	StartedAt         *time.Time `json:"started_at"`
+	FinishedAt        *time.Time `json:"finished_at"`
+	ArtifactsExpireAt *time.Time `json:"artifacts_expire_at"` // 结果产物过期时间
+	CostTimeMs        int64      `json:"cost_time_ms"`
+	WorkerID          string     `gorm:"type:varchar(36)" json:"worker_id"`
+	VersionID         string     `gorm:"type:varchar(36)" json:"version_id"`         // 执行时算法的当前版本
+	RetriedFrom       string     `gorm:"type:varchar(36);index" json:"retried_from"` // 由重试创建时为原任务 ID
+	Request           string     `gorm:"type:text" json:"request"`                   // 原始执行请求（JSON），用于重试
+	Warning           string     `gorm:"type:text" json:"warning"`                   // 不影响任务结果的问题，如日志上传失败
+	FailureReason     string     `gorm:"type:text" json:"failure_reason"`            // 失败、超时或被中断的原因
+	WebhookStatus     string     `gorm:"type:varchar(20)" json:"webhook_status"`     // webhook 最终投递结果，未发送时为空
+	WebhookStatusCode int        `json:"webhook_status_code"`                        // 最后一次 webhook 请求的 HTTP 状态码
+	WebhookError      string     `gorm:"type:text" json:"webhook_error"`             // 投递失败的原因
+	CreatedAt         time.Time  `gorm:"index;index:idx_jobs_algorithm_created,priority:2;index:idx_jobs_status_created,priority:2" json:"created_at"`
 }
 
 type PresetData struct {
-	ID        string    `gorm:"primaryKey;type:varchar(36)" json:"id"`
-	Filename  string    `gorm:"type:varchar(255);not null" json:"filename"`
-	Category  string    `gorm:"type:varchar(255);index" json:"category"`
-	MinioPath string    `gorm:"type:text" json:"minio_path"` // MinIO路径
-	MinioURL  string    `gorm:"type:text" json:"minio_url"`  // 完整URL（已废弃，保留兼容性）
-	CreatedAt time.Time `json:"created_at"`
+	ID          string    `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	Filename    string    `gorm:"type:varchar(255);not null" json:"filename"`
+	Category    string    `gorm:"type:varchar(255);index" json:"category"`
+	MinioPath   string    `gorm:"type:text" json:"minio_path"`           // MinIO路径
+	MinioURL    string    `gorm:"type:text" json:"minio_url"`            // 完整URL（已废弃，保留兼容性）
+	Checksum    string    `gorm:"type:varchar(64)" json:"checksum"`      // 内容的 SHA-256（十六进制），旧数据和引用已有对象的记录为空，下载时不校验
+	Size        int64     `json:"size"`                                  // 文件大小（字节），旧数据为 0
+	ContentType string    `gorm:"type:varchar(255)" json:"content_type"` // 上传时检测的内容类型，旧数据为空，下载时使用对象的类型
+	CreatedAt   time.Time `json:"created_at"`
 }
 
-func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+// All 返回需要迁移的全部模型
+func All() []interface{} {
+	return []interface{}{
 		&DatabaseMetadata{},
 		&Algorithm{},
 		&Version{},
 		&Job{},
 		&PresetData{},
-	)
+	}
+}
+
+func AutoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(All()...)
 }
 
 func (Job) TableName() string {
@@ -0,0 +1,92 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidToken 分页令牌格式错误、签名不匹配或与请求不一致
+var ErrInvalidToken = errors.New("invalid page token")
+
+// State 分页令牌中保存的查询状态
+type State struct {
+	Endpoint string            `json:"e"` // 所属列表接口，防止令牌跨接口使用
+	Offset   int               `json:"o"`
+	Filters  map[string]string `json:"f,omitempty"`
+}
+
+// Codec 分页令牌编解码器，令牌为 base64(状态).base64(HMAC-SHA256 签名)，对客户端不透明
+type Codec struct {
+	key []byte
+}
+
+// NewCodec 创建编解码器，secret 为空时使用随机密钥（重启或多副本之间令牌不通用）
+func NewCodec(secret string) *Codec {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic(fmt.Sprintf("failed to generate page token key: %v", err))
+		}
+	}
+	return &Codec{key: key}
+}
+
+// Encode 生成分页令牌
+func (c *Codec) Encode(state State) string {
+	payload, _ := json.Marshal(state)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload))
+}
+
+// Decode 校验并解析分页令牌，令牌必须属于 endpoint 且过滤条件与本次请求一致
+func (c *Codec) Decode(token, endpoint string, filters map[string]string) (State, error) {
+	encodedPayload, encodedSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return State{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return State{}, ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil || !hmac.Equal(sig, c.sign(payload)) {
+		return State{}, ErrInvalidToken
+	}
+
+	var state State
+	if err := json.Unmarshal(payload, &state); err != nil {
+		return State{}, ErrInvalidToken
+	}
+	if state.Endpoint != endpoint || state.Offset < 0 || !sameFilters(state.Filters, filters) {
+		return State{}, ErrInvalidToken
+	}
+	return state, nil
+}
+
+func (c *Codec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// sameFilters 比较过滤条件，空值等同于未设置
+func sameFilters(a, b map[string]string) bool {
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	for k, v := range b {
+		if a[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTokenRoundTrip(t *testing.T) {
+	codec := NewCodec("secret")
+	filters := map[string]string{"status": "failed"}
+
+	token := codec.Encode(State{Endpoint: "jobs", Offset: 40, Filters: filters})
+	state, err := codec.Decode(token, "jobs", filters)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if state.Offset != 40 {
+		t.Errorf("Expected offset 40, got %d", state.Offset)
+	}
+}
+
+func TestTokenRejectsTampering(t *testing.T) {
+	codec := NewCodec("secret")
+	filters := map[string]string{"status": "failed"}
+	token := codec.Encode(State{Endpoint: "jobs", Offset: 40, Filters: filters})
+
+	// 修改载荷中的偏移量，签名不再匹配
+	payload, sig, _ := strings.Cut(token, ".")
+	raw, _ := base64.RawURLEncoding.DecodeString(payload)
+	forged := base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(raw), "40", "0", 1))) + "." + sig
+
+	cases := map[string]struct {
+		token    string
+		endpoint string
+		filters  map[string]string
+	}{
+		"forged payload":   {forged, "jobs", filters},
+		"garbage":          {"not-a-token", "jobs", filters},
+		"other endpoint":   {token, "algorithms", filters},
+		"changed filters":  {token, "jobs", map[string]string{"status": "completed"}},
+		"dropped filters":  {token, "jobs", nil},
+		"different secret": {NewCodec("other").Encode(State{Endpoint: "jobs", Offset: 40, Filters: filters}), "jobs", filters},
+	}
+	for name, tc := range cases {
+		if _, err := codec.Decode(tc.token, tc.endpoint, tc.filters); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: expected ErrInvalidToken, got %v", name, err)
+		}
+	}
+}
+
+func TestEmptyFilterValuesAreIgnored(t *testing.T) {
+	codec := NewCodec("")
+	token := codec.Encode(State{Endpoint: "data", Offset: 10})
+	if _, err := codec.Decode(token, "data", map[string]string{"category": ""}); err != nil {
+		t.Errorf("Expected empty filter to match missing filter, got %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+// Package paramschema 校验算法执行参数。算法可以声明一个 JSON Schema（支持常用子集），
+// 执行请求的参数都是字符串，按属性声明的类型解析后再校验约束。
+package paramschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// 支持的属性类型
+const (
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+)
+
+// Schema 参数的 JSON Schema，顶层必须是 object
+type Schema struct {
+	Type                 string               `json:"type"`
+	Properties           map[string]*Property `json:"properties"`
+	Required             []string             `json:"required"`
+	AdditionalProperties *bool                `json:"additionalProperties"` // 为 false 时拒绝未声明的参数
+}
+
+// Property 单个参数的约束
+type Property struct {
+	Type        string        `json:"type"`
+	Description string        `json:"description"`
+	Enum        []interface{} `json:"enum"`
+	Minimum     *float64      `json:"minimum"`
+	Maximum     *float64      `json:"maximum"`
+	MinLength   *int          `json:"minLength"`
+	MaxLength   *int          `json:"maxLength"`
+	Pattern     string        `json:"pattern"`
+
+	pattern *regexp.Regexp
+}
+
+// Parse 解析并检查 schema，使用了不支持的类型或无效的正则时返回错误
+func Parse(data string) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		return nil, fmt.Errorf("invalid params schema: %w", err)
+	}
+	if s.Type != "" && s.Type != "object" {
+		return nil, fmt.Errorf("invalid params schema: top-level type must be object, got %q", s.Type)
+	}
+
+	for name, prop := range s.Properties {
+		if prop == nil {
+			return nil, fmt.Errorf("invalid params schema: property %q is empty", name)
+		}
+		switch prop.Type {
+		case "", TypeString, TypeInteger, TypeNumber, TypeBoolean:
+		default:
+			return nil, fmt.Errorf("invalid params schema: property %q has unsupported type %q", name, prop.Type)
+		}
+		if prop.Pattern != "" {
+			re, err := regexp.Compile(prop.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid params schema: property %q has invalid pattern: %w", name, err)
+			}
+			prop.pattern = re
+		}
+	}
+	for _, name := range s.Required {
+		if _, ok := s.Properties[name]; !ok {
+			return nil, fmt.Errorf("invalid params schema: required property %q is not declared", name)
+		}
+	}
+	return &s, nil
+}
+
+// Validate 校验参数，返回所有校验错误（按参数名排序），通过时返回 nil
+func (s *Schema) Validate(params map[string]string) []string {
+	var errs []string
+
+	for _, name := range s.Required {
+		if _, ok := params[name]; !ok {
+			errs = append(errs, fmt.Sprintf("%s: is required", name))
+		}
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				errs = append(errs, fmt.Sprintf("%s: unknown parameter", name))
+			}
+			continue
+		}
+		if err := prop.validate(params[name]); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	return errs
+}
+
+// validate 按类型解析参数值并检查约束
+func (p *Property) validate(value string) error {
+	switch p.Type {
+	case TypeInteger:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("must be an integer, got %q", value)
+		}
+		return p.checkNumber(float64(n))
+	case TypeNumber:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("must be a number, got %q", value)
+		}
+		return p.checkNumber(f)
+	case TypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("must be a boolean, got %q", value)
+		}
+		return p.checkEnum(b)
+	default:
+		length := len([]rune(value))
+		if p.MinLength != nil && length < *p.MinLength {
+			return fmt.Errorf("must be at least %d characters", *p.MinLength)
+		}
+		if p.MaxLength != nil && length > *p.MaxLength {
+			return fmt.Errorf("must be at most %d characters", *p.MaxLength)
+		}
+		if p.pattern != nil && !p.pattern.MatchString(value) {
+			return fmt.Errorf("must match pattern %s", p.Pattern)
+		}
+		return p.checkEnum(value)
+	}
+}
+
+func (p *Property) checkNumber(f float64) error {
+	if p.Minimum != nil && f < *p.Minimum {
+		return fmt.Errorf("must be >= %v", *p.Minimum)
+	}
+	if p.Maximum != nil && f > *p.Maximum {
+		return fmt.Errorf("must be <= %v", *p.Maximum)
+	}
+	return p.checkEnum(f)
+}
+
+// checkEnum 检查值是否在枚举中，JSON 数字解析为 float64
+func (p *Property) checkEnum(v interface{}) error {
+	if len(p.Enum) == 0 {
+		return nil
+	}
+	for _, allowed := range p.Enum {
+		if allowed == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %v", p.Enum)
+}
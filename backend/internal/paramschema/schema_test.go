@@ -0,0 +1,70 @@
+package paramschema
+
+import (
+	"strings"
+	"testing"
+)
+
+const detectorSchema = `{
+	"type": "object",
+	"properties": {
+		"threshold": {"type": "number", "minimum": 0, "maximum": 1},
+		"max_boxes": {"type": "integer", "minimum": 1},
+		"mode":      {"type": "string", "enum": ["fast", "accurate"]},
+		"label":     {"type": "string", "pattern": "^[a-z_]+$", "maxLength": 16},
+		"debug":     {"type": "boolean"}
+	},
+	"required": ["threshold"],
+	"additionalProperties": false
+}`
+
+func TestValidate(t *testing.T) {
+	schema, err := Parse(detectorSchema)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		params  map[string]string
+		wantErr string
+	}{
+		{"valid", map[string]string{"threshold": "0.5", "max_boxes": "10", "mode": "fast", "label": "car", "debug": "true"}, ""},
+		{"missing required", map[string]string{"mode": "fast"}, "threshold: is required"},
+		{"out of range", map[string]string{"threshold": "1.5"}, "threshold: must be <= 1"},
+		{"NaN", map[string]string{"threshold": "NaN"}, "threshold: must be a number"},
+		{"infinity", map[string]string{"threshold": "-Inf"}, "threshold: must be a number"},
+		{"not an integer", map[string]string{"threshold": "0.5", "max_boxes": "2.5"}, "max_boxes: must be an integer"},
+		{"not in enum", map[string]string{"threshold": "0.5", "mode": "slow"}, "mode: must be one of"},
+		{"pattern", map[string]string{"threshold": "0.5", "label": "Car"}, "label: must match pattern"},
+		{"boolean", map[string]string{"threshold": "0.5", "debug": "maybe"}, "debug: must be a boolean"},
+		{"typo", map[string]string{"threshold": "0.5", "treshold": "0.1"}, "treshold: unknown parameter"},
+	}
+
+	for _, tt := range tests {
+		errs := schema.Validate(tt.params)
+		if tt.wantErr == "" {
+			if len(errs) != 0 {
+				t.Errorf("%s: unexpected errors %v", tt.name, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || !strings.Contains(errs[0], tt.wantErr) {
+			t.Errorf("%s: errors = %v, want one containing %q", tt.name, errs, tt.wantErr)
+		}
+	}
+}
+
+func TestParseRejectsInvalidSchemas(t *testing.T) {
+	for _, data := range []string{
+		`not json`,
+		`{"type": "array"}`,
+		`{"properties": {"x": {"type": "object"}}}`,
+		`{"properties": {"x": {"type": "string", "pattern": "("}}}`,
+		`{"properties": {}, "required": ["x"]}`,
+	} {
+		if _, err := Parse(data); err == nil {
+			t.Errorf("Parse(%s) should fail", data)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Package redact 提供日志和配置输出时统一使用的敏感信息脱敏工具
+package redact
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Placeholder 脱敏后的占位值
+const Placeholder = "******"
+
+// sensitiveKeyParts 键名（忽略大小写）包含这些片段时视为敏感
+var sensitiveKeyParts = []string{
+	"password", "passwd", "secret", "token", "credential", "authorization", "apikey", "api_key", "access_key", "private_key",
+}
+
+// IsSensitiveKey 判断键名是否属于需要脱敏的密钥、密码或令牌
+func IsSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// String 非空时返回占位值，空值保持为空以便区分“未配置”
+func String(s string) string {
+	if s == "" {
+		return ""
+	}
+	return Placeholder
+}
+
+// URL 隐藏 URL 中的用户密码和敏感查询参数，无法解析时整体替换为占位值
+func URL(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Placeholder
+	}
+	if u.RawQuery != "" {
+		pairs := strings.Split(u.RawQuery, "&")
+		for i, pair := range pairs {
+			key, _, _ := strings.Cut(pair, "=")
+			if name, err := url.QueryUnescape(key); err == nil && isSensitiveQueryKey(name) {
+				pairs[i] = key + "=" + Placeholder
+			}
+		}
+		u.RawQuery = strings.Join(pairs, "&")
+	}
+	// Redacted 将用户密码替换为 xxxxx
+	return u.Redacted()
+}
+
+// isSensitiveQueryKey 查询参数除敏感键名外，签名参数（如预签名 URL 的 X-Amz-Signature）同样需要隐藏
+func isSensitiveQueryKey(key string) bool {
+	lower := strings.ToLower(key)
+	return IsSensitiveKey(key) || lower == "sig" || strings.HasSuffix(lower, "signature")
+}
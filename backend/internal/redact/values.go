@@ -0,0 +1,73 @@
+package redact
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Values 将文本中出现的指定值（如注入容器的密钥）替换为占位值，为 nil 时不做替换
+type Values struct {
+	replacer *strings.Replacer
+}
+
+// NewValues 为非空的值创建替换器，没有需要替换的值时返回 nil
+func NewValues(values []string) *Values {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		if value != "" && !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+	// 较长的值优先替换，避免一个值是另一个的前缀时只替换一部分
+	sort.Slice(unique, func(i, j int) bool { return len(unique[i]) > len(unique[j]) })
+
+	args := make([]string, 0, 2*len(unique))
+	for _, value := range unique {
+		args = append(args, value, Placeholder)
+	}
+	return &Values{replacer: strings.NewReplacer(args...)}
+}
+
+// String 返回替换后的文本
+func (v *Values) String(s string) string {
+	if v == nil {
+		return s
+	}
+	return v.replacer.Replace(s)
+}
+
+// Reader 逐行替换 src 中的值。值不含换行时不会被拆分到两次读取中
+func (v *Values) Reader(src io.Reader) io.Reader {
+	if v == nil {
+		return src
+	}
+	return &valuesReader{values: v, src: bufio.NewReader(src)}
+}
+
+type valuesReader struct {
+	values  *Values
+	src     *bufio.Reader
+	pending []byte
+	err     error
+}
+
+func (r *valuesReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		var line string
+		line, r.err = r.src.ReadString('\n')
+		r.pending = []byte(r.values.String(line))
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
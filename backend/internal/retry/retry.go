@@ -0,0 +1,95 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Policy 重试策略
+type Policy struct {
+	MaxAttempts int           // 最大尝试次数（含第一次），小于 1 时按 1 处理
+	BaseDelay   time.Duration // 第一次重试前的等待时间，之后按 2 的幂增长
+	MaxDelay    time.Duration // 单次等待上限，0 表示不限制
+	Jitter      float64       // 随机抖动比例（0~1），避免多个调用方同时重试
+	// Retryable 判断错误是否可重试，为 nil 时所有错误都重试
+	Retryable func(error) bool
+	// OnRetry 每次重试前回调，用于打印日志
+	OnRetry func(attempt int, delay time.Duration, err error)
+}
+
+// Default 默认策略：最多 3 次，100ms 起步，上限 5s，20% 抖动
+func Default() Policy {
+	return Policy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// Backoff 返回第 attempt 次失败后的等待时间（attempt 从 1 开始）
+func (p Policy) Backoff(attempt int) time.Duration {
+	return p.backoff(attempt, rand.Float64())
+}
+
+// backoff 根据给定的随机数 r（0~1）计算等待时间，便于测试
+func (p Policy) backoff(attempt int, r float64) time.Duration {
+	if attempt < 1 || p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	if p.Jitter > 0 {
+		// 在 [1-jitter, 1+jitter] 范围内随机缩放
+		delay *= 1 + p.Jitter*(2*r-1)
+	}
+
+	return time.Duration(delay)
+}
+
+// Do 按策略执行 fn，直到成功、遇到不可重试的错误、次数用尽或 ctx 取消
+func Do(ctx context.Context, p Policy, fn func() error) error {
+	attempts := p.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		if p.Retryable != nil && !p.Retryable(err) {
+			return err
+		}
+		if attempt == attempts {
+			break
+		}
+
+		delay := p.Backoff(attempt)
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, delay, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("retry aborted: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+
+	if attempts == 1 {
+		return err
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+}
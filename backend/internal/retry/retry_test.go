@@ -0,0 +1,112 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoffGrowsExponentiallyAndCaps(t *testing.T) {
+	p := Policy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	want := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for i, w := range want {
+		if got := p.backoff(i+1, 0.5); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+
+	if got := p.backoff(0, 0.5); got != 0 {
+		t.Errorf("backoff(0) = %v, want 0", got)
+	}
+}
+
+func TestBackoffJitterBounds(t *testing.T) {
+	p := Policy{BaseDelay: time.Second, Jitter: 0.2}
+
+	if got := p.backoff(1, 0); got != 800*time.Millisecond {
+		t.Errorf("Lower jitter bound = %v, want 800ms", got)
+	}
+	if got := p.backoff(1, 1); got != 1200*time.Millisecond {
+		t.Errorf("Upper jitter bound = %v, want 1.2s", got)
+	}
+
+	for i := 0; i < 100; i++ {
+		got := p.Backoff(2)
+		if got < 1600*time.Millisecond || got > 2400*time.Millisecond {
+			t.Fatalf("Backoff(2) = %v outside jitter range", got)
+		}
+	}
+}
+
+func TestDoRetriesUntilSuccess(t *testing.T) {
+	p := Policy{MaxAttempts: 5, BaseDelay: time.Millisecond}
+
+	calls := 0
+	err := Do(context.Background(), p, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("temporary")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestDoStopsOnNonRetryableError(t *testing.T) {
+	permanent := errors.New("permanent")
+	p := Policy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Millisecond,
+		Retryable:   func(err error) bool { return !errors.Is(err, permanent) },
+	}
+
+	calls := 0
+	err := Do(context.Background(), p, func() error {
+		calls++
+		return permanent
+	})
+	if !errors.Is(err, permanent) || calls != 1 {
+		t.Errorf("Expected single attempt with permanent error, got calls=%d err=%v", calls, err)
+	}
+}
+
+func TestDoGivesUpAfterMaxAttempts(t *testing.T) {
+	p := Policy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	retries := 0
+	p.OnRetry = func(attempt int, delay time.Duration, err error) { retries++ }
+
+	temporary := errors.New("temporary")
+	err := Do(context.Background(), p, func() error { return temporary })
+	if !errors.Is(err, temporary) {
+		t.Errorf("Expected wrapped last error, got %v", err)
+	}
+	if retries != 2 {
+		t.Errorf("Expected 2 retries, got %d", retries)
+	}
+}
+
+func TestDoRespectsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := Policy{MaxAttempts: 3, BaseDelay: time.Hour}
+	err := Do(ctx, p, func() error { return errors.New("temporary") })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context cancellation, got %v", err)
+	}
+}
@@ -2,28 +2,91 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"algorithm-platform/pkg/docker"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
 )
 
+// ContainerClient 调度器使用的容器操作，由 docker.Client 实现
+type ContainerClient interface {
+	CreateContainer(ctx context.Context, name string, cfg docker.ContainerConfig) (string, error)
+	StartContainer(ctx context.Context, id string) error
+	StopContainer(ctx context.Context, id string) error
+	RemoveContainer(ctx context.Context, id string, force bool) error
+	GetContainerStatus(ctx context.Context, id string) (container.InspectResponse, error)
+	ListContainers(ctx context.Context, filterLabels map[string][]string) ([]types.Container, error)
+	ImageDigest(ctx context.Context, imageRef string) (string, error)
+}
+
+// ImagePullError 任务的镜像拉取失败或本地不存在
+type ImagePullError struct {
+	Image string
+	Err   error
+}
+
+func (e *ImagePullError) Error() string {
+	return fmt.Sprintf("image %s is not available: %v", e.Image, e.Err)
+}
+
+func (e *ImagePullError) Unwrap() error {
+	return e.Err
+}
+
+// platformLabel 平台创建的所有容器都带有该标签，用于清理
+const platformLabel = "algorithm_platform"
+
 type Scheduler struct {
-	dockerClient *docker.Client
+	dockerClient ContainerClient
+	warmPool     *WarmPool
+
+	// 容器 inspect 结果的短时缓存，避免频繁查看任务详情时反复请求 Docker
+	inspectMu    sync.Mutex
+	inspectCache map[string]cachedContainerInfo
 }
 
-func New(dockerClient *docker.Client) *Scheduler {
+func New(dockerClient ContainerClient, warmPool *WarmPool) *Scheduler {
 	return &Scheduler{
 		dockerClient: dockerClient,
+		warmPool:     warmPool,
+		inspectCache: make(map[string]cachedContainerInfo),
 	}
 }
 
+// 容器信息缓存时间：运行中的容器状态变化快，已退出的容器状态不会再变
+const (
+	containerInfoTTL       = 5 * time.Second
+	exitedContainerInfoTTL = 5 * time.Minute
+)
+
+// ContainerInfo 任务容器在 Docker 中的实际状态
+type ContainerInfo struct {
+	ID         string
+	State      string // created, running, exited, dead 等
+	ExitCode   int
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	OOMKilled  bool
+}
+
+type cachedContainerInfo struct {
+	info     *ContainerInfo
+	cachedAt time.Time
+}
+
 type JobConfig struct {
 	Image       string
 	AlgorithmID string
 	JobID       string
 	Env         map[string]string
 	Mounts      []docker.Mount
+	Command     []string // 覆盖镜像的 ENTRYPOINT，为空时使用镜像默认命令
+	WorkingDir  string
 	ResourceConfig
 	TimeoutSeconds int
 }
@@ -33,7 +96,22 @@ type ResourceConfig struct {
 	MemoryMB int
 }
 
-func (s *Scheduler) RunJob(ctx context.Context, cfg JobConfig) error {
+// ResolveImageDigest 确保镜像已拉取并返回其摘要引用（repo@sha256:...）
+func (s *Scheduler) ResolveImageDigest(ctx context.Context, image string) (string, error) {
+	if s.warmPool != nil {
+		if err := s.warmPool.EnsureImage(ctx, image); err != nil {
+			return "", err
+		}
+	}
+	digest, err := s.dockerClient.ImageDigest(ctx, image)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve digest of %s: %w", image, err)
+	}
+	return digest, nil
+}
+
+// RunJob 创建并启动任务容器，返回容器 ID
+func (s *Scheduler) RunJob(ctx context.Context, cfg JobConfig) (string, error) {
 	containerName := fmt.Sprintf("alg_%s_%s", cfg.AlgorithmID, cfg.JobID)
 
 	env := make([]string, 0, len(cfg.Env))
@@ -42,27 +120,47 @@ func (s *Scheduler) RunJob(ctx context.Context, cfg JobConfig) error {
 	}
 
 	dockerCfg := docker.ContainerConfig{
-		Image:    cfg.Image,
-		Env:      env,
-		Mounts:   cfg.Mounts,
-		CPULimit: cfg.CPULimit,
-		MemoryMB: cfg.MemoryMB,
+		Image:      cfg.Image,
+		Entrypoint: cfg.Command,
+		Env:        env,
+		WorkingDir: cfg.WorkingDir,
+		Mounts:     cfg.Mounts,
+		CPULimit:   cfg.CPULimit,
+		MemoryMB:   cfg.MemoryMB,
 		Labels: map[string]string{
+			platformLabel:  "1",
 			"job_id":       cfg.JobID,
 			"algorithm_id": cfg.AlgorithmID,
 		},
 	}
 
+	// 镜像已预热时跳过拉取
+	if s.warmPool != nil {
+		if err := s.warmPool.EnsureImage(ctx, cfg.Image); err != nil {
+			if ctx.Err() != nil {
+				return "", err
+			}
+			return "", &ImagePullError{Image: cfg.Image, Err: err}
+		}
+	}
+
 	containerID, err := s.dockerClient.CreateContainer(ctx, containerName, dockerCfg)
+	if errors.Is(err, docker.ErrImageNotFound) {
+		return "", &ImagePullError{Image: cfg.Image, Err: err}
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create container: %w", err)
+		return "", fmt.Errorf("failed to create container: %w", err)
 	}
 
 	if err := s.dockerClient.StartContainer(ctx, containerID); err != nil {
-		return fmt.Errorf("failed to start container: %w", err)
+		// 启动失败的容器不会再被使用，避免同名容器阻塞重试
+		if rmErr := s.dockerClient.RemoveContainer(context.WithoutCancel(ctx), containerID, true); rmErr != nil {
+			fmt.Printf("Warning: failed to remove container %s: %v\n", containerID, rmErr)
+		}
+		return "", fmt.Errorf("failed to start container: %w", err)
 	}
 
-	return nil
+	return containerID, nil
 }
 
 func (s *Scheduler) StopJob(ctx context.Context, jobID string) error {
@@ -132,7 +230,7 @@ func (s *Scheduler) GetJobStatus(ctx context.Context, jobID string) (string, int
 
 func (s *Scheduler) CleanUp(ctx context.Context, olderThan time.Duration) error {
 	filters := map[string][]string{
-		"label": {"algorithm_platform=1"},
+		"label": {platformLabel + "=1"},
 	}
 
 	containers, err := s.dockerClient.ListContainers(ctx, filters)
@@ -152,3 +250,95 @@ func (s *Scheduler) CleanUp(ctx context.Context, olderThan time.Duration) error
 
 	return nil
 }
+
+// CleanupStaleContainers 清理上次运行遗留的算法容器
+// isJobRunning 为 nil 时清理全部平台容器（无状态 worker），否则保留仍在运行的任务对应的容器
+func (s *Scheduler) CleanupStaleContainers(ctx context.Context, isJobRunning func(jobID string) bool) (int, error) {
+	containers, err := s.dockerClient.ListContainers(ctx, map[string][]string{
+		"label": {platformLabel + "=1"},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list platform containers: %w", err)
+	}
+
+	removed := 0
+	for _, c := range containers {
+		jobID := c.Labels["job_id"]
+		if isJobRunning != nil && jobID != "" && isJobRunning(jobID) {
+			continue
+		}
+
+		if c.State == "running" {
+			if err := s.dockerClient.StopContainer(ctx, c.ID); err != nil {
+				fmt.Printf("Warning: failed to stop stale container %s (job %s): %v\n", c.ID, jobID, err)
+			}
+		}
+		if err := s.dockerClient.RemoveContainer(ctx, c.ID, true); err != nil {
+			fmt.Printf("Warning: failed to remove stale container %s (job %s): %v\n", c.ID, jobID, err)
+			continue
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// InspectJobContainer 通过标签查找任务容器并返回其实际状态，找不到容器时返回 nil
+func (s *Scheduler) InspectJobContainer(ctx context.Context, jobID string) (*ContainerInfo, error) {
+	s.inspectMu.Lock()
+	if cached, ok := s.inspectCache[jobID]; ok {
+		ttl := containerInfoTTL
+		if cached.info != nil && cached.info.State == "exited" {
+			ttl = exitedContainerInfoTTL
+		}
+		if time.Since(cached.cachedAt) < ttl {
+			s.inspectMu.Unlock()
+			return cached.info, nil
+		}
+	}
+	s.inspectMu.Unlock()
+
+	containers, err := s.dockerClient.ListContainers(ctx, map[string][]string{
+		"label": {fmt.Sprintf("job_id=%s", jobID)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job containers: %w", err)
+	}
+
+	var info *ContainerInfo
+	if len(containers) > 0 {
+		inspect, err := s.dockerClient.GetContainerStatus(ctx, containers[0].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect container: %w", err)
+		}
+
+		info = &ContainerInfo{ID: inspect.ID}
+		if inspect.State != nil {
+			info.State = string(inspect.State.Status)
+			info.ExitCode = inspect.State.ExitCode
+			info.OOMKilled = inspect.State.OOMKilled
+			info.StartedAt = parseDockerTime(inspect.State.StartedAt)
+			info.FinishedAt = parseDockerTime(inspect.State.FinishedAt)
+		}
+	}
+
+	s.inspectMu.Lock()
+	for id, cached := range s.inspectCache {
+		if time.Since(cached.cachedAt) > exitedContainerInfoTTL {
+			delete(s.inspectCache, id)
+		}
+	}
+	s.inspectCache[jobID] = cachedContainerInfo{info: info, cachedAt: time.Now()}
+	s.inspectMu.Unlock()
+
+	return info, nil
+}
+
+// parseDockerTime 解析 Docker 返回的时间，未设置（零值）时返回 nil
+func parseDockerTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil || t.IsZero() || t.Year() <= 1 {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,199 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+
+	"algorithm-platform/pkg/docker"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+)
+
+type fakeContainerClient struct {
+	containers []types.Container
+	inspect    container.InspectResponse
+	inspects   int
+	filters    map[string][]string
+	stopped    []string
+	removed    []string
+	created    docker.ContainerConfig
+	createErr  error
+	startErr   error
+	digest     string
+}
+
+func (f *fakeContainerClient) CreateContainer(ctx context.Context, name string, cfg docker.ContainerConfig) (string, error) {
+	f.created = cfg
+	if f.createErr != nil {
+		return "", f.createErr
+	}
+	return name, nil
+}
+
+func (f *fakeContainerClient) StartContainer(ctx context.Context, id string) error {
+	return f.startErr
+}
+
+func (f *fakeContainerClient) StopContainer(ctx context.Context, id string) error {
+	f.stopped = append(f.stopped, id)
+	return nil
+}
+
+func (f *fakeContainerClient) RemoveContainer(ctx context.Context, id string, force bool) error {
+	f.removed = append(f.removed, id)
+	return nil
+}
+
+func (f *fakeContainerClient) GetContainerStatus(ctx context.Context, id string) (container.InspectResponse, error) {
+	f.inspects++
+	return f.inspect, nil
+}
+
+func (f *fakeContainerClient) ListContainers(ctx context.Context, filterLabels map[string][]string) ([]types.Container, error) {
+	f.filters = filterLabels
+	return f.containers, nil
+}
+
+func (f *fakeContainerClient) ImageDigest(ctx context.Context, imageRef string) (string, error) {
+	if f.digest == "" {
+		return "", errors.New("no such image")
+	}
+	return f.digest, nil
+}
+
+func staleContainers() []types.Container {
+	return []types.Container{
+		{ID: "c1", State: "running", Labels: map[string]string{platformLabel: "1", "job_id": "job_running"}},
+		{ID: "c2", State: "running", Labels: map[string]string{platformLabel: "1", "job_id": "job_failed"}},
+		{ID: "c3", State: "exited", Labels: map[string]string{platformLabel: "1", "job_id": "job_done"}},
+	}
+}
+
+func TestRunJob(t *testing.T) {
+	client := &fakeContainerClient{}
+	s := New(client, nil)
+
+	id, err := s.RunJob(context.Background(), JobConfig{
+		Image:          "python:3.11",
+		AlgorithmID:    "alg_1",
+		JobID:          "job_1",
+		Command:        []string{"python", "main.py"},
+		WorkingDir:     "/app",
+		ResourceConfig: ResourceConfig{CPULimit: 2, MemoryMB: 256},
+	})
+	if err != nil || id != "alg_alg_1_job_1" {
+		t.Fatalf("RunJob() = %q, %v", id, err)
+	}
+	if !slices.Equal(client.created.Entrypoint, []string{"python", "main.py"}) || client.created.WorkingDir != "/app" {
+		t.Errorf("Unexpected container config: %+v", client.created)
+	}
+	if client.created.CPULimit != 2 || client.created.MemoryMB != 256 || client.created.Labels["job_id"] != "job_1" {
+		t.Errorf("Resources or labels not applied: %+v", client.created)
+	}
+
+	client.startErr = errors.New("port already allocated")
+	_, err = s.RunJob(context.Background(), JobConfig{AlgorithmID: "alg_1", JobID: "job_2"})
+	if err == nil {
+		t.Fatal("Expected start error")
+	}
+	if !slices.Equal(client.removed, []string{"alg_alg_1_job_2"}) {
+		t.Errorf("Expected container that failed to start to be removed, got %v", client.removed)
+	}
+	var pullErr *ImagePullError
+	if errors.As(err, &pullErr) {
+		t.Errorf("A start failure should not be reported as a pull failure: %v", err)
+	}
+
+	client.createErr = fmt.Errorf("%w: python:3.11", docker.ErrImageNotFound)
+	_, err = s.RunJob(context.Background(), JobConfig{Image: "python:3.11", AlgorithmID: "alg_1", JobID: "job_3"})
+	if !errors.As(err, &pullErr) || pullErr.Image != "python:3.11" {
+		t.Errorf("Expected an image pull error for a missing image, got %v", err)
+	}
+}
+
+func TestCleanupStaleContainersKeepsRunningJobs(t *testing.T) {
+	client := &fakeContainerClient{containers: staleContainers()}
+	s := New(client, nil)
+
+	removed, err := s.CleanupStaleContainers(context.Background(), func(jobID string) bool {
+		return jobID == "job_running"
+	})
+	if err != nil {
+		t.Fatalf("CleanupStaleContainers failed: %v", err)
+	}
+
+	if got := client.filters["label"]; len(got) != 1 || got[0] != platformLabel+"=1" {
+		t.Errorf("Expected containers to be filtered by platform label, got %v", got)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 containers removed, got %d", removed)
+	}
+
+	sort.Strings(client.removed)
+	if len(client.removed) != 2 || client.removed[0] != "c2" || client.removed[1] != "c3" {
+		t.Errorf("Unexpected removed containers: %v", client.removed)
+	}
+	// 只有运行中的容器需要先停止
+	if len(client.stopped) != 1 || client.stopped[0] != "c2" {
+		t.Errorf("Unexpected stopped containers: %v", client.stopped)
+	}
+}
+
+func TestCleanupStaleContainersStatelessRemovesAll(t *testing.T) {
+	client := &fakeContainerClient{containers: staleContainers()}
+	s := New(client, nil)
+
+	removed, err := s.CleanupStaleContainers(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("CleanupStaleContainers failed: %v", err)
+	}
+	if removed != 3 {
+		t.Errorf("Expected all 3 containers removed, got %d", removed)
+	}
+}
+
+func TestInspectJobContainer(t *testing.T) {
+	client := &fakeContainerClient{
+		containers: []types.Container{{ID: "c1", Labels: map[string]string{"job_id": "job_1"}}},
+		inspect: container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{
+				ID: "c1",
+				State: &container.State{
+					Status:     "exited",
+					ExitCode:   137,
+					OOMKilled:  true,
+					StartedAt:  "2026-01-02T03:04:05.123456789Z",
+					FinishedAt: "0001-01-01T00:00:00Z",
+				},
+			},
+		},
+	}
+	s := New(client, nil)
+
+	info, err := s.InspectJobContainer(context.Background(), "job_1")
+	if err != nil {
+		t.Fatalf("InspectJobContainer failed: %v", err)
+	}
+	if info.State != "exited" || info.ExitCode != 137 || !info.OOMKilled {
+		t.Errorf("Unexpected container info: %+v", info)
+	}
+	if info.StartedAt == nil || info.StartedAt.Year() != 2026 {
+		t.Errorf("Expected parsed start time, got %v", info.StartedAt)
+	}
+	if info.FinishedAt != nil {
+		t.Errorf("Expected zero finish time to be nil, got %v", info.FinishedAt)
+	}
+
+	// 第二次查询命中缓存
+	if _, err := s.InspectJobContainer(context.Background(), "job_1"); err != nil {
+		t.Fatalf("InspectJobContainer failed: %v", err)
+	}
+	if client.inspects != 1 {
+		t.Errorf("Expected cached result to be reused, got %d inspects", client.inspects)
+	}
+}
@@ -0,0 +1,216 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 镜像预热状态
+const (
+	ImageStatusUnknown = "unknown"
+	ImageStatusPulling = "pulling"
+	ImageStatusReady   = "ready"
+	ImageStatusFailed  = "failed"
+)
+
+// pinUseThreshold 使用次数达到该值的镜像视为常用镜像，会被定期检查并保持预热
+const pinUseThreshold = 3
+
+// imagePullTimeout 后台拉取单个镜像的超时时间
+const imagePullTimeout = 10 * time.Minute
+
+// ImagePuller 预热所需的镜像操作，由 docker.Client 实现
+type ImagePuller interface {
+	PullImage(ctx context.Context, imageRef string) error
+	ImageExists(ctx context.Context, imageRef string) (bool, error)
+}
+
+type imageState struct {
+	status   string
+	err      error
+	pinned   bool
+	useCount int
+	lastUsed time.Time
+	done     chan struct{} // 拉取完成时关闭
+}
+
+// WarmPool 镜像预热池，提前在后台拉取算法镜像，避免任务首次执行时等待拉取
+type WarmPool struct {
+	puller ImagePuller
+	mu     sync.Mutex
+	images map[string]*imageState
+	stop   chan struct{}
+}
+
+// NewWarmPool 创建镜像预热池
+func NewWarmPool(puller ImagePuller) *WarmPool {
+	return &WarmPool{
+		puller: puller,
+		images: make(map[string]*imageState),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Prewarm 在后台拉取镜像，已就绪或正在拉取的镜像会被忽略
+func (p *WarmPool) Prewarm(image string) {
+	if image == "" {
+		return
+	}
+
+	p.mu.Lock()
+	state, started := p.startPullLocked(image)
+	p.mu.Unlock()
+
+	if started {
+		go p.pull(image, state)
+	}
+}
+
+// Pin 固定镜像，固定的镜像会立即预热并被定期检查
+func (p *WarmPool) Pin(image string) {
+	p.mu.Lock()
+	p.stateLocked(image).pinned = true
+	p.mu.Unlock()
+
+	p.Prewarm(image)
+}
+
+// Status 返回镜像的预热状态
+func (p *WarmPool) Status(image string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if state, ok := p.images[image]; ok {
+		return state.status
+	}
+	return ImageStatusUnknown
+}
+
+// EnsureImage 确保镜像在本地可用：已预热时直接返回，正在拉取时等待，否则同步拉取
+func (p *WarmPool) EnsureImage(ctx context.Context, image string) error {
+	p.mu.Lock()
+	state := p.stateLocked(image)
+	state.useCount++
+	state.lastUsed = time.Now()
+	if state.useCount >= pinUseThreshold {
+		state.pinned = true
+	}
+
+	if state.status == ImageStatusReady {
+		p.mu.Unlock()
+		return nil
+	}
+
+	state, started := p.startPullLocked(image)
+	p.mu.Unlock()
+
+	if started {
+		p.pull(image, state)
+	} else {
+		select {
+		case <-state.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if state.status != ImageStatusReady {
+		return fmt.Errorf("failed to pull image %s: %w", image, state.err)
+	}
+	return nil
+}
+
+// Start 启动后台检查，定期确认固定镜像仍在本地（被清理后重新拉取）
+func (p *WarmPool) Start(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.refreshPinned()
+			}
+		}
+	}()
+}
+
+// Stop 停止后台检查
+func (p *WarmPool) Stop() {
+	close(p.stop)
+}
+
+func (p *WarmPool) refreshPinned() {
+	p.mu.Lock()
+	var pinned []string
+	for image, state := range p.images {
+		if state.pinned && state.status != ImageStatusPulling {
+			pinned = append(pinned, image)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, image := range pinned {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		exists, err := p.puller.ImageExists(ctx, image)
+		cancel()
+		if err != nil || exists {
+			continue
+		}
+
+		p.mu.Lock()
+		p.images[image].status = ImageStatusUnknown
+		p.mu.Unlock()
+		p.Prewarm(image)
+	}
+}
+
+// stateLocked 获取镜像状态，不存在时创建，调用方需持有锁
+func (p *WarmPool) stateLocked(image string) *imageState {
+	state, ok := p.images[image]
+	if !ok {
+		state = &imageState{status: ImageStatusUnknown}
+		p.images[image] = state
+	}
+	return state
+}
+
+// startPullLocked 将镜像标记为拉取中，返回 false 表示无需由调用方拉取，调用方需持有锁
+func (p *WarmPool) startPullLocked(image string) (*imageState, bool) {
+	state := p.stateLocked(image)
+	if state.status == ImageStatusReady || state.status == ImageStatusPulling {
+		return state, false
+	}
+
+	state.status = ImageStatusPulling
+	state.err = nil
+	state.done = make(chan struct{})
+	return state, true
+}
+
+func (p *WarmPool) pull(image string, state *imageState) {
+	ctx, cancel := context.WithTimeout(context.Background(), imagePullTimeout)
+	defer cancel()
+
+	exists, err := p.puller.ImageExists(ctx, image)
+	if err == nil && !exists {
+		err = p.puller.PullImage(ctx, image)
+	}
+
+	p.mu.Lock()
+	if err != nil {
+		state.status = ImageStatusFailed
+		state.err = err
+		fmt.Printf("Warning: failed to prewarm image %s: %v\n", image, err)
+	} else {
+		state.status = ImageStatusReady
+	}
+	close(state.done)
+	p.mu.Unlock()
+}
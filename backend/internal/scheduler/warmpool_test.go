@@ -0,0 +1,137 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakePuller struct {
+	mu      sync.Mutex
+	local   map[string]bool
+	pulls   map[string]int
+	failFor map[string]bool
+	release chan struct{}
+}
+
+func newFakePuller() *fakePuller {
+	return &fakePuller{
+		local:   make(map[string]bool),
+		pulls:   make(map[string]int),
+		failFor: make(map[string]bool),
+	}
+}
+
+func (f *fakePuller) PullImage(ctx context.Context, imageRef string) error {
+	if f.release != nil {
+		<-f.release
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pulls[imageRef]++
+	if f.failFor[imageRef] {
+		return errors.New("pull denied")
+	}
+	f.local[imageRef] = true
+	return nil
+}
+
+func (f *fakePuller) ImageExists(ctx context.Context, imageRef string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.local[imageRef], nil
+}
+
+func (f *fakePuller) pullCount(image string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.pulls[image]
+}
+
+func waitForStatus(t *testing.T, pool *WarmPool, image, want string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if pool.Status(image) == want {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Image %s status = %s, want %s", image, pool.Status(image), want)
+}
+
+func TestWarmPoolPrewarmSkipsPullOnExecute(t *testing.T) {
+	puller := newFakePuller()
+	pool := NewWarmPool(puller)
+
+	if got := pool.Status("python:3.11"); got != ImageStatusUnknown {
+		t.Errorf("Expected unknown status before prewarm, got %s", got)
+	}
+
+	pool.Prewarm("python:3.11")
+	waitForStatus(t, pool, "python:3.11", ImageStatusReady)
+
+	if err := pool.EnsureImage(context.Background(), "python:3.11"); err != nil {
+		t.Fatalf("EnsureImage failed: %v", err)
+	}
+	if n := puller.pullCount("python:3.11"); n != 1 {
+		t.Errorf("Expected a single pull, got %d", n)
+	}
+}
+
+func TestWarmPoolEnsureImageWaitsForInFlightPull(t *testing.T) {
+	puller := newFakePuller()
+	puller.release = make(chan struct{})
+	pool := NewWarmPool(puller)
+
+	pool.Prewarm("cpp:latest")
+	waitForStatus(t, pool, "cpp:latest", ImageStatusPulling)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- pool.EnsureImage(context.Background(), "cpp:latest")
+	}()
+
+	close(puller.release)
+	if err := <-done; err != nil {
+		t.Fatalf("EnsureImage failed: %v", err)
+	}
+	if n := puller.pullCount("cpp:latest"); n != 1 {
+		t.Errorf("Expected executor to reuse the in-flight pull, got %d pulls", n)
+	}
+}
+
+func TestWarmPoolReportsFailedPull(t *testing.T) {
+	puller := newFakePuller()
+	puller.failFor["private:latest"] = true
+	pool := NewWarmPool(puller)
+
+	if err := pool.EnsureImage(context.Background(), "private:latest"); err == nil {
+		t.Fatal("Expected EnsureImage to fail")
+	}
+	if got := pool.Status("private:latest"); got != ImageStatusFailed {
+		t.Errorf("Expected failed status, got %s", got)
+	}
+}
+
+func TestWarmPoolRefreshRepullsPinnedImages(t *testing.T) {
+	puller := newFakePuller()
+	pool := NewWarmPool(puller)
+
+	pool.Pin("python:3.11")
+	waitForStatus(t, pool, "python:3.11", ImageStatusReady)
+
+	// 模拟镜像被清理
+	puller.mu.Lock()
+	delete(puller.local, "python:3.11")
+	puller.mu.Unlock()
+
+	pool.refreshPinned()
+	waitForStatus(t, pool, "python:3.11", ImageStatusReady)
+	if n := puller.pullCount("python:3.11"); n != 2 {
+		t.Errorf("Expected pinned image to be pulled again, got %d pulls", n)
+	}
+}
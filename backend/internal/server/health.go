@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// 健康检查状态
+const (
+	healthStarting = "starting"
+	healthOK       = "ok"
+)
+
+func writeHealth(w http.ResponseWriter, code int, state string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"status": state})
+}
+
+// handleHealthz 服务已启动，依赖在启动阶段均已就绪
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, healthOK)
+}
+
+// ServeStartupHealth 在启动期间（等待依赖、恢复未结束的任务）占用 HTTP 端口，/healthz 返回 503 starting，其余请求返回 503。
+// 返回的 stop 关闭监听，之后 Server.Start 才能绑定该端口
+func ServeStartupHealth(httpPort int) (stop func(), err error) {
+	listen, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", httpPort))
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeHealth(w, http.StatusServiceUnavailable, healthStarting)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "server is starting", http.StatusServiceUnavailable)
+	})
+
+	srv := &http.Server{Handler: mux}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := srv.Serve(listen); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("Warning: startup health listener stopped: %v\n", err)
+		}
+	}()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+		<-done
+	}, nil
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func healthState(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	var body map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode health response: %v", err)
+	}
+	return body["status"]
+}
+
+func TestServeStartupHealth(t *testing.T) {
+	port := freePort(t)
+	stop, err := ServeStartupHealth(port)
+	if err != nil {
+		t.Fatalf("ServeStartupHealth failed: %v", err)
+	}
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	resp, err := client.Get(fmt.Sprintf("http://127.0.0.1:%d/healthz", port))
+	if err != nil {
+		t.Fatalf("GET /healthz failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || healthState(t, resp) != healthStarting {
+		t.Errorf("/healthz = %d, want 503 starting", resp.StatusCode)
+	}
+
+	resp, err = client.Get(fmt.Sprintf("http://127.0.0.1:%d/api/v1/jobs/job_1", port))
+	if err != nil {
+		t.Fatalf("GET API failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("API during startup = %d, want 503", resp.StatusCode)
+	}
+
+	stop()
+	// 停止后端口应立即可以被正式服务绑定
+	l, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
+	if err != nil {
+		t.Fatalf("Port still in use after stop: %v", err)
+	}
+	l.Close()
+}
+
+func TestHandleHealthz(t *testing.T) {
+	rec := httptest.NewRecorder()
+	handleHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK || healthState(t, rec.Result()) != healthOK {
+		t.Errorf("/healthz = %d %s", rec.Code, rec.Body.String())
+	}
+}
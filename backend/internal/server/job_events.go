@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/events"
+	"algorithm-platform/internal/service"
+
+	"golang.org/x/net/websocket"
+)
+
+// handleJobEventsWebSocket 通过 WebSocket 推送任务状态变化，任务进入终止状态后关闭连接
+// 任务不存在时在握手前返回 404
+func handleJobEventsWebSocket(managementSvc *service.ManagementService, bus *events.Bus) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobID := strings.TrimPrefix(r.URL.Path, "/ws/jobs/")
+		if jobID == "" {
+			http.Error(w, "job id is required", http.StatusBadRequest)
+			return
+		}
+
+		// 先订阅再读取当前状态，避免两者之间的状态变化丢失
+		updates := bus.Subscribe(jobID)
+		defer bus.Unsubscribe(jobID, updates)
+
+		snapshot, err := jobSnapshot(r.Context(), managementSvc, jobID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get job: %v", err), http.StatusNotFound)
+			return
+		}
+
+		websocket.Server{
+			// 允许非浏览器客户端（无 Origin 头）连接
+			Handshake: func(config *websocket.Config, r *http.Request) error {
+				return nil
+			},
+			Handler: func(ws *websocket.Conn) {
+				defer ws.Close()
+				pushJobEvents(ws, jobID, snapshot, updates)
+			},
+		}.ServeHTTP(w, r)
+	})
+}
+
+// pushJobEvents 向 WebSocket 连接发送任务快照和后续事件，直到任务终止或客户端断开
+func pushJobEvents(ws *websocket.Conn, jobID string, snapshot events.JobEvent, updates <-chan events.JobEvent) {
+	if err := websocket.JSON.Send(ws, snapshot); err != nil || snapshot.Terminal() {
+		return
+	}
+
+	// 客户端只接收消息，读循环仅用于感知连接断开
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard []byte
+		for {
+			if err := websocket.Message.Receive(ws, &discard); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-updates:
+			if !ok {
+				return
+			}
+			if err := websocket.JSON.Send(ws, event); err != nil {
+				fmt.Printf("Failed to push job event for %s: %v\n", jobID, err)
+				return
+			}
+			if event.Terminal() {
+				return
+			}
+		}
+	}
+}
+
+// sseHeartbeatInterval SSE 心跳间隔，防止代理因连接空闲而断开
+const sseHeartbeatInterval = 15 * time.Second
+
+// handleJobEventsSSE 通过 Server-Sent Events 推送任务状态变化，任务进入终止状态后结束响应
+func handleJobEventsSSE(managementSvc *service.ManagementService, bus *events.Bus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+			return
+		}
+
+		jobID := r.PathValue("id")
+
+		updates := bus.Subscribe(jobID)
+		defer bus.Unsubscribe(jobID, updates)
+
+		snapshot, err := jobSnapshot(r.Context(), managementSvc, jobID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get job: %v", err), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		if err := writeSSEEvent(w, snapshot); err != nil || snapshot.Terminal() {
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+
+		heartbeat := time.NewTicker(sseHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+				flusher.Flush()
+			case event, ok := <-updates:
+				if !ok {
+					return
+				}
+				if err := writeSSEEvent(w, event); err != nil {
+					fmt.Printf("Failed to push job event for %s: %v\n", jobID, err)
+					return
+				}
+				flusher.Flush()
+				if event.Terminal() {
+					return
+				}
+			}
+		}
+	}
+}
+
+// writeSSEEvent 按 text/event-stream 格式写入一条事件，事件名为任务状态
+func writeSSEEvent(w http.ResponseWriter, event events.JobEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job event: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Status, data)
+	return err
+}
+
+// jobSnapshot 读取任务当前状态，作为实时推送的第一条事件
+func jobSnapshot(ctx context.Context, managementSvc *service.ManagementService, jobID string) (events.JobEvent, error) {
+	job, err := managementSvc.GetJobDetail(ctx, &v1.GetJobDetailRequest{JobId: jobID})
+	if err != nil {
+		return events.JobEvent{}, err
+	}
+
+	return events.JobEvent{
+		JobID:     job.JobId,
+		Status:    job.Status,
+		ResultURL: job.OutputUrl,
+		Timestamp: time.Now(),
+	}, nil
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/events"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/service"
+	"algorithm-platform/internal/testutil"
+
+	"golang.org/x/net/websocket"
+)
+
+// newJobEventsServer 启动挂载任务事件接口的测试服务器，数据库中预置给定任务
+func newJobEventsServer(t *testing.T, jobs ...models.Job) (*httptest.Server, *events.Bus) {
+	t.Helper()
+	db := testutil.NewDB(t)
+	// 内存数据库每个连接相互独立
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	for _, job := range jobs {
+		if err := db.Create(&job).Error; err != nil {
+			t.Fatalf("Failed to create job: %v", err)
+		}
+	}
+
+	cfg := config.Default()
+	cfg.MinIO.Endpoint = "" // 不连接 MinIO
+	managementSvc := service.NewManagementService(database.NewWithDB(db, cfg), config.NewStore(cfg), nil, nil, nil)
+	bus := events.NewBus()
+	mux := http.NewServeMux()
+	mux.Handle("/ws/jobs/", handleJobEventsWebSocket(managementSvc, bus))
+	mux.HandleFunc("/api/v1/jobs/{id}/events", handleJobEventsSSE(managementSvc, bus))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, bus
+}
+
+func TestJobEventsWebSocket(t *testing.T) {
+	server, bus := newJobEventsServer(t, models.Job{ID: "job_1", Status: models.JobStatusPending})
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws/jobs/job_1", "", server.URL)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer ws.Close()
+	ws.SetDeadline(time.Now().Add(5 * time.Second))
+
+	var event events.JobEvent
+	if err := websocket.JSON.Receive(ws, &event); err != nil {
+		t.Fatalf("Failed to receive snapshot: %v", err)
+	}
+	if event.JobID != "job_1" || event.Status != models.JobStatusPending {
+		t.Errorf("Snapshot = %+v, want job_1 pending", event)
+	}
+
+	// 收到快照时已完成订阅，之后发布的事件都会推送
+	for _, status := range []string{models.JobStatusRunning, models.JobStatusCompleted} {
+		bus.Publish(events.JobEvent{JobID: "job_1", Status: status})
+		if err := websocket.JSON.Receive(ws, &event); err != nil {
+			t.Fatalf("Failed to receive %s event: %v", status, err)
+		}
+		if event.Status != status {
+			t.Errorf("Event status = %s, want %s", event.Status, status)
+		}
+	}
+
+	// 终止状态后服务端关闭连接
+	if err := websocket.JSON.Receive(ws, &event); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected the connection to close after a terminal status, got %v (%+v)", err, event)
+	}
+}
+
+func TestJobEventsWebSocketUnknownJob(t *testing.T) {
+	server, bus := newJobEventsServer(t)
+
+	_, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws/jobs/missing", "", server.URL)
+	if err == nil {
+		t.Fatal("Expected the handshake to fail for an unknown job")
+	}
+
+	resp, err := http.Get(server.URL + "/ws/jobs/missing")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Status = %d, want 404", resp.StatusCode)
+	}
+	if n := bus.SubscriberCount("missing"); n != 0 {
+		t.Errorf("Left %d subscribers behind", n)
+	}
+}
+
+// readSSEEvent 读取一条 SSE 事件，返回事件名和 data，忽略心跳注释
+func readSSEEvent(t *testing.T, r *bufio.Reader) (string, events.JobEvent, error) {
+	t.Helper()
+	var name, data string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", events.JobEvent{}, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && data != "":
+			var event events.JobEvent
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				t.Fatalf("Invalid event data %q: %v", data, err)
+			}
+			return name, event, nil
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestJobEventsSSE(t *testing.T) {
+	server, bus := newJobEventsServer(t, models.Job{ID: "job_1", Status: models.JobStatusRunning})
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	resp, err := client.Get(server.URL + "/api/v1/jobs/job_1/events")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Status = %d, want 200", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", got)
+	}
+	if resp.Header.Get("Cache-Control") != "no-cache" || resp.Header.Get("X-Accel-Buffering") != "no" {
+		t.Errorf("Unexpected streaming headers: %v", resp.Header)
+	}
+
+	body := bufio.NewReader(resp.Body)
+	name, event, err := readSSEEvent(t, body)
+	if err != nil {
+		t.Fatalf("Failed to read snapshot: %v", err)
+	}
+	if name != models.JobStatusRunning || event.JobID != "job_1" || event.Status != models.JobStatusRunning {
+		t.Errorf("Snapshot = %s %+v, want running job_1", name, event)
+	}
+
+	// 读到快照时已完成订阅，终止状态的事件推送后响应结束
+	bus.Publish(events.JobEvent{JobID: "job_1", Status: models.JobStatusFailed, Message: "exit code 1"})
+	name, event, err = readSSEEvent(t, body)
+	if err != nil {
+		t.Fatalf("Failed to read published event: %v", err)
+	}
+	if name != models.JobStatusFailed || event.Status != models.JobStatusFailed || event.Message != "exit code 1" {
+		t.Errorf("Event = %s %+v, want failed with message", name, event)
+	}
+	if _, _, err := readSSEEvent(t, body); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected the stream to end after a terminal status, got %v", err)
+	}
+}
+
+func TestJobEventsSSETerminalSnapshot(t *testing.T) {
+	server, _ := newJobEventsServer(t, models.Job{ID: "job_1", Status: models.JobStatusCompleted})
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	resp, err := client.Get(server.URL + "/api/v1/jobs/job_1/events")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// 已结束的任务只推送一次快照
+	body := bufio.NewReader(resp.Body)
+	if name, _, err := readSSEEvent(t, body); err != nil || name != models.JobStatusCompleted {
+		t.Fatalf("Snapshot = %s, err = %v, want completed", name, err)
+	}
+	if _, _, err := readSSEEvent(t, body); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected the stream to end, got %v", err)
+	}
+
+	missing, err := client.Get(server.URL + "/api/v1/jobs/missing/events")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	missing.Body.Close()
+	if missing.StatusCode != http.StatusNotFound {
+		t.Errorf("Unknown job status = %d, want 404", missing.StatusCode)
+	}
+}
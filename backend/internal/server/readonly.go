@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/maintenance"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mutatingMethods 只读模式下拒绝的写操作（SetMaintenanceMode 本身不在其中）
+var mutatingMethods = map[string]bool{
+	v1.AlgorithmService_ExecuteAlgorithm_FullMethodName:           true,
+	v1.ManagementService_CreateAlgorithm_FullMethodName:           true,
+	v1.ManagementService_UpdateAlgorithm_FullMethodName:           true,
+	v1.ManagementService_DisableAlgorithm_FullMethodName:          true,
+	v1.ManagementService_EnableAlgorithm_FullMethodName:           true,
+	v1.ManagementService_DeleteAlgorithm_FullMethodName:           true,
+	v1.ManagementService_CreateVersion_FullMethodName:             true,
+	v1.ManagementService_RollbackVersion_FullMethodName:           true,
+	v1.ManagementService_UploadPresetData_FullMethodName:          true,
+	v1.ManagementService_DeletePresetData_FullMethodName:          true,
+	v1.ManagementService_CreatePresetDataUploadURL_FullMethodName: true,
+	v1.AlgorithmService_RetryJob_FullMethodName:                   true,
+	v1.AlgorithmService_CancelJob_FullMethodName:                  true,
+	v1.ManagementService_DeleteJob_FullMethodName:                 true,
+	v1.ManagementService_PurgeJobs_FullMethodName:                 true,
+	v1.ManagementService_EnsureStorage_FullMethodName:             true,
+}
+
+// maintenanceMethods 需要管理员令牌的维护操作，执行时自己开启只读（Engage）。
+// 运维通常先手动开启只读再执行它们，因此不受手动只读限制，只在另一个维护操作进行中时拒绝
+var maintenanceMethods = map[string]bool{
+	v1.ManagementService_RestoreBackup_FullMethodName:  true,
+	v1.ManagementService_MigrateObjects_FullMethodName: true,
+}
+
+// readOnlyInterceptor 只读模式下写操作返回 FailedPrecondition，读操作照常处理
+func readOnlyInterceptor(mode *maintenance.Mode) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if mutatingMethods[info.FullMethod] {
+			if err := readOnlyError(mode); err != nil {
+				return nil, err
+			}
+		}
+		if maintenanceMethods[info.FullMethod] {
+			if s := mode.EngagedStatus(); s.ReadOnly {
+				return nil, status.Errorf(codes.FailedPrecondition, "another maintenance operation is in progress: %s", s.Reason)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// readOnlyError 处于只读模式时返回错误
+func readOnlyError(mode *maintenance.Mode) error {
+	if mode == nil {
+		return nil
+	}
+	if s := mode.Status(); s.ReadOnly {
+		return status.Error(codes.FailedPrecondition, readOnlyMessage(s))
+	}
+	return nil
+}
+
+func readOnlyMessage(s maintenance.Status) string {
+	if s.Reason == "" {
+		return "server is in read-only maintenance mode"
+	}
+	return fmt.Sprintf("server is in read-only maintenance mode: %s", s.Reason)
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/maintenance"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReadOnlyInterceptorRejectsWrites(t *testing.T) {
+	mode := maintenance.NewMode()
+	mode.SetReadOnly(true, "upgrading")
+	interceptor := readOnlyInterceptor(mode)
+
+	call := func(method string) (bool, error) {
+		called := false
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+		return called, err
+	}
+
+	if called, err := call(v1.ManagementService_CreateAlgorithm_FullMethodName); called || status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition without calling the handler, got called=%v err=%v", called, err)
+	}
+	if called, err := call(v1.ManagementService_GetServerInfo_FullMethodName); !called || err != nil {
+		t.Errorf("Expected reads to pass in read-only mode, got called=%v err=%v", called, err)
+	}
+	// 维护操作在手动只读期间照常执行，它们自己开启只读
+	for _, method := range []string{v1.ManagementService_MigrateObjects_FullMethodName, v1.ManagementService_RestoreBackup_FullMethodName} {
+		if called, err := call(method); !called || err != nil {
+			t.Errorf("%s: expected to run in manual read-only mode, got called=%v err=%v", method, called, err)
+		}
+	}
+
+	// 另一个维护操作进行中时拒绝
+	mode.SetReadOnly(false, "")
+	release := mode.Engage("restoring database from backup")
+	defer release()
+	if called, err := call(v1.ManagementService_MigrateObjects_FullMethodName); called || status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected MigrateObjects to be rejected during a restore, got called=%v err=%v", called, err)
+	}
+}
@@ -2,19 +2,27 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	v1 "algorithm-platform/api/v1/proto"
 	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/events"
+	"algorithm-platform/internal/maintenance"
+	"algorithm-platform/internal/metrics"
 	"algorithm-platform/internal/service"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -24,13 +32,20 @@ type Server struct {
 	httpMux       *http.ServeMux
 	mux           *runtime.ServeMux
 	managementSvc *service.ManagementService
+	jobEvents     *events.Bus
 	cfg           config.ServerConfig
+	// 关闭 grpc-gateway 到 gRPC 服务的客户端连接，RegisterGateway 之前为 nil
+	stopGateway context.CancelFunc
 }
 
-func New(cfg config.ServerConfig, managementSvc *service.ManagementService) *Server {
-	grpcServer := grpc.NewServer()
+func New(cfg config.ServerConfig, managementSvc *service.ManagementService, jobEvents *events.Bus, mode *maintenance.Mode) *Server {
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(messageSizeInterceptor(), readOnlyInterceptor(mode)),
+		grpc.MaxRecvMsgSize(grpcMaxRecvMsgSize(cfg.GetMaxUploadBytes())),
+	)
 
 	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(mimeEventStream, newSSEMarshaler()),
 		runtime.WithForwardResponseOption(func(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
@@ -45,6 +60,10 @@ func New(cfg config.ServerConfig, managementSvc *service.ManagementService) *Ser
 			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
 			w.Header().Set("Access-Control-Expose-Headers", "Content-Type")
 
+			// ResourceExhausted 默认映射为 429，超出大小上限时应返回 413
+			if requestTooLarge(r, err) {
+				err = &runtime.HTTPStatusError{HTTPStatus: http.StatusRequestEntityTooLarge, Err: err}
+			}
 			runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
 		}),
 		runtime.WithForwardResponseOption(func(ctx context.Context, w http.ResponseWriter, m proto.Message) error {
@@ -54,6 +73,7 @@ func New(cfg config.ServerConfig, managementSvc *service.ManagementService) *Ser
 	)
 
 	httpMux := http.NewServeMux()
+	httpMux.HandleFunc("/healthz", handleHealthz)
 	httpMux.HandleFunc("/api/v1/data-download", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Printf("=== data-download called: %s %s\n", r.Method, r.URL.Path)
 		fmt.Printf("Query: %v\n", r.URL.Query())
@@ -81,6 +101,10 @@ func New(cfg config.ServerConfig, managementSvc *service.ManagementService) *Ser
 		}
 
 		presignedURL, err := managementSvc.GetPresetDataDownloadURL(r.Context(), fileID)
+		if errors.Is(err, service.ErrPresetDataNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
 		if err != nil {
 			fmt.Printf("Error generating presigned URL: %v\n", err)
 			http.Error(w, fmt.Sprintf("Failed to generate download URL: %v", err), http.StatusInternalServerError)
@@ -91,11 +115,17 @@ func New(cfg config.ServerConfig, managementSvc *service.ManagementService) *Ser
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintf(w, `{"download_url": "%s"}`, presignedURL)
 	})
-	httpMux.HandleFunc("/api/v1/data/upload-multipart", handleUploadMultipart(managementSvc))
+	httpMux.HandleFunc("/api/v1/data/upload-multipart", handleUploadMultipart(managementSvc, mode, cfg.GetMaxUploadBytes()))
+	httpMux.HandleFunc("/api/v1/data/{id}/download", handleProxyDownloadData(managementSvc))
+	httpMux.Handle("/ws/jobs/", handleJobEventsWebSocket(managementSvc, jobEvents))
+	httpMux.HandleFunc("/api/v1/jobs/{id}/events", handleJobEventsSSE(managementSvc, jobEvents))
 	httpMux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("test ok"))
 	})
-	httpMux.Handle("/api/", corsMiddleware(mux))
+	httpMux.Handle("/api/", corsMiddleware(limitRequestBody(mux, cfg.GetMaxUploadBytes())))
+	if metrics.Enabled() {
+		httpMux.Handle("/metrics", metrics.Default.Handler())
+	}
 
 	return &Server{
 		grpcServer:    grpcServer,
@@ -103,6 +133,7 @@ func New(cfg config.ServerConfig, managementSvc *service.ManagementService) *Ser
 		mux:           mux,
 		httpMux:       httpMux,
 		managementSvc: managementSvc,
+		jobEvents:     jobEvents,
 		cfg:           cfg,
 	}
 }
@@ -115,8 +146,10 @@ func (s *Server) RegisterServices(
 	v1.RegisterManagementServiceServer(s.grpcServer, managementSvc)
 }
 
+// RegisterGateway 注册 HTTP 网关，网关到 gRPC 服务的连接在 ctx 结束或 Stop 时关闭
 func (s *Server) RegisterGateway(ctx context.Context) error {
 	grpcAddr := fmt.Sprintf("0.0.0.0:%d", s.cfg.GRPCPort)
+	ctx, s.stopGateway = context.WithCancel(ctx)
 
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -151,7 +184,7 @@ func (s *Server) Start(ctx context.Context) error {
 	}()
 
 	go func() {
-		if err := s.grpcServer.Serve(listen); err != nil {
+		if err := s.grpcServer.Serve(listen); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
 			panic(err)
 		}
 	}()
@@ -170,6 +203,9 @@ func (s *Server) Stop(ctx context.Context) error {
 	}
 
 	s.grpcServer.GracefulStop()
+	if s.stopGateway != nil {
+		s.stopGateway()
+	}
 	return nil
 }
 
@@ -190,7 +226,7 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func handleUploadMultipart(managementSvc *service.ManagementService) http.HandlerFunc {
+func handleUploadMultipart(uploader presetDataUploader, mode *maintenance.Mode, maxSize int64) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
@@ -206,31 +242,59 @@ func handleUploadMultipart(managementSvc *service.ManagementService) http.Handle
 			return
 		}
 
-		err := r.ParseMultipartForm(32 << 20) // 32MB max memory
-		if err != nil {
-			http.Error(w, fmt.Sprintf("Failed to parse multipart form: %v", err), http.StatusBadRequest)
+		if mode.ReadOnly() {
+			http.Error(w, readOnlyMessage(mode.Status()), http.StatusServiceUnavailable)
 			return
 		}
 
-		file, fileHeader, err := r.FormFile("file")
-		if err != nil {
+		// 文件直接写入 MinIO，读完整个表单后再登记；请求在写入文件后失败时删除已写入的对象。
+		// category 在文件之前时写入前按文件开头校验分类，之后到达时登记时校验
+		var staged *service.StagedPresetData
+		var originalFilename string
+		var stageErr error
+		fields, err := streamMultipartUpload(w, r, maxSize, func(filename string, fields map[string]string, file io.Reader) error {
+			originalFilename = filename
+			staged, stageErr = uploader.StagePresetDataFile(r.Context(), filename, fields["category"], file)
+			return stageErr
+		})
+		if err != nil && staged != nil {
+			uploader.DiscardPresetDataFile(r.Context(), staged)
+		}
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			http.Error(w, fmt.Sprintf("Upload exceeds the maximum file size of %d bytes", maxSize), http.StatusRequestEntityTooLarge)
+			return
+		case errors.Is(err, errNoUploadFile):
 			http.Error(w, fmt.Sprintf("Failed to get file: %v", err), http.StatusBadRequest)
 			return
+		case status.Code(stageErr) == codes.InvalidArgument:
+			http.Error(w, status.Convert(stageErr).Message(), http.StatusBadRequest)
+			return
+		case stageErr != nil:
+			http.Error(w, fmt.Sprintf("Failed to upload file: %v", stageErr), http.StatusInternalServerError)
+			return
+		case err != nil:
+			http.Error(w, fmt.Sprintf("Failed to parse multipart form: %v", err), http.StatusBadRequest)
+			return
 		}
-		defer file.Close()
 
-		filename := r.FormValue("filename")
-		category := r.FormValue("category")
+		filename := fields["filename"]
+		category := fields["category"]
 
 		if filename == "" {
-			filename = fileHeader.Filename
+			filename = originalFilename
 		}
 
 		if category == "" {
 			category = "通用"
 		}
 
-		result, err := managementSvc.UploadPresetDataFile(r.Context(), filename, category, fileHeader.Filename, file)
+		result, err := uploader.CommitPresetDataFile(r.Context(), staged, filename, category)
+		if status.Code(err) == codes.InvalidArgument {
+			http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+			return
+		}
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Failed to upload file: %v", err), http.StatusInternalServerError)
 			return
@@ -238,7 +302,54 @@ func handleUploadMultipart(managementSvc *service.ManagementService) http.Handle
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		fmt.Fprintf(w, `{"file_id": "%s", "minio_url": "%s"}`, result.FileId, result.MinioUrl)
+		fmt.Fprintf(w, `{"file_id": "%s", "minio_url": "%s", "checksum": "%s"}`, result.FileId, result.MinioUrl, result.Checksum)
+	}
+}
+
+// handleProxyDownloadData 通过服务端代理下载预置数据，响应中带上原始文件名
+func handleProxyDownloadData(managementSvc *service.ManagementService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
+		w.Header().Set("Access-Control-Expose-Headers", "Content-Type, Content-Disposition")
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		file, err := managementSvc.OpenPresetData(r.Context(), r.PathValue("id"))
+		if errors.Is(err, service.ErrPresetDataNotFound) {
+			http.Error(w, "File not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to download file: %v", err), http.StatusInternalServerError)
+			return
+		}
+		defer file.Body.Close()
+
+		writePresetDataFile(w, file)
+	}
+}
+
+// writePresetDataFile 写出预置数据文件内容
+func writePresetDataFile(w http.ResponseWriter, file *service.PresetDataFile) {
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", service.AttachmentDisposition(file.Filename))
+	if file.Size >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(file.Size, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, file.Body); err != nil {
+		fmt.Printf("Warning: preset data download interrupted: %v\n", err)
 	}
 }
 
@@ -268,6 +379,10 @@ func handleDownloadData(managementSvc *service.ManagementService) http.HandlerFu
 		}
 
 		presignedURL, err := managementSvc.GetPresetDataDownloadURL(r.Context(), fileID)
+		if errors.Is(err, service.ErrPresetDataNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
 		if err != nil {
 			fmt.Printf("Error generating presigned URL: %v\n", err)
 			http.Error(w, fmt.Sprintf("Failed to generate download URL: %v", err), http.StatusInternalServerError)
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/events"
+
+	"go.uber.org/goleak"
+)
+
+// freePort 返回一个当前空闲的本地端口
+func freePort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to find a free port: %v", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// 启停一次服务后不应留下 goroutine：HTTP 和 gRPC 的服务 goroutine 随 Stop 退出，
+// 网关到 gRPC 的客户端连接随 Stop 关闭
+func TestServerStopLeavesNoGoroutines(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	cfg := config.ServerConfig{GRPCPort: freePort(t), HTTPPort: freePort(t)}
+	bus := events.NewBus()
+	defer bus.Close()
+
+	srv := New(cfg, nil, bus, nil)
+	srv.RegisterServices(v1.UnimplementedAlgorithmServiceServer{}, v1.UnimplementedManagementServiceServer{})
+	if err := srv.RegisterGateway(context.Background()); err != nil {
+		t.Fatalf("RegisterGateway failed: %v", err)
+	}
+	if err := srv.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	// 经网关调用一次 gRPC，确保网关连接已建立
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	url := fmt.Sprintf("http://127.0.0.1:%d/api/v1/jobs/job_1", cfg.HTTPPort)
+	var resp *http.Response
+	var err error
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		if resp, err = client.Get(url); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatalf("Gateway request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotImplemented {
+		t.Errorf("Gateway status = %d, want 501 from the unimplemented service", resp.StatusCode)
+	}
+
+	if err := srv.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// mimeEventStream 请求头 Accept 为该类型时，流式接口以 Server-Sent Events 格式输出
+const mimeEventStream = "text/event-stream"
+
+// sseMarshaler 将流式接口的每条消息输出为一个 SSE 事件（data: <json>），
+// 其余与 grpc-gateway 默认的 JSON 序列化一致
+type sseMarshaler struct {
+	runtime.JSONPb
+}
+
+func newSSEMarshaler() *sseMarshaler {
+	return &sseMarshaler{runtime.JSONPb{
+		MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+		UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}}
+}
+
+func (m *sseMarshaler) ContentType(_ interface{}) string {
+	return mimeEventStream
+}
+
+func (m *sseMarshaler) Marshal(v interface{}) ([]byte, error) {
+	data, err := m.JSONPb.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte("data: "), data...), nil
+}
+
+func (m *sseMarshaler) Delimiter() []byte {
+	return []byte("\n\n")
+}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+)
+
+func TestSSEMarshaler(t *testing.T) {
+	m := newSSEMarshaler()
+	data, err := m.Marshal(map[string]interface{}{"result": &v1.JobLogChunk{Data: "line\n", Source: "container"}})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	// protojson 会随机插入空格，比较时去掉
+	want := `data:{"result":{"data":"line\n","source":"container"}}`
+	if strings.ReplaceAll(string(data), " ", "") != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+	if string(m.Delimiter()) != "\n\n" || m.ContentType(nil) != mimeEventStream {
+		t.Errorf("Delimiter = %q, ContentType = %s", m.Delimiter(), m.ContentType(nil))
+	}
+}
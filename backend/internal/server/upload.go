@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxUploadFieldSize 上传表单中普通字段（filename、category）的大小上限
+const maxUploadFieldSize = 64 << 10
+
+// maxUploadFormOverhead 请求体中文件之外的部分（表单字段、multipart 分隔和头）允许的大小
+const maxUploadFormOverhead = 1 << 20
+
+// errNoUploadFile 表单中没有 file 字段
+var errNoUploadFile = errors.New("no file in multipart form")
+
+// streamMultipartUpload 流式读取 multipart 上传请求，file part 到达时直接交给 onFile（写入 MinIO），
+// 不在内存或磁盘中缓存文件，onFile 收到文件之前已读取的表单字段。返回文件之外的表单字段，文件前后的字段都会读取。
+// 文件超过 maxFileSize 或请求体过大时返回 *http.MaxBytesError，即使 onFile 已经把读取错误包装成了其他错误
+func streamMultipartUpload(w http.ResponseWriter, r *http.Request, maxFileSize int64, onFile func(filename string, fields map[string]string, file io.Reader) error) (map[string]string, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxFileSize+maxUploadFormOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string)
+	found := false
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := part.FormName()
+		switch {
+		case name == "file" && !found:
+			found = true
+			file := &readErrorRecorder{r: &fileSizeLimiter{r: part, limit: maxFileSize}}
+			err = onFile(part.FileName(), fields, file)
+			var tooLarge *http.MaxBytesError
+			if errors.As(file.err, &tooLarge) {
+				err = file.err
+			}
+		case part.FileName() == "":
+			var value []byte
+			value, err = io.ReadAll(io.LimitReader(part, maxUploadFieldSize+1))
+			if err == nil && len(value) > maxUploadFieldSize {
+				err = fmt.Errorf("form field %q is too large", name)
+			}
+			fields[name] = string(value)
+		}
+		part.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !found {
+		return nil, errNoUploadFile
+	}
+	return fields, nil
+}
+
+// fileSizeLimiter 读取超过 limit 字节时返回 *http.MaxBytesError，恰好等于上限的文件可以完整读取
+type fileSizeLimiter struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (l *fileSizeLimiter) Read(p []byte) (int, error) {
+	if l.read > l.limit {
+		return 0, &http.MaxBytesError{Limit: l.limit}
+	}
+	// 最多多读一个字节，用于区分恰好达到上限和超出上限
+	if max := l.limit - l.read + 1; int64(len(p)) > max {
+		p = p[:max]
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n - int(l.read-l.limit), &http.MaxBytesError{Limit: l.limit}
+	}
+	return n, err
+}
+
+// readErrorRecorder 记录读取时遇到的错误（io.EOF 除外）
+type readErrorRecorder struct {
+	r   io.Reader
+	err error
+}
+
+func (rr *readErrorRecorder) Read(p []byte) (int, error) {
+	n, err := rr.r.Read(p)
+	if err != nil && err != io.EOF {
+		rr.err = err
+	}
+	return n, err
+}
+
+// grpcDefaultMaxRecvMsgSize gRPC 服务默认的最大请求大小，上传方法之外的请求仍使用该上限
+const grpcDefaultMaxRecvMsgSize = 4 << 20
+
+// uploadMethods 请求中带 file_data 的方法，只有它们的请求可以超过 grpcDefaultMaxRecvMsgSize
+var uploadMethods = map[string]bool{
+	v1.ManagementService_CreateAlgorithm_FullMethodName:  true,
+	v1.ManagementService_CreateVersion_FullMethodName:    true,
+	v1.ManagementService_UploadPresetData_FullMethodName: true,
+}
+
+// uploadRoutes 上传方法在 HTTP 网关上的路由，用于放宽请求体大小上限
+var uploadRoutes = func() *http.ServeMux {
+	mux := http.NewServeMux()
+	for _, pattern := range []string{
+		"POST /api/v1/algorithms",
+		"POST /api/v1/algorithms/{algorithm_id}/versions",
+		"POST /api/v1/data/upload",
+	} {
+		mux.HandleFunc(pattern, http.NotFound)
+	}
+	return mux
+}()
+
+// grpcMaxRecvMsgSize gRPC 服务的接收上限，需要容纳 file_data 达到上传上限的请求，
+// 超出上传上限的 file_data 由服务返回带上限说明的 ResourceExhausted。
+// gRPC 只能按服务设置接收上限，其他方法的请求由 messageSizeInterceptor 限制在默认上限内
+func grpcMaxRecvMsgSize(maxUploadBytes int64) int {
+	size := maxUploadBytes + maxUploadFormOverhead
+	if size < grpcDefaultMaxRecvMsgSize {
+		return grpcDefaultMaxRecvMsgSize
+	}
+	if size > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(size)
+}
+
+// messageSizeInterceptor 上传方法之外的请求超过 grpcDefaultMaxRecvMsgSize 时返回 ResourceExhausted
+func messageSizeInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if msg, ok := req.(proto.Message); ok && !uploadMethods[info.FullMethod] {
+			if size := proto.Size(msg); size > grpcDefaultMaxRecvMsgSize {
+				return nil, service.TooLargeError("request is %d bytes, exceeds the maximum message size of %d bytes", size, grpcDefaultMaxRecvMsgSize)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// gatewayBodyLimit HTTP 网关请求体的大小上限。上传路由的 JSON 中 file_data 为 base64 编码，按编码后的大小放宽
+func gatewayBodyLimit(r *http.Request, maxUploadBytes int64) int64 {
+	if _, pattern := uploadRoutes.Handler(r); pattern != "" {
+		return (maxUploadBytes+2)/3*4 + maxUploadFormOverhead
+	}
+	return grpcDefaultMaxRecvMsgSize
+}
+
+// limitRequestBody 限制 HTTP 网关的请求体大小，超出时由网关的错误处理返回 413
+func limitRequestBody(next http.Handler, maxUploadBytes int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = &limitedBody{ReadCloser: http.MaxBytesReader(w, r.Body, gatewayBodyLimit(r, maxUploadBytes))}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limitedBody 记录请求体是否超出上限。网关把读取请求体的错误转换为 InvalidArgument，需要据此识别
+type limitedBody struct {
+	io.ReadCloser
+	tooLarge *http.MaxBytesError
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	errors.As(err, &b.tooLarge)
+	return n, err
+}
+
+// requestTooLarge 网关错误是否由请求体或上传文件超出上限引起
+func requestTooLarge(r *http.Request, err error) bool {
+	if body, ok := r.Body.(*limitedBody); ok && body.tooLarge != nil {
+		return true
+	}
+	return service.IsTooLarge(err)
+}
+
+// presetDataUploader 上传接口使用的预置数据操作，由 *service.ManagementService 实现
+type presetDataUploader interface {
+	StagePresetDataFile(ctx context.Context, originalFilename, category string, file io.Reader) (*service.StagedPresetData, error)
+	CommitPresetDataFile(ctx context.Context, staged *service.StagedPresetData, filename, category string) (*v1.UploadDataResponse, error)
+	DiscardPresetDataFile(ctx context.Context, staged *service.StagedPresetData)
+}
@@ -0,0 +1,298 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/events"
+	"algorithm-platform/internal/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newUploadRequest 构造上传请求，与前端一致，file 字段在 filename、category 之前
+func newUploadRequest(t *testing.T, content []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "data.csv")
+	if err != nil {
+		t.Fatalf("CreateFormFile failed: %v", err)
+	}
+	part.Write(content)
+	writer.WriteField("filename", "销售数据")
+	writer.WriteField("category", "samples")
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/data/upload-multipart", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// fakeUploader 记录上传流程的调用，文件内容读入内存便于断言
+type fakeUploader struct {
+	content   []byte
+	filename  string
+	category  string
+	discarded bool
+	commitErr error
+
+	stageCategory string // 写入文件时已知的分类
+	stageErr      error
+}
+
+func (f *fakeUploader) StagePresetDataFile(ctx context.Context, originalFilename, category string, file io.Reader) (*service.StagedPresetData, error) {
+	f.stageCategory = category
+	if f.stageErr != nil {
+		return nil, f.stageErr
+	}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	f.content = content
+	return &service.StagedPresetData{}, nil
+}
+
+func (f *fakeUploader) CommitPresetDataFile(ctx context.Context, staged *service.StagedPresetData, filename, category string) (*v1.UploadDataResponse, error) {
+	if f.commitErr != nil {
+		return nil, f.commitErr
+	}
+	f.filename, f.category = filename, category
+	return &v1.UploadDataResponse{FileId: "data_1", Checksum: "abc"}, nil
+}
+
+func (f *fakeUploader) DiscardPresetDataFile(ctx context.Context, staged *service.StagedPresetData) {
+	f.discarded = true
+}
+
+func TestStreamMultipartUpload(t *testing.T) {
+	content := bytes.Repeat([]byte("a,b\n"), 1024)
+
+	var filename string
+	var got []byte
+	fields, err := streamMultipartUpload(httptest.NewRecorder(), newUploadRequest(t, content), 1<<20, func(name string, fields map[string]string, file io.Reader) error {
+		filename = name
+		var err error
+		got, err = io.ReadAll(file)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("streamMultipartUpload failed: %v", err)
+	}
+	if filename != "data.csv" || fields["category"] != "samples" || fields["filename"] != "销售数据" {
+		t.Errorf("filename = %q, fields = %v", filename, fields)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("Read %d bytes, want %d", len(got), len(content))
+	}
+}
+
+func TestStreamMultipartUploadTooLarge(t *testing.T) {
+	_, err := streamMultipartUpload(httptest.NewRecorder(), newUploadRequest(t, make([]byte, 8192)), 4096, func(name string, fields map[string]string, file io.Reader) error {
+		if _, err := io.Copy(io.Discard, file); err != nil {
+			return errors.New("upload failed") // 模拟对象存储把读取错误包装成自己的错误
+		}
+		return nil
+	})
+	var tooLarge *http.MaxBytesError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("err = %v, want *http.MaxBytesError", err)
+	}
+}
+
+func TestHandleUploadMultipart(t *testing.T) {
+	uploader := &fakeUploader{}
+	rec := httptest.NewRecorder()
+	handleUploadMultipart(uploader, nil, 1<<20)(rec, newUploadRequest(t, []byte("a,b\n")))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp["file_id"] != "data_1" {
+		t.Errorf("Unexpected response %s: %v", rec.Body.String(), err)
+	}
+	// 文件之后到达的字段也用于登记
+	if string(uploader.content) != "a,b\n" || uploader.filename != "销售数据" || uploader.category != "samples" || uploader.discarded {
+		t.Errorf("Unexpected upload: %+v", uploader)
+	}
+}
+
+func TestHandleUploadMultipartInvalidCategory(t *testing.T) {
+	uploader := &fakeUploader{commitErr: status.Error(codes.InvalidArgument, "bad category")}
+	rec := httptest.NewRecorder()
+	handleUploadMultipart(uploader, nil, 1<<20)(rec, newUploadRequest(t, []byte("a,b\n")))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Status = %d, want 400", rec.Code)
+	}
+}
+
+func TestHandleUploadMultipartCategoryBeforeFile(t *testing.T) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("category", "images")
+	part, _ := writer.CreateFormFile("file", "data.png")
+	part.Write([]byte("a,b\n"))
+	writer.Close()
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/data/upload-multipart", bytes.NewReader(body.Bytes()))
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+
+	// 分类在文件之前时写入文件前就能校验
+	uploader := &fakeUploader{}
+	handleUploadMultipart(uploader, nil, 1<<20)(httptest.NewRecorder(), newRequest())
+	if uploader.stageCategory != "images" {
+		t.Errorf("Category when staging = %q, want images", uploader.stageCategory)
+	}
+
+	uploader = &fakeUploader{stageErr: status.Error(codes.InvalidArgument, "bad category")}
+	rec := httptest.NewRecorder()
+	handleUploadMultipart(uploader, nil, 1<<20)(rec, newRequest())
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Status = %d, want 400: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleUploadMultipartSizeLimit(t *testing.T) {
+	const limit = 4096
+	tests := []struct {
+		name     string
+		size     int
+		wantCode int
+	}{
+		{"one byte under", limit - 1, http.StatusOK},
+		{"at the limit", limit, http.StatusOK},
+		{"one byte over", limit + 1, http.StatusRequestEntityTooLarge},
+		{"far over", 4 * limit, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uploader := &fakeUploader{}
+			rec := httptest.NewRecorder()
+			handleUploadMultipart(uploader, nil, limit)(rec, newUploadRequest(t, make([]byte, tt.size)))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("Status = %d, want %d: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantCode == http.StatusOK && len(uploader.content) != tt.size {
+				t.Errorf("Uploaded %d bytes, want %d", len(uploader.content), tt.size)
+			}
+			if tt.wantCode == http.StatusRequestEntityTooLarge && !strings.Contains(rec.Body.String(), "4096 bytes") {
+				t.Errorf("Error does not mention the limit: %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGRPCMaxRecvMsgSize(t *testing.T) {
+	if got := grpcMaxRecvMsgSize(1 << 20); got != grpcDefaultMaxRecvMsgSize {
+		t.Errorf("Small limit: %d, want the gRPC default", got)
+	}
+	if got := grpcMaxRecvMsgSize(64 << 20); got != 64<<20+maxUploadFormOverhead {
+		t.Errorf("64 MB limit: %d", got)
+	}
+	if got := grpcMaxRecvMsgSize(8 << 30); got != math.MaxInt32 {
+		t.Errorf("8 GB limit: %d, want MaxInt32", got)
+	}
+}
+
+func TestMessageSizeInterceptor(t *testing.T) {
+	interceptor := messageSizeInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	big := &v1.UploadDataRequest{FileData: make([]byte, grpcDefaultMaxRecvMsgSize+1)}
+
+	_, err := interceptor(context.Background(), big, &grpc.UnaryServerInfo{FullMethod: v1.ManagementService_PurgeJobs_FullMethodName}, handler)
+	if !service.IsTooLarge(err) {
+		t.Errorf("Oversized request to a non-upload method: err = %v, want a too-large error", err)
+	}
+	if _, err := interceptor(context.Background(), big, &grpc.UnaryServerInfo{FullMethod: v1.ManagementService_UploadPresetData_FullMethodName}, handler); err != nil {
+		t.Errorf("Upload methods should accept requests above the default limit: %v", err)
+	}
+}
+
+// sizeLimitedManagement 上传返回超出大小上限，清理任务返回执行槽位耗尽
+type sizeLimitedManagement struct {
+	v1.UnimplementedManagementServiceServer
+}
+
+func (sizeLimitedManagement) UploadPresetData(ctx context.Context, req *v1.UploadDataRequest) (*v1.UploadDataResponse, error) {
+	return nil, service.TooLargeError("file is %d bytes, exceeds the maximum upload size of %d bytes", len(req.FileData), 1)
+}
+
+func (sizeLimitedManagement) PurgeJobs(ctx context.Context, req *v1.PurgeJobsRequest) (*v1.PurgeJobsResponse, error) {
+	return nil, status.Error(codes.ResourceExhausted, "server busy")
+}
+
+func TestGatewayRequestSizeLimits(t *testing.T) {
+	cfg := config.ServerConfig{GRPCPort: freePort(t), HTTPPort: freePort(t), UploadMaxSizeMB: 1}
+	bus := events.NewBus()
+	defer bus.Close()
+
+	srv := New(cfg, nil, bus, nil)
+	srv.RegisterServices(v1.UnimplementedAlgorithmServiceServer{}, sizeLimitedManagement{})
+	if err := srv.RegisterGateway(context.Background()); err != nil {
+		t.Fatalf("RegisterGateway failed: %v", err)
+	}
+	if err := srv.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer srv.Stop(context.Background())
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	post := func(path string, body []byte) int {
+		t.Helper()
+		url := fmt.Sprintf("http://127.0.0.1:%d%s", cfg.HTTPPort, path)
+		var resp *http.Response
+		var err error
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+			if resp, err = client.Post(url, "application/json", bytes.NewReader(body)); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			t.Fatalf("POST %s failed: %v", path, err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	jsonBody := func(v interface{}) []byte {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		return data
+	}
+
+	// 上传路由的请求体可以超过默认上限，超出上传上限的文件由服务拒绝
+	upload := jsonBody(map[string]interface{}{"filename": "a.csv", "file_data": make([]byte, 1<<20+1)})
+	if code := post("/api/v1/data/upload", upload); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Oversized upload: status = %d, want 413", code)
+	}
+	// 其他路由的请求体限制在默认上限内
+	big := jsonBody(map[string]interface{}{"before": strings.Repeat("x", grpcDefaultMaxRecvMsgSize)})
+	if code := post("/api/v1/jobs/purge", big); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Oversized request body: status = %d, want 413", code)
+	}
+	// 其他 ResourceExhausted 仍为 429
+	if code := post("/api/v1/jobs/purge", []byte("{}")); code != http.StatusTooManyRequests {
+		t.Errorf("Busy server: status = %d, want 429", code)
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+)
+
+// requireAdmin 校验请求携带的管理令牌（Authorization: Bearer <token>），未配置令牌时拒绝所有请求
+func requireAdmin(ctx context.Context, adminToken string) error {
+	if adminToken == "" {
+		return status.Error(codes.PermissionDenied, "admin API is disabled: server.admin_token is not configured")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(value, "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid or missing admin token")
+}
+
+// GetConfig 返回进程当前生效的配置（已应用环境变量覆盖和默认值），密钥已脱敏
+func (s *ManagementService) GetConfig(ctx context.Context, req *v1.GetConfigRequest) (*v1.GetConfigResponse, error) {
+	if err := requireAdmin(ctx, s.cfg().Server.AdminToken); err != nil {
+		return nil, err
+	}
+
+	cfgStruct, err := configToStruct(s.cfg().Redacted())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode config: %v", err)
+	}
+
+	configPath, _ := config.GetConfigPath()
+	return &v1.GetConfigResponse{
+		Config:     cfgStruct,
+		ConfigPath: configPath,
+		LocalMode:  os.Getenv("LOCAL_MODE") == "true",
+	}, nil
+}
+
+// configToStruct 按 yaml 字段名将配置转换为 Struct，与 config.yaml 的结构保持一致
+func configToStruct(cfg *config.Config) (*structpb.Struct, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	// 经过 JSON 转换，统一数值和嵌套 map 的类型
+	jsonData, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	result := &structpb.Struct{}
+	if err := result.UnmarshalJSON(jsonData); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/maintenance"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestRequireAdmin(t *testing.T) {
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+
+	if err := requireAdmin(withToken("secret"), ""); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied without configured token, got %v", err)
+	}
+	if err := requireAdmin(context.Background(), "secret"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without token, got %v", err)
+	}
+	if err := requireAdmin(withToken("wrong"), "secret"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated for wrong token, got %v", err)
+	}
+	if err := requireAdmin(withToken("secret"), "secret"); err != nil {
+		t.Errorf("Expected valid token to pass, got %v", err)
+	}
+}
+
+func TestConfigToStructRedactsSecrets(t *testing.T) {
+	cfg := config.Default()
+	cfg.Server.AdminToken = "admin-secret"
+
+	s, err := configToStruct(cfg.Redacted())
+	if err != nil {
+		t.Fatalf("configToStruct failed: %v", err)
+	}
+
+	minio := s.Fields["minio"].GetStructValue().GetFields()
+	if got := minio["secret_access_key"].GetStringValue(); got != "******" {
+		t.Errorf("Expected MinIO secret to be redacted, got %q", got)
+	}
+	if got := minio["endpoint"].GetStringValue(); got != cfg.MinIO.Endpoint {
+		t.Errorf("Expected MinIO endpoint %q, got %q", cfg.MinIO.Endpoint, got)
+	}
+
+	server := s.Fields["server"].GetStructValue().GetFields()
+	if got := server["admin_token"].GetStringValue(); got != "******" {
+		t.Errorf("Expected admin token to be redacted, got %q", got)
+	}
+	if cfg.Server.AdminToken != "admin-secret" {
+		t.Error("Redacted must not modify the original config")
+	}
+}
+
+func TestTriggerBackupRequiresSQLiteBackups(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Server.AdminToken = "secret"
+	s := &ManagementService{db: database.NewWithDB(testutil.NewDB(t), cfg), cfgStore: config.NewStore(cfg)}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+
+	if _, err := s.TriggerBackup(context.Background(), &v1.TriggerBackupRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without token, got %v", err)
+	}
+	if _, err := s.TriggerBackup(ctx, &v1.TriggerBackupRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition without a backup manager, got %v", err)
+	}
+}
+
+func TestRestoreBackupRefusesWhileJobsRun(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Server.AdminToken = "secret"
+	db := testutil.NewDB(t)
+	mode := maintenance.NewMode()
+	s := &ManagementService{db: database.NewWithDB(db, cfg), cfgStore: config.NewStore(cfg), maintenance: mode}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+
+	if _, err := s.RestoreBackup(ctx, &v1.RestoreBackupRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without a path, got %v", err)
+	}
+
+	createJob(t, db, "job_1", models.JobStatusRunning)
+	// 统计任务时必须已经处于只读，否则统计之后提交的任务会在恢复时被清除
+	countedWritable := false
+	if err := db.Callback().Query().Before("gorm:query").Register("test:read_only", func(*gorm.DB) {
+		countedWritable = countedWritable || !mode.ReadOnly()
+	}); err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+	_, err := s.RestoreBackup(ctx, &v1.RestoreBackupRequest{Path: "database-backup/backup-20240101-000000.json"})
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "running") {
+		t.Errorf("Expected FailedPrecondition while a job runs, got %v", err)
+	}
+	if countedWritable {
+		t.Error("Expected active jobs to be counted while read-only")
+	}
+	if mode.ReadOnly() {
+		t.Error("Expected read-only to be released after refusing the restore")
+	}
+}
+
+func TestSetMaintenanceModeRequiresAdmin(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Server.AdminToken = "secret"
+	mode := maintenance.NewMode()
+	s := &ManagementService{cfgStore: config.NewStore(cfg), maintenance: mode}
+	req := &v1.SetMaintenanceModeRequest{ReadOnly: true, Reason: "test"}
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+
+	if _, err := s.SetMaintenanceMode(context.Background(), req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without token, got %v", err)
+	}
+	if _, err := s.SetMaintenanceMode(withToken("wrong"), req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated with a wrong token, got %v", err)
+	}
+	if mode.ReadOnly() {
+		t.Fatal("Read-only mode must not change without a valid token")
+	}
+
+	resp, err := s.SetMaintenanceMode(withToken("secret"), req)
+	if err != nil || !resp.ReadOnly || !mode.ReadOnly() {
+		t.Errorf("Expected read-only mode with a valid token, got %+v, %v", resp, err)
+	}
+
+	// 未配置管理员令牌时拒绝
+	cfg.Server.AdminToken = ""
+	if _, err := s.SetMaintenanceMode(withToken("secret"), &v1.SetMaintenanceModeRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied without a configured admin token, got %v", err)
+	}
+}
+
+// 用 go test -race 运行时检查配置重载与请求处理之间没有数据竞争
+func TestGetConfigWhileReloading(t *testing.T) {
+	dir := t.TempDir()
+	paths := make([]string, 2)
+	for i, limit := range []int{4, 8} {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("config-%d.yaml", i))
+		content := fmt.Sprintf("server:\n  admin_token: secret\ndocker:\n  max_cpu_limit: %d\n  pinned_images: [python:3.1%d]\n", limit, i)
+		if err := os.WriteFile(paths[i], []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+	}
+	cfg := &config.Config{}
+	cfg.Server.AdminToken = "secret"
+	store := config.NewStore(cfg)
+	s := &ManagementService{cfgStore: store}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret"))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			if _, err := store.Reload(paths[i%2]); err != nil {
+				t.Errorf("Reload failed: %v", err)
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			if got := s.cfg().Docker.MaxCPULimit; got != 8 {
+				t.Errorf("Expected the last reload to be visible, got max_cpu_limit %v", got)
+			}
+			return
+		default:
+		}
+		if _, err := s.GetConfig(ctx, &v1.GetConfigRequest{}); err != nil {
+			t.Fatalf("GetConfig failed: %v", err)
+		}
+	}
+}
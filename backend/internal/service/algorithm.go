@@ -1,98 +1,149 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"sync"
 	"time"
 
 	v1 "algorithm-platform/api/v1/proto"
 	"algorithm-platform/internal/config"
 	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/events"
+	"algorithm-platform/internal/keys"
+	"algorithm-platform/internal/metrics"
 	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/redact"
+	"algorithm-platform/internal/retry"
+	"algorithm-platform/internal/scheduler"
+	"algorithm-platform/internal/webhook"
+	"algorithm-platform/pkg/docker"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 type AlgorithmService struct {
 	v1.UnimplementedAlgorithmServiceServer
 	db          *database.Database
-	cfg         *config.Config
+	cfgStore    *config.Store
 	minioClient *minio.Client
+	jobEvents   *events.Bus
+
+	// Docker 不可用时为 nil，此时任务执行直接失败
+	dockerClient *docker.Client
+	scheduler    *scheduler.Scheduler
+	resultCache  ResultCache // 未开启结果缓存时为 nil
+
+	// 正在清理任务历史的算法 ID
+	trimming sync.Map
+	// 本实例中正在执行的任务 ID → context.CancelFunc，用于 CancelJob 中断执行
+	jobCancels sync.Map
+	// 限制同时执行的任务数，为 nil 时不限制
+	jobSlots *jobPool
 }
 
-func NewAlgorithmService(db *database.Database, cfg *config.Config) *AlgorithmService {
+func NewAlgorithmService(db *database.Database, cfgStore *config.Store, jobEvents *events.Bus, dockerClient *docker.Client, sched *scheduler.Scheduler, resultCache ResultCache) *AlgorithmService {
+	cfg := cfgStore.Load()
 	minioClient, err := minio.New(cfg.MinIO.Endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(cfg.MinIO.AccessKeyID, cfg.MinIO.SecretAccessKey, ""),
-		Secure: cfg.MinIO.UseSSL,
+		Creds:     credentials.NewStaticV4(cfg.MinIO.AccessKeyID, cfg.MinIO.SecretAccessKey, ""),
+		Secure:    cfg.MinIO.UseSSL,
+		Transport: metrics.MinIOTransport(cfg.MinIO.UseSSL),
 	})
 	if err != nil {
 		fmt.Printf("Failed to initialize MinIO client: %v\n", err)
 	}
 	return &AlgorithmService{
-		db:          db,
-		cfg:         cfg,
-		minioClient: minioClient,
+		db:           db,
+		cfgStore:     cfgStore,
+		minioClient:  minioClient,
+		jobEvents:    jobEvents,
+		dockerClient: dockerClient,
+		scheduler:    sched,
+		resultCache:  resultCache,
+		jobSlots:     newJobPool(cfg.Server.MaxConcurrentJobs),
 	}
 }
 
-func (s *AlgorithmService) ExecuteAlgorithm(ctx context.Context, req *v1.ExecuteRequest) (*v1.ExecuteResponse, error) {
-	jobID := fmt.Sprintf("job_%d", time.Now().UnixNano())
-
-	if req.IsAsync && req.WebhookUrl == "" {
-		return nil, fmt.Errorf("webhook_url is required when is_async is true")
-	}
+// cfg 返回当前配置快照，配置重载后对新请求生效
+func (s *AlgorithmService) cfg() *config.Config {
+	return s.cfgStore.Load()
+}
 
-	algorithm := &models.Algorithm{}
-	if err := s.db.DB().First(algorithm, "id = ?", req.AlgorithmId).Error; err != nil {
-		return nil, fmt.Errorf("algorithm not found: %w", err)
+func (s *AlgorithmService) ExecuteAlgorithm(ctx context.Context, req *v1.ExecuteRequest) (*v1.ExecuteResponse, error) {
+	mode, err := resolveExecutionMode(req)
+	if err != nil {
+		return nil, err
 	}
+	// 保存规范化后的请求，重试和任务详情只需读取 mode
+	req.Mode = mode
+	req.IsAsync = false
+	warning := dropUnusedWebhook(req, mode)
 
-	if _, err := s.checkPlatformConsistency(algorithm.Platform); err != nil {
-		return nil, fmt.Errorf("platform consistency check failed: %w", err)
+	resp, err := s.executeWithCache(ctx, req)
+	if warning != "" {
+		fmt.Printf("Warning: algorithm %s: %s\n", req.AlgorithmId, warning)
+		if resp != nil {
+			resp.Message += " (" + warning + ")"
+		}
 	}
+	return resp, err
+}
 
-	inputDir := filepath.Join("/tmp", "input", jobID)
-	if err := os.MkdirAll(inputDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create input directory: %w", err)
+// execute 创建任务并执行，retriedFrom 为重试时的原任务 ID，versionID 为空时运行算法的当前版本
+func (s *AlgorithmService) execute(ctx context.Context, req *v1.ExecuteRequest, retriedFrom, versionID string) (*v1.ExecuteResponse, error) {
+	mode, err := resolveExecutionMode(req)
+	if err != nil {
+		return nil, err
 	}
 
-	if req.InputSource != nil {
-		if err := s.downloadPresetData(ctx, req.InputSource, inputDir); err != nil {
-			return nil, fmt.Errorf("failed to download preset data: %w", err)
-		}
-	}
+	jobID := fmt.Sprintf("job_%d", time.Now().UnixNano())
 
-	if req.Params != nil {
-		paramsFile := filepath.Join(inputDir, "params.json")
-		paramsJSON := fmt.Sprintf(`%v`, req.Params)
-		if err := os.WriteFile(paramsFile, []byte(paramsJSON), 0644); err != nil {
-			return nil, fmt.Errorf("failed to write params file: %w", err)
-		}
+	prepared, err := s.prepareJob(ctx, jobID, req, versionID)
+	if err != nil {
+		return nil, err
 	}
+	algorithm := prepared.algorithm
 
 	job := &models.Job{
 		ID:            jobID,
 		AlgorithmID:   req.AlgorithmId,
 		AlgorithmName: algorithm.Name,
-		Mode:          req.Mode,
+		Mode:          mode,
 		Status:        "pending",
-		InputParams:   fmt.Sprintf("%v", req.Params),
-		InputURL:      req.InputSource.GetUrl(),
-		WorkerID:      "default-worker",
+		InputParams:   encodeParams(req.Params),
+		InputURL:      joinInputRefs(req),
+		WorkerID:      s.cfg().Server.GetWorkerID(),
+		VersionID:     prepared.version.ID,
+		RetriedFrom:   retriedFrom,
+		Request:       encodeExecuteRequest(req),
 		CreatedAt:     time.Now(),
 	}
 
 	if err := s.db.DB().Create(job).Error; err != nil {
 		return nil, fmt.Errorf("failed to create job record: %w", err)
 	}
+	fmt.Printf("Job %s created for algorithm %s (mode %s)\n", jobID, req.AlgorithmId, mode)
+	s.publishJobEvent(job, "")
+	go s.trimJobHistory(algorithm)
 
-	if req.IsAsync {
-		go s.runJobAsync(ctx, jobID, req, algorithm, inputDir)
+	if isBackgroundMode(mode) {
+		go s.runJobAsync(jobID, req, prepared)
 		return &v1.ExecuteResponse{
 			JobId:   jobID,
 			Status:  "pending",
@@ -100,12 +151,14 @@ func (s *AlgorithmService) ExecuteAlgorithm(ctx context.Context, req *v1.Execute
 		}, nil
 	}
 
-	result, err := s.runJobSync(ctx, jobID, req, algorithm, inputDir)
+	result, err := s.runJobSync(ctx, jobID, req, prepared)
 	if err != nil {
-		job.Status = "failed"
-		job.FinishedAt = &[]time.Time{time.Now()}[0]
-		if err := s.db.DB().Save(job).Error; err != nil {
-			fmt.Printf("Failed to update job status: %v\n", err)
+		if tErr := transitionJob(s.db.DB(), job, models.JobStatusFailed, map[string]interface{}{
+			"finished_at": time.Now(),
+		}); tErr != nil {
+			fmt.Printf("Failed to update job status: %v\n", tErr)
+		} else {
+			s.publishJobEvent(job, err.Error())
 		}
 		return nil, err
 	}
@@ -113,9 +166,72 @@ func (s *AlgorithmService) ExecuteAlgorithm(ctx context.Context, req *v1.Execute
 	return result, nil
 }
 
+// preparedJob 执行任务前准备好的算法、代码版本、输入目录和资源配置
+type preparedJob struct {
+	algorithm *models.Algorithm
+	version   *models.Version
+	inputDir  string
+	resources scheduler.ResourceConfig
+}
+
+// prepareJob 校验请求并准备任务输入（预置数据和 params.json），新建任务和重启后恢复排队任务时使用。
+// versionID 为任务要运行的代码版本，为空时使用算法的当前版本
+func (s *AlgorithmService) prepareJob(ctx context.Context, jobID string, req *v1.ExecuteRequest, versionID string) (*preparedJob, error) {
+	algorithm := &models.Algorithm{}
+	if err := s.db.DB().First(algorithm, "id = ?", req.AlgorithmId).Error; err != nil {
+		return nil, fmt.Errorf("algorithm not found: %w", err)
+	}
+
+	if err := checkAlgorithmRunnable(algorithm); err != nil {
+		return nil, err
+	}
+
+	version, err := resolveJobVersion(s.db.DB().WithContext(ctx), algorithm, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateExecutionParams(algorithm, req.Params); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.checkPlatformConsistency(algorithm.Platform); err != nil {
+		return nil, fmt.Errorf("platform consistency check failed: %w", err)
+	}
+
+	if _, err := resolveSecretEnv(&s.cfg().Docker, algorithm, req.Secrets); err != nil {
+		return nil, err
+	}
+
+	resources, err := resolveResourceConfig(req.ResourceConfig, algorithm, &s.cfg().Docker)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid resource config: %v", err)
+	}
+	if err := validateResourceLimits(resources.CPULimit, resources.MemoryMB, &s.cfg().Docker); err != nil {
+		return nil, err
+	}
+
+	inputDir := filepath.Join("/tmp", "input", jobID)
+	if err := os.MkdirAll(inputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create input directory: %w", err)
+	}
+
+	if err := s.downloadPresetData(ctx, inputSources(req), inputDir); err != nil {
+		return nil, fmt.Errorf("failed to download preset data: %w", err)
+	}
+
+	if req.Params != nil {
+		if err := writeParamsFile(inputDir, req.Params); err != nil {
+			return nil, err
+		}
+	}
+
+	return &preparedJob{algorithm: algorithm, version: version, inputDir: inputDir, resources: resources}, nil
+}
+
 func (s *AlgorithmService) GetJobStatus(ctx context.Context, req *v1.GetJobStatusRequest) (*v1.GetJobStatusResponse, error) {
 	job := &models.Job{}
-	if err := s.db.DB().First(job, "job_id = ?", req.JobId).Error; err != nil {
+	if err := s.db.DB().First(job, "id = ?", req.JobId).Error; err != nil {
 		return nil, fmt.Errorf("job not found: %w", err)
 	}
 
@@ -128,13 +244,20 @@ func (s *AlgorithmService) GetJobStatus(ctx context.Context, req *v1.GetJobStatu
 		status = "completed"
 	}
 
+	resultURL, expired := resolveJobArtifacts(ctx, s.minioClient, &s.cfg().MinIO, job)
+
 	response := &v1.GetJobStatusResponse{
-		JobId:      job.ID,
-		Status:     status,
-		ResultUrl:  job.OutputURL,
-		StartedAt:  timestampProto(job.StartedAt),
-		FinishedAt: timestampProto(job.FinishedAt),
-		CostTimeMs: int32(job.CostTimeMs),
+		JobId:             job.ID,
+		Status:            status,
+		ResultUrl:         resultURL,
+		StartedAt:         timestampProto(job.StartedAt),
+		FinishedAt:        timestampProto(job.FinishedAt),
+		CostTimeMs:        int32(job.CostTimeMs),
+		ArtifactsExpired:  expired,
+		ArtifactsExpireAt: timestampProto(job.ArtifactsExpireAt),
+		LogUrl:            externalObjectURL(&s.cfg().MinIO, objectPathFromURL(s.cfg().MinIO.Bucket, job.LogURL)),
+		Warning:           job.Warning,
+		QueueDepth:        int32(s.jobSlots.queueDepth()),
 	}
 
 	if job.Status == "pending" {
@@ -144,122 +267,184 @@ func (s *AlgorithmService) GetJobStatus(ctx context.Context, req *v1.GetJobStatu
 	return response, nil
 }
 
-func (s *AlgorithmService) checkPlatformConsistency(algorithmPlatform string) (*v1.GetServerInfoResponse, error) {
-	bucketName := "algorithm-platform"
-
-	exists, err := s.minioClient.BucketExists(context.Background(), bucketName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check MinIO bucket: %w", err)
-	}
-	if !exists {
-		if err := s.minioClient.MakeBucket(context.Background(), bucketName, minio.MakeBucketOptions{}); err != nil {
-			return nil, fmt.Errorf("failed to create MinIO bucket: %w", err)
-		}
-	}
-
-	return &v1.GetServerInfoResponse{
-		Os:           "linux",
-		Arch:         "x86_64",
-		Platform:     v1.Platform_PLATFORM_LINUX_X86_64,
-		PlatformName: "Linux x86_64",
-	}, nil
-}
-
-func (s *AlgorithmService) downloadPresetData(ctx context.Context, inputSource *v1.InputSource, targetDir string) error {
-	if inputSource.Url == "" {
+// downloadPresetData 将全部输入文件下载到任务输入目录，文件名与预置数据一致。
+// 先确认所有输入都存在再开始下载，下载后按上传时记录的校验和验证内容
+func (s *AlgorithmService) downloadPresetData(ctx context.Context, sources []*v1.InputSource, targetDir string) error {
+	if len(sources) == 0 {
 		return nil
 	}
 
-	bucketName := "algorithm-platform"
-
-	presetData := &models.PresetData{}
-	if err := s.db.DB().First(presetData, "minio_url = ?", inputSource.Url).Error; err != nil {
-		return fmt.Errorf("preset data not found: %w", err)
-	}
-
-	minioPath := presetData.MinioURL
-	if idx := len(minioPath) - 1; idx > 0 && minioPath[idx] == '/' {
-		minioPath = minioPath[:idx]
+	bucketName := s.cfg().MinIO.Bucket
+	inputs, err := resolveInputs(s.db.DB().WithContext(ctx), bucketName, sources)
+	if err != nil {
+		return err
 	}
 
-	bucketName = "algorithm-platform"
-	if idx := len(minioPath) - 1; idx >= 0 {
-		if minioPath[idx] == '/' {
-			bucketName = minioPath[:idx]
-			minioPath = ""
+	for _, input := range inputs {
+		filename := filepath.Join(targetDir, input.filename)
+		checksum, err := s.downloadObject(ctx, bucketName, input.objectPath, filename)
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %w", input.objectPath, err)
+		}
+		if err := verifyChecksum(input.objectPath, input.checksum, checksum); err != nil {
+			os.Remove(filename)
+			return err
 		}
 	}
+	return nil
+}
 
-	obj, err := s.minioClient.GetObject(ctx, bucketName, minioPath, minio.GetObjectOptions{})
+// downloadObject 将 MinIO 对象保存到本地文件，返回内容的 SHA-256
+func (s *AlgorithmService) downloadObject(ctx context.Context, bucketName, objectPath, filename string) (string, error) {
+	obj, err := s.minioClient.GetObject(ctx, bucketName, objectPath, minio.GetObjectOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to get preset data from MinIO: %w", err)
+		return "", fmt.Errorf("failed to get preset data from MinIO: %w", err)
 	}
 	defer obj.Close()
 
-	filename := filepath.Join(targetDir, filepath.Base(presetData.Filename))
 	file, err := os.Create(filename)
 	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+		return "", fmt.Errorf("failed to create file: %w", err)
 	}
 	defer file.Close()
 
-	if _, err := io.Copy(file, obj); err != nil {
-		return fmt.Errorf("failed to copy data: %w", err)
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, hash), obj); err != nil {
+		return "", fmt.Errorf("failed to copy data: %w", err)
 	}
 
-	return nil
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
-func (s *AlgorithmService) runJobSync(ctx context.Context, jobID string, req *v1.ExecuteRequest, algorithm *models.Algorithm, inputDir string) (*v1.ExecuteResponse, error) {
-	job := &models.Job{}
-	s.db.DB().First(job, "job_id = ?", jobID)
+func (s *AlgorithmService) runJobSync(ctx context.Context, jobID string, req *v1.ExecuteRequest, prepared *preparedJob) (*v1.ExecuteResponse, error) {
+	job := &models.Job{ID: jobID}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s.jobCancels.Store(jobID, cancel)
+	defer s.jobCancels.Delete(jobID)
+
+	// 并发已满时等待槽位，期间任务保持 pending；后台任务一直等待，同步任务超时后返回服务繁忙
+	queueTimeout := syncJobQueueTimeout
+	if isBackgroundMode(req.Mode) {
+		queueTimeout = 0
+	}
+	release, err := s.jobSlots.acquire(ctx, queueTimeout)
+	if err != nil && ctx.Err() == nil {
+		return nil, err
+	}
+	if release != nil {
+		defer release()
+	}
+
+	// 请求在开始执行前已被取消（客户端断开或 CancelJob）时不再启动容器
+	if ctx.Err() != nil {
+		return s.cancelJob(job, ctx.Err()), nil
+	}
 
-	job.Status = "running"
 	now := time.Now()
-	job.StartedAt = &now
-	s.db.DB().Save(job)
+	if err := transitionJob(s.db.DB(), job, models.JobStatusRunning, map[string]interface{}{"started_at": now}); err != nil {
+		if errors.Is(err, ErrInvalidJobTransition) && job.Status == models.JobStatusCancelled {
+			// 启动前已通过 CancelJob 取消
+			return &v1.ExecuteResponse{JobId: jobID, Status: job.Status, Message: getJobMessage(job.Status, nil)}, nil
+		}
+		// 任务已被取消等情况下不再执行
+		return nil, fmt.Errorf("failed to start job: %w", err)
+	}
+	s.publishJobEvent(job, "")
 
-	resultURL, err := s.executeInContainer(ctx, jobID, algorithm, inputDir, req.ResourceConfig, req.TimeoutSeconds)
+	run, err := s.executeInContainer(ctx, jobID, prepared, req.TimeoutSeconds, req.Secrets, req.UseImageTag)
 
 	endTime := time.Now()
-	job.FinishedAt = &endTime
-	job.CostTimeMs = endTime.Sub(now).Milliseconds()
+	updates := map[string]interface{}{
+		"finished_at":  endTime,
+		"cost_time_ms": endTime.Sub(now).Milliseconds(),
+	}
+	// 只保存对象路径，读取时再生成URL
+	if run.LogPath != "" {
+		updates["log_url"] = run.LogPath
+	}
+	if run.Warning != "" {
+		updates["warning"] = run.Warning
+	}
 
+	target := models.JobStatusCompleted
 	if err != nil {
-		job.Status = "failed"
-		job.LogURL = ""
+		updates["failure_reason"] = failureReason(err)
+		target = models.JobStatusFailed
+		if classifyJobError(err).Category == FailureTimeout {
+			target = models.JobStatusTimeout
+		} else if errors.Is(ctx.Err(), context.Canceled) {
+			// 同步请求被客户端取消，容器已在 executeInContainer 中停止
+			target = models.JobStatusCancelled
+		}
 	} else {
-		job.Status = "completed"
-		job.OutputURL = resultURL
+		updates["output_url"] = run.ResultPath
+		updates["artifacts_expire_at"] = endTime.Add(s.cfg().MinIO.GetResultRetention())
 	}
-	s.db.DB().Save(job)
+
+	if tErr := transitionJob(s.db.DB(), job, target, updates); tErr != nil {
+		// 执行期间任务已进入终态（如被取消），保留已有状态
+		fmt.Printf("Job %s result discarded: %v\n", jobID, tErr)
+		return &v1.ExecuteResponse{
+			JobId:   jobID,
+			Status:  job.Status,
+			Message: getJobMessage(job.Status, nil),
+		}, nil
+	}
+	message := getJobMessage(job.Status, err)
+	s.publishJobEvent(job, message)
 
 	return &v1.ExecuteResponse{
 		JobId:     jobID,
 		Status:    job.Status,
-		ResultUrl: resultURL,
-		Message:   getJobMessage(job.Status, err),
+		ResultUrl: externalObjectURL(&s.cfg().MinIO, job.OutputURL),
+		Message:   message,
+		Failure:   failureDetail(err),
 	}, nil
 }
 
-func (s *AlgorithmService) runJobAsync(ctx context.Context, jobID string, req *v1.ExecuteRequest, algorithm *models.Algorithm, inputDir string) {
-	result, err := s.runJobSync(ctx, jobID, req, algorithm, inputDir)
-
-	if req.WebhookUrl != "" {
-		s.sendWebhook(ctx, req.WebhookUrl, jobID, result, err)
+// cancelJob 将尚未开始的任务标记为已取消
+func (s *AlgorithmService) cancelJob(job *models.Job, cause error) *v1.ExecuteResponse {
+	if err := transitionJob(s.db.DB(), job, models.JobStatusCancelled, map[string]interface{}{
+		"finished_at": time.Now(),
+	}); err != nil {
+		if !errors.Is(err, ErrInvalidJobTransition) {
+			fmt.Printf("Failed to cancel job %s: %v\n", job.ID, err)
+		}
+	} else {
+		s.publishJobEvent(job, fmt.Sprintf("request cancelled: %v", cause))
+	}
+	return &v1.ExecuteResponse{
+		JobId:   job.ID,
+		Status:  job.Status,
+		Message: getJobMessage(job.Status, nil),
 	}
 }
 
-func (s *AlgorithmService) executeInContainer(ctx context.Context, jobID string, algorithm *models.Algorithm, inputDir string, resourceConfig *v1.ResourceConfig, timeoutSeconds int32) (string, error) {
-	return fmt.Sprintf("http://localhost:9000/algorithm-platform/results/%s", jobID), nil
+// webhookDeliveryTimeout 一次 webhook 投递（包括读取内联结果和所有重试）的最长时间
+const webhookDeliveryTimeout = 2 * time.Minute
+
+// runJobAsync 在后台执行任务。gRPC 在请求返回后会取消请求的 context，
+// 因此后台任务使用独立的 context，执行时长由 executeInContainer 按 timeout_seconds 限制
+func (s *AlgorithmService) runJobAsync(jobID string, req *v1.ExecuteRequest, prepared *preparedJob) {
+	ctx := context.Background()
+	result, err := s.runJobSync(ctx, jobID, req, prepared)
+
+	if req.WebhookUrl != "" {
+		webhookCtx, cancel := context.WithTimeout(ctx, webhookDeliveryTimeout)
+		defer cancel()
+		s.sendWebhook(webhookCtx, req.WebhookUrl, jobID, result, err)
+	}
 }
 
+// sendWebhook 投递任务结束的 webhook 并记录投递结果。重试和每次请求都受 ctx 约束，ctx 结束后不再重试
 func (s *AlgorithmService) sendWebhook(ctx context.Context, webhookURL, jobID string, result *v1.ExecuteResponse, err error) {
 	webhookData := map[string]interface{}{
 		"job_id":     jobID,
-		"status":     result.Status,
-		"result_url": result.ResultUrl,
-		"message":    result.Message,
+		"status":     result.GetStatus(),
+		"result_url": result.GetResultUrl(),
+		"message":    result.GetMessage(),
 		"error":      "",
 		"timestamp":  time.Now().Format(time.RFC3339),
 	}
@@ -267,9 +452,127 @@ func (s *AlgorithmService) sendWebhook(ctx context.Context, webhookURL, jobID st
 	if err != nil {
 		webhookData["error"] = err.Error()
 		webhookData["status"] = "failed"
+	} else {
+		s.addInlineResult(ctx, webhookData, jobID, result)
+	}
+	if failure := result.GetFailure(); failure != nil {
+		webhookData["failure"] = map[string]interface{}{
+			"category":  failure.Category,
+			"exit_code": failure.ExitCode,
+			"log_tail":  failure.LogTail,
+		}
+	} else if err != nil {
+		webhookData["failure"] = map[string]interface{}{"category": classifyJobError(err).Category}
+	}
+
+	body, marshalErr := json.Marshal(webhookData)
+	if marshalErr != nil {
+		fmt.Printf("Failed to marshal webhook payload for job %s: %v\n", jobID, marshalErr)
+		return
+	}
+
+	policy := retry.Default()
+	policy.MaxAttempts = 3
+	policy.BaseDelay = time.Second
+	policy.MaxDelay = 30 * time.Second
+	policy.Retryable = isRetryableWebhookError
+	policy.OnRetry = func(attempt int, delay time.Duration, err error) {
+		fmt.Printf("Webhook for job %s failed, retrying in %v: %v\n", jobID, delay.Round(time.Millisecond), err)
+	}
+
+	statusCode := 0
+	sendErr := retry.Do(ctx, policy, func() error {
+		var err error
+		statusCode, err = postWebhook(ctx, webhookURL, body, s.cfg().Server.WebhookSecret)
+		return err
+	})
+	if sendErr != nil {
+		fmt.Printf("Failed to send webhook for job %s to %s: %v\n", jobID, redact.URL(webhookURL), sendErr)
+	}
+	if err := recordWebhookDelivery(s.db.DB(), jobID, statusCode, sendErr); err != nil {
+		fmt.Printf("Failed to record webhook delivery for job %s: %v\n", jobID, err)
 	}
 }
 
+// recordWebhookDelivery 在任务上记录 webhook 的最终投递结果，statusCode 为最后一次请求的 HTTP 状态码（网络错误时为 0）
+func recordWebhookDelivery(db *gorm.DB, jobID string, statusCode int, sendErr error) error {
+	updates := map[string]interface{}{
+		"webhook_status":      models.WebhookStatusDelivered,
+		"webhook_status_code": statusCode,
+		"webhook_error":       "",
+	}
+	if sendErr != nil {
+		updates["webhook_status"] = models.WebhookStatusFailed
+		updates["webhook_error"] = sendErr.Error()
+	}
+	return db.Model(&models.Job{}).Where("id = ?", jobID).Updates(updates).Error
+}
+
+// webhookStatusError webhook 接收方返回了非 2xx 状态码
+type webhookStatusError struct {
+	StatusCode int
+}
+
+func (e *webhookStatusError) Error() string {
+	return fmt.Sprintf("webhook returned status %d", e.StatusCode)
+}
+
+// postWebhook 发送一次 webhook 请求，返回 HTTP 状态码（请求未完成时为 0）。
+// secret 不为空时附带请求体的 HMAC-SHA256 签名
+func postWebhook(ctx context.Context, webhookURL string, body []byte, secret string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign(secret, body))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// 错误信息中的 URL 会写入日志和任务记录，隐藏其中的令牌
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = redact.URL(urlErr.URL)
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, &webhookStatusError{StatusCode: resp.StatusCode}
+	}
+	return resp.StatusCode, nil
+}
+
+// isRetryableWebhookError 网络错误、429 和 5xx 可以重试，其余 4xx 说明请求本身有问题
+func isRetryableWebhookError(err error) bool {
+	var statusErr *webhookStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	return true
+}
+
+// publishJobEvent 发布任务状态变更事件，供 WebSocket 等实时通道推送
+func (s *AlgorithmService) publishJobEvent(job *models.Job, message string) {
+	if s.jobEvents == nil {
+		return
+	}
+
+	s.jobEvents.Publish(events.JobEvent{
+		JobID:     job.ID,
+		Status:    job.Status,
+		Message:   message,
+		ResultURL: externalObjectURL(&s.cfg().MinIO, job.OutputURL),
+	})
+}
+
 func getJobMessage(status string, err error) string {
 	messages := map[string]string{
 		"pending":   "Job is pending",
@@ -278,8 +581,8 @@ func getJobMessage(status string, err error) string {
 		"failed":    "Job execution failed",
 	}
 
-	if err != nil {
-		return fmt.Sprintf("Job failed: %v", err)
+	if failure := classifyJobError(err); failure != nil {
+		return fmt.Sprintf("Job failed (%s): %v", failure.Category, failure)
 	}
 
 	if msg, ok := messages[status]; ok {
@@ -288,6 +591,43 @@ func getJobMessage(status string, err error) string {
 	return "Job status: " + status
 }
 
+// resultObjectPath 返回任务结果在 MinIO 中的对象路径
+func resultObjectPath(minioCfg *config.MinIOConfig, jobID string) string {
+	return minioCfg.ObjectKey(keys.JobResult(jobID))
+}
+
+// logObjectPath 返回任务容器日志在 MinIO 中的对象路径
+func logObjectPath(minioCfg *config.MinIOConfig, jobID string) string {
+	return minioCfg.ObjectKey(keys.JobLog(jobID))
+}
+
+// resolveJobArtifacts 检查任务结果是否仍然可用
+// 未过期时按当前配置生成访问地址；过期后若对象仍存在则重新生成预签名URL，否则标记为已过期
+func resolveJobArtifacts(ctx context.Context, client *minio.Client, minioCfg *config.MinIOConfig, job *models.Job) (string, bool) {
+	if job.OutputURL == "" {
+		return "", false
+	}
+
+	objectPath := objectPathFromURL(minioCfg.Bucket, job.OutputURL)
+	if job.ArtifactsExpireAt == nil || time.Now().Before(*job.ArtifactsExpireAt) {
+		return externalObjectURL(minioCfg, objectPath), false
+	}
+
+	if client == nil {
+		return "", true
+	}
+
+	presignedURL, err := presignExistingObject(ctx, client, minioCfg.Bucket, objectPath, time.Hour*24, path.Base(objectPath))
+	if err != nil {
+		if !errors.Is(err, errObjectMissing) {
+			fmt.Printf("Failed to regenerate result URL for job %s: %v\n", job.ID, err)
+		}
+		return "", true
+	}
+
+	return presignedURL, false
+}
+
 func timestampProto(t *time.Time) *timestamppb.Timestamp {
 	if t == nil {
 		return nil
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"algorithm-platform/internal/models"
+
+	"gorm.io/gorm"
+)
+
+func newDeleteTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := newAlgorithmTestDB(t,
+		models.Algorithm{ID: "alg_1", Name: "one"},
+		models.Algorithm{ID: "alg_2", Name: "two"},
+	)
+	if err := db.AutoMigrate(&models.Version{}, &models.Job{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	for _, v := range []models.Version{
+		{ID: "v1", AlgorithmID: "alg_1", VersionNumber: 1, MinioPath: "algorithms/alg_1/v1/main.py"},
+		{ID: "v2", AlgorithmID: "alg_1", VersionNumber: 2, MinioPath: "algorithms/alg_1/v2/main.py"},
+		{ID: "v3", AlgorithmID: "alg_2", VersionNumber: 1, MinioPath: "algorithms/alg_2/v1/main.py"},
+	} {
+		if err := db.Create(&v).Error; err != nil {
+			t.Fatalf("Failed to create version: %v", err)
+		}
+	}
+	return db
+}
+
+func TestDeleteAlgorithmRows(t *testing.T) {
+	db := newDeleteTestDB(t)
+	db.Create(&models.Job{ID: "job_done", AlgorithmID: "alg_1", Status: models.JobStatusCompleted})
+
+	versions, err := deleteAlgorithmRows(db, "alg_1")
+	if err != nil {
+		t.Fatalf("deleteAlgorithmRows failed: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Errorf("Deleted %d versions, want 2", len(versions))
+	}
+
+	var algorithms, remaining, jobs int64
+	db.Model(&models.Algorithm{}).Count(&algorithms)
+	db.Model(&models.Version{}).Count(&remaining)
+	db.Model(&models.Job{}).Count(&jobs)
+	if algorithms != 1 || remaining != 1 || jobs != 1 {
+		t.Errorf("After delete: %d algorithms, %d versions, %d jobs; want 1, 1, 1", algorithms, remaining, jobs)
+	}
+
+	if _, err := deleteAlgorithmRows(db, "alg_1"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Deleting again: err = %v, want ErrRecordNotFound", err)
+	}
+}
+
+func TestDeleteAlgorithmRowsRefusesActiveJobs(t *testing.T) {
+	db := newDeleteTestDB(t)
+	db.Create(&models.Job{ID: "job_running", AlgorithmID: "alg_2", Status: models.JobStatusRunning})
+
+	if _, err := deleteAlgorithmRows(db, "alg_2"); !errors.Is(err, errAlgorithmHasActiveJobs) {
+		t.Fatalf("err = %v, want errAlgorithmHasActiveJobs", err)
+	}
+
+	var versions int64
+	db.Model(&models.Version{}).Where("algorithm_id = ?", "alg_2").Count(&versions)
+	if versions != 1 {
+		t.Errorf("Versions were deleted despite the active job")
+	}
+}
+
+func TestAlgorithmObjectKeys(t *testing.T) {
+	listed := []string{"algorithms/alg_1/v1/main.py", "algorithms/alg_1/v2/main.py"}
+	versions := []models.Version{
+		{MinioPath: "algorithms/alg_1/v2/main.py"},
+		{MinioPath: "http://minio:9000/bucket/legacy/alg_1/main.py"},
+		{MinioPath: ""},
+	}
+
+	got := algorithmObjectKeys("bucket", listed, versions)
+	want := []string{"algorithms/alg_1/v1/main.py", "algorithms/alg_1/v2/main.py", "legacy/alg_1/main.py"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("algorithmObjectKeys() = %v, want %v", got, want)
+	}
+}
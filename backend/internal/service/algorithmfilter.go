@@ -0,0 +1,42 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// likeEscaper 转义 LIKE 中的通配符，配合 ESCAPE '\' 使用
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// filterAlgorithmsByQuery 按名称或描述做不区分大小写的子串匹配
+func filterAlgorithmsByQuery(query *gorm.DB, search string) *gorm.DB {
+	if search == "" {
+		return query
+	}
+	pattern := "%" + likeEscaper.Replace(strings.ToLower(search)) + "%"
+	return query.Where(`(LOWER(name) LIKE ? ESCAPE '\' OR LOWER(description) LIKE ? ESCAPE '\')`, pattern, pattern)
+}
+
+// normalizedTagList 标签过滤条件去空格、小写、去重并排序
+func normalizedTagList(tags []string) []string {
+	set := normalizeTags(strings.Join(tags, ","))
+	list := make([]string, 0, len(set))
+	for tag := range set {
+		list = append(list, tag)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// filterAlgorithmsByTags 只保留包含全部标签的算法
+// 标签以逗号拼接保存，两端补上逗号后按 ",tag," 匹配整个标签，避免 "ml" 匹配到 "html"
+// 比较时两边都去掉空格，兼容 "a, b" 这样带空格保存的标签
+func filterAlgorithmsByTags(query *gorm.DB, tags []string) *gorm.DB {
+	for _, tag := range tags {
+		pattern := "%," + likeEscaper.Replace(strings.ReplaceAll(tag, " ", "")) + ",%"
+		query = query.Where(`(',' || LOWER(REPLACE(tags, ' ', '')) || ',') LIKE ? ESCAPE '\'`, pattern)
+	}
+	return query
+}
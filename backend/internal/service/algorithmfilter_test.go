@@ -0,0 +1,51 @@
+package service
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"algorithm-platform/internal/models"
+)
+
+func TestFilterAlgorithms(t *testing.T) {
+	db := newAlgorithmTestDB(t,
+		models.Algorithm{ID: "alg_ml", Name: "Classifier", Description: "gradient boosting", Tags: "ml,Tabular"},
+		models.Algorithm{ID: "alg_html", Name: "Page parser", Description: "extract text", Tags: "html,parser"},
+		models.Algorithm{ID: "alg_spaced", Name: "Vision", Description: "100% accurate", Tags: "cv, ML, deep learning"},
+		models.Algorithm{ID: "alg_none", Name: "ml_helper", Description: ""},
+	)
+
+	tests := []struct {
+		name   string
+		search string
+		tags   []string
+		want   []string
+	}{
+		{"no filters", "", nil, []string{"alg_html", "alg_ml", "alg_none", "alg_spaced"}},
+		{"whole tag only", "", []string{"ml"}, []string{"alg_ml", "alg_spaced"}},
+		{"all tags required", "", []string{"ML", "tabular"}, []string{"alg_ml"}},
+		{"multi-word tag", "", []string{" deep learning "}, []string{"alg_spaced"}},
+		{"name match", "PARSER", nil, []string{"alg_html"}},
+		{"description match", "boost", nil, []string{"alg_ml"}},
+		{"wildcards are literal", "%", nil, []string{"alg_spaced"}},
+		{"underscore is literal", "l_h", nil, []string{"alg_none"}},
+		{"query and tags", "vision", []string{"cv"}, []string{"alg_spaced"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := filterAlgorithmsByTags(filterAlgorithmsByQuery(db.Model(&models.Algorithm{}), tt.search), normalizedTagList(tt.tags))
+			var ids []string
+			if err := query.Pluck("id", &ids).Error; err != nil {
+				t.Fatalf("Query failed: %v", err)
+			}
+			sort.Strings(ids)
+			if len(ids) == 0 {
+				ids = nil
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("Got %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TriggerBackup 立即执行一次数据库备份，不等待定时备份。
+// 与定时备份、关闭时的备份和恢复互斥，已有操作在进行时返回 Aborted
+func (s *ManagementService) TriggerBackup(ctx context.Context, req *v1.TriggerBackupRequest) (*v1.TriggerBackupResponse, error) {
+	if err := requireAdmin(ctx, s.cfg().Server.AdminToken); err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.Backup()
+	switch {
+	case errors.Is(err, database.ErrBackupBusy):
+		return nil, status.Error(codes.Aborted, "a backup or restore is already in progress, try again later")
+	case errors.Is(err, database.ErrBackupUnsupported):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, fmt.Errorf("backup failed: %w", err)
+	}
+
+	return &v1.TriggerBackupResponse{
+		Path:        result.Path,
+		Source:      result.Source,
+		Version:     result.Version,
+		RecordCount: result.RecordCount,
+		CreatedAt:   timestamppb.New(result.CreatedAt),
+	}, nil
+}
+
+// ListBackups 列出可以恢复的 JSON 备份（MinIO 和本地目录），从新到旧排序
+func (s *ManagementService) ListBackups(ctx context.Context, req *v1.ListBackupsRequest) (*v1.ListBackupsResponse, error) {
+	if err := requireAdmin(ctx, s.cfg().Server.AdminToken); err != nil {
+		return nil, err
+	}
+
+	backups, err := s.db.ListBackups(ctx)
+	if errors.Is(err, database.ErrBackupUnsupported) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	resp := &v1.ListBackupsResponse{Backups: make([]*v1.BackupEntry, 0, len(backups))}
+	for _, backup := range backups {
+		resp.Backups = append(resp.Backups, &v1.BackupEntry{
+			Path:          backup.Path,
+			Source:        backup.Source,
+			CreatedAt:     timestamppb.New(backup.Timestamp),
+			Version:       backup.Version,
+			RecordCount:   backup.RecordCount,
+			Size:          backup.Size,
+			SchemaVersion: int32(backup.SchemaVersion),
+		})
+	}
+	return resp, nil
+}
+
+// RestoreBackup 用指定的备份替换当前的算法、版本、任务和预置数据。
+// 有任务在排队或执行时拒绝恢复，避免清表后任务结果写回到不存在的记录。
+// 先开启只读再检查任务，检查之后提交的任务不会在恢复时被清除
+func (s *ManagementService) RestoreBackup(ctx context.Context, req *v1.RestoreBackupRequest) (*v1.RestoreBackupResponse, error) {
+	if err := requireAdmin(ctx, s.cfg().Server.AdminToken); err != nil {
+		return nil, err
+	}
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path is required")
+	}
+
+	if s.maintenance != nil {
+		release := s.maintenance.Engage("restoring database from backup")
+		defer release()
+	}
+
+	var active int64
+	if err := s.db.DB().Model(&models.Job{}).
+		Where("status IN ?", []string{models.JobStatusPending, models.JobStatusRunning}).
+		Count(&active).Error; err != nil {
+		return nil, fmt.Errorf("failed to count active jobs: %w", err)
+	}
+	if active > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "%d jobs are pending or running, wait for them to finish before restoring", active)
+	}
+
+	result, err := s.db.RestoreBackup(ctx, req.Path)
+	switch {
+	case errors.Is(err, database.ErrBackupNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, database.ErrBackupHashMismatch):
+		return nil, status.Error(codes.DataLoss, err.Error())
+	case errors.Is(err, database.ErrBackupBusy):
+		return nil, status.Error(codes.Aborted, "a backup or restore is already in progress, try again later")
+	case errors.Is(err, database.ErrBackupUnsupported):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, fmt.Errorf("restore failed: %w", err)
+	}
+
+	resp := &v1.RestoreBackupResponse{
+		Path:       result.Path,
+		Source:     result.Source,
+		Partial:    result.Partial(),
+		Warnings:   result.Warnings,
+		DurationMs: result.Duration.Milliseconds(),
+	}
+	for name, table := range result.Tables {
+		resp.Tables = append(resp.Tables, &v1.RestoredTable{
+			Name:     name,
+			Restored: int32(table.Restored),
+			Failed:   int32(table.Failed),
+		})
+	}
+	sort.Slice(resp.Tables, func(i, j int) bool { return resp.Tables[i].Name < resp.Tables[j].Name })
+	return resp, nil
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sha256Hex 返回内容的 SHA-256（十六进制）
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyChecksum 比较下载内容的校验和，expected 为空（旧数据）时不校验。不一致时返回 DataLoss
+func verifyChecksum(objectPath, expected, actual string) error {
+	if expected == "" || expected == actual {
+		return nil
+	}
+	return status.Errorf(codes.DataLoss, "checksum mismatch for %s: expected sha256 %s, got %s", objectPath, expected, actual)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sha256 of "x,y\n"
+const xyChecksum = "9c6536d38fa37da58fac066342747e6d20fdace12ab5b287cf04506f2afe95b0"
+
+func TestUploadPresetDataStoresChecksum(t *testing.T) {
+	s := newPresetTestService(t)
+	ctx := context.Background()
+
+	fromFile, err := s.UploadPresetDataFile(ctx, "数据", "", "data.csv", strings.NewReader("x,y\n"))
+	if err != nil {
+		t.Fatalf("UploadPresetDataFile failed: %v", err)
+	}
+	fromBytes, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "data.csv", FileData: []byte("x,y\n")})
+	if err != nil {
+		t.Fatalf("UploadPresetData failed: %v", err)
+	}
+
+	for _, resp := range []*v1.UploadDataResponse{fromFile, fromBytes} {
+		if resp.Checksum != xyChecksum {
+			t.Errorf("Response checksum = %s, want %s", resp.Checksum, xyChecksum)
+		}
+		var data models.PresetData
+		s.db.DB().First(&data, "id = ?", resp.FileId)
+		if data.Checksum != xyChecksum {
+			t.Errorf("Stored checksum = %s, want %s", data.Checksum, xyChecksum)
+		}
+	}
+}
+
+func TestDownloadPresetDataVerifiesChecksum(t *testing.T) {
+	m := newPresetTestService(t)
+	createPresetData(t, m,
+		models.PresetData{ID: "data_ok", Filename: "ok.csv", MinioPath: "preset-data/data_ok/ok.csv", Checksum: xyChecksum},
+		models.PresetData{ID: "data_legacy", Filename: "legacy.csv", MinioPath: "preset-data/data_legacy/legacy.csv"},
+		models.PresetData{ID: "data_bad", Filename: "bad.csv", MinioPath: "preset-data/data_bad/bad.csv", Checksum: strings.Repeat("0", 64)},
+	)
+	store, client := testutil.NewS3(t)
+	for _, key := range []string{"preset-data/data_ok/ok.csv", "preset-data/data_legacy/legacy.csv", "preset-data/data_bad/bad.csv"} {
+		store.Put(key, []byte("x,y\n"))
+	}
+	s := &AlgorithmService{db: m.db, cfgStore: m.cfgStore, minioClient: client}
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	if err := s.downloadPresetData(ctx, []*v1.InputSource{{PresetDataId: "data_ok"}, {PresetDataId: "data_legacy"}}, dir); err != nil {
+		t.Fatalf("downloadPresetData failed: %v", err)
+	}
+
+	err := s.downloadPresetData(ctx, []*v1.InputSource{{PresetDataId: "data_bad"}}, dir)
+	if status.Code(err) != codes.DataLoss || !strings.Contains(err.Error(), "checksum mismatch for preset-data/data_bad/bad.csv") {
+		t.Fatalf("err = %v, want DataLoss checksum mismatch", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bad.csv")); !os.IsNotExist(err) {
+		t.Error("Corrupted file was left in the input directory")
+	}
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"github.com/minio/minio-go/v7"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxCompareBytes 比较时单个输出下载的上限
+	maxCompareBytes = 10 << 20
+	// maxDiffPreview 差异预览保留的行数
+	maxDiffPreview = 20
+	// maxOrderedDiffCells 按顺序比较（LCS）时允许的最大行数乘积，超过时按行计数比较
+	maxOrderedDiffCells = 4_000_000
+)
+
+// CompareJobs 比较两个已完成任务的输出：是否完全一致、大小差异，以及文本输出的行级差异
+func (s *ManagementService) CompareJobs(ctx context.Context, req *v1.CompareJobsRequest) (*v1.CompareJobsResponse, error) {
+	if req.LeftJobId == "" || req.RightJobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "left_job_id and right_job_id are required")
+	}
+	if s.minioClient == nil {
+		return nil, status.Error(codes.Unavailable, "MinIO client is not available")
+	}
+
+	limit := int64(maxCompareBytes)
+	if req.MaxBytes > 0 && req.MaxBytes < limit {
+		limit = req.MaxBytes
+	}
+
+	left, leftData, err := s.loadJobOutput(ctx, req.LeftJobId, limit)
+	if err != nil {
+		return nil, err
+	}
+	right, rightData, err := s.loadJobOutput(ctx, req.RightJobId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &v1.CompareJobsResponse{
+		Left:     left,
+		Right:    right,
+		SizeDiff: right.Size - left.Size,
+	}
+	if leftData == nil || rightData == nil {
+		resp.Truncated = true
+		return resp, nil
+	}
+
+	resp.Identical = bytes.Equal(leftData, rightData)
+	if !resp.Identical && isText(leftData) && isText(rightData) {
+		resp.LineDiff = diffLines(splitLines(leftData), splitLines(rightData))
+	}
+	return resp, nil
+}
+
+// loadJobOutput 读取任务的结果对象，超过 limit 时只返回对象信息，data 为 nil
+func (s *ManagementService) loadJobOutput(ctx context.Context, jobID string, limit int64) (*v1.JobOutput, []byte, error) {
+	var job models.Job
+	if err := s.db.DB().First(&job, "id = ?", jobID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, status.Errorf(codes.NotFound, "job %s not found", jobID)
+		}
+		return nil, nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if job.Status != models.JobStatusCompleted {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "job %s is %s, only completed jobs can be compared", jobID, job.Status)
+	}
+	if job.OutputURL == "" {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "job %s has no output", jobID)
+	}
+
+	key := objectPathFromURL(s.bucketName, job.OutputURL)
+	info, err := s.minioClient.StatObject(ctx, s.bucketName, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, nil, status.Errorf(codes.FailedPrecondition, "output of job %s has expired", jobID)
+		}
+		return nil, nil, fmt.Errorf("failed to stat output of job %s: %w", jobID, err)
+	}
+
+	output := &v1.JobOutput{
+		JobId:       jobID,
+		ObjectKey:   key,
+		Size:        info.Size,
+		ContentType: info.ContentType,
+	}
+	if info.Size > limit {
+		return output, nil, nil
+	}
+
+	obj, err := s.minioClient.GetObject(ctx, s.bucketName, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get output of job %s: %w", jobID, err)
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(io.LimitReader(obj, limit+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read output of job %s: %w", jobID, err)
+	}
+	if int64(len(data)) > limit {
+		return output, nil, nil
+	}
+
+	sum := sha256.Sum256(data)
+	output.Sha256 = hex.EncodeToString(sum[:])
+	return output, data, nil
+}
+
+// isText 判断内容是否为文本（合法 UTF-8 且不含 NUL）
+func isText(data []byte) bool {
+	return utf8.Valid(data) && bytes.IndexByte(data, 0) < 0
+}
+
+// splitLines 按行拆分，忽略末尾换行
+func splitLines(data []byte) []string {
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	if len(data) == 0 {
+		return nil
+	}
+	parts := bytes.Split(data, []byte("\n"))
+	lines := make([]string, len(parts))
+	for i, part := range parts {
+		lines[i] = string(bytes.TrimSuffix(part, []byte("\r")))
+	}
+	return lines
+}
+
+// diffLines 计算行级差异。先去掉相同的首尾，剩余部分较小时用 LCS 按顺序比较，否则按行计数比较
+func diffLines(left, right []string) *v1.LineDiffSummary {
+	prefix := 0
+	for prefix < len(left) && prefix < len(right) && left[prefix] == right[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(left)-prefix && suffix < len(right)-prefix &&
+		left[len(left)-1-suffix] == right[len(right)-1-suffix] {
+		suffix++
+	}
+
+	summary := &v1.LineDiffSummary{UnchangedLines: int32(prefix + suffix)}
+	l, r := left[prefix:len(left)-suffix], right[prefix:len(right)-suffix]
+
+	if len(l)*len(r) > maxOrderedDiffCells {
+		summary.Unordered = true
+		diffLineCounts(l, r, summary)
+		return summary
+	}
+	diffLCS(l, r, summary)
+	return summary
+}
+
+// diffLCS 基于最长公共子序列的按顺序比较
+func diffLCS(left, right []string, summary *v1.LineDiffSummary) {
+	n, m := len(left), len(right)
+	// lcs[i][j] 为 left[i:] 与 right[j:] 的最长公共子序列长度
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if left[i] == right[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && left[i] == right[j]:
+			summary.UnchangedLines++
+			i++
+			j++
+		case j < m && (i == n || lcs[i][j+1] >= lcs[i+1][j]):
+			summary.AddedLines++
+			addPreview(summary, "+ "+right[j])
+			j++
+		default:
+			summary.RemovedLines++
+			addPreview(summary, "- "+left[i])
+			i++
+		}
+	}
+}
+
+// diffLineCounts 按每行出现次数比较，不考虑顺序
+func diffLineCounts(left, right []string, summary *v1.LineDiffSummary) {
+	counts := make(map[string]int)
+	for _, line := range left {
+		counts[line]++
+	}
+	for _, line := range right {
+		if counts[line] > 0 {
+			counts[line]--
+			summary.UnchangedLines++
+		} else {
+			summary.AddedLines++
+			addPreview(summary, "+ "+line)
+		}
+	}
+	for _, line := range left {
+		if counts[line] > 0 {
+			counts[line]--
+			summary.RemovedLines++
+			addPreview(summary, "- "+line)
+		}
+	}
+}
+
+func addPreview(summary *v1.LineDiffSummary, line string) {
+	if len(summary.Preview) < maxDiffPreview {
+		summary.Preview = append(summary.Preview, line)
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	left := splitLines([]byte("id,score\n1,0.5\n2,0.7\n3,0.9\n"))
+	right := splitLines([]byte("id,score\r\n1,0.5\r\n2,0.8\r\n3,0.9\r\n4,0.1\r\n"))
+
+	summary := diffLines(left, right)
+	if summary.AddedLines != 2 || summary.RemovedLines != 1 || summary.UnchangedLines != 3 {
+		t.Errorf("Unexpected summary: +%d -%d =%d", summary.AddedLines, summary.RemovedLines, summary.UnchangedLines)
+	}
+	if summary.Unordered {
+		t.Error("Small inputs should be compared in order")
+	}
+	if !slices.Contains(summary.Preview, "- 2,0.7") || !slices.Contains(summary.Preview, "+ 4,0.1") {
+		t.Errorf("Unexpected preview: %v", summary.Preview)
+	}
+}
+
+func TestDiffLinesFallsBackToCounts(t *testing.T) {
+	var left, right []string
+	for i := 0; i < 3000; i++ {
+		left = append(left, fmt.Sprintf("row %d", i))
+		right = append(right, fmt.Sprintf("row %d", 2999-i))
+	}
+	right[0] = "changed"
+
+	summary := diffLines(left, right)
+	if !summary.Unordered {
+		t.Fatal("Expected large inputs to use unordered comparison")
+	}
+	if summary.AddedLines != 1 || summary.RemovedLines != 1 || summary.UnchangedLines != 2999 {
+		t.Errorf("Unexpected summary: +%d -%d =%d", summary.AddedLines, summary.RemovedLines, summary.UnchangedLines)
+	}
+	if len(summary.Preview) > maxDiffPreview {
+		t.Errorf("Preview has %d lines, want at most %d", len(summary.Preview), maxDiffPreview)
+	}
+}
+
+func TestIsText(t *testing.T) {
+	if !isText([]byte("a,b\n1,2\n")) {
+		t.Error("CSV should be text")
+	}
+	if isText([]byte{0x89, 'P', 'N', 'G', 0, 0}) {
+		t.Error("Binary data should not be text")
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// defaultContentType 无法识别文件类型时使用的类型
+const defaultContentType = "application/octet-stream"
+
+// knownContentTypes 常见数据和代码包的类型，不依赖系统的 mime.types（精简镜像中通常没有 .csv、.zip 等）
+var knownContentTypes = map[string]string{
+	".csv":     "text/csv; charset=utf-8",
+	".tsv":     "text/tab-separated-values; charset=utf-8",
+	".txt":     "text/plain; charset=utf-8",
+	".json":    "application/json",
+	".jsonl":   "application/jsonl",
+	".yaml":    "application/yaml",
+	".yml":     "application/yaml",
+	".zip":     "application/zip",
+	".tar":     "application/x-tar",
+	".gz":      "application/gzip",
+	".tgz":     "application/gzip",
+	".parquet": "application/vnd.apache.parquet",
+	".png":     "image/png",
+	".jpg":     "image/jpeg",
+	".jpeg":    "image/jpeg",
+	".pdf":     "application/pdf",
+}
+
+// detectContentType 根据文件扩展名判断内容类型，扩展名无法识别时检测文件开头的内容
+func detectContentType(filename string, head []byte) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if contentType, ok := knownContentTypes[ext]; ok {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(ext); ext != "" && contentType != "" {
+		return contentType
+	}
+	if len(head) > 0 {
+		return http.DetectContentType(head)
+	}
+	return defaultContentType
+}
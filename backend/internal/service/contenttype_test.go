@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		filename string
+		head     string
+		want     string
+	}{
+		{"data.csv", "a,b\n", "text/csv; charset=utf-8"},
+		{"DATA.CSV", "a,b\n", "text/csv; charset=utf-8"},
+		{"model.zip", "PK\x03\x04", "application/zip"},
+		{"labels.json", `{"a":1}`, "application/json"},
+		{"photo.jpeg", "", "image/jpeg"},
+		{"archive.tar.gz", "", "application/gzip"},
+		{"noext", "\x89PNG\r\n\x1a\n", "image/png"},
+		{"noext", "hello", "text/plain; charset=utf-8"},
+		{"noext", "", defaultContentType},
+	}
+	for _, tt := range tests {
+		if got := detectContentType(tt.filename, []byte(tt.head)); got != tt.want {
+			t.Errorf("detectContentType(%q, %q) = %q, want %q", tt.filename, tt.head, got, tt.want)
+		}
+	}
+}
+
+func TestUploadPresetDataStoresContentType(t *testing.T) {
+	s := newPresetTestService(t)
+	ctx := context.Background()
+
+	fromFile, err := s.UploadPresetDataFile(ctx, "表格", "", "data.csv", strings.NewReader("x,y\n"))
+	if err != nil {
+		t.Fatalf("UploadPresetDataFile failed: %v", err)
+	}
+	fromBytes, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "model.zip", FileData: []byte("PK\x03\x04")})
+	if err != nil {
+		t.Fatalf("UploadPresetData failed: %v", err)
+	}
+
+	for id, want := range map[string]string{fromFile.FileId: "text/csv; charset=utf-8", fromBytes.FileId: "application/zip"} {
+		got, err := s.GetPresetData(ctx, &v1.GetPresetDataRequest{Id: id})
+		if err != nil {
+			t.Fatalf("GetPresetData failed: %v", err)
+		}
+		if got.ContentType != want {
+			t.Errorf("%s: content type = %q, want %q", got.Filename, got.ContentType, want)
+		}
+	}
+}
+
+func TestOpenPresetDataPrefersStoredContentType(t *testing.T) {
+	s := newPresetTestService(t)
+	_, client := testutil.NewS3(t)
+	for _, key := range []string{"preset-data/new.csv", "preset-data/old.csv"} {
+		if _, err := client.PutObject(context.Background(), "bucket", key, strings.NewReader("x,y\n"), 4, minio.PutObjectOptions{ContentType: "text/plain; charset=utf-8"}); err != nil {
+			t.Fatalf("PutObject failed: %v", err)
+		}
+	}
+	s.minioClient = client
+	createPresetData(t, s,
+		models.PresetData{ID: "data_new", Filename: "new.csv", MinioPath: "preset-data/new.csv", ContentType: "text/csv; charset=utf-8"},
+		models.PresetData{ID: "data_old", Filename: "old.csv", MinioPath: "preset-data/old.csv"},
+	)
+
+	for id, want := range map[string]string{"data_new": "text/csv; charset=utf-8", "data_old": "text/plain; charset=utf-8"} {
+		file, err := s.OpenPresetData(context.Background(), id)
+		if err != nil {
+			t.Fatalf("OpenPresetData(%s) failed: %v", id, err)
+		}
+		file.Body.Close()
+		// 旧数据没有保存类型，使用对象的类型
+		if file.ContentType != want {
+			t.Errorf("%s: content type = %q, want %q", id, file.ContentType, want)
+		}
+	}
+}
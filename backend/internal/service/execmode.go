@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// legacyExecutionModes 旧版本 mode 字段的取值，只作记录，执行方式由 is_async 决定
+var legacyExecutionModes = map[string]bool{"": true, "batch": true, "streaming": true}
+
+// resolveExecutionMode 根据 mode 和已废弃的 is_async 确定执行模式，async 模式必须带 webhook_url
+func resolveExecutionMode(req *v1.ExecuteRequest) (string, error) {
+	mode := strings.ToLower(strings.TrimSpace(req.Mode))
+	switch {
+	case legacyExecutionModes[mode]:
+		mode = models.ExecutionModeSync
+		if req.IsAsync {
+			mode = models.ExecutionModeAsync
+		}
+	case mode == models.ExecutionModeSync:
+		if req.IsAsync {
+			return "", status.Error(codes.InvalidArgument, "is_async conflicts with mode sync")
+		}
+	case mode == models.ExecutionModeAsync, mode == models.ExecutionModeFireAndForget:
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unknown mode %q, expected %s, %s or %s",
+			req.Mode, models.ExecutionModeSync, models.ExecutionModeAsync, models.ExecutionModeFireAndForget)
+	}
+
+	if mode == models.ExecutionModeAsync && req.WebhookUrl == "" {
+		return "", status.Error(codes.InvalidArgument, "webhook_url is required for async mode")
+	}
+	return mode, nil
+}
+
+// dropUnusedWebhook 非 async 模式不会回调，清空请求中的 webhook_url 并返回提示，没有可忽略的地址时返回空字符串
+func dropUnusedWebhook(req *v1.ExecuteRequest, mode string) string {
+	if mode == models.ExecutionModeAsync || req.WebhookUrl == "" {
+		return ""
+	}
+	req.WebhookUrl = ""
+	return fmt.Sprintf("webhook_url is only used in async mode and was ignored for %s", mode)
+}
+
+// isBackgroundMode 任务是否在后台执行，请求立即返回
+func isBackgroundMode(mode string) bool {
+	return mode == models.ExecutionModeAsync || mode == models.ExecutionModeFireAndForget
+}
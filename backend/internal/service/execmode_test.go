@@ -0,0 +1,76 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResolveExecutionMode(t *testing.T) {
+	const hook = "http://callback/hook"
+	tests := []struct {
+		name string
+		req  *v1.ExecuteRequest
+		want string
+	}{
+		{"default", &v1.ExecuteRequest{}, models.ExecutionModeSync},
+		{"sync", &v1.ExecuteRequest{Mode: "sync"}, models.ExecutionModeSync},
+		{"async", &v1.ExecuteRequest{Mode: "async", WebhookUrl: hook}, models.ExecutionModeAsync},
+		{"fire and forget", &v1.ExecuteRequest{Mode: " Fire_And_Forget "}, models.ExecutionModeFireAndForget},
+		{"legacy is_async", &v1.ExecuteRequest{IsAsync: true, WebhookUrl: hook}, models.ExecutionModeAsync},
+		{"legacy batch", &v1.ExecuteRequest{Mode: "batch"}, models.ExecutionModeSync},
+		{"legacy streaming async", &v1.ExecuteRequest{Mode: "streaming", IsAsync: true, WebhookUrl: hook}, models.ExecutionModeAsync},
+		{"async with is_async", &v1.ExecuteRequest{Mode: "async", IsAsync: true, WebhookUrl: hook}, models.ExecutionModeAsync},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveExecutionMode(tt.req)
+			if err != nil || got != tt.want {
+				t.Errorf("resolveExecutionMode() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveExecutionModeRejects(t *testing.T) {
+	const hook = "http://callback/hook"
+	tests := []struct {
+		name string
+		req  *v1.ExecuteRequest
+	}{
+		{"async without webhook", &v1.ExecuteRequest{Mode: "async"}},
+		{"legacy is_async without webhook", &v1.ExecuteRequest{IsAsync: true}},
+		{"sync with is_async", &v1.ExecuteRequest{Mode: "sync", IsAsync: true}},
+		{"unknown", &v1.ExecuteRequest{Mode: "realtime"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := resolveExecutionMode(tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDropUnusedWebhook(t *testing.T) {
+	const hook = "http://callback/hook"
+	for _, mode := range []string{models.ExecutionModeSync, models.ExecutionModeFireAndForget} {
+		req := &v1.ExecuteRequest{Mode: mode, WebhookUrl: hook}
+		if got, err := resolveExecutionMode(req); err != nil || got != mode {
+			t.Fatalf("%s with webhook: resolveExecutionMode() = %q, %v", mode, got, err)
+		}
+		if warning := dropUnusedWebhook(req, mode); !strings.Contains(warning, "ignored for "+mode) || req.WebhookUrl != "" {
+			t.Errorf("%s: warning %q, webhook_url %q, want the webhook ignored", mode, warning, req.WebhookUrl)
+		}
+	}
+
+	req := &v1.ExecuteRequest{Mode: models.ExecutionModeAsync, WebhookUrl: hook}
+	if warning := dropUnusedWebhook(req, models.ExecutionModeAsync); warning != "" || req.WebhookUrl != hook {
+		t.Errorf("async: warning %q, webhook_url %q, want the webhook kept", warning, req.WebhookUrl)
+	}
+}
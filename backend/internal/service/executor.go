@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/redact"
+	"algorithm-platform/internal/scheduler"
+	"algorithm-platform/pkg/docker"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/minio/minio-go/v7"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 容器内的工作目录和输入输出路径，算法将结果写入 /app/output/result
+const (
+	containerWorkDir   = "/app"
+	containerInputDir  = "/app/input"
+	containerOutputDir = "/app/output"
+	resultFileName     = "result"
+)
+
+// maxLogTailBytes 失败时读取的容器 stderr 上限
+const maxLogTailBytes = 64 << 10
+
+// containerCleanupTimeout 停止、删除容器和读取日志的超时时间
+const containerCleanupTimeout = 30 * time.Second
+
+// 容器日志上传的超时时间和分片大小（MinIO 允许的最小分片）
+const (
+	logUploadTimeout  = 5 * time.Minute
+	logUploadPartSize = 5 << 20
+)
+
+// executionResult 容器执行产生的对象路径，执行失败时也会返回已保存的日志
+type executionResult struct {
+	ResultPath string
+	LogPath    string
+	Warning    string // 不影响任务结果的问题，如日志上传失败
+}
+
+// executeInContainer 在算法运行镜像中执行任务版本的代码，将 /app/output/result 和容器日志上传到 MinIO。
+// 版本的代码下载到任务自己的目录并挂载为容器的工作目录 /app，输入和输出目录挂载在其中。
+// secretRefs 中的密钥作为环境变量注入容器，保存的日志和失败信息中的密钥值会被替换。
+// 默认按版本固定的镜像摘要运行，useImageTag 为 true 时按标签运行。
+// 返回值始终非 nil，容器运行过时即使任务失败也会带上日志路径
+func (s *AlgorithmService) executeInContainer(ctx context.Context, jobID string, prepared *preparedJob, timeoutSeconds int32, secretRefs map[string]string, useImageTag bool) (*executionResult, error) {
+	algorithm := prepared.algorithm
+	run := &executionResult{}
+	if s.scheduler == nil || s.dockerClient == nil {
+		return run, fmt.Errorf("docker is not available")
+	}
+
+	image := s.cfg().Docker.GetRuntimeImage(algorithm.Language)
+	if image == "" {
+		return run, status.Errorf(codes.FailedPrecondition, "no runtime image configured for language %q", algorithm.Language)
+	}
+	image = s.jobImage(ctx, prepared.version, image, useImageTag)
+	command, err := algorithmCommand(algorithm)
+	if err != nil {
+		return run, err
+	}
+
+	secretEnv, err := resolveSecretEnv(&s.cfg().Docker, algorithm, secretRefs)
+	if err != nil {
+		return run, err
+	}
+	redactor := envRedactor(secretEnv)
+
+	env := map[string]string{
+		"JOB_ID":       jobID,
+		"ALGORITHM_ID": algorithm.ID,
+		"INPUT_DIR":    containerInputDir,
+		"OUTPUT_DIR":   containerOutputDir,
+	}
+	for name, value := range secretEnv {
+		env[name] = value
+	}
+
+	keep := s.cfg().Docker.KeepContainers
+	codeDir := filepath.Join("/tmp", "code", jobID)
+	outputDir := filepath.Join("/tmp", "output", jobID)
+	if !keep {
+		defer os.RemoveAll(codeDir)
+		defer os.RemoveAll(outputDir)
+	}
+	if err := s.stageVersionCode(ctx, prepared.version, codeDir); err != nil {
+		return run, &JobFailure{Category: FailurePull, Err: err}
+	}
+	// 挂载点在代码目录中预先创建，代码包中的同名目录会被输入输出目录覆盖
+	for _, dir := range []string{filepath.Join(codeDir, "input"), filepath.Join(codeDir, "output"), outputDir} {
+		if err := os.MkdirAll(dir, 0777); err != nil {
+			return run, fmt.Errorf("failed to create job directory: %w", err)
+		}
+	}
+
+	runCtx := ctx
+	if timeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+		defer cancel()
+	}
+
+	containerID, err := s.scheduler.RunJob(runCtx, scheduler.JobConfig{
+		Image:       image,
+		AlgorithmID: algorithm.ID,
+		JobID:       jobID,
+		Env:         env,
+		Mounts: []docker.Mount{
+			{Type: "bind", Source: codeDir, Target: containerWorkDir},
+			{Type: "bind", Source: prepared.inputDir, Target: containerInputDir, ReadOnly: true},
+			{Type: "bind", Source: outputDir, Target: containerOutputDir},
+		},
+		Command:        command,
+		WorkingDir:     containerWorkDir,
+		ResourceConfig: prepared.resources,
+		TimeoutSeconds: int(timeoutSeconds),
+	})
+	if err != nil {
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return run, &JobFailure{Category: FailureTimeout,
+				Err: fmt.Errorf("algorithm did not start within %ds: %w", timeoutSeconds, context.DeadlineExceeded)}
+		}
+		return run, fmt.Errorf("failed to run container: %w", err)
+	}
+	if !keep {
+		defer s.removeContainer(containerID)
+	}
+
+	exitCode, err := s.dockerClient.WaitContainer(runCtx, containerID)
+	if err != nil {
+		if runCtx.Err() == nil {
+			return run, fmt.Errorf("failed to wait for container: %w", err)
+		}
+		s.stopContainer(containerID)
+		s.saveContainerLogs(jobID, containerID, run, redactor)
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return run, &JobFailure{Category: FailureTimeout, LogTail: tailLines(s.containerStderr(containerID, redactor), logTailLines),
+				Err: fmt.Errorf("algorithm timed out after %ds: %w", timeoutSeconds, context.DeadlineExceeded)}
+		}
+		return run, fmt.Errorf("job cancelled: %w", runCtx.Err())
+	}
+	s.saveContainerLogs(jobID, containerID, run, redactor)
+
+	oomKilled := false
+	if info, err := s.dockerClient.GetContainerStatus(ctx, containerID); err == nil && info.State != nil {
+		oomKilled = info.State.OOMKilled
+	}
+	if exitCode != 0 || oomKilled {
+		return run, failureFromExit(int(exitCode), oomKilled, s.containerStderr(containerID, redactor))
+	}
+
+	resultPath := resultObjectPath(&s.cfg().MinIO, jobID)
+	resultFile := filepath.Join(outputDir, resultFileName)
+	if _, err := os.Stat(resultFile); err != nil {
+		return run, fmt.Errorf("algorithm did not write %s/%s: %w", containerOutputDir, resultFileName, err)
+	}
+	if _, err := s.minioClient.FPutObject(ctx, s.cfg().MinIO.Bucket, resultPath, resultFile, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	}); err != nil {
+		return run, fmt.Errorf("failed to upload result: %w", err)
+	}
+
+	run.ResultPath = resultPath
+	return run, nil
+}
+
+// saveContainerLogs 将容器的 stdout 和 stderr 上传到 logs/<job_id>.log。
+// 上传失败只记录到 run.Warning，不影响任务结果
+func (s *AlgorithmService) saveContainerLogs(jobID, containerID string, run *executionResult, redactor *redact.Values) {
+	ctx, cancel := context.WithTimeout(context.Background(), logUploadTimeout)
+	defer cancel()
+
+	logPath := logObjectPath(&s.cfg().MinIO, jobID)
+	if err := s.uploadContainerLogs(ctx, containerID, logPath, redactor); err != nil {
+		fmt.Printf("Warning: failed to save logs of job %s: %v\n", jobID, err)
+		run.Warning = fmt.Sprintf("failed to save container logs: %v", err)
+		return
+	}
+	run.LogPath = logPath
+}
+
+func (s *AlgorithmService) uploadContainerLogs(ctx context.Context, containerID, logPath string, redactor *redact.Values) error {
+	if s.minioClient == nil {
+		return fmt.Errorf("MinIO client is not available")
+	}
+
+	logs, err := s.dockerClient.StreamContainerLogs(ctx, containerID, false, false)
+	if err != nil {
+		return fmt.Errorf("failed to read container logs: %w", err)
+	}
+	defer logs.Close()
+
+	// 日志大小未知，按分片边读边传，内存占用不超过一个分片
+	if _, err := s.minioClient.PutObject(ctx, s.cfg().MinIO.Bucket, logPath, redactor.Reader(logs), -1, minio.PutObjectOptions{
+		ContentType: "text/plain; charset=utf-8",
+		PartSize:    logUploadPartSize,
+	}); err != nil {
+		return fmt.Errorf("failed to upload logs: %w", err)
+	}
+	return nil
+}
+
+// algorithmCommand 根据算法语言和入口生成容器启动命令
+func algorithmCommand(algorithm *models.Algorithm) ([]string, error) {
+	entrypoint := strings.TrimSpace(algorithm.Entrypoint)
+
+	switch strings.ToLower(algorithm.Language) {
+	case "python":
+		if entrypoint == "" {
+			entrypoint = "main.py"
+		}
+		if strings.HasSuffix(entrypoint, ".py") && !strings.ContainsAny(entrypoint, " \t") {
+			return []string{"python", entrypoint}, nil
+		}
+	case "cpp":
+		if entrypoint == "" {
+			entrypoint = "./alg"
+		}
+	}
+
+	if entrypoint == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "algorithm %s has no entrypoint", algorithm.ID)
+	}
+	return []string{"sh", "-c", entrypoint}, nil
+}
+
+// containerStderr 读取容器 stderr 的末尾部分并替换其中的密钥值，失败时返回空
+func (s *AlgorithmService) containerStderr(containerID string, redactor *redact.Values) string {
+	ctx, cancel := context.WithTimeout(context.Background(), containerCleanupTimeout)
+	defer cancel()
+
+	logs, err := s.dockerClient.GetContainerLogs(ctx, containerID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get logs of container %s: %v\n", containerID, err)
+		return ""
+	}
+	defer logs.Close()
+
+	stderr := &tailBuffer{limit: maxLogTailBytes}
+	if _, err := stdcopy.StdCopy(io.Discard, stderr, logs); err != nil {
+		fmt.Printf("Warning: failed to read logs of container %s: %v\n", containerID, err)
+	}
+	return redactor.String(stderr.String())
+}
+
+func (s *AlgorithmService) stopContainer(containerID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), containerCleanupTimeout)
+	defer cancel()
+
+	if err := s.dockerClient.StopContainer(ctx, containerID); err != nil {
+		fmt.Printf("Warning: failed to stop container %s: %v\n", containerID, err)
+	}
+}
+
+func (s *AlgorithmService) removeContainer(containerID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), containerCleanupTimeout)
+	defer cancel()
+
+	if err := s.dockerClient.RemoveContainer(ctx, containerID, true); err != nil {
+		fmt.Printf("Warning: failed to remove container %s: %v\n", containerID, err)
+	}
+}
+
+// tailBuffer 只保留最后 limit 字节的写入内容
+type tailBuffer struct {
+	limit int
+	data  []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.data = append(b.data, p...)
+	if over := len(b.data) - b.limit; over > 0 {
+		n := copy(b.data, b.data[over:])
+		b.data = b.data[:n]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	return string(b.data)
+}
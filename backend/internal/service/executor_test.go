@@ -0,0 +1,384 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/events"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/scheduler"
+	"algorithm-platform/internal/testutil"
+	"algorithm-platform/pkg/docker"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestAlgorithmCommand(t *testing.T) {
+	tests := []struct {
+		language   string
+		entrypoint string
+		want       []string
+	}{
+		{"python", "", []string{"python", "main.py"}},
+		{"Python", "src/run.py", []string{"python", "src/run.py"}},
+		{"python", "python -u main.py --fast", []string{"sh", "-c", "python -u main.py --fast"}},
+		{"cpp", "", []string{"sh", "-c", "./alg"}},
+		{"go", "./bin/alg", []string{"sh", "-c", "./bin/alg"}},
+	}
+	for _, tt := range tests {
+		got, err := algorithmCommand(&models.Algorithm{Language: tt.language, Entrypoint: tt.entrypoint})
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("algorithmCommand(%s, %q) = %v, %v, want %v", tt.language, tt.entrypoint, got, err, tt.want)
+		}
+	}
+
+	if _, err := algorithmCommand(&models.Algorithm{ID: "alg_1", Language: "go"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition without entrypoint, got %v", err)
+	}
+}
+
+func TestTailBufferKeepsLastBytes(t *testing.T) {
+	buf := &tailBuffer{limit: 8}
+	for _, chunk := range []string{"Traceback\n", "  line 3\n", "Error"} {
+		if n, err := buf.Write([]byte(chunk)); n != len(chunk) || err != nil {
+			t.Fatalf("Write(%q) = %d, %v", chunk, n, err)
+		}
+	}
+	if got := buf.String(); got != " 3\nError" {
+		t.Errorf("Unexpected tail %q", got)
+	}
+}
+
+// fakeDockerEngine 模拟 Docker Engine API 中执行任务用到的接口。
+// 启动容器时按入口命令 [解释器, 脚本] 读取挂载到 /app 的代码：
+// 脚本内容写入 /app/output/result 作为结果；内容以 "fail" 开头时写入 stderr 并以 3 退出。
+// 镜像名以 missing/ 开头时按镜像不存在处理
+type fakeDockerEngine struct {
+	mu       sync.Mutex
+	exitCode int
+	stderr   string
+}
+
+var dockerAPIPath = regexp.MustCompile(`^(/v[0-9.]+)?(/.*)$`)
+
+func newFakeDockerEngine(t *testing.T) (*fakeDockerEngine, *docker.Client) {
+	t.Helper()
+	engine := &fakeDockerEngine{}
+	server := httptest.NewServer(http.HandlerFunc(engine.serveHTTP))
+	t.Cleanup(server.Close)
+
+	client, err := docker.New("tcp://" + strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("Failed to create Docker client: %v", err)
+	}
+	return engine, client
+}
+
+func (e *fakeDockerEngine) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := dockerAPIPath.FindStringSubmatch(r.URL.Path)[2]
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch {
+	case path == "/_ping":
+		w.Header().Set("Api-Version", "1.43")
+		w.Write([]byte("OK"))
+	case path == "/containers/create":
+		var body struct {
+			Image      string
+			Entrypoint []string
+			HostConfig struct {
+				Mounts []struct{ Source, Target string }
+			}
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if strings.HasPrefix(body.Image, "missing/") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"message":"No such image: %s"}`, body.Image)
+			return
+		}
+		e.run(body.Entrypoint, body.HostConfig.Mounts)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"Id":"ctr_1"}`))
+	case strings.HasSuffix(path, "/start"):
+		w.WriteHeader(http.StatusNoContent)
+	case strings.HasSuffix(path, "/wait"):
+		fmt.Fprintf(w, `{"StatusCode":%d}`, e.exitCode)
+	case strings.HasSuffix(path, "/logs"):
+		stdcopy.NewStdWriter(w, stdcopy.Stderr).Write([]byte(e.stderr))
+	case strings.HasSuffix(path, "/json"):
+		fmt.Fprintf(w, `{"Id":"ctr_1","State":{"Status":"exited","ExitCode":%d}}`, e.exitCode)
+	case r.Method == http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (e *fakeDockerEngine) run(entrypoint []string, mounts []struct{ Source, Target string }) {
+	dirs := map[string]string{}
+	for _, m := range mounts {
+		dirs[m.Target] = m.Source
+	}
+	code, err := os.ReadFile(filepath.Join(dirs[containerWorkDir], entrypoint[len(entrypoint)-1]))
+	if err != nil {
+		e.exitCode, e.stderr = 2, fmt.Sprintf("can't open file: %v\n", err)
+		return
+	}
+	if bytes.HasPrefix(code, []byte("fail")) {
+		e.exitCode, e.stderr = 3, "Traceback (most recent call last):\nValueError: "+string(code)+"\n"
+		return
+	}
+	e.exitCode, e.stderr = 0, ""
+	os.WriteFile(filepath.Join(dirs[containerOutputDir], resultFileName), code, 0644)
+}
+
+// newExecutorTestService 创建连接模拟 Docker 和 MinIO 的服务，算法有两个版本，当前版本为 v2
+func newExecutorTestService(t *testing.T, versions map[string][]byte) (*AlgorithmService, *testutil.S3) {
+	t.Helper()
+	store, minioClient := testutil.NewS3(t)
+	_, dockerClient := newFakeDockerEngine(t)
+
+	db := testutil.NewDB(t)
+	cfg := &config.Config{
+		MinIO:  config.MinIOConfig{Bucket: "bucket"},
+		Docker: config.DockerConfig{RuntimeImages: map[string]string{"python": "python:3.11"}},
+	}
+	algorithm := &models.Algorithm{ID: "alg_1", Name: "echo", Language: "python", Platform: "docker", Status: models.AlgorithmStatusReady}
+	for id, code := range versions {
+		objectPath := "algorithms/alg_1/" + id + "/main.py"
+		store.Put(objectPath, code)
+		if err := db.Create(&models.Version{ID: id, AlgorithmID: algorithm.ID, MinioPath: objectPath, SourceCodeFile: "main.py"}).Error; err != nil {
+			t.Fatalf("Failed to create version: %v", err)
+		}
+		algorithm.CurrentVersionID = max(algorithm.CurrentVersionID, id)
+	}
+	if err := db.Create(algorithm).Error; err != nil {
+		t.Fatalf("Failed to create algorithm: %v", err)
+	}
+
+	return &AlgorithmService{
+		db:           database.NewWithDB(db, cfg),
+		cfgStore:     config.NewStore(cfg),
+		minioClient:  minioClient,
+		dockerClient: dockerClient,
+		scheduler:    scheduler.New(dockerClient, nil),
+	}, store
+}
+
+// waitForJobFinished 等待后台任务结束并返回任务记录
+func waitForJobFinished(t *testing.T, db *gorm.DB, jobID string) *models.Job {
+	t.Helper()
+	job := &models.Job{}
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(20 * time.Millisecond) {
+		if err := db.First(job, "id = ?", jobID).Error; err != nil {
+			t.Fatalf("Failed to load job: %v", err)
+		}
+		if events.IsTerminalStatus(job.Status) {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Job %s did not finish, status %s", jobID, job.Status)
+		}
+	}
+}
+
+func TestExecuteRunsTheJobVersionCode(t *testing.T) {
+	s, store := newExecutorTestService(t, map[string][]byte{"ver_1": []byte("print('v1')"), "ver_2": []byte("print('v2')")})
+
+	resp, err := s.ExecuteAlgorithm(context.Background(), &v1.ExecuteRequest{AlgorithmId: "alg_1", Mode: models.ExecutionModeSync, UseImageTag: true})
+	if err != nil {
+		t.Fatalf("ExecuteAlgorithm failed: %v", err)
+	}
+	if resp.Status != models.JobStatusCompleted {
+		t.Fatalf("Expected the job to complete, got %q: %s", resp.Status, resp.Message)
+	}
+	result, ok := store.Object(resultObjectPath(&s.cfg().MinIO, resp.JobId))
+	if !ok || string(result) != "print('v2')" {
+		t.Errorf("Expected the current version's code to run, got result %q", result)
+	}
+
+	var job models.Job
+	if err := s.db.DB().First(&job, "id = ?", resp.JobId).Error; err != nil {
+		t.Fatalf("Failed to load job: %v", err)
+	}
+	if job.VersionID != "ver_2" {
+		t.Errorf("Expected the job to record version ver_2, got %q", job.VersionID)
+	}
+}
+
+func TestExecuteSyncIgnoresWebhook(t *testing.T) {
+	s, _ := newExecutorTestService(t, map[string][]byte{"ver_1": []byte("print('v1')")})
+
+	req := &v1.ExecuteRequest{AlgorithmId: "alg_1", Mode: models.ExecutionModeSync, UseImageTag: true, WebhookUrl: "http://callback/hook"}
+	resp, err := s.ExecuteAlgorithm(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ExecuteAlgorithm failed: %v", err)
+	}
+	if resp.Status != models.JobStatusCompleted || !strings.Contains(resp.Message, "webhook_url is only used in async mode") {
+		t.Errorf("Expected a completed job with a webhook warning, got status %q message %q", resp.Status, resp.Message)
+	}
+}
+
+func TestExecuteRecordsArtifactExpiry(t *testing.T) {
+	s, _ := newExecutorTestService(t, map[string][]byte{"ver_1": []byte("print('v1')")})
+	s.cfg().MinIO.ResultRetentionStr = "2h"
+
+	resp, err := s.ExecuteAlgorithm(context.Background(), &v1.ExecuteRequest{AlgorithmId: "alg_1", Mode: models.ExecutionModeSync, UseImageTag: true})
+	if err != nil || resp.Status != models.JobStatusCompleted {
+		t.Fatalf("ExecuteAlgorithm() = %+v, %v", resp, err)
+	}
+
+	var job models.Job
+	if err := s.db.DB().First(&job, "id = ?", resp.JobId).Error; err != nil {
+		t.Fatalf("Failed to load job: %v", err)
+	}
+	if job.ArtifactsExpireAt == nil || job.FinishedAt == nil {
+		t.Fatalf("Expected finished_at and artifacts_expire_at, got %v and %v", job.FinishedAt, job.ArtifactsExpireAt)
+	}
+	if got := job.ArtifactsExpireAt.Sub(*job.FinishedAt); got < 2*time.Hour-time.Second || got > 2*time.Hour+time.Second {
+		t.Errorf("Expected artifacts to expire 2h after the job finished, got %v", got)
+	}
+
+	status, err := s.GetJobStatus(context.Background(), &v1.GetJobStatusRequest{JobId: resp.JobId})
+	if err != nil {
+		t.Fatalf("GetJobStatus failed: %v", err)
+	}
+	if status.ArtifactsExpired || status.ResultUrl == "" || status.ArtifactsExpireAt == nil {
+		t.Errorf("Expected an unexpired result, got expired=%v url=%q expire_at=%v", status.ArtifactsExpired, status.ResultUrl, status.ArtifactsExpireAt)
+	}
+}
+
+func TestExecuteFailureReasonIncludesStderrTail(t *testing.T) {
+	s, _ := newExecutorTestService(t, map[string][]byte{"ver_1": []byte("fail: bad input")})
+
+	resp, err := s.ExecuteAlgorithm(context.Background(), &v1.ExecuteRequest{AlgorithmId: "alg_1", Mode: models.ExecutionModeSync, UseImageTag: true})
+	if err != nil {
+		t.Fatalf("ExecuteAlgorithm failed: %v", err)
+	}
+	if resp.Status != models.JobStatusFailed || resp.Failure.GetCategory() != FailureNonzeroExit || resp.Failure.GetExitCode() != 3 {
+		t.Fatalf("Expected a nonzero exit failure, got status %q failure %+v", resp.Status, resp.Failure)
+	}
+
+	var job models.Job
+	if err := s.db.DB().First(&job, "id = ?", resp.JobId).Error; err != nil {
+		t.Fatalf("Failed to load job: %v", err)
+	}
+	if !strings.Contains(job.FailureReason, "exited with code 3") || !strings.Contains(job.FailureReason, "ValueError: fail: bad input") {
+		t.Errorf("Expected the failure reason to include the stderr tail, got %q", job.FailureReason)
+	}
+}
+
+func TestExecuteSavesContainerLogs(t *testing.T) {
+	s, store := newExecutorTestService(t, map[string][]byte{"ver_1": []byte("fail: bad input")})
+
+	resp, err := s.ExecuteAlgorithm(context.Background(), &v1.ExecuteRequest{AlgorithmId: "alg_1", Mode: models.ExecutionModeSync, UseImageTag: true})
+	if err != nil {
+		t.Fatalf("ExecuteAlgorithm failed: %v", err)
+	}
+
+	logPath := logObjectPath(&s.cfg().MinIO, resp.JobId)
+	logs, ok := store.Object(logPath)
+	if !ok || !strings.Contains(string(logs), "ValueError: fail: bad input") {
+		t.Errorf("Expected the container stderr in %s, got %q", logPath, logs)
+	}
+
+	var job models.Job
+	if err := s.db.DB().First(&job, "id = ?", resp.JobId).Error; err != nil {
+		t.Fatalf("Failed to load job: %v", err)
+	}
+	if job.LogURL != logPath || job.Warning != "" {
+		t.Errorf("Expected log_url %q without warning, got %q, warning %q", logPath, job.LogURL, job.Warning)
+	}
+}
+
+func TestExecuteLogUploadFailureIsWarning(t *testing.T) {
+	s, store := newExecutorTestService(t, map[string][]byte{"ver_1": []byte("fail: bad input")})
+	store.FailPuts()
+
+	resp, err := s.ExecuteAlgorithm(context.Background(), &v1.ExecuteRequest{AlgorithmId: "alg_1", Mode: models.ExecutionModeSync, UseImageTag: true})
+	if err != nil {
+		t.Fatalf("ExecuteAlgorithm failed: %v", err)
+	}
+	// 日志上传失败不影响任务本身的失败原因
+	if resp.Status != models.JobStatusFailed || resp.Failure.GetCategory() != FailureNonzeroExit {
+		t.Errorf("Expected the nonzero exit failure to be kept, got status %q failure %+v", resp.Status, resp.Failure)
+	}
+
+	var job models.Job
+	if err := s.db.DB().First(&job, "id = ?", resp.JobId).Error; err != nil {
+		t.Fatalf("Failed to load job: %v", err)
+	}
+	if job.LogURL != "" || !strings.Contains(job.Warning, "failed to save container logs") {
+		t.Errorf("Expected no log_url and a log warning, got %q, warning %q", job.LogURL, job.Warning)
+	}
+}
+
+func TestExecuteMissingImageIsPullFailure(t *testing.T) {
+	s, _ := newExecutorTestService(t, map[string][]byte{"ver_1": []byte("print('v1')")})
+	cfg := *s.cfg()
+	cfg.Docker.RuntimeImages = map[string]string{"python": "missing/python:3.11"}
+	s.cfgStore = config.NewStore(&cfg)
+
+	resp, err := s.ExecuteAlgorithm(context.Background(), &v1.ExecuteRequest{AlgorithmId: "alg_1", Mode: models.ExecutionModeSync, UseImageTag: true})
+	if err != nil {
+		t.Fatalf("ExecuteAlgorithm failed: %v", err)
+	}
+	if resp.Status != models.JobStatusFailed || resp.Failure.GetCategory() != FailurePull {
+		t.Errorf("Expected a pull failure, got status %q failure %+v: %s", resp.Status, resp.Failure, resp.Message)
+	}
+}
+
+func TestStageVersionCodeExtractsZip(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for name, content := range map[string]string{"project/main.py": "print(1)", "project/lib/util.py": "x = 1"} {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content))
+	}
+	zw.Close()
+
+	store, client := testutil.NewS3(t)
+	store.Put("code.zip", archive.Bytes())
+	s := &AlgorithmService{cfgStore: config.NewStore(&config.Config{MinIO: config.MinIOConfig{Bucket: "bucket"}}), minioClient: client}
+
+	dir := filepath.Join(t.TempDir(), "code")
+	if err := s.stageVersionCode(context.Background(), &models.Version{ID: "ver_1", MinioPath: "code.zip", SourceCodeFile: "code.zip"}, dir); err != nil {
+		t.Fatalf("stageVersionCode failed: %v", err)
+	}
+	for name, want := range map[string]string{"main.py": "print(1)", "lib/util.py": "x = 1"} {
+		if got, err := os.ReadFile(filepath.Join(dir, name)); err != nil || string(got) != want {
+			t.Errorf("%s = %q, %v, want %q", name, got, err, want)
+		}
+	}
+
+	evil := &bytes.Buffer{}
+	zw = zip.NewWriter(evil)
+	w, _ := zw.Create("../escape.py")
+	w.Write([]byte("x"))
+	zw.Close()
+	store.Put("evil.zip", evil.Bytes())
+	if err := s.stageVersionCode(context.Background(), &models.Version{ID: "ver_2", MinioPath: "evil.zip"}, filepath.Join(t.TempDir(), "code")); err == nil {
+		t.Error("Expected entries outside the code directory to be rejected")
+	}
+}
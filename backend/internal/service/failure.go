@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/scheduler"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 任务失败类别
+const (
+	FailureValidation  = "validation"   // 请求参数或算法状态不合法
+	FailurePull        = "pull"         // 镜像或输入数据拉取失败
+	FailureTimeout     = "timeout"      // 超过执行时限
+	FailureOOM         = "oom"          // 超出内存限制被杀死
+	FailureNonzeroExit = "nonzero_exit" // 算法进程以非零状态退出
+	FailureInfra       = "infra"        // 平台内部错误（Docker、存储、数据库等）
+)
+
+// logTailLines 失败信息中保留的日志行数
+const logTailLines = 50
+
+// failureReasonLogLines 任务记录的失败原因中附带的 stderr 行数
+const failureReasonLogLines = 10
+
+// JobFailure 执行器返回的结构化失败信息
+type JobFailure struct {
+	Category string
+	ExitCode int
+	LogTail  string
+	Err      error
+}
+
+func (f *JobFailure) Error() string {
+	if f.Err != nil {
+		return f.Err.Error()
+	}
+	if f.Category == FailureNonzeroExit {
+		return fmt.Sprintf("algorithm exited with code %d", f.ExitCode)
+	}
+	return f.Category
+}
+
+func (f *JobFailure) Unwrap() error {
+	return f.Err
+}
+
+// failureFromExit 根据容器退出状态构造失败信息，exitCode 为 0 且未被 OOM 杀死时返回 nil
+func failureFromExit(exitCode int, oomKilled bool, logs string) *JobFailure {
+	switch {
+	case oomKilled:
+		return &JobFailure{Category: FailureOOM, ExitCode: exitCode, LogTail: tailLines(logs, logTailLines),
+			Err: fmt.Errorf("algorithm was killed for exceeding its memory limit")}
+	case exitCode != 0:
+		return &JobFailure{Category: FailureNonzeroExit, ExitCode: exitCode, LogTail: tailLines(logs, logTailLines)}
+	default:
+		return nil
+	}
+}
+
+// classifyJobError 将执行错误归类，err 为 nil 时返回 nil
+func classifyJobError(err error) *JobFailure {
+	if err == nil {
+		return nil
+	}
+
+	var failure *JobFailure
+	if errors.As(err, &failure) {
+		return failure
+	}
+	var pullErr *scheduler.ImagePullError
+	if errors.As(err, &pullErr) {
+		return &JobFailure{Category: FailurePull, Err: err}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &JobFailure{Category: FailureTimeout, Err: err}
+	}
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.NotFound:
+		return &JobFailure{Category: FailureValidation, Err: err}
+	case codes.DeadlineExceeded:
+		return &JobFailure{Category: FailureTimeout, Err: err}
+	}
+	return &JobFailure{Category: FailureInfra, Err: err}
+}
+
+// failureReason 任务记录中保存的失败原因，算法自身失败时附带 stderr 的最后几行
+func failureReason(err error) string {
+	failure := classifyJobError(err)
+	if failure == nil || failure.LogTail == "" {
+		return err.Error()
+	}
+	return fmt.Sprintf("%s\n%s", err.Error(), tailLines(failure.LogTail, failureReasonLogLines))
+}
+
+// failureDetail 将执行错误转换为 proto 格式，err 为 nil 时返回 nil
+func failureDetail(err error) *v1.FailureDetail {
+	failure := classifyJobError(err)
+	if failure == nil {
+		return nil
+	}
+	return &v1.FailureDetail{
+		Category: failure.Category,
+		ExitCode: int32(failure.ExitCode),
+		LogTail:  failure.LogTail,
+	}
+}
+
+// tailLines 返回文本的最后 n 行
+func tailLines(s string, n int) string {
+	s = strings.TrimRight(s, "\n")
+	lines := strings.Split(s, "\n")
+	if len(lines) <= n {
+		return s
+	}
+	return strings.Join(lines[len(lines)-n:], "\n")
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"algorithm-platform/internal/scheduler"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClassifyJobError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("run: %w", context.DeadlineExceeded), FailureTimeout},
+		{status.Error(codes.InvalidArgument, "bad params"), FailureValidation},
+		{errors.New("docker daemon unavailable"), FailureInfra},
+		{fmt.Errorf("wrapped: %w", &JobFailure{Category: FailurePull}), FailurePull},
+		{fmt.Errorf("failed to run container: %w", &scheduler.ImagePullError{Image: "python:3.11", Err: errors.New("manifest unknown")}), FailurePull},
+	}
+	for _, tt := range tests {
+		if got := classifyJobError(tt.err).Category; got != tt.want {
+			t.Errorf("classifyJobError(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+
+	if classifyJobError(nil) != nil || failureDetail(nil) != nil {
+		t.Error("Expected nil failure for nil error")
+	}
+}
+
+func TestFailureFromExit(t *testing.T) {
+	if failureFromExit(0, false, "ok") != nil {
+		t.Error("Expected no failure for zero exit code")
+	}
+
+	var logs strings.Builder
+	for i := 1; i <= 60; i++ {
+		fmt.Fprintf(&logs, "line %d\n", i)
+	}
+
+	failure := failureFromExit(2, false, logs.String())
+	if failure.Category != FailureNonzeroExit || failure.ExitCode != 2 {
+		t.Errorf("Unexpected failure: %+v", failure)
+	}
+	lines := strings.Split(failure.LogTail, "\n")
+	if len(lines) != logTailLines || lines[0] != "line 11" || lines[len(lines)-1] != "line 60" {
+		t.Errorf("Unexpected log tail: first=%q last=%q count=%d", lines[0], lines[len(lines)-1], len(lines))
+	}
+
+	if got := failureFromExit(137, true, "").Category; got != FailureOOM {
+		t.Errorf("Expected oom category, got %s", got)
+	}
+
+	detail := failureDetail(fmt.Errorf("job: %w", failure))
+	if detail.Category != FailureNonzeroExit || detail.ExitCode != 2 || detail.LogTail == "" {
+		t.Errorf("Unexpected failure detail: %+v", detail)
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"algorithm-platform/internal/models"
+	"algorithm-platform/pkg/docker"
+)
+
+// jobImage 返回任务使用的运行镜像。任务的代码版本第一次执行时解析标签对应的镜像摘要并保存到版本上，
+// 之后的任务按摘要运行，同一版本不会因为标签被重新推送而换了镜像；配置的运行镜像标签变更后重新解析。
+// useTag 为 true 或摘要无法解析时按标签运行
+func (s *AlgorithmService) jobImage(ctx context.Context, version *models.Version, tag string, useTag bool) string {
+	if useTag || version == nil {
+		return tag
+	}
+	if imagePinMatches(version, tag) {
+		return version.ImageDigest
+	}
+
+	digest, err := s.scheduler.ResolveImageDigest(ctx, tag)
+	if err != nil {
+		fmt.Printf("Warning: failed to pin image for version %s, running %s by tag: %v\n", version.ID, tag, err)
+		return tag
+	}
+
+	// 只替换读取时的固定结果，同一版本并发首次执行时只保留最先写入的摘要
+	result := s.db.DB().Model(&models.Version{}).
+		Where("id = ? AND COALESCE(image_digest, '') = ? AND COALESCE(image_tag, '') = ?", version.ID, version.ImageDigest, version.ImageTag).
+		Updates(map[string]interface{}{"image_digest": digest, "image_tag": tag})
+	if result.Error != nil {
+		fmt.Printf("Warning: failed to save image digest for version %s: %v\n", version.ID, result.Error)
+		return digest
+	}
+	if result.RowsAffected == 0 {
+		var current models.Version
+		if err := s.db.DB().Select("id", "image_digest", "image_tag").First(&current, "id = ?", version.ID).Error; err == nil && imagePinMatches(&current, tag) {
+			return current.ImageDigest
+		}
+	}
+	return digest
+}
+
+// imagePinMatches 版本保存的摘要是否由 tag 解析而来。
+// 早期版本只保存了摘要，摘要与 tag 属于同一仓库时沿用；本地镜像 ID 之类不带仓库的摘要需要重新解析
+func imagePinMatches(version *models.Version, tag string) bool {
+	if version.ImageDigest == "" {
+		return false
+	}
+	if version.ImageTag != "" {
+		return version.ImageTag == tag
+	}
+	return docker.SameRepository(version.ImageDigest, tag)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/scheduler"
+)
+
+func newImagePinTestService(t *testing.T, containers *fakeJobContainers) *AlgorithmService {
+	t.Helper()
+	s := newJobContextTestService(t)
+	if err := s.db.DB().AutoMigrate(&models.Version{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if err := s.db.DB().Create(&models.Version{ID: "ver_1", AlgorithmID: "alg_1", VersionNumber: 1}).Error; err != nil {
+		t.Fatalf("Failed to create version: %v", err)
+	}
+	s.scheduler = scheduler.New(containers, nil)
+	return s
+}
+
+// loadVersion 读取版本当前保存的镜像摘要
+func loadVersion(t *testing.T, s *AlgorithmService, id string) *models.Version {
+	t.Helper()
+	version := &models.Version{}
+	if err := s.db.DB().First(version, "id = ?", id).Error; err != nil {
+		t.Fatalf("Failed to load version: %v", err)
+	}
+	return version
+}
+
+func TestJobImagePinsDigestOnFirstRun(t *testing.T) {
+	containers := &fakeJobContainers{digest: "python@sha256:abc"}
+	s := newImagePinTestService(t, containers)
+
+	for i := 0; i < 2; i++ {
+		if got := s.jobImage(context.Background(), loadVersion(t, s, "ver_1"), "python:3.11", false); got != "python@sha256:abc" {
+			t.Fatalf("Run %d: image = %q, want the pinned digest", i+1, got)
+		}
+	}
+	if containers.digests != 1 {
+		t.Errorf("ImageDigest called %d times, want 1", containers.digests)
+	}
+
+	version := loadVersion(t, s, "ver_1")
+	if version.ImageDigest != "python@sha256:abc" || version.ImageTag != "python:3.11" {
+		t.Errorf("Stored pin = %q from %q", version.ImageDigest, version.ImageTag)
+	}
+
+	// 标签被重新推送后仍按保存的摘要运行
+	containers.digest = "python@sha256:def"
+	if got := s.jobImage(context.Background(), version, "python:3.11", false); got != "python@sha256:abc" {
+		t.Errorf("Image after retag = %q, want the pinned digest", got)
+	}
+}
+
+func TestJobImagePinsEachVersion(t *testing.T) {
+	containers := &fakeJobContainers{digest: "python@sha256:abc"}
+	s := newImagePinTestService(t, containers)
+	if err := s.db.DB().Create(&models.Version{ID: "ver_2", AlgorithmID: "alg_1", VersionNumber: 2, ImageDigest: "python@sha256:old", ImageTag: "python:3.11"}).Error; err != nil {
+		t.Fatalf("Failed to create version: %v", err)
+	}
+
+	if got := s.jobImage(context.Background(), loadVersion(t, s, "ver_2"), "python:3.11", false); got != "python@sha256:old" {
+		t.Errorf("ver_2 image = %q, want its own pin", got)
+	}
+	if got := s.jobImage(context.Background(), loadVersion(t, s, "ver_1"), "python:3.11", false); got != "python@sha256:abc" {
+		t.Errorf("ver_1 image = %q, want a new pin", got)
+	}
+}
+
+func TestJobImageRepinsWhenRuntimeImageChanges(t *testing.T) {
+	containers := &fakeJobContainers{digest: "python@sha256:abc"}
+	s := newImagePinTestService(t, containers)
+	s.jobImage(context.Background(), loadVersion(t, s, "ver_1"), "python:3.11", false)
+
+	containers.digest = "python@sha256:def"
+	if got := s.jobImage(context.Background(), loadVersion(t, s, "ver_1"), "python:3.12", false); got != "python@sha256:def" {
+		t.Errorf("Image after changing the runtime image = %q, want a new pin", got)
+	}
+	if version := loadVersion(t, s, "ver_1"); version.ImageDigest != "python@sha256:def" || version.ImageTag != "python:3.12" {
+		t.Errorf("Stored pin = %q from %q", version.ImageDigest, version.ImageTag)
+	}
+}
+
+func TestJobImageLegacyPins(t *testing.T) {
+	old := "sha256:" + strings.Repeat("0", 64)
+	tests := []struct {
+		name   string
+		digest string
+		want   string
+	}{
+		{"same repository", "python@" + old, "python@" + old},
+		{"other repository", "mirror/python@" + old, "python@sha256:abc"},
+		{"local image ID", "sha256:local", "python@sha256:abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newImagePinTestService(t, &fakeJobContainers{digest: "python@sha256:abc"})
+			// 早期版本只保存了摘要
+			s.db.DB().Model(&models.Version{}).Where("id = ?", "ver_1").Update("image_digest", tt.digest)
+
+			if got := s.jobImage(context.Background(), loadVersion(t, s, "ver_1"), "python:3.11", false); got != tt.want {
+				t.Errorf("image = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJobImageUseTag(t *testing.T) {
+	containers := &fakeJobContainers{digest: "python@sha256:abc"}
+	s := newImagePinTestService(t, containers)
+
+	got := s.jobImage(context.Background(), loadVersion(t, s, "ver_1"), "python:3.11", true)
+	if got != "python:3.11" || containers.digests != 0 {
+		t.Errorf("image = %q, ImageDigest calls = %d", got, containers.digests)
+	}
+}
+
+func TestJobImageFallsBackToTag(t *testing.T) {
+	containers := &fakeJobContainers{}
+	s := newImagePinTestService(t, containers)
+
+	got := s.jobImage(context.Background(), loadVersion(t, s, "ver_1"), "python:3.11", false)
+	if got != "python:3.11" {
+		t.Errorf("image = %q, want the tag", got)
+	}
+	if version := loadVersion(t, s, "ver_1"); version.ImageDigest != "" {
+		t.Errorf("Stored digest = %q, want none after a failed resolve", version.ImageDigest)
+	}
+}
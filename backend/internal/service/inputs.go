@@ -0,0 +1,96 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// inputSources 返回请求的全部输入，input_source 在前，跳过未指定 url 和 preset_data_id 的项
+func inputSources(req *v1.ExecuteRequest) []*v1.InputSource {
+	var sources []*v1.InputSource
+	for _, src := range append([]*v1.InputSource{req.InputSource}, req.InputSources...) {
+		if inputRef(src) != "" {
+			sources = append(sources, src)
+		}
+	}
+	return sources
+}
+
+// inputRef 输入的标识：预置数据 ID 写作 preset:<id>，否则为 url
+func inputRef(src *v1.InputSource) string {
+	if id := src.GetPresetDataId(); id != "" {
+		return "preset:" + id
+	}
+	return src.GetUrl()
+}
+
+// joinInputRefs 拼接请求的全部输入，用于任务记录和结果缓存键；只有一个 url 时与原来的单输入一致
+func joinInputRefs(req *v1.ExecuteRequest) string {
+	sources := inputSources(req)
+	refs := make([]string, len(sources))
+	for i, src := range sources {
+		refs[i] = inputRef(src)
+	}
+	return strings.Join(refs, ",")
+}
+
+// resolvedInput 已找到的输入文件
+type resolvedInput struct {
+	objectPath string
+	filename   string // 输入目录中的文件名
+	checksum   string // 上传时记录的 SHA-256，为空时不校验
+}
+
+// resolveInputs 查找每个输入对应的预置数据。有输入不存在时返回 NotFound 并列出全部缺失项，
+// 文件名重复时返回 InvalidArgument
+func resolveInputs(db *gorm.DB, bucket string, sources []*v1.InputSource) ([]resolvedInput, error) {
+	var inputs []resolvedInput
+	var missing []string
+	seen := make(map[string]string)
+
+	for _, src := range sources {
+		ref := inputRef(src)
+		presetData := &models.PresetData{}
+		var err error
+		if id := src.GetPresetDataId(); id != "" {
+			err = db.First(presetData, "id = ?", id).Error
+		} else {
+			// 输入地址可能是完整URL，也可能只是对象路径，统一按路径查找
+			// 新数据的路径按 ID 分目录不会重复，旧数据同名文件共用一个对象时取最新的记录
+			err = db.Order("created_at DESC").First(presetData, "minio_path = ? OR minio_url = ?",
+				objectPathFromURL(bucket, src.Url), src.Url).Error
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			missing = append(missing, ref)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find preset data %s: %w", ref, err)
+		}
+
+		filename := filepath.Base(presetData.Filename)
+		if other, ok := seen[filename]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "inputs %s and %s both use filename %s", other, ref, filename)
+		}
+		seen[filename] = ref
+		inputs = append(inputs, resolvedInput{
+			objectPath: presetDataObjectPath(bucket, presetData),
+			filename:   filename,
+			checksum:   presetData.Checksum,
+		})
+	}
+
+	if len(missing) > 0 {
+		return nil, status.Errorf(codes.NotFound, "preset data not found: %s", strings.Join(missing, ", "))
+	}
+	return inputs, nil
+}
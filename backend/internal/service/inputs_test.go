@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestJoinInputRefs(t *testing.T) {
+	single := &v1.ExecuteRequest{InputSource: &v1.InputSource{Url: "preset-data/a.csv"}}
+	if got := joinInputRefs(single); got != "preset-data/a.csv" {
+		t.Errorf("Single input = %q, want the url unchanged", got)
+	}
+
+	multiple := &v1.ExecuteRequest{
+		InputSource:  &v1.InputSource{Url: "preset-data/a.csv"},
+		InputSources: []*v1.InputSource{{PresetDataId: "data_2"}, {}, {Url: "preset-data/c.csv"}},
+	}
+	if got := joinInputRefs(multiple); got != "preset-data/a.csv,preset:data_2,preset-data/c.csv" {
+		t.Errorf("Multiple inputs = %q", got)
+	}
+	if got := joinInputRefs(&v1.ExecuteRequest{}); got != "" {
+		t.Errorf("No inputs = %q", got)
+	}
+}
+
+func createPresetData(t *testing.T, s *ManagementService, data ...models.PresetData) {
+	t.Helper()
+	for i := range data {
+		data[i].CreatedAt = time.Now()
+		if err := s.db.DB().Create(&data[i]).Error; err != nil {
+			t.Fatalf("Failed to create preset data: %v", err)
+		}
+	}
+}
+
+func TestResolveInputs(t *testing.T) {
+	s := newPresetTestService(t)
+	createPresetData(t, s,
+		models.PresetData{ID: "data_1", Filename: "a.csv", MinioPath: "preset-data/data_1/a.csv"},
+		models.PresetData{ID: "data_2", Filename: "b.csv", MinioPath: "preset-data/data_2/b.csv"},
+		models.PresetData{ID: "data_3", Filename: "a.csv", MinioPath: "preset-data/data_3/a.csv"},
+	)
+	db := s.db.DB()
+
+	inputs, err := resolveInputs(db, "bucket", []*v1.InputSource{
+		{Url: "http://localhost:9000/bucket/preset-data/data_1/a.csv"},
+		{PresetDataId: "data_2"},
+	})
+	if err != nil {
+		t.Fatalf("resolveInputs failed: %v", err)
+	}
+	if len(inputs) != 2 || inputs[0].filename != "a.csv" || inputs[1].objectPath != "preset-data/data_2/b.csv" {
+		t.Errorf("inputs = %+v", inputs)
+	}
+
+	_, err = resolveInputs(db, "bucket", []*v1.InputSource{{PresetDataId: "data_1"}, {PresetDataId: "missing"}, {Url: "preset-data/gone.csv"}})
+	if status.Code(err) != codes.NotFound || !strings.Contains(err.Error(), "preset:missing, preset-data/gone.csv") {
+		t.Errorf("Missing inputs: err = %v", err)
+	}
+
+	_, err = resolveInputs(db, "bucket", []*v1.InputSource{{PresetDataId: "data_1"}, {PresetDataId: "data_3"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Duplicate filenames: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestDownloadPresetDataWritesEveryInput(t *testing.T) {
+	m := newPresetTestService(t)
+	createPresetData(t, m,
+		models.PresetData{ID: "data_1", Filename: "a.csv", MinioPath: "preset-data/data_1/a.csv"},
+		models.PresetData{ID: "data_2", Filename: "b.csv", MinioPath: "preset-data/data_2/b.csv"},
+	)
+	store, client := testutil.NewS3(t)
+	store.Put("preset-data/data_1/a.csv", []byte("x,y\n"))
+	store.Put("preset-data/data_2/b.csv", []byte("x,y\n"))
+	s := &AlgorithmService{db: m.db, cfgStore: m.cfgStore, minioClient: client}
+
+	dir := t.TempDir()
+	req := &v1.ExecuteRequest{
+		InputSource:  &v1.InputSource{Url: "preset-data/data_1/a.csv"},
+		InputSources: []*v1.InputSource{{PresetDataId: "data_2"}},
+	}
+	if err := s.downloadPresetData(context.Background(), inputSources(req), dir); err != nil {
+		t.Fatalf("downloadPresetData failed: %v", err)
+	}
+	for _, name := range []string{"a.csv", "b.csv"} {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err != nil || string(data) != "x,y\n" {
+			t.Errorf("%s = %q, %v", name, data, err)
+		}
+	}
+
+	// 有输入不存在时不下载任何文件
+	empty := t.TempDir()
+	req.InputSources = append(req.InputSources, &v1.InputSource{PresetDataId: "missing"})
+	if err := s.downloadPresetData(context.Background(), inputSources(req), empty); status.Code(err) != codes.NotFound {
+		t.Errorf("err = %v, want NotFound", err)
+	}
+	if entries, _ := os.ReadDir(empty); len(entries) != 0 {
+		t.Errorf("Downloaded %d files despite a missing input", len(entries))
+	}
+}
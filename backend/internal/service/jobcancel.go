@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// CancelJob 取消尚未结束的任务：先将任务标记为已取消，再中断本实例中的执行并停止任务容器
+func (s *AlgorithmService) CancelJob(ctx context.Context, req *v1.CancelJobRequest) (*v1.CancelJobResponse, error) {
+	if req.JobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+
+	db := s.db.DB().WithContext(ctx)
+	job := &models.Job{}
+	if err := db.First(job, "id = ?", req.JobId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "job %s not found", req.JobId)
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if slices.Contains(terminalJobStatuses, job.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s is already %s", job.ID, job.Status)
+	}
+
+	if err := transitionJob(db, job, models.JobStatusCancelled, map[string]interface{}{
+		"finished_at": time.Now(),
+	}); err != nil {
+		if errors.Is(err, ErrInvalidJobTransition) {
+			// 任务在此期间已结束
+			return nil, status.Errorf(codes.FailedPrecondition, "job %s is already %s", job.ID, job.Status)
+		}
+		return nil, err
+	}
+	s.publishJobEvent(job, "cancelled by request")
+
+	owned := s.interruptJob(job.ID)
+	s.stopJobContainers(job.ID, !owned)
+
+	return &v1.CancelJobResponse{
+		JobId:   job.ID,
+		Status:  job.Status,
+		Message: fmt.Sprintf("Job %s cancelled", job.ID),
+	}, nil
+}
+
+// interruptJob 取消本实例中任务执行的 context，尚未启动容器的任务不再启动，
+// 等待中的容器由 executeInContainer 停止并保存日志。任务不在本实例执行时返回 false
+func (s *AlgorithmService) interruptJob(jobID string) bool {
+	cancel, ok := s.jobCancels.Load(jobID)
+	if !ok {
+		return false
+	}
+	cancel.(context.CancelFunc)()
+	return true
+}
+
+// stopJobContainers 按 job_id 标签停止任务容器；没有执行方负责清理时（如服务重启后遗留的容器）同时删除容器
+func (s *AlgorithmService) stopJobContainers(jobID string, remove bool) {
+	if s.scheduler == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), containerCleanupTimeout)
+	defer cancel()
+
+	if err := s.scheduler.StopJob(ctx, jobID); err != nil {
+		fmt.Printf("Warning: failed to stop containers of job %s: %v\n", jobID, err)
+	}
+	if remove && !s.cfg().Docker.KeepContainers {
+		if err := s.scheduler.RemoveJob(ctx, jobID); err != nil {
+			fmt.Printf("Warning: failed to remove containers of job %s: %v\n", jobID, err)
+		}
+	}
+}
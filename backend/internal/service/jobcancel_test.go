@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/scheduler"
+	"algorithm-platform/pkg/docker"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeJobContainers 按 job_id 标签返回容器，记录停止和删除的容器
+type fakeJobContainers struct {
+	byJob   map[string][]string
+	stopped []string
+	removed []string
+	digest  string // ImageDigest 返回的摘要，为空时返回错误
+	digests int    // ImageDigest 调用次数
+}
+
+func (f *fakeJobContainers) CreateContainer(ctx context.Context, name string, cfg docker.ContainerConfig) (string, error) {
+	return name, nil
+}
+
+func (f *fakeJobContainers) StartContainer(ctx context.Context, id string) error { return nil }
+
+func (f *fakeJobContainers) StopContainer(ctx context.Context, id string) error {
+	f.stopped = append(f.stopped, id)
+	return nil
+}
+
+func (f *fakeJobContainers) RemoveContainer(ctx context.Context, id string, force bool) error {
+	f.removed = append(f.removed, id)
+	return nil
+}
+
+func (f *fakeJobContainers) GetContainerStatus(ctx context.Context, id string) (container.InspectResponse, error) {
+	return container.InspectResponse{}, nil
+}
+
+func (f *fakeJobContainers) ImageDigest(ctx context.Context, imageRef string) (string, error) {
+	f.digests++
+	if f.digest == "" {
+		return "", errors.New("no such image")
+	}
+	return f.digest, nil
+}
+
+func (f *fakeJobContainers) ListContainers(ctx context.Context, filterLabels map[string][]string) ([]types.Container, error) {
+	var containers []types.Container
+	for jobID, ids := range f.byJob {
+		if slices.Contains(filterLabels["label"], "job_id="+jobID) {
+			for _, id := range ids {
+				containers = append(containers, types.Container{ID: id})
+			}
+		}
+	}
+	return containers, nil
+}
+
+func TestCancelJobBeforeStart(t *testing.T) {
+	s := newJobContextTestService(t)
+	createJob(t, s.db.DB(), "job_pending", models.JobStatusPending)
+
+	resp, err := s.CancelJob(context.Background(), &v1.CancelJobRequest{JobId: "job_pending"})
+	if err != nil {
+		t.Fatalf("CancelJob failed: %v", err)
+	}
+	if resp.Status != models.JobStatusCancelled {
+		t.Errorf("Response status = %s, want cancelled", resp.Status)
+	}
+
+	// 后台执行在取消之后才开始，不再启动
+	s.runJobAsync("job_pending", &v1.ExecuteRequest{}, &preparedJob{algorithm: &models.Algorithm{ID: "alg_1"}, inputDir: t.TempDir()})
+
+	var job models.Job
+	s.db.DB().First(&job, "id = ?", "job_pending")
+	if job.Status != models.JobStatusCancelled || job.StartedAt != nil || job.FinishedAt == nil {
+		t.Errorf("Unexpected job after cancel: status %s, started %v, finished %v", job.Status, job.StartedAt, job.FinishedAt)
+	}
+}
+
+func TestCancelJobInterruptsRunningJob(t *testing.T) {
+	s := newJobContextTestService(t)
+	containers := &fakeJobContainers{byJob: map[string][]string{"job_running": {"c1"}, "job_other": {"c2"}}}
+	s.scheduler = scheduler.New(containers, nil)
+	createJob(t, s.db.DB(), "job_running", models.JobStatusRunning)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.jobCancels.Store("job_running", cancel)
+
+	if _, err := s.CancelJob(context.Background(), &v1.CancelJobRequest{JobId: "job_running"}); err != nil {
+		t.Fatalf("CancelJob failed: %v", err)
+	}
+	if ctx.Err() == nil {
+		t.Error("Execution context was not cancelled")
+	}
+	if !slices.Equal(containers.stopped, []string{"c1"}) {
+		t.Errorf("Stopped containers = %v, want [c1]", containers.stopped)
+	}
+	// 本实例的执行负责删除容器
+	if len(containers.removed) != 0 {
+		t.Errorf("Removed containers = %v, want none", containers.removed)
+	}
+}
+
+func TestCancelJobRemovesOrphanedContainers(t *testing.T) {
+	s := newJobContextTestService(t)
+	containers := &fakeJobContainers{byJob: map[string][]string{"job_running": {"c1"}}}
+	s.scheduler = scheduler.New(containers, nil)
+	createJob(t, s.db.DB(), "job_running", models.JobStatusRunning)
+
+	if _, err := s.CancelJob(context.Background(), &v1.CancelJobRequest{JobId: "job_running"}); err != nil {
+		t.Fatalf("CancelJob failed: %v", err)
+	}
+	if !slices.Equal(containers.stopped, []string{"c1"}) || !slices.Equal(containers.removed, []string{"c1"}) {
+		t.Errorf("Stopped %v, removed %v, want c1 for both", containers.stopped, containers.removed)
+	}
+}
+
+func TestCancelJobRejects(t *testing.T) {
+	s := newJobContextTestService(t)
+	createJob(t, s.db.DB(), "job_done", models.JobStatusCompleted)
+
+	tests := []struct {
+		jobID string
+		want  codes.Code
+	}{
+		{"", codes.InvalidArgument},
+		{"missing", codes.NotFound},
+		{"job_done", codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		if _, err := s.CancelJob(context.Background(), &v1.CancelJobRequest{JobId: tt.jobID}); status.Code(err) != tt.want {
+			t.Errorf("CancelJob(%q): err = %v, want %v", tt.jobID, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// DeleteJob 删除已结束的任务及其结果和日志对象
+func (s *ManagementService) DeleteJob(ctx context.Context, req *v1.DeleteJobRequest) (*v1.DeleteJobResponse, error) {
+	if req.JobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+
+	db := s.db.DB().WithContext(ctx)
+	var job models.Job
+	if err := db.First(&job, "id = ?", req.JobId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "job %s not found", req.JobId)
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if !slices.Contains(terminalJobStatuses, job.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s is %s, only finished jobs can be deleted", job.ID, job.Status)
+	}
+
+	removal, err := removeJobsWithArtifacts(ctx, newArtifactStore(s.minioClient), s.bucketName, db, []models.Job{job})
+	if err != nil {
+		return nil, err
+	}
+	if removal.failedJobs > 0 {
+		return nil, status.Errorf(codes.Unavailable, "failed to delete artifacts of job %s, try again later", job.ID)
+	}
+
+	return &v1.DeleteJobResponse{
+		JobId:          job.ID,
+		DeletedObjects: int32(removal.deletedObjects),
+		BytesReclaimed: removal.bytes,
+	}, nil
+}
+
+// jobPurgeFilter 批量清理任务的条件
+type jobPurgeFilter struct {
+	createdBefore time.Time // 零值表示不限制
+	statuses      []string
+	algorithmID   string
+}
+
+// newJobPurgeFilter 校验清理条件，至少需要一个条件，避免误删全部历史
+func newJobPurgeFilter(req *v1.PurgeJobsRequest, now time.Time) (*jobPurgeFilter, error) {
+	if req.OlderThanHours < 0 {
+		return nil, status.Error(codes.InvalidArgument, "older_than_hours must not be negative")
+	}
+	if req.OlderThanHours == 0 && len(req.Statuses) == 0 && req.AlgorithmId == "" {
+		return nil, status.Error(codes.InvalidArgument, "at least one of older_than_hours, statuses or algorithm_id is required")
+	}
+	for _, st := range req.Statuses {
+		if !slices.Contains(terminalJobStatuses, st) {
+			return nil, status.Errorf(codes.InvalidArgument, "cannot purge jobs in status %q, only finished jobs can be purged", st)
+		}
+	}
+
+	filter := &jobPurgeFilter{statuses: req.Statuses, algorithmID: req.AlgorithmId}
+	if len(filter.statuses) == 0 {
+		filter.statuses = terminalJobStatuses
+	}
+	if req.OlderThanHours > 0 {
+		filter.createdBefore = now.Add(-time.Duration(req.OlderThanHours) * time.Hour)
+	}
+	return filter, nil
+}
+
+// selectPurgeJobs 返回符合条件的一批任务，从最旧的开始，跳过 skip 中上次删除失败的任务
+func selectPurgeJobs(db *gorm.DB, filter *jobPurgeFilter, skip []string) ([]models.Job, error) {
+	query := db.Where("status IN ?", filter.statuses)
+	if !filter.createdBefore.IsZero() {
+		query = query.Where("created_at < ?", filter.createdBefore)
+	}
+	if filter.algorithmID != "" {
+		query = query.Where("algorithm_id = ?", filter.algorithmID)
+	}
+	if len(skip) > 0 {
+		query = query.Where("id NOT IN ?", skip)
+	}
+
+	var jobs []models.Job
+	if err := query.Order("created_at ASC, id ASC").Limit(jobTrimBatchSize).Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list jobs to purge: %w", err)
+	}
+	return jobs, nil
+}
+
+// PurgeJobs 按创建时间、状态和算法批量删除已结束的任务及其结果和日志对象。
+// 中途出错时返回已删除部分的统计和错误，统计同时写入错误信息，供只能看到错误的 gRPC/HTTP 客户端使用
+func (s *ManagementService) PurgeJobs(ctx context.Context, req *v1.PurgeJobsRequest) (*v1.PurgeJobsResponse, error) {
+	filter, err := newJobPurgeFilter(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jobTrimTimeout)
+	defer cancel()
+
+	db := s.db.DB().WithContext(ctx)
+	store := newArtifactStore(s.minioClient)
+	resp := &v1.PurgeJobsResponse{}
+	var skip []string
+	for {
+		jobs, err := selectPurgeJobs(db, filter, skip)
+		if err != nil {
+			return resp, purgeStopped(resp, err)
+		}
+		if len(jobs) == 0 {
+			break
+		}
+
+		removal, err := removeJobsWithArtifacts(ctx, store, s.bucketName, db, jobs)
+		resp.PurgedJobs += int32(len(removal.deletedIDs))
+		resp.DeletedObjects += int32(removal.deletedObjects)
+		resp.BytesReclaimed += removal.bytes
+		resp.FailedJobs += int32(removal.failedJobs)
+		if err != nil {
+			return resp, purgeStopped(resp, err)
+		}
+
+		for _, job := range jobs {
+			if !slices.Contains(removal.deletedIDs, job.ID) {
+				skip = append(skip, job.ID)
+			}
+		}
+		if len(jobs) < jobTrimBatchSize {
+			break
+		}
+	}
+
+	fmt.Printf("Purged %d jobs (%d objects, %d bytes reclaimed, %d failed)\n",
+		resp.PurgedJobs, resp.DeletedObjects, resp.BytesReclaimed, resp.FailedJobs)
+	return resp, nil
+}
+
+// purgeStopped 记录中途停止的清理已完成的部分，并在错误信息中带上统计
+func purgeStopped(resp *v1.PurgeJobsResponse, err error) error {
+	fmt.Printf("Purge stopped after %d jobs (%d objects, %d bytes reclaimed, %d failed): %v\n",
+		resp.PurgedJobs, resp.DeletedObjects, resp.BytesReclaimed, resp.FailedJobs, err)
+	return fmt.Errorf("purge stopped after deleting %d jobs (%d objects, %d bytes): %w",
+		resp.PurgedJobs, resp.DeletedObjects, resp.BytesReclaimed, err)
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"github.com/minio/minio-go/v7"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// fakeArtifactStore 内存中的对象存储，fail 中的对象删除失败
+type fakeArtifactStore struct {
+	mu      sync.Mutex
+	objects map[string]int64
+	fail    map[string]bool
+}
+
+func (f *fakeArtifactStore) StatObject(ctx context.Context, bucketName, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	size, ok := f.objects[objectName]
+	if !ok {
+		return minio.ObjectInfo{}, minio.ErrorResponse{Code: "NoSuchKey"}
+	}
+	return minio.ObjectInfo{Key: objectName, Size: size}, nil
+}
+
+func (f *fakeArtifactStore) RemoveObjects(ctx context.Context, bucketName string, objectsCh <-chan minio.ObjectInfo, opts minio.RemoveObjectsOptions) <-chan minio.RemoveObjectError {
+	errCh := make(chan minio.RemoveObjectError)
+	go func() {
+		defer close(errCh)
+		for object := range objectsCh {
+			f.mu.Lock()
+			fail := f.fail[object.Key]
+			if !fail {
+				delete(f.objects, object.Key)
+			}
+			f.mu.Unlock()
+			if fail {
+				errCh <- minio.RemoveObjectError{ObjectName: object.Key, Err: errors.New("access denied")}
+			}
+		}
+	}()
+	return errCh
+}
+
+func TestRemoveJobsWithArtifacts(t *testing.T) {
+	db := testutil.NewDB(t)
+	jobs := []models.Job{
+		{ID: "job_1", Status: models.JobStatusCompleted, OutputURL: "results/job_1", LogURL: "logs/job_1.log"},
+		// 结果对象已过期删除，只剩日志
+		{ID: "job_2", Status: models.JobStatusFailed, OutputURL: "results/job_2", LogURL: "logs/job_2.log"},
+		{ID: "job_3", Status: models.JobStatusCompleted, OutputURL: "http://minio:9000/bucket/results/job_3"},
+	}
+	if err := db.Create(&jobs).Error; err != nil {
+		t.Fatalf("Failed to create jobs: %v", err)
+	}
+	store := &fakeArtifactStore{
+		objects: map[string]int64{"results/job_1": 100, "logs/job_1.log": 20, "logs/job_2.log": 5, "results/job_3": 7},
+		fail:    map[string]bool{"results/job_3": true},
+	}
+
+	removal, err := removeJobsWithArtifacts(context.Background(), store, "bucket", db, jobs)
+	if err != nil {
+		t.Fatalf("removeJobsWithArtifacts failed: %v", err)
+	}
+	if !reflect.DeepEqual(removal.deletedIDs, []string{"job_1", "job_2"}) || removal.failedJobs != 1 {
+		t.Errorf("Deleted %v, %d failed", removal.deletedIDs, removal.failedJobs)
+	}
+	if removal.deletedObjects != 3 || removal.bytes != 125 {
+		t.Errorf("Deleted %d objects, %d bytes; want 3 objects, 125 bytes", removal.deletedObjects, removal.bytes)
+	}
+
+	var remaining []string
+	db.Model(&models.Job{}).Pluck("id", &remaining)
+	if !reflect.DeepEqual(remaining, []string{"job_3"}) {
+		t.Errorf("Remaining jobs = %v, want job_3 kept for retry", remaining)
+	}
+	if _, ok := store.objects["results/job_3"]; !ok || len(store.objects) != 1 {
+		t.Errorf("Remaining objects = %v", store.objects)
+	}
+}
+
+func TestNewJobPurgeFilter(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		req  *v1.PurgeJobsRequest
+		code codes.Code
+	}{
+		{"no criteria", &v1.PurgeJobsRequest{}, codes.InvalidArgument},
+		{"negative age", &v1.PurgeJobsRequest{OlderThanHours: -1}, codes.InvalidArgument},
+		{"running jobs", &v1.PurgeJobsRequest{Statuses: []string{models.JobStatusRunning}}, codes.InvalidArgument},
+		{"age only", &v1.PurgeJobsRequest{OlderThanHours: 24}, codes.OK},
+		{"status only", &v1.PurgeJobsRequest{Statuses: []string{models.JobStatusFailed}}, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newJobPurgeFilter(tt.req, now)
+			if status.Code(err) != tt.code {
+				t.Fatalf("err = %v, want %v", err, tt.code)
+			}
+			if err == nil && tt.req.OlderThanHours > 0 && !filter.createdBefore.Equal(now.Add(-24*time.Hour)) {
+				t.Errorf("createdBefore = %v", filter.createdBefore)
+			}
+		})
+	}
+}
+
+func TestSelectPurgeJobs(t *testing.T) {
+	db := testutil.NewDB(t)
+	now := time.Now()
+	jobs := []models.Job{
+		{ID: "old_done", AlgorithmID: "alg_1", Status: models.JobStatusCompleted, CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "old_failed", AlgorithmID: "alg_2", Status: models.JobStatusFailed, CreatedAt: now.Add(-47 * time.Hour)},
+		{ID: "old_running", AlgorithmID: "alg_1", Status: models.JobStatusRunning, CreatedAt: now.Add(-72 * time.Hour)},
+		{ID: "new_done", AlgorithmID: "alg_1", Status: models.JobStatusCompleted, CreatedAt: now},
+	}
+	if err := db.Create(&jobs).Error; err != nil {
+		t.Fatalf("Failed to create jobs: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *v1.PurgeJobsRequest
+		skip []string
+		want []string
+	}{
+		{"older than a day", &v1.PurgeJobsRequest{OlderThanHours: 24}, nil, []string{"old_done", "old_failed"}},
+		{"failed only", &v1.PurgeJobsRequest{Statuses: []string{models.JobStatusFailed}}, nil, []string{"old_failed"}},
+		{"one algorithm", &v1.PurgeJobsRequest{AlgorithmId: "alg_1"}, nil, []string{"new_done", "old_done"}},
+		{"skips failed deletions", &v1.PurgeJobsRequest{OlderThanHours: 24}, []string{"old_done"}, []string{"old_failed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newJobPurgeFilter(tt.req, now)
+			if err != nil {
+				t.Fatalf("newJobPurgeFilter failed: %v", err)
+			}
+			selected, err := selectPurgeJobs(db, filter, tt.skip)
+			if err != nil {
+				t.Fatalf("selectPurgeJobs failed: %v", err)
+			}
+			ids := jobIDs(selected)
+			sort.Strings(ids)
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("Selected %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteJob(t *testing.T) {
+	db := testutil.NewDB(t)
+	cfg := &config.Config{}
+	s := &ManagementService{db: database.NewWithDB(db, cfg), cfgStore: config.NewStore(cfg)}
+	createJob(t, db, "job_done", models.JobStatusCompleted)
+	createJob(t, db, "job_running", models.JobStatusRunning)
+
+	if _, err := s.DeleteJob(context.Background(), &v1.DeleteJobRequest{JobId: "job_running"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Deleting a running job: err = %v, want FailedPrecondition", err)
+	}
+	if _, err := s.DeleteJob(context.Background(), &v1.DeleteJobRequest{JobId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Deleting a missing job: err = %v, want NotFound", err)
+	}
+	if _, err := s.DeleteJob(context.Background(), &v1.DeleteJobRequest{JobId: "job_done"}); err != nil {
+		t.Fatalf("DeleteJob failed: %v", err)
+	}
+
+	var count int64
+	db.Model(&models.Job{}).Where("id = ?", "job_done").Count(&count)
+	if count != 0 {
+		t.Error("Finished job was not deleted")
+	}
+}
+
+func TestPurgeJobsReturnsPartialCountsOnError(t *testing.T) {
+	db := testutil.NewDB(t)
+	store, client := testutil.NewS3(t)
+	cfg := &config.Config{}
+	s := &ManagementService{db: database.NewWithDB(db, cfg), cfgStore: config.NewStore(cfg), minioClient: client, bucketName: "bucket"}
+
+	job := &models.Job{ID: "job_done", Status: models.JobStatusCompleted, OutputURL: "results/job_done", LogURL: "logs/job_done.log"}
+	if err := db.Create(job).Error; err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	store.Put("results/job_done", []byte("{}"))
+	store.Put("logs/job_done.log", []byte("done\n"))
+
+	// 对象删除后删除任务记录失败
+	db.Callback().Delete().Before("gorm:delete").Register("test:fail_delete", func(tx *gorm.DB) {
+		tx.AddError(errors.New("database is locked"))
+	})
+
+	resp, err := s.PurgeJobs(context.Background(), &v1.PurgeJobsRequest{Statuses: []string{models.JobStatusCompleted}})
+	if err == nil {
+		t.Fatalf("Expected PurgeJobs to fail, got %+v", resp)
+	}
+	if resp == nil || resp.DeletedObjects != 2 || resp.BytesReclaimed != 7 || resp.PurgedJobs != 0 {
+		t.Errorf("Expected the objects deleted before the failure to be reported, got %+v", resp)
+	}
+	if !strings.Contains(err.Error(), "2 objects") {
+		t.Errorf("Expected the error to include the partial counts, got %v", err)
+	}
+}
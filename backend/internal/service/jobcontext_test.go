@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+)
+
+func newJobContextTestService(t *testing.T) *AlgorithmService {
+	t.Helper()
+	cfg := &config.Config{}
+	return &AlgorithmService{db: database.NewWithDB(testutil.NewDB(t), cfg), cfgStore: config.NewStore(cfg)}
+}
+
+func TestRunJobSyncHonorsCancelledRequest(t *testing.T) {
+	s := newJobContextTestService(t)
+	createJob(t, s.db.DB(), "job_sync", models.JobStatusPending)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	resp, err := s.runJobSync(ctx, "job_sync", &v1.ExecuteRequest{}, &preparedJob{algorithm: &models.Algorithm{ID: "alg_1"}, inputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("runJobSync failed: %v", err)
+	}
+	if resp.Status != models.JobStatusCancelled {
+		t.Errorf("Response status = %s, want cancelled", resp.Status)
+	}
+
+	var job models.Job
+	s.db.DB().First(&job, "id = ?", "job_sync")
+	if job.Status != models.JobStatusCancelled || job.StartedAt != nil || job.FinishedAt == nil {
+		t.Errorf("Unexpected job after cancelled request: status %s, started %v, finished %v", job.Status, job.StartedAt, job.FinishedAt)
+	}
+}
+
+func TestRunJobAsyncOutlivesRequest(t *testing.T) {
+	s := newJobContextTestService(t)
+	createJob(t, s.db.DB(), "job_async", models.JobStatusPending)
+
+	// runJobAsync 不接收请求的 context，请求返回后任务照常执行到结束
+	s.runJobAsync("job_async", &v1.ExecuteRequest{IsAsync: true}, &preparedJob{algorithm: &models.Algorithm{ID: "alg_1"}, inputDir: t.TempDir()})
+
+	var job models.Job
+	s.db.DB().First(&job, "id = ?", "job_async")
+	// 测试环境没有 Docker，任务会执行失败，但必须是实际运行后的失败而不是被取消
+	if job.Status != models.JobStatusFailed || job.StartedAt == nil || job.FinishedAt == nil {
+		t.Errorf("Unexpected async job: status %s, started %v, finished %v", job.Status, job.StartedAt, job.FinishedAt)
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetJobDetailReadsStoredJob(t *testing.T) {
+	db := testutil.NewDB(t)
+	cfg := &config.Config{}
+	cfg.MinIO.Bucket = "bucket"
+	cfg.MinIO.ExternalEndpoint = "localhost:9000"
+	s := &ManagementService{db: database.NewWithDB(db, cfg), cfgStore: config.NewStore(cfg), bucketName: "bucket"}
+
+	started := time.Now().Add(-time.Minute).Truncate(time.Second)
+	finished := started.Add(30 * time.Second)
+	job := &models.Job{
+		ID:          "job_1",
+		AlgorithmID: "alg_1",
+		Mode:        "batch",
+		Status:      models.JobStatusCompleted,
+		OutputURL:   "results/job_1/result.json",
+		StartedAt:   &started,
+		FinishedAt:  &finished,
+	}
+	if err := db.Create(job).Error; err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	detail, err := s.GetJobDetail(context.Background(), &v1.GetJobDetailRequest{JobId: "job_1"})
+	if err != nil {
+		t.Fatalf("GetJobDetail failed: %v", err)
+	}
+	if detail.AlgorithmId != "alg_1" || detail.Mode != "batch" || detail.Status != models.JobStatusCompleted {
+		t.Errorf("Unexpected detail %+v", detail)
+	}
+	if detail.OutputUrl != "http://localhost:9000/bucket/results/job_1/result.json" {
+		t.Errorf("OutputUrl = %q", detail.OutputUrl)
+	}
+	if !detail.StartedAt.AsTime().Equal(started) || !detail.FinishedAt.AsTime().Equal(finished) || detail.CreatedAt == nil {
+		t.Errorf("Unexpected timestamps created=%v started=%v finished=%v", detail.CreatedAt, detail.StartedAt, detail.FinishedAt)
+	}
+
+	if _, err := s.GetJobDetail(context.Background(), &v1.GetJobDetailRequest{JobId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Missing job: err = %v, want NotFound", err)
+	}
+	if _, err := s.GetJobDetail(context.Background(), &v1.GetJobDetailRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Empty job id: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestGetJobDetailReportsExpiredArtifacts(t *testing.T) {
+	db := testutil.NewDB(t)
+	_, client := testutil.NewS3(t)
+	cfg := &config.Config{}
+	cfg.MinIO.Bucket = "bucket"
+	s := &ManagementService{db: database.NewWithDB(db, cfg), cfgStore: config.NewStore(cfg), minioClient: client, bucketName: "bucket"}
+
+	expireAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	job := &models.Job{ID: "job_1", Status: models.JobStatusCompleted, OutputURL: "results/job_1", ArtifactsExpireAt: &expireAt}
+	if err := db.Create(job).Error; err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	detail, err := s.GetJobDetail(context.Background(), &v1.GetJobDetailRequest{JobId: "job_1"})
+	if err != nil {
+		t.Fatalf("GetJobDetail failed: %v", err)
+	}
+	if !detail.ArtifactsExpired || detail.OutputUrl != "" {
+		t.Errorf("Expected expired artifacts without output URL, got expired=%v url=%q", detail.ArtifactsExpired, detail.OutputUrl)
+	}
+	if !detail.ArtifactsExpireAt.AsTime().Equal(expireAt) {
+		t.Errorf("ArtifactsExpireAt = %v, want %v", detail.ArtifactsExpireAt.AsTime(), expireAt)
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"algorithm-platform/internal/models"
+	"algorithm-platform/pkg/storage"
+
+	"github.com/minio/minio-go/v7"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// 单次清理的最大任务数和超时时间，超出的部分在下次创建任务时继续清理
+const (
+	jobTrimBatchSize = 500
+	jobTrimTimeout   = 5 * time.Minute
+)
+
+// terminalJobStatuses 已结束的任务状态，只有这些任务会被清理
+var terminalJobStatuses = []string{
+	models.JobStatusCompleted,
+	models.JobStatusFailed,
+	models.JobStatusTimeout,
+	models.JobStatusCancelled,
+}
+
+// validateJobHistoryLimit 校验算法的任务保留数量，-1 表示不限制
+func validateJobHistoryLimit(limit int32) error {
+	if limit < models.JobHistoryUnlimited {
+		return status.Error(codes.InvalidArgument, "job_history_limit must be -1 (unlimited), 0 (server default) or positive")
+	}
+	return nil
+}
+
+// effectiveJobHistoryLimit 算法保留的已结束任务数量，算法未设置时使用全局配置，0 表示不限制
+func effectiveJobHistoryLimit(algorithm *models.Algorithm, global int) int {
+	if algorithm.JobHistoryLimit == models.JobHistoryUnlimited {
+		return 0
+	}
+	if algorithm.JobHistoryLimit > 0 {
+		return algorithm.JobHistoryLimit
+	}
+	return max(global, 0)
+}
+
+// selectExcessJobs 返回算法超出保留数量的已结束任务，从最旧的开始，最多 jobTrimBatchSize 个
+func selectExcessJobs(db *gorm.DB, algorithmID string, keep int) ([]models.Job, error) {
+	var jobs []models.Job
+	err := db.Where("algorithm_id = ? AND status IN ?", algorithmID, terminalJobStatuses).
+		Order("created_at DESC, id DESC").
+		Offset(keep).
+		Limit(jobTrimBatchSize).
+		Find(&jobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list old jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// trimJobHistory 删除算法超出保留数量的旧任务及其结果和日志，在后台运行
+func (s *AlgorithmService) trimJobHistory(algorithm *models.Algorithm) {
+	keep := effectiveJobHistoryLimit(algorithm, s.cfg().Server.JobHistoryLimit)
+	if keep == 0 || s.minioClient == nil {
+		return
+	}
+
+	// 同一算法同时只运行一个清理
+	if _, running := s.trimming.LoadOrStore(algorithm.ID, struct{}{}); running {
+		return
+	}
+	defer s.trimming.Delete(algorithm.ID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), jobTrimTimeout)
+	defer cancel()
+
+	db := s.db.DB().WithContext(ctx)
+	jobs, err := selectExcessJobs(db, algorithm.ID, keep)
+	if err != nil || len(jobs) == 0 {
+		if err != nil {
+			fmt.Printf("Warning: failed to trim job history of algorithm %s: %v\n", algorithm.ID, err)
+		}
+		return
+	}
+
+	removal, err := removeJobsWithArtifacts(ctx, newArtifactStore(s.minioClient), s.cfg().MinIO.Bucket, db, jobs)
+	if removal.failedJobs > 0 {
+		fmt.Printf("Warning: failed to delete artifacts of %d old jobs of algorithm %s\n", removal.failedJobs, algorithm.ID)
+	}
+	if err != nil {
+		fmt.Printf("Warning: failed to delete old jobs of algorithm %s: %v\n", algorithm.ID, err)
+		return
+	}
+	if len(removal.deletedIDs) > 0 {
+		fmt.Printf("Trimmed %d old jobs of algorithm %s (keeping %d)\n", len(removal.deletedIDs), algorithm.ID, keep)
+	}
+}
+
+// artifactStore 删除任务产物所需的对象存储操作，由 *minio.Client 实现
+type artifactStore interface {
+	storage.ObjectRemover
+	StatObject(ctx context.Context, bucketName, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error)
+}
+
+// newArtifactStore client 为 nil 时返回 nil，避免 nil 指针被包装成非 nil 接口
+func newArtifactStore(client *minio.Client) artifactStore {
+	if client == nil {
+		return nil
+	}
+	return client
+}
+
+// jobRemoval 一批任务的删除结果
+type jobRemoval struct {
+	deletedIDs     []string
+	deletedObjects int
+	bytes          int64
+	failedJobs     int
+}
+
+// removeJobsWithArtifacts 删除任务的结果和日志对象，再删除产物已清理的任务记录
+// 产物删除失败的任务保留记录，下次清理时重试，避免留下无人引用的对象；store 为 nil 时只删除记录
+func removeJobsWithArtifacts(ctx context.Context, store artifactStore, bucket string, db *gorm.DB, jobs []models.Job) (*jobRemoval, error) {
+	removal := &jobRemoval{}
+
+	jobKeys := make(map[string][]string, len(jobs))
+	failed := map[string]error{}
+	if store != nil {
+		var keys []string
+		sizes := make(map[string]int64)
+		for _, job := range jobs {
+			for _, stored := range []string{job.OutputURL, job.LogURL} {
+				key := objectPathFromURL(bucket, stored)
+				if key == "" {
+					continue
+				}
+				info, err := store.StatObject(ctx, bucket, key, minio.StatObjectOptions{})
+				if err != nil && isObjectMissing(err) {
+					// 对象已不存在（如已过期清理），无需删除
+					continue
+				}
+				if err == nil {
+					sizes[key] = info.Size
+				}
+				keys = append(keys, key)
+				jobKeys[job.ID] = append(jobKeys[job.ID], key)
+			}
+		}
+		failed = storage.RemoveObjects(ctx, store, bucket, keys)
+		for _, key := range keys {
+			if _, bad := failed[key]; !bad {
+				removal.deletedObjects++
+				removal.bytes += sizes[key]
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ok := true
+		for _, key := range jobKeys[job.ID] {
+			if _, bad := failed[key]; bad {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			ids = append(ids, job.ID)
+		} else {
+			removal.failedJobs++
+		}
+	}
+	if len(ids) == 0 {
+		return removal, nil
+	}
+
+	if err := db.Where("id IN ?", ids).Delete(&models.Job{}).Error; err != nil {
+		return removal, fmt.Errorf("failed to delete jobs: %w", err)
+	}
+	removal.deletedIDs = ids
+	return removal, nil
+}
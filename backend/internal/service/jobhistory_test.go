@@ -0,0 +1,81 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEffectiveJobHistoryLimit(t *testing.T) {
+	if got := effectiveJobHistoryLimit(&models.Algorithm{JobHistoryLimit: 5}, 100); got != 5 {
+		t.Errorf("Algorithm limit should win, got %d", got)
+	}
+	if got := effectiveJobHistoryLimit(&models.Algorithm{}, 100); got != 100 {
+		t.Errorf("Expected global limit, got %d", got)
+	}
+	if got := effectiveJobHistoryLimit(&models.Algorithm{}, 0); got != 0 {
+		t.Errorf("Expected unlimited, got %d", got)
+	}
+	if got := effectiveJobHistoryLimit(&models.Algorithm{JobHistoryLimit: models.JobHistoryUnlimited}, 100); got != 0 {
+		t.Errorf("Algorithm set to unlimited should ignore the global limit, got %d", got)
+	}
+}
+
+func TestValidateJobHistoryLimit(t *testing.T) {
+	for _, limit := range []int32{models.JobHistoryUnlimited, 0, 10} {
+		if err := validateJobHistoryLimit(limit); err != nil {
+			t.Errorf("validateJobHistoryLimit(%d) = %v", limit, err)
+		}
+	}
+	if err := validateJobHistoryLimit(-2); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("validateJobHistoryLimit(-2) = %v, want InvalidArgument", err)
+	}
+}
+
+func TestSelectExcessJobs(t *testing.T) {
+	db := testutil.NewDB(t)
+	base := time.Now().Add(-time.Hour)
+	var jobs []models.Job
+	for i := 0; i < 5; i++ {
+		jobs = append(jobs, models.Job{
+			ID:          fmt.Sprintf("job_%d", i),
+			AlgorithmID: "alg_1",
+			Status:      models.JobStatusCompleted,
+			CreatedAt:   base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	jobs = append(jobs,
+		// 未结束的任务和其他算法的任务不参与清理
+		models.Job{ID: "job_running", AlgorithmID: "alg_1", Status: models.JobStatusRunning, CreatedAt: base.Add(-time.Hour)},
+		models.Job{ID: "job_other", AlgorithmID: "alg_2", Status: models.JobStatusFailed, CreatedAt: base.Add(-time.Hour)},
+	)
+	if err := db.Create(&jobs).Error; err != nil {
+		t.Fatalf("Failed to create jobs: %v", err)
+	}
+
+	excess, err := selectExcessJobs(db, "alg_1", 3)
+	if err != nil {
+		t.Fatalf("selectExcessJobs failed: %v", err)
+	}
+	if len(excess) != 2 || excess[0].ID != "job_1" || excess[1].ID != "job_0" {
+		t.Errorf("Expected the two oldest finished jobs, got %v", jobIDs(excess))
+	}
+
+	if excess, _ := selectExcessJobs(db, "alg_1", 10); len(excess) != 0 {
+		t.Errorf("Expected nothing to trim, got %v", jobIDs(excess))
+	}
+}
+
+func jobIDs(jobs []models.Job) []string {
+	ids := make([]string, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+	return ids
+}
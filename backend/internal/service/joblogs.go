@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"github.com/minio/minio-go/v7"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// 日志来源
+const (
+	logSourceContainer = "container"
+	logSourceStored    = "stored"
+)
+
+// logChunkSize 每条 JobLogChunk 的最大字节数
+const logChunkSize = 32 << 10
+
+// StreamJobLogs 运行中的任务跟随容器日志直到容器退出，已结束或容器已删除的任务输出保存在 MinIO 中的日志。
+// 客户端断开时 stream 的 context 被取消，底层的日志读取随之关闭
+func (s *AlgorithmService) StreamJobLogs(req *v1.StreamJobLogsRequest, stream v1.AlgorithmService_StreamJobLogsServer) error {
+	if req.JobId == "" {
+		return status.Error(codes.InvalidArgument, "job_id is required")
+	}
+	ctx := stream.Context()
+
+	job := &models.Job{}
+	if err := s.db.DB().WithContext(ctx).First(job, "id = ?", req.JobId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return status.Errorf(codes.NotFound, "job %s not found", req.JobId)
+		}
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+
+	if !slices.Contains(terminalJobStatuses, job.Status) {
+		logs, err := s.followJobContainerLogs(ctx, job.ID)
+		if err != nil {
+			return err
+		}
+		if logs != nil {
+			defer logs.Close()
+			// 保存的日志在上传时已脱敏，实时日志需要在这里替换密钥值
+			return sendLogChunks(stream, jobSecretRedactor(&s.cfg().Docker, job).Reader(logs), logSourceContainer)
+		}
+	}
+
+	if job.LogURL == "" {
+		if job.Status == models.JobStatusPending {
+			return status.Errorf(codes.FailedPrecondition, "job %s has not started", job.ID)
+		}
+		return status.Errorf(codes.NotFound, "no logs available for job %s", job.ID)
+	}
+	if s.minioClient == nil {
+		return status.Error(codes.Unavailable, "MinIO client is not available")
+	}
+
+	obj, err := s.minioClient.GetObject(ctx, s.cfg().MinIO.Bucket, objectPathFromURL(s.cfg().MinIO.Bucket, job.LogURL), minio.GetObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get job logs: %w", err)
+	}
+	defer obj.Close()
+	return sendLogChunks(stream, obj, logSourceStored)
+}
+
+// followJobContainerLogs 打开任务容器的跟随日志流，找不到容器时返回 nil
+func (s *AlgorithmService) followJobContainerLogs(ctx context.Context, jobID string) (io.ReadCloser, error) {
+	if s.scheduler == nil || s.dockerClient == nil {
+		return nil, nil
+	}
+
+	info, err := s.scheduler.InspectJobContainer(ctx, jobID)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to find container of job %s: %v", jobID, err)
+	}
+	if info == nil {
+		return nil, nil
+	}
+
+	logs, err := s.dockerClient.StreamContainerLogs(ctx, info.ID, true, false)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to read container logs: %v", err)
+	}
+	return logs, nil
+}
+
+// sendLogChunks 按读取到的内容逐块发送日志，直到 r 结束
+func sendLogChunks(stream v1.AlgorithmService_StreamJobLogsServer, r io.Reader, source string) error {
+	buf := make([]byte, logChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&v1.JobLogChunk{Data: string(buf[:n]), Source: source}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if ctxErr := stream.Context().Err(); ctxErr != nil {
+				return status.FromContextError(ctxErr).Err()
+			}
+			return fmt.Errorf("failed to read job logs: %w", err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeLogStream 记录发送的日志块，failAfter 次发送后返回错误（模拟客户端断开）
+type fakeLogStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	chunks    []*v1.JobLogChunk
+	failAfter int
+}
+
+func (f *fakeLogStream) Context() context.Context { return f.ctx }
+
+func (f *fakeLogStream) Send(chunk *v1.JobLogChunk) error {
+	if f.failAfter > 0 && len(f.chunks) >= f.failAfter {
+		return status.Error(codes.Canceled, "client disconnected")
+	}
+	f.chunks = append(f.chunks, chunk)
+	return nil
+}
+
+func (f *fakeLogStream) data() string {
+	var b strings.Builder
+	for _, chunk := range f.chunks {
+		b.WriteString(chunk.Data)
+	}
+	return b.String()
+}
+
+func TestStreamJobLogsErrors(t *testing.T) {
+	s := newJobContextTestService(t)
+	createJob(t, s.db.DB(), "job_pending", models.JobStatusPending)
+	createJob(t, s.db.DB(), "job_failed", models.JobStatusFailed)
+
+	tests := []struct {
+		jobID string
+		code  codes.Code
+	}{
+		{"", codes.InvalidArgument},
+		{"missing", codes.NotFound},
+		{"job_pending", codes.FailedPrecondition},
+		{"job_failed", codes.NotFound},
+	}
+	for _, tt := range tests {
+		stream := &fakeLogStream{ctx: context.Background()}
+		err := s.StreamJobLogs(&v1.StreamJobLogsRequest{JobId: tt.jobID}, stream)
+		if status.Code(err) != tt.code {
+			t.Errorf("StreamJobLogs(%q): err = %v, want %v", tt.jobID, err, tt.code)
+		}
+	}
+}
+
+func TestStreamJobLogsFromStoredLog(t *testing.T) {
+	logs := strings.Repeat("line\n", 10000)
+	store, client := testutil.NewS3(t)
+	store.Put("logs/job_done.log", []byte(logs))
+
+	s := newJobContextTestService(t)
+	s.minioClient = client
+	s.cfg().MinIO.Bucket = "bucket"
+	job := &models.Job{ID: "job_done", Status: models.JobStatusCompleted, LogURL: "logs/job_done.log"}
+	if err := s.db.DB().Create(job).Error; err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	stream := &fakeLogStream{ctx: context.Background()}
+	if err := s.StreamJobLogs(&v1.StreamJobLogsRequest{JobId: job.ID}, stream); err != nil {
+		t.Fatalf("StreamJobLogs failed: %v", err)
+	}
+	if stream.data() != logs {
+		t.Errorf("Streamed %d bytes, want %d", len(stream.data()), len(logs))
+	}
+	for _, chunk := range stream.chunks {
+		if chunk.Source != logSourceStored || len(chunk.Data) > logChunkSize {
+			t.Fatalf("Chunk source = %s, size = %d", chunk.Source, len(chunk.Data))
+		}
+	}
+}
+
+func TestSendLogChunksStopsOnDisconnect(t *testing.T) {
+	stream := &fakeLogStream{ctx: context.Background(), failAfter: 1}
+	r := strings.NewReader(strings.Repeat("x", 3*logChunkSize))
+
+	err := sendLogChunks(stream, r, logSourceContainer)
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("err = %v, want Canceled", err)
+	}
+	if len(stream.chunks) != 1 {
+		t.Errorf("Sent %d chunks after disconnect", len(stream.chunks))
+	}
+}
+
+// cancelledReader 模拟 context 取消后被关闭的日志流
+type cancelledReader struct{}
+
+func (cancelledReader) Read([]byte) (int, error) { return 0, errors.New("use of closed connection") }
+
+func TestSendLogChunksReportsCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stream := &fakeLogStream{ctx: ctx}
+
+	if err := sendLogChunks(stream, cancelledReader{}, logSourceContainer); status.Code(err) != codes.Canceled {
+		t.Errorf("err = %v, want Canceled", err)
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// syncJobQueueTimeout 同步任务等待执行槽位的最长时间，超时后返回服务繁忙
+const syncJobQueueTimeout = 30 * time.Second
+
+// jobPool 限制同时执行的任务数，超出的任务等待槽位释放
+type jobPool struct {
+	slots  chan struct{} // 为 nil 时不限制
+	queued atomic.Int64  // 正在等待槽位的任务数
+}
+
+// newJobPool 创建任务池，limit <= 0 时不限制并发
+func newJobPool(limit int) *jobPool {
+	p := &jobPool{}
+	if limit > 0 {
+		p.slots = make(chan struct{}, limit)
+	}
+	return p
+}
+
+// acquire 等待执行槽位，timeout > 0 时最多等待 timeout，超时返回 ResourceExhausted；
+// ctx 结束时返回 ctx.Err()。成功后调用返回的函数释放槽位
+func (p *jobPool) acquire(ctx context.Context, timeout time.Duration) (func(), error) {
+	if p == nil || p.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+		return p.release, nil
+	default:
+	}
+
+	p.queued.Add(1)
+	defer p.queued.Add(-1)
+
+	waitCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+		return p.release, nil
+	case <-waitCtx.Done():
+		if ctx.Err() == nil && errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			return nil, status.Errorf(codes.ResourceExhausted, "server busy: all %d job slots are in use, retry later or run the job asynchronously", cap(p.slots))
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func (p *jobPool) release() {
+	<-p.slots
+}
+
+// queueDepth 当前等待槽位的任务数
+func (p *jobPool) queueDepth() int {
+	if p == nil {
+		return 0
+	}
+	return int(p.queued.Load())
+}
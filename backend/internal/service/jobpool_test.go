@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// waitForQueueDepth 等待排队任务数达到 want
+func waitForQueueDepth(t *testing.T, p *jobPool, want int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for p.queueDepth() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("Queue depth = %d, want %d", p.queueDepth(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestJobPoolUnlimited(t *testing.T) {
+	for _, p := range []*jobPool{nil, newJobPool(0)} {
+		for i := 0; i < 3; i++ {
+			if _, err := p.acquire(context.Background(), time.Millisecond); err != nil {
+				t.Fatalf("acquire failed: %v", err)
+			}
+		}
+	}
+}
+
+func TestJobPoolQueuesUntilSlotFrees(t *testing.T) {
+	p := newJobPool(1)
+	release, err := p.acquire(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	acquired := make(chan error, 1)
+	go func() {
+		release, err := p.acquire(context.Background(), 0)
+		if err == nil {
+			release()
+		}
+		acquired <- err
+	}()
+
+	waitForQueueDepth(t, p, 1)
+	release()
+	if err := <-acquired; err != nil {
+		t.Fatalf("Queued acquire failed: %v", err)
+	}
+	if p.queueDepth() != 0 {
+		t.Errorf("Queue depth = %d after acquire, want 0", p.queueDepth())
+	}
+}
+
+func TestJobPoolSyncTimeoutReportsBusy(t *testing.T) {
+	p := newJobPool(1)
+	if _, err := p.acquire(context.Background(), 0); err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	if _, err := p.acquire(context.Background(), 10*time.Millisecond); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.acquire(ctx, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestCancelQueuedAsyncJob(t *testing.T) {
+	s := newJobContextTestService(t)
+	s.jobSlots = newJobPool(1)
+	release, _ := s.jobSlots.acquire(context.Background(), 0)
+	defer release()
+	createJob(t, s.db.DB(), "job_queued", models.JobStatusPending)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req := &v1.ExecuteRequest{Mode: models.ExecutionModeFireAndForget}
+		s.runJobAsync("job_queued", req, &preparedJob{algorithm: &models.Algorithm{ID: "alg_1"}, inputDir: t.TempDir()})
+	}()
+	waitForQueueDepth(t, s.jobSlots, 1)
+
+	resp, err := s.GetJobStatus(context.Background(), &v1.GetJobStatusRequest{JobId: "job_queued"})
+	if err != nil {
+		t.Fatalf("GetJobStatus failed: %v", err)
+	}
+	if resp.Status != "queued" || resp.QueueDepth != 1 {
+		t.Errorf("Queued job: status %s, queue depth %d", resp.Status, resp.QueueDepth)
+	}
+
+	if _, err := s.CancelJob(context.Background(), &v1.CancelJobRequest{JobId: "job_queued"}); err != nil {
+		t.Fatalf("CancelJob failed: %v", err)
+	}
+	<-done
+
+	var job models.Job
+	s.db.DB().First(&job, "id = ?", "job_queued")
+	if job.Status != models.JobStatusCancelled || job.StartedAt != nil {
+		t.Errorf("Unexpected job after cancelling in queue: status %s, started %v", job.Status, job.StartedAt)
+	}
+}
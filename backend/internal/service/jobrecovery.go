@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"algorithm-platform/internal/models"
+)
+
+// reasonInterruptedByRestart 服务重启时正在运行的任务的失败原因，容器的执行结果已无法收集
+const reasonInterruptedByRestart = "interrupted by server restart"
+
+// JobRecovery 启动时处理未结束任务的结果
+type JobRecovery struct {
+	Requeued int // 重新排队执行的任务
+	Failed   int // 无法恢复、标记为失败的任务
+}
+
+// RecoverJobs 处理本副本上次运行遗留的未结束任务，在服务启动时调用：
+// 运行中的任务随进程中断，标记为失败并清理容器；排队中的后台任务按保存的请求和原版本重新执行，
+// 同步任务的调用方已断开，和无法重新执行的任务一起标记为失败。其他副本的任务不受影响
+func (s *AlgorithmService) RecoverJobs(ctx context.Context) (*JobRecovery, error) {
+	db := s.db.DB().WithContext(ctx)
+
+	var jobs []models.Job
+	err := db.Where("status IN ? AND worker_id = ?", []string{models.JobStatusPending, models.JobStatusRunning}, s.cfg().Server.GetWorkerID()).
+		Order("created_at, id").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unfinished jobs: %w", err)
+	}
+
+	recovery := &JobRecovery{}
+	for i := range jobs {
+		job := &jobs[i]
+
+		reason := reasonInterruptedByRestart
+		if job.Status == models.JobStatusPending {
+			err := s.requeueJob(ctx, job)
+			if err == nil {
+				recovery.Requeued++
+				continue
+			}
+			reason = fmt.Sprintf("could not be requeued after server restart: %v", err)
+		} else {
+			s.stopJobContainers(job.ID, true)
+		}
+
+		if err := transitionJob(db, job, models.JobStatusFailed, map[string]interface{}{
+			"finished_at":    time.Now(),
+			"failure_reason": reason,
+		}); err != nil {
+			fmt.Printf("Warning: failed to mark job %s as failed: %v\n", job.ID, err)
+			continue
+		}
+		s.publishJobEvent(job, reason)
+		recovery.Failed++
+	}
+	return recovery, nil
+}
+
+// requeueJob 按任务记录中保存的请求重新准备输入，并以原任务 ID 和原版本放入后台执行
+func (s *AlgorithmService) requeueJob(ctx context.Context, job *models.Job) error {
+	req, err := decodeExecuteRequest(job.Request)
+	if err != nil {
+		return err
+	}
+	mode, err := resolveExecutionMode(req)
+	if err != nil {
+		return err
+	}
+	if !isBackgroundMode(mode) {
+		return errors.New("the synchronous caller is gone")
+	}
+	req.Mode = mode
+	req.IsAsync = false
+
+	prepared, err := s.prepareJob(ctx, job.ID, req, job.VersionID)
+	if err != nil {
+		return err
+	}
+	go s.runJobAsync(job.ID, req, prepared)
+	return nil
+}
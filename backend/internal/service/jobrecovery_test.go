@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+)
+
+func TestRecoverJobsFailsUnrecoverableJobs(t *testing.T) {
+	s := newJobContextTestService(t)
+	db := s.db.DB()
+	if err := db.AutoMigrate(&models.Algorithm{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	createJob(t, db, "job_running", models.JobStatusRunning)
+	createJob(t, db, "job_done", models.JobStatusCompleted)
+	createJob(t, db, "job_unrecorded", models.JobStatusPending)
+	for id, req := range map[string]*v1.ExecuteRequest{
+		"job_sync":  {AlgorithmId: "alg_1", Mode: models.ExecutionModeSync},
+		"job_async": {AlgorithmId: "alg_missing", Mode: models.ExecutionModeFireAndForget},
+	} {
+		job := &models.Job{ID: id, Status: models.JobStatusPending, Request: encodeExecuteRequest(req)}
+		if err := db.Create(job).Error; err != nil {
+			t.Fatalf("Failed to create job: %v", err)
+		}
+	}
+	if err := db.Model(&models.Job{}).Where("1 = 1").Update("worker_id", s.cfg().Server.GetWorkerID()).Error; err != nil {
+		t.Fatalf("Failed to assign jobs: %v", err)
+	}
+	// 其他副本的任务由它自己恢复
+	if err := db.Create(&models.Job{ID: "job_other_worker", Status: models.JobStatusRunning, WorkerID: "other-worker"}).Error; err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	recovery, err := s.RecoverJobs(context.Background())
+	if err != nil {
+		t.Fatalf("RecoverJobs failed: %v", err)
+	}
+	if recovery.Requeued != 0 || recovery.Failed != 4 {
+		t.Errorf("Recovery = %+v, want 4 failed", recovery)
+	}
+
+	wantReasons := map[string]string{
+		"job_running":    reasonInterruptedByRestart,
+		"job_unrecorded": "original request was not recorded",
+		"job_sync":       "synchronous caller is gone",
+		"job_async":      "algorithm not found",
+	}
+	for id, want := range wantReasons {
+		var job models.Job
+		db.First(&job, "id = ?", id)
+		if job.Status != models.JobStatusFailed || job.FinishedAt == nil || !strings.Contains(job.FailureReason, want) {
+			t.Errorf("%s: status %s, finished %v, reason %q (want %q)", id, job.Status, job.FinishedAt, job.FailureReason, want)
+		}
+	}
+
+	var done models.Job
+	db.First(&done, "id = ?", "job_done")
+	if done.Status != models.JobStatusCompleted || done.FailureReason != "" {
+		t.Errorf("Finished job was modified: %+v", done)
+	}
+	var other models.Job
+	db.First(&other, "id = ?", "job_other_worker")
+	if other.Status != models.JobStatusRunning {
+		t.Errorf("Job of another worker was modified: %+v", other)
+	}
+}
+
+func TestRecoverJobsRequeuesPendingJobsWithTheirVersion(t *testing.T) {
+	s, store := newExecutorTestService(t, map[string][]byte{"ver_1": []byte("print('v1')"), "ver_2": []byte("print('v2')")})
+	db := s.db.DB()
+
+	req := &v1.ExecuteRequest{AlgorithmId: "alg_1", Mode: models.ExecutionModeFireAndForget, UseImageTag: true}
+	job := &models.Job{ID: "job_queued", AlgorithmID: "alg_1", Status: models.JobStatusPending, VersionID: "ver_1",
+		WorkerID: s.cfg().Server.GetWorkerID(), Request: encodeExecuteRequest(req)}
+	if err := db.Create(job).Error; err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	recovery, err := s.RecoverJobs(context.Background())
+	if err != nil {
+		t.Fatalf("RecoverJobs failed: %v", err)
+	}
+	if recovery.Requeued != 1 || recovery.Failed != 0 {
+		t.Fatalf("Recovery = %+v, want 1 requeued", recovery)
+	}
+
+	job = waitForJobFinished(t, db, "job_queued")
+	if job.Status != models.JobStatusCompleted {
+		t.Fatalf("Requeued job status = %s: %s", job.Status, job.FailureReason)
+	}
+	// 按任务创建时的版本执行，而不是算法的当前版本
+	if result, _ := store.Object(resultObjectPath(&s.cfg().MinIO, job.ID)); string(result) != "print('v1')" {
+		t.Errorf("Requeued job ran %q, want the code of ver_1", result)
+	}
+}
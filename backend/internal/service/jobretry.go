@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gorm.io/gorm"
+)
+
+// retryableJobStatuses 可以重试的任务状态，运行中的任务不能重试
+var retryableJobStatuses = []string{
+	models.JobStatusCompleted,
+	models.JobStatusFailed,
+	models.JobStatusTimeout,
+	models.JobStatusCancelled,
+}
+
+// RetryJob 按原任务记录的执行请求（算法、输入、参数、资源配置）和代码版本创建新任务，并在后台执行
+func (s *AlgorithmService) RetryJob(ctx context.Context, req *v1.RetryJobRequest) (*v1.RetryJobResponse, error) {
+	var original models.Job
+	if err := s.db.DB().First(&original, "id = ?", req.JobId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "job %s not found", req.JobId)
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	retryReq, err := retryRequest(&original)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.execute(ctx, retryReq, original.ID, original.VersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.RetryJobResponse{
+		JobId:       resp.JobId,
+		RetriedFrom: original.ID,
+		Status:      resp.Status,
+		Message:     fmt.Sprintf("Retry of job %s queued as %s", original.ID, resp.JobId),
+	}, nil
+}
+
+// retryRequest 根据原任务构造重试的执行请求
+func retryRequest(job *models.Job) (*v1.ExecuteRequest, error) {
+	if !slices.Contains(retryableJobStatuses, job.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s is %s and cannot be retried", job.ID, job.Status)
+	}
+
+	req, err := decodeExecuteRequest(job.Request)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s cannot be retried: %v", job.ID, err)
+	}
+	// 重试总是放入后台执行，原请求的 webhook 仍然生效
+	req.IsAsync = false
+	req.Mode = models.ExecutionModeFireAndForget
+	if req.WebhookUrl != "" {
+		req.Mode = models.ExecutionModeAsync
+	}
+	return req, nil
+}
+
+// encodeExecuteRequest 序列化执行请求保存到任务记录，失败时返回空字符串（任务将不能重试）
+func encodeExecuteRequest(req *v1.ExecuteRequest) string {
+	data, err := protojson.Marshal(req)
+	if err != nil {
+		fmt.Printf("Warning: failed to encode execute request: %v\n", err)
+		return ""
+	}
+	return string(data)
+}
+
+// decodeExecuteRequest 解析任务记录中保存的执行请求
+func decodeExecuteRequest(data string) (*v1.ExecuteRequest, error) {
+	if data == "" {
+		return nil, errors.New("original request was not recorded")
+	}
+	req := &v1.ExecuteRequest{}
+	if err := protojson.Unmarshal([]byte(data), req); err != nil {
+		return nil, fmt.Errorf("failed to decode original request: %w", err)
+	}
+	return req, nil
+}
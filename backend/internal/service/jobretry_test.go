@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRetryRequestCopiesOriginal(t *testing.T) {
+	original := &v1.ExecuteRequest{
+		AlgorithmId:    "alg_1",
+		Mode:           "batch",
+		Params:         map[string]string{"threshold": "0.5"},
+		InputSource:    &v1.InputSource{Type: "preset", Url: "preset-data/input.csv"},
+		ResourceConfig: &v1.ResourceConfig{CpuLimit: 2, MemoryLimit: "1g"},
+		TimeoutSeconds: 60,
+	}
+	job := &models.Job{ID: "job_1", Status: models.JobStatusFailed, Request: encodeExecuteRequest(original)}
+
+	req, err := retryRequest(job)
+	if err != nil {
+		t.Fatalf("retryRequest failed: %v", err)
+	}
+	if req.Mode != models.ExecutionModeFireAndForget {
+		t.Errorf("Retries should run in the background, got mode %q", req.Mode)
+	}
+
+	req.Mode = original.Mode
+	if !proto.Equal(req, original) {
+		t.Errorf("Retry request differs from original:\n got %v\nwant %v", req, original)
+	}
+}
+
+func TestRetryRequestKeepsWebhook(t *testing.T) {
+	original := &v1.ExecuteRequest{AlgorithmId: "alg_1", WebhookUrl: "http://callback/hook"}
+	job := &models.Job{ID: "job_1", Status: models.JobStatusFailed, Request: encodeExecuteRequest(original)}
+
+	req, err := retryRequest(job)
+	if err != nil {
+		t.Fatalf("retryRequest failed: %v", err)
+	}
+	if req.Mode != models.ExecutionModeAsync || req.WebhookUrl != original.WebhookUrl {
+		t.Errorf("Retry with webhook: mode %q, webhook %q", req.Mode, req.WebhookUrl)
+	}
+}
+
+func TestRetryRequestRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		job  *models.Job
+	}{
+		{"running", &models.Job{ID: "job_1", Status: models.JobStatusRunning, Request: `{"algorithmId": "alg_1"}`}},
+		{"not recorded", &models.Job{ID: "job_2", Status: models.JobStatusFailed}},
+	}
+
+	for _, tt := range tests {
+		if _, err := retryRequest(tt.job); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("%s: expected FailedPrecondition, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestRetryJobRunsTheOriginalVersion(t *testing.T) {
+	s, store := newExecutorTestService(t, map[string][]byte{"ver_1": []byte("print('v1')"), "ver_2": []byte("print('v2')")})
+	req := &v1.ExecuteRequest{AlgorithmId: "alg_1", Mode: models.ExecutionModeSync, UseImageTag: true}
+	original := &models.Job{ID: "job_1", AlgorithmID: "alg_1", Status: models.JobStatusFailed, VersionID: "ver_1", Request: encodeExecuteRequest(req)}
+	if err := s.db.DB().Create(original).Error; err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	resp, err := s.RetryJob(context.Background(), &v1.RetryJobRequest{JobId: "job_1"})
+	if err != nil {
+		t.Fatalf("RetryJob failed: %v", err)
+	}
+	retry := waitForJobFinished(t, s.db.DB(), resp.JobId)
+	if retry.Status != models.JobStatusCompleted || retry.VersionID != "ver_1" {
+		t.Fatalf("Retry status %s on version %q, want completed on ver_1: %s", retry.Status, retry.VersionID, retry.FailureReason)
+	}
+	if result, _ := store.Object(resultObjectPath(&s.cfg().MinIO, retry.ID)); string(result) != "print('v1')" {
+		t.Errorf("Retry ran %q, want the code of ver_1", result)
+	}
+}
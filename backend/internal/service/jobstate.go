@@ -0,0 +1,50 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"algorithm-platform/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidJobTransition 任务状态迁移不合法（如已取消的任务被改回运行中）
+var ErrInvalidJobTransition = errors.New("invalid job status transition")
+
+// jobTransitionSources 每个目标状态允许的来源状态
+// pending → running → completed / failed / timeout / cancelled，pending 也可直接失败或取消
+var jobTransitionSources = map[string][]string{
+	models.JobStatusRunning:   {models.JobStatusPending},
+	models.JobStatusCompleted: {models.JobStatusRunning},
+	models.JobStatusFailed:    {models.JobStatusPending, models.JobStatusRunning},
+	models.JobStatusTimeout:   {models.JobStatusRunning},
+	models.JobStatusCancelled: {models.JobStatusPending, models.JobStatusRunning},
+}
+
+// transitionJob 将任务迁移到 to 状态并写入 fields，使用带状态条件的 UPDATE，
+// 并发修改导致来源状态不匹配时返回 ErrInvalidJobTransition。成功后 job 会被重新加载
+func transitionJob(db *gorm.DB, job *models.Job, to string, fields map[string]interface{}) error {
+	sources, ok := jobTransitionSources[to]
+	if !ok {
+		return fmt.Errorf("%w: unknown target status %q", ErrInvalidJobTransition, to)
+	}
+
+	updates := map[string]interface{}{"status": to}
+	for k, v := range fields {
+		updates[k] = v
+	}
+
+	result := db.Model(&models.Job{}).Where("id = ? AND status IN ?", job.ID, sources).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update job %s: %w", job.ID, result.Error)
+	}
+
+	if err := db.First(job, "id = ?", job.ID).Error; err != nil {
+		return fmt.Errorf("failed to reload job %s: %w", job.ID, err)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: job %s is %s, cannot move to %s", ErrInvalidJobTransition, job.ID, job.Status, to)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"gorm.io/gorm"
+)
+
+func createJob(t *testing.T, db *gorm.DB, id, status string) *models.Job {
+	t.Helper()
+	job := &models.Job{ID: id, Status: status}
+	if err := db.Create(job).Error; err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	return job
+}
+
+func TestTransitionJobValidMoves(t *testing.T) {
+	db := testutil.NewDB(t)
+	job := createJob(t, db, "job_1", models.JobStatusPending)
+
+	if err := transitionJob(db, job, models.JobStatusRunning, nil); err != nil {
+		t.Fatalf("pending -> running failed: %v", err)
+	}
+	if err := transitionJob(db, job, models.JobStatusCompleted, map[string]interface{}{"output_url": "results/job_1"}); err != nil {
+		t.Fatalf("running -> completed failed: %v", err)
+	}
+	if job.Status != models.JobStatusCompleted || job.OutputURL != "results/job_1" {
+		t.Errorf("Expected reloaded job with updates, got %+v", job)
+	}
+}
+
+func TestTransitionJobRejectsInvalidMoves(t *testing.T) {
+	tests := []struct {
+		from, to string
+	}{
+		{models.JobStatusCancelled, models.JobStatusRunning},
+		{models.JobStatusCompleted, models.JobStatusRunning},
+		{models.JobStatusFailed, models.JobStatusRunning},
+		{models.JobStatusTimeout, models.JobStatusRunning},
+		{models.JobStatusRunning, models.JobStatusRunning},
+		{models.JobStatusPending, models.JobStatusCompleted},
+		{models.JobStatusPending, models.JobStatusTimeout},
+		{models.JobStatusCancelled, models.JobStatusCompleted},
+		{models.JobStatusCompleted, models.JobStatusFailed},
+		{models.JobStatusFailed, models.JobStatusCancelled},
+		{models.JobStatusRunning, models.JobStatusPending},
+	}
+
+	db := testutil.NewDB(t)
+	for i, tt := range tests {
+		job := createJob(t, db, "job_"+string(rune('a'+i)), tt.from)
+
+		err := transitionJob(db, job, tt.to, map[string]interface{}{"log_url": "changed"})
+		if !errors.Is(err, ErrInvalidJobTransition) {
+			t.Errorf("%s -> %s: expected ErrInvalidJobTransition, got %v", tt.from, tt.to, err)
+			continue
+		}
+
+		var stored models.Job
+		db.First(&stored, "id = ?", job.ID)
+		if stored.Status != tt.from || stored.LogURL != "" {
+			t.Errorf("%s -> %s: job was modified: status=%s log_url=%q", tt.from, tt.to, stored.Status, stored.LogURL)
+		}
+	}
+}
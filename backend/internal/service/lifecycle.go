@@ -0,0 +1,58 @@
+package service
+
+import (
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// algorithmTransitions 允许的算法状态迁移
+var algorithmTransitions = map[string][]string{
+	models.AlgorithmStatusDraft:    {models.AlgorithmStatusReady, models.AlgorithmStatusDisabled},
+	models.AlgorithmStatusReady:    {models.AlgorithmStatusDisabled},
+	models.AlgorithmStatusDisabled: {models.AlgorithmStatusDraft, models.AlgorithmStatusReady},
+}
+
+// isValidAlgorithmStatus 判断状态值是否合法
+func isValidAlgorithmStatus(s string) bool {
+	_, ok := algorithmTransitions[s]
+	return ok
+}
+
+// transitionAlgorithmStatus 按状态机迁移算法状态，不允许的迁移返回 FailedPrecondition
+func transitionAlgorithmStatus(alg *models.Algorithm, to string) error {
+	from := algorithmStatus(alg)
+	if from == to {
+		return nil
+	}
+	for _, allowed := range algorithmTransitions[from] {
+		if allowed == to {
+			alg.Status = to
+			return nil
+		}
+	}
+	return status.Errorf(codes.FailedPrecondition, "algorithm %s cannot transition from %s to %s", alg.ID, from, to)
+}
+
+// checkAlgorithmRunnable 只有 ready 状态的算法可以执行
+func checkAlgorithmRunnable(alg *models.Algorithm) error {
+	switch algorithmStatus(alg) {
+	case models.AlgorithmStatusReady:
+		return nil
+	case models.AlgorithmStatusDraft:
+		return status.Errorf(codes.FailedPrecondition, "algorithm %s is a draft and has no runnable version yet", alg.ID)
+	case models.AlgorithmStatusDisabled:
+		return status.Errorf(codes.FailedPrecondition, "algorithm %s is disabled", alg.ID)
+	default:
+		return status.Errorf(codes.FailedPrecondition, "algorithm %s has unknown status %q", alg.ID, alg.Status)
+	}
+}
+
+// algorithmStatus 返回算法状态，未设置时（旧数据）视为 ready
+func algorithmStatus(alg *models.Algorithm) string {
+	if alg.Status == "" {
+		return models.AlgorithmStatusReady
+	}
+	return alg.Status
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"github.com/minio/minio-go/v7"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestTransitionAlgorithmStatus(t *testing.T) {
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{models.AlgorithmStatusDraft, models.AlgorithmStatusReady, true},
+		{models.AlgorithmStatusReady, models.AlgorithmStatusDisabled, true},
+		{models.AlgorithmStatusDisabled, models.AlgorithmStatusReady, true},
+		{models.AlgorithmStatusDisabled, models.AlgorithmStatusDraft, true},
+		{models.AlgorithmStatusReady, models.AlgorithmStatusReady, true},
+		{models.AlgorithmStatusReady, models.AlgorithmStatusDraft, false},
+		{"", models.AlgorithmStatusDisabled, true}, // 旧数据视为 ready
+	}
+
+	for _, tt := range tests {
+		alg := &models.Algorithm{ID: "alg_1", Status: tt.from}
+		err := transitionAlgorithmStatus(alg, tt.to)
+		if tt.ok {
+			if err != nil || algorithmStatus(alg) != tt.to {
+				t.Errorf("%q -> %q: unexpected result status=%q err=%v", tt.from, tt.to, alg.Status, err)
+			}
+			continue
+		}
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("%q -> %q: expected FailedPrecondition, got %v", tt.from, tt.to, err)
+		}
+		if alg.Status != tt.from {
+			t.Errorf("%q -> %q: status changed on rejected transition", tt.from, tt.to)
+		}
+	}
+}
+
+func TestCheckAlgorithmRunnable(t *testing.T) {
+	for _, s := range []string{models.AlgorithmStatusReady, ""} {
+		if err := checkAlgorithmRunnable(&models.Algorithm{Status: s}); err != nil {
+			t.Errorf("Expected status %q to be runnable, got %v", s, err)
+		}
+	}
+	for _, s := range []string{models.AlgorithmStatusDraft, models.AlgorithmStatusDisabled} {
+		if err := checkAlgorithmRunnable(&models.Algorithm{Status: s}); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("Expected status %q to be rejected with FailedPrecondition, got %v", s, err)
+		}
+	}
+}
+
+func newAlgorithmTestService(t *testing.T, client *minio.Client) (*ManagementService, *gorm.DB) {
+	t.Helper()
+	db := testutil.NewDB(t)
+	cfg := &config.Config{MinIO: config.MinIOConfig{Bucket: "bucket"}}
+	s := &ManagementService{db: database.NewWithDB(db, cfg), cfgStore: config.NewStore(cfg), minioClient: client, bucketName: cfg.MinIO.Bucket}
+	return s, db
+}
+
+func TestCreateAlgorithmWithCodeIsReady(t *testing.T) {
+	store, client := testutil.NewS3(t)
+	s, db := newAlgorithmTestService(t, client)
+
+	alg, err := s.CreateAlgorithm(context.Background(), &v1.CreateAlgorithmRequest{Name: "detector", FileName: "main.py", FileData: []byte("print(1)")})
+	if err != nil {
+		t.Fatalf("CreateAlgorithm failed: %v", err)
+	}
+	if alg.Status != models.AlgorithmStatusReady || alg.CurrentVersionId == "" {
+		t.Errorf("Expected a ready algorithm with a current version, got status %q version %q", alg.Status, alg.CurrentVersionId)
+	}
+	var version models.Version
+	if err := db.First(&version, "id = ?", alg.CurrentVersionId).Error; err != nil {
+		t.Fatalf("Version not saved: %v", err)
+	}
+	if _, ok := store.Object(version.MinioPath); !ok {
+		t.Errorf("Code not uploaded to %s", version.MinioPath)
+	}
+}
+
+func TestCreateAlgorithmUploadFailureCreatesNothing(t *testing.T) {
+	store, client := testutil.NewS3(t)
+	store.FailPuts()
+	s, db := newAlgorithmTestService(t, client)
+
+	if _, err := s.CreateAlgorithm(context.Background(), &v1.CreateAlgorithmRequest{Name: "detector", FileName: "main.py", FileData: []byte("print(1)")}); err == nil {
+		t.Fatal("Expected CreateAlgorithm to fail when the code upload fails")
+	}
+	var algorithms, versions int64
+	db.Model(&models.Algorithm{}).Count(&algorithms)
+	db.Model(&models.Version{}).Count(&versions)
+	if algorithms != 0 || versions != 0 {
+		t.Errorf("Expected nothing to be saved, got %d algorithms and %d versions", algorithms, versions)
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"runtime"
@@ -12,13 +13,22 @@ import (
 
 	"algorithm-platform/internal/config"
 	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/keys"
+	"algorithm-platform/internal/maintenance"
+	"algorithm-platform/internal/metrics"
 	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/pagination"
+	"algorithm-platform/internal/scheduler"
+	"algorithm-platform/pkg/storage"
 
 	v1 "algorithm-platform/api/v1/proto"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 type ManagementService struct {
@@ -28,37 +38,73 @@ type ManagementService struct {
 	db          *database.Database
 	minioClient *minio.Client
 	bucketName  string
-	cfg         *config.Config
+	cfgStore    *config.Store
+	warmPool    *scheduler.WarmPool
+	scheduler   *scheduler.Scheduler
+	maintenance *maintenance.Mode
+	pageTokens  *pagination.Codec
+
+	// 概览统计的短时缓存，避免仪表盘频繁刷新时重复统计
+	overviewMu       sync.Mutex
+	overviewCache    *v1.GetOverviewResponse
+	overviewCachedAt time.Time
+
+	// 使用统计按查询参数缓存
+	usageMu    sync.Mutex
+	usageCache map[usageCacheKey]cachedUsage
+
+	// 相关算法推荐按算法和数量缓存
+	relatedMu    sync.Mutex
+	relatedCache map[relatedCacheKey]cachedRelated
 }
 
-func NewManagementService(db *database.Database, cfg *config.Config) *ManagementService {
+// cfg 返回当前配置快照，配置重载后对新请求生效
+func (s *ManagementService) cfg() *config.Config {
+	return s.cfgStore.Load()
+}
+
+// overviewCacheTTL 概览统计缓存时间
+const overviewCacheTTL = 10 * time.Second
+
+func NewManagementService(db *database.Database, cfgStore *config.Store, warmPool *scheduler.WarmPool, sched *scheduler.Scheduler, mode *maintenance.Mode) *ManagementService {
+	cfg := cfgStore.Load()
 	minioClient, err := minio.New(cfg.MinIO.Endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(cfg.MinIO.AccessKeyID, cfg.MinIO.SecretAccessKey, ""),
-		Secure: cfg.MinIO.UseSSL,
+		Creds:     credentials.NewStaticV4(cfg.MinIO.AccessKeyID, cfg.MinIO.SecretAccessKey, ""),
+		Secure:    cfg.MinIO.UseSSL,
+		Transport: metrics.MinIOTransport(cfg.MinIO.UseSSL),
 	})
 	if err != nil {
 		fmt.Printf("Failed to initialize MinIO client: %v\n", err)
 	}
 
 	bucketName := cfg.MinIO.Bucket
-	ctx := context.Background()
 	if minioClient != nil {
-		err = minioClient.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{})
-		if err != nil {
-			exists, errBucketExists := minioClient.BucketExists(ctx, bucketName)
-			if errBucketExists == nil && exists {
-			} else {
-				fmt.Printf("Failed to create bucket: %v\n", err)
-			}
+		// MinIO 尚未就绪时可稍后通过 EnsureStorage 重试
+		if _, err := storage.EnsureBucket(context.Background(), minioClient, bucketName); err != nil {
+			fmt.Printf("Failed to create bucket: %v\n", err)
 		}
 	}
 
 	return &ManagementService{
-		db:          db,
-		minioClient: minioClient,
-		bucketName:  bucketName,
-		cfg:         cfg,
+		db:           db,
+		minioClient:  minioClient,
+		bucketName:   bucketName,
+		cfgStore:     cfgStore,
+		warmPool:     warmPool,
+		scheduler:    sched,
+		maintenance:  mode,
+		pageTokens:   pagination.NewCodec(cfg.Server.PageTokenSecret),
+		usageCache:   make(map[usageCacheKey]cachedUsage),
+		relatedCache: make(map[relatedCacheKey]cachedRelated),
+	}
+}
+
+// prewarmAlgorithmImage 在后台预拉取算法的运行镜像
+func (s *ManagementService) prewarmAlgorithmImage(dbAlg *models.Algorithm) {
+	if s.warmPool == nil {
+		return
 	}
+	s.warmPool.Prewarm(s.cfg().Docker.GetRuntimeImage(dbAlg.Language))
 }
 
 // modelToProto 将数据库模型转换为proto格式
@@ -73,7 +119,7 @@ func modelToProto(dbAlg *models.Algorithm) *v1.Algorithm {
 		Name:             dbAlg.Name,
 		Description:      dbAlg.Description,
 		Language:         dbAlg.Language,
-		Platform:         v1.Platform(v1.Platform_value["PLATFORM_"+strings.ToUpper(dbAlg.Platform)]),
+		Platform:         parseAlgorithmPlatform(dbAlg.Platform),
 		Category:         dbAlg.Category,
 		Entrypoint:       dbAlg.Entrypoint,
 		Tags:             tags,
@@ -81,11 +127,19 @@ func modelToProto(dbAlg *models.Algorithm) *v1.Algorithm {
 		CurrentVersionId: dbAlg.CurrentVersionID,
 		CreatedAt:        timestamppb.New(dbAlg.CreatedAt),
 		UpdatedAt:        timestamppb.New(dbAlg.UpdatedAt),
+		DefaultCpuLimit:  float32(dbAlg.DefaultCPULimit),
+		DefaultMemoryMb:  int32(dbAlg.DefaultMemoryMB),
+		Status:           algorithmStatus(dbAlg),
+		DisabledBy:       dbAlg.DisabledBy,
+		DisabledReason:   dbAlg.DisabledReason,
+		DisabledAt:       timestampProto(dbAlg.DisabledAt),
+		ParamsSchema:     dbAlg.ParamsSchema,
+		JobHistoryLimit:  int32(dbAlg.JobHistoryLimit),
 	}
 }
 
 // versionModelToProto 将版本模型转换为proto格式
-func versionModelToProto(dbVer *models.Version) *v1.Version {
+func versionModelToProto(dbVer *models.Version, minioCfg *config.MinIOConfig) *v1.Version {
 	return &v1.Version{
 		Id:             dbVer.ID,
 		AlgorithmId:    dbVer.AlgorithmID,
@@ -94,17 +148,22 @@ func versionModelToProto(dbVer *models.Version) *v1.Version {
 		SourceCodeFile: dbVer.SourceCodeFile,
 		CommitMessage:  dbVer.CommitMessage,
 		CreatedAt:      timestamppb.New(dbVer.CreatedAt),
+		DownloadUrl:    externalObjectURL(minioCfg, dbVer.MinioPath),
+		ImageDigest:    dbVer.ImageDigest,
 	}
 }
 
 // presetDataModelToProto 将预设数据模型转换为proto格式
-func presetDataModelToProto(dbData *models.PresetData, scheme, endpoint, bucket string) *v1.PresetData {
+func presetDataModelToProto(dbData *models.PresetData, minioCfg *config.MinIOConfig) *v1.PresetData {
 	return &v1.PresetData{
-		Id:        dbData.ID,
-		Filename:  dbData.Filename,
-		Category:  dbData.Category,
-		MinioUrl:  fmt.Sprintf("%s://%s/%s/%s", scheme, endpoint, bucket, dbData.MinioPath),
-		CreatedAt: timestamppb.New(dbData.CreatedAt),
+		Id:          dbData.ID,
+		Filename:    dbData.Filename,
+		Category:    dbData.Category,
+		MinioUrl:    externalObjectURL(minioCfg, presetDataObjectPath(minioCfg.Bucket, dbData)),
+		CreatedAt:   timestamppb.New(dbData.CreatedAt),
+		Checksum:    dbData.Checksum,
+		Size:        dbData.Size,
+		ContentType: dbData.ContentType,
 	}
 }
 
@@ -112,43 +171,63 @@ func (s *ManagementService) CreateAlgorithm(ctx context.Context, req *v1.CreateA
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := validateResourceLimits(float64(req.DefaultCpuLimit), int(req.DefaultMemoryMb), &s.cfg().Docker); err != nil {
+		return nil, err
+	}
+	if err := checkUploadSize(&s.cfg().Server, len(req.FileData)); err != nil {
+		return nil, err
+	}
+
+	if err := validateParamsSchema(req.ParamsSchema); err != nil {
+		return nil, err
+	}
+	if err := validateJobHistoryLimit(req.JobHistoryLimit); err != nil {
+		return nil, err
+	}
+	if s.cfg().Database.UniqueAlgorithmNames {
+		if err := checkAlgorithmNameAvailable(s.db.DB(), req.Name, ""); err != nil {
+			return nil, err
+		}
+	}
+
 	id := fmt.Sprintf("alg_%d", time.Now().UnixNano())
 	now := time.Now()
 
 	// 创建数据库模型
 	dbAlgorithm := &models.Algorithm{
-		ID:           id,
-		Name:         req.Name,
-		Description:  req.Description,
-		Language:     req.Language,
-		Platform:     strings.ToLower(req.Platform.String()),
-		Category:     "",
-		Entrypoint:   req.Entrypoint,
-		Tags:         strings.Join(req.Tags, ","),
-		PresetDataID: req.PresetDataId,
-		CreatedAt:    now,
-		UpdatedAt:    now,
-	}
-
-	// 保存到数据库
-	if err := s.db.DB().Create(dbAlgorithm).Error; err != nil {
-		return nil, fmt.Errorf("failed to create algorithm: %w", err)
+		ID:              id,
+		Name:            req.Name,
+		Description:     req.Description,
+		Language:        req.Language,
+		Platform:        strings.ToLower(req.Platform.String()),
+		Category:        "",
+		Entrypoint:      req.Entrypoint,
+		Tags:            strings.Join(req.Tags, ","),
+		PresetDataID:    req.PresetDataId,
+		DefaultCPULimit: float64(req.DefaultCpuLimit),
+		DefaultMemoryMB: int(req.DefaultMemoryMb),
+		ParamsSchema:    req.ParamsSchema,
+		JobHistoryLimit: int(req.JobHistoryLimit),
+		Status:          models.AlgorithmStatusDraft, // 上传代码版本后变为 ready
+		CreatedAt:       now,
+		UpdatedAt:       now,
 	}
 
-	// 处理文件上传
+	// 先上传代码，上传失败时不创建任何记录，避免版本指向不存在的对象
+	var dbVersion *models.Version
 	if len(req.FileData) > 0 && req.FileName != "" {
-		minioPath := fmt.Sprintf("algorithms/%s/v1/%s", id, req.FileName)
+		minioPath := s.cfg().MinIO.ObjectKey(keys.AlgorithmCode(id, 1, req.FileName))
 		if s.minioClient != nil {
 			_, err := s.minioClient.PutObject(ctx, s.bucketName, minioPath, bytes.NewReader(req.FileData), int64(len(req.FileData)), minio.PutObjectOptions{
-				ContentType: "application/zip",
+				ContentType: detectContentType(req.FileName, req.FileData[:min(len(req.FileData), sniffLen)]),
 			})
 			if err != nil {
 				fmt.Printf("Failed to upload file to MinIO: %v\n", err)
+				return nil, fmt.Errorf("failed to upload file: %w", err)
 			}
 		}
 
-		// 创建版本记录
-		dbVersion := &models.Version{
+		dbVersion = &models.Version{
 			ID:             fmt.Sprintf("ver_%d", time.Now().UnixNano()),
 			AlgorithmID:    id,
 			VersionNumber:  1,
@@ -157,16 +236,39 @@ func (s *ManagementService) CreateAlgorithm(ctx context.Context, req *v1.CreateA
 			CommitMessage:  "Initial version",
 			CreatedAt:      now,
 		}
+		// 有了代码版本即可执行
+		dbAlgorithm.CurrentVersionID = dbVersion.ID
+		dbAlgorithm.Status = models.AlgorithmStatusReady
+	}
 
-		if err := s.db.DB().Create(dbVersion).Error; err != nil {
-			fmt.Printf("Failed to create version: %v\n", err)
-		} else {
-			// 更新算法的当前版本ID
-			dbAlgorithm.CurrentVersionID = dbVersion.ID
-			s.db.DB().Save(dbAlgorithm)
+	// 算法和首个版本在同一事务中保存，版本写入失败时算法也不会以 ready 状态留下
+	nameTaken := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(dbAlgorithm).Error; err != nil {
+			nameTaken = isUniqueViolation(err)
+			return err
+		}
+		if dbVersion != nil {
+			if err := tx.Create(dbVersion).Error; err != nil {
+				return fmt.Errorf("failed to create version: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if dbVersion != nil && s.minioClient != nil {
+			if rmErr := s.minioClient.RemoveObject(ctx, s.bucketName, dbVersion.MinioPath, minio.RemoveObjectOptions{}); rmErr != nil {
+				fmt.Printf("Warning: failed to remove uploaded code %s: %v\n", dbVersion.MinioPath, rmErr)
+			}
+		}
+		if nameTaken {
+			return nil, status.Errorf(codes.AlreadyExists, "algorithm name %q already exists", req.Name)
 		}
+		return nil, fmt.Errorf("failed to create algorithm: %w", err)
 	}
 
+	s.prewarmAlgorithmImage(dbAlgorithm)
+
 	return modelToProto(dbAlgorithm), nil
 }
 
@@ -174,17 +276,39 @@ func (s *ManagementService) UpdateAlgorithm(ctx context.Context, req *v1.UpdateA
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := validateResourceLimits(float64(req.DefaultCpuLimit), int(req.DefaultMemoryMb), &s.cfg().Docker); err != nil {
+		return nil, err
+	}
+	if err := validateParamsSchema(req.ParamsSchema); err != nil {
+		return nil, err
+	}
+	if err := validateJobHistoryLimit(req.JobHistoryLimit); err != nil {
+		return nil, err
+	}
+
 	var dbAlgorithm models.Algorithm
 	if err := s.db.DB().First(&dbAlgorithm, "id = ?", req.Id).Error; err != nil {
 		return nil, fmt.Errorf("algorithm not found: %w", err)
 	}
+	if req.Name != dbAlgorithm.Name && s.cfg().Database.UniqueAlgorithmNames {
+		if err := checkAlgorithmNameAvailable(s.db.DB(), req.Name, dbAlgorithm.ID); err != nil {
+			return nil, err
+		}
+	}
 
 	dbAlgorithm.Name = req.Name
 	dbAlgorithm.Description = req.Description
 	dbAlgorithm.Tags = strings.Join(req.Tags, ",")
+	dbAlgorithm.DefaultCPULimit = float64(req.DefaultCpuLimit)
+	dbAlgorithm.DefaultMemoryMB = int(req.DefaultMemoryMb)
+	dbAlgorithm.ParamsSchema = req.ParamsSchema
+	dbAlgorithm.JobHistoryLimit = int(req.JobHistoryLimit)
 	dbAlgorithm.UpdatedAt = time.Now()
 
-	if err := s.db.DB().Save(&dbAlgorithm).Error; err != nil {
+	if err := s.db.WithRetry(ctx, func(db *gorm.DB) error { return db.Save(&dbAlgorithm).Error }); err != nil {
+		if isUniqueViolation(err) {
+			return nil, status.Errorf(codes.AlreadyExists, "algorithm name %q already exists", req.Name)
+		}
 		return nil, fmt.Errorf("failed to update algorithm: %w", err)
 	}
 
@@ -195,8 +319,37 @@ func (s *ManagementService) ListAlgorithms(ctx context.Context, req *v1.ListAlgo
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	query := s.db.DB().Model(&models.Algorithm{})
+	if req.Status != "" {
+		if !isValidAlgorithmStatus(req.Status) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid algorithm status: %s", req.Status)
+		}
+		query = query.Where("status = ?", req.Status)
+	}
+	search := strings.TrimSpace(req.Query)
+	query = filterAlgorithmsByQuery(query, search)
+	tags := normalizedTagList(req.Tags)
+	query = filterAlgorithmsByTags(query, tags)
+
+	p, err := s.resolvePage(pageEndpointAlgorithms, req.PageToken, req.PageSize, defaultAlgorithmPageSize, map[string]string{
+		"status": req.Status,
+		"query":  search,
+		"tags":   strings.Join(tags, ","),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := p.withLegacyPage(req.PageToken, req.Page); err != nil {
+		return nil, err
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count algorithms: %w", err)
+	}
+
 	var dbAlgorithms []models.Algorithm
-	if err := s.db.DB().Find(&dbAlgorithms).Error; err != nil {
+	if err := p.apply(query.Order("created_at DESC, id DESC")).Find(&dbAlgorithms).Error; err != nil {
 		return nil, fmt.Errorf("failed to list algorithms: %w", err)
 	}
 
@@ -206,11 +359,187 @@ func (s *ManagementService) ListAlgorithms(ctx context.Context, req *v1.ListAlgo
 	}
 
 	return &v1.ListAlgorithmsResponse{
-		Algorithms: algorithms,
-		Total:      int32(len(algorithms)),
+		Algorithms:    algorithms,
+		Total:         int32(total),
+		NextPageToken: s.nextPageToken(p, len(algorithms), total),
 	}, nil
 }
 
+// DisableAlgorithm 停用算法，拒绝新的执行请求，已在运行的任务不受影响
+func (s *ManagementService) DisableAlgorithm(ctx context.Context, req *v1.DisableAlgorithmRequest) (*v1.Algorithm, error) {
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required when disabling an algorithm")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var dbAlgorithm models.Algorithm
+	if err := s.db.DB().First(&dbAlgorithm, "id = ?", req.Id).Error; err != nil {
+		return nil, fmt.Errorf("algorithm not found: %w", err)
+	}
+
+	if err := transitionAlgorithmStatus(&dbAlgorithm, models.AlgorithmStatusDisabled); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	dbAlgorithm.DisabledBy = req.DisabledBy
+	dbAlgorithm.DisabledReason = req.Reason
+	dbAlgorithm.DisabledAt = &now
+	dbAlgorithm.UpdatedAt = now
+
+	if err := s.db.WithRetry(ctx, func(db *gorm.DB) error { return db.Save(&dbAlgorithm).Error }); err != nil {
+		return nil, fmt.Errorf("failed to disable algorithm: %w", err)
+	}
+
+	fmt.Printf("Algorithm %s disabled by %q: %s\n", dbAlgorithm.ID, req.DisabledBy, req.Reason)
+	return modelToProto(&dbAlgorithm), nil
+}
+
+// EnableAlgorithm 重新启用算法，没有代码版本的算法恢复为草稿状态
+func (s *ManagementService) EnableAlgorithm(ctx context.Context, req *v1.EnableAlgorithmRequest) (*v1.Algorithm, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var dbAlgorithm models.Algorithm
+	if err := s.db.DB().First(&dbAlgorithm, "id = ?", req.Id).Error; err != nil {
+		return nil, fmt.Errorf("algorithm not found: %w", err)
+	}
+
+	if algorithmStatus(&dbAlgorithm) != models.AlgorithmStatusDisabled {
+		return modelToProto(&dbAlgorithm), nil
+	}
+
+	target := models.AlgorithmStatusReady
+	if dbAlgorithm.CurrentVersionID == "" {
+		target = models.AlgorithmStatusDraft
+	}
+	if err := transitionAlgorithmStatus(&dbAlgorithm, target); err != nil {
+		return nil, err
+	}
+
+	dbAlgorithm.DisabledBy = ""
+	dbAlgorithm.DisabledReason = ""
+	dbAlgorithm.DisabledAt = nil
+	dbAlgorithm.UpdatedAt = time.Now()
+
+	if err := s.db.WithRetry(ctx, func(db *gorm.DB) error { return db.Save(&dbAlgorithm).Error }); err != nil {
+		return nil, fmt.Errorf("failed to enable algorithm: %w", err)
+	}
+
+	return modelToProto(&dbAlgorithm), nil
+}
+
+// errAlgorithmHasActiveJobs 算法还有未结束的任务，不能删除
+var errAlgorithmHasActiveJobs = errors.New("algorithm has active jobs")
+
+// DeleteAlgorithm 删除算法及其所有版本，并清理 MinIO 中 algorithms/<id>/ 下的代码文件
+// 有未结束任务的算法不能删除；历史任务记录保留
+func (s *ManagementService) DeleteAlgorithm(ctx context.Context, req *v1.DeleteAlgorithmRequest) (*v1.DeleteAlgorithmResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var versions []models.Version
+	err := s.db.WithRetry(ctx, func(db *gorm.DB) error {
+		var err error
+		versions, err = deleteAlgorithmRows(db.WithContext(ctx), req.Id)
+		return err
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "algorithm %s not found", req.Id)
+	}
+	if errors.Is(err, errAlgorithmHasActiveJobs) {
+		return nil, status.Errorf(codes.FailedPrecondition, "algorithm %s has pending or running jobs, cancel or wait for them before deleting", req.Id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &v1.DeleteAlgorithmResponse{Id: req.Id, DeletedVersions: int32(len(versions))}
+	if s.minioClient != nil {
+		var listed []string
+		prefix := s.cfg().MinIO.ObjectKey(keys.AlgorithmPrefix(req.Id))
+		for object := range s.minioClient.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+			if object.Err != nil {
+				fmt.Printf("Warning: failed to list objects of algorithm %s: %v\n", req.Id, object.Err)
+				break
+			}
+			listed = append(listed, object.Key)
+		}
+
+		keys := algorithmObjectKeys(s.bucketName, listed, versions)
+		failed := storage.RemoveObjects(ctx, s.minioClient, s.bucketName, keys)
+		for key, removeErr := range failed {
+			fmt.Printf("Warning: failed to delete object %s of algorithm %s: %v\n", key, req.Id, removeErr)
+		}
+		resp.DeletedObjects = int32(len(keys) - len(failed))
+		resp.FailedObjects = int32(len(failed))
+	}
+
+	fmt.Printf("Algorithm %s deleted (%d versions, %d objects)\n", req.Id, resp.DeletedVersions, resp.DeletedObjects)
+	return resp, nil
+}
+
+// deleteAlgorithmRows 在一个事务中删除算法和它的版本记录，返回被删除的版本
+func deleteAlgorithmRows(db *gorm.DB, algorithmID string) ([]models.Version, error) {
+	var versions []models.Version
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var algorithm models.Algorithm
+		if err := tx.First(&algorithm, "id = ?", algorithmID).Error; err != nil {
+			return err
+		}
+
+		var active int64
+		if err := tx.Model(&models.Job{}).
+			Where("algorithm_id = ? AND status NOT IN ?", algorithmID, terminalJobStatuses).
+			Count(&active).Error; err != nil {
+			return fmt.Errorf("failed to check active jobs: %w", err)
+		}
+		if active > 0 {
+			return errAlgorithmHasActiveJobs
+		}
+
+		if err := tx.Where("algorithm_id = ?", algorithmID).Find(&versions).Error; err != nil {
+			return fmt.Errorf("failed to list versions: %w", err)
+		}
+		if err := tx.Where("algorithm_id = ?", algorithmID).Delete(&models.Version{}).Error; err != nil {
+			return fmt.Errorf("failed to delete versions: %w", err)
+		}
+		if err := tx.Delete(&algorithm).Error; err != nil {
+			return fmt.Errorf("failed to delete algorithm: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// algorithmObjectKeys 合并前缀下列出的对象和版本记录中的代码路径（兼容不在前缀下的旧数据），去重
+func algorithmObjectKeys(bucket string, listed []string, versions []models.Version) []string {
+	seen := make(map[string]bool, len(listed)+len(versions))
+	keys := make([]string, 0, len(listed)+len(versions))
+	add := func(key string) {
+		if key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range listed {
+		add(key)
+	}
+	for _, version := range versions {
+		add(objectPathFromURL(bucket, version.MinioPath))
+	}
+	return keys
+}
+
 func (s *ManagementService) GetAlgorithm(ctx context.Context, req *v1.GetAlgorithmRequest) (*v1.GetAlgorithmResponse, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -220,23 +549,53 @@ func (s *ManagementService) GetAlgorithm(ctx context.Context, req *v1.GetAlgorit
 		return nil, fmt.Errorf("algorithm not found: %w", err)
 	}
 
+	return s.algorithmDetail(&dbAlgorithm)
+}
+
+// GetAlgorithmByName 按名称查找算法，同名算法不唯一时返回 FailedPrecondition
+func (s *ManagementService) GetAlgorithmByName(ctx context.Context, req *v1.GetAlgorithmByNameRequest) (*v1.GetAlgorithmResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	dbAlgorithm, err := findAlgorithmByName(s.db.DB(), req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.algorithmDetail(dbAlgorithm)
+}
+
+// algorithmDetail 组装算法详情，包括版本列表和运行镜像状态
+func (s *ManagementService) algorithmDetail(dbAlgorithm *models.Algorithm) (*v1.GetAlgorithmResponse, error) {
 	var dbVersions []models.Version
-	if err := s.db.DB().Where("algorithm_id = ?", req.Id).Order("version_number ASC").Find(&dbVersions).Error; err != nil {
+	if err := s.db.DB().Where("algorithm_id = ?", dbAlgorithm.ID).Order("version_number ASC, id ASC").Find(&dbVersions).Error; err != nil {
 		return nil, fmt.Errorf("failed to get versions: %w", err)
 	}
 
 	versions := make([]*v1.Version, len(dbVersions))
 	for i, dbVer := range dbVersions {
-		versions[i] = versionModelToProto(&dbVer)
+		versions[i] = versionModelToProto(&dbVer, &s.cfg().MinIO)
+	}
+
+	image := s.cfg().Docker.GetRuntimeImage(dbAlgorithm.Language)
+	imageStatus := scheduler.ImageStatusUnknown
+	if s.warmPool != nil && image != "" {
+		imageStatus = s.warmPool.Status(image)
 	}
 
 	return &v1.GetAlgorithmResponse{
-		Algorithm: modelToProto(&dbAlgorithm),
-		Versions:  versions,
+		Algorithm:   modelToProto(dbAlgorithm),
+		Versions:    versions,
+		Image:       image,
+		ImageStatus: imageStatus,
 	}, nil
 }
 
 func (s *ManagementService) CreateVersion(ctx context.Context, req *v1.CreateVersionRequest) (*v1.Version, error) {
+	if err := checkUploadSize(&s.cfg().Server, len(req.FileData)); err != nil {
+		return nil, err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -253,12 +612,12 @@ func (s *ManagementService) CreateVersion(ctx context.Context, req *v1.CreateVer
 		nextVersionNumber = lastVersion.VersionNumber + 1
 	}
 
-	minioPath := req.SourceCodeZipUrl
+	minioPath := objectPathFromURL(s.bucketName, req.SourceCodeZipUrl)
 	if len(req.FileData) > 0 && req.FileName != "" {
-		minioPath = fmt.Sprintf("algorithms/%s/v%d/%s", req.AlgorithmId, nextVersionNumber, req.FileName)
+		minioPath = s.cfg().MinIO.ObjectKey(keys.AlgorithmCode(req.AlgorithmId, nextVersionNumber, req.FileName))
 		if s.minioClient != nil {
 			_, err := s.minioClient.PutObject(ctx, s.bucketName, minioPath, bytes.NewReader(req.FileData), int64(len(req.FileData)), minio.PutObjectOptions{
-				ContentType: "application/zip",
+				ContentType: detectContentType(req.FileName, req.FileData[:min(len(req.FileData), sniffLen)]),
 			})
 			if err != nil {
 				fmt.Printf("Failed to upload file to MinIO: %v\n", err)
@@ -277,15 +636,22 @@ func (s *ManagementService) CreateVersion(ctx context.Context, req *v1.CreateVer
 		CreatedAt:      time.Now(),
 	}
 
-	if err := s.db.DB().Create(dbVersion).Error; err != nil {
+	if err := s.db.WithRetry(ctx, func(db *gorm.DB) error { return db.Create(dbVersion).Error }); err != nil {
 		return nil, fmt.Errorf("failed to create version: %w", err)
 	}
 
-	// 更新算法的当前版本
+	// 更新算法的当前版本，草稿状态的算法在上传首个版本后变为 ready
 	dbAlgorithm.CurrentVersionID = dbVersion.ID
-	s.db.DB().Save(&dbAlgorithm)
+	if algorithmStatus(&dbAlgorithm) == models.AlgorithmStatusDraft {
+		dbAlgorithm.Status = models.AlgorithmStatusReady
+	}
+	if err := s.db.WithRetry(ctx, func(db *gorm.DB) error { return db.Save(&dbAlgorithm).Error }); err != nil {
+		fmt.Printf("Failed to set current version: %v\n", err)
+	}
 
-	return versionModelToProto(dbVersion), nil
+	s.prewarmAlgorithmImage(&dbAlgorithm)
+
+	return versionModelToProto(dbVersion, &s.cfg().MinIO), nil
 }
 
 func (s *ManagementService) RollbackVersion(ctx context.Context, req *v1.RollbackVersionRequest) (*v1.Algorithm, error) {
@@ -305,7 +671,7 @@ func (s *ManagementService) RollbackVersion(ctx context.Context, req *v1.Rollbac
 	dbAlgorithm.CurrentVersionID = req.VersionId
 	dbAlgorithm.UpdatedAt = time.Now()
 
-	if err := s.db.DB().Save(&dbAlgorithm).Error; err != nil {
+	if err := s.db.WithRetry(ctx, func(db *gorm.DB) error { return db.Save(&dbAlgorithm).Error }); err != nil {
 		return nil, fmt.Errorf("failed to rollback version: %w", err)
 	}
 
@@ -313,23 +679,36 @@ func (s *ManagementService) RollbackVersion(ctx context.Context, req *v1.Rollbac
 }
 
 func (s *ManagementService) UploadPresetData(ctx context.Context, req *v1.UploadDataRequest) (*v1.UploadDataResponse, error) {
+	if err := checkUploadSize(&s.cfg().Server, len(req.FileData)); err != nil {
+		return nil, err
+	}
+	if req.MinioPath != "" && (len(req.FileData) == 0 || req.Filename == "") {
+		return s.registerUploadedPresetData(ctx, req)
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	id := fmt.Sprintf("data_%d", time.Now().UnixNano())
-	var minioPath string
+	var minioPath, checksum, contentType string
 
 	if len(req.FileData) > 0 && req.Filename != "" {
-		minioPath = fmt.Sprintf("preset-data/%s", req.Filename)
+		checksum = sha256Hex(req.FileData)
+		head := req.FileData[:min(len(req.FileData), sniffLen)]
+		if err := validatePresetDataContent(&s.cfg().Server, req.Category, req.Filename, head); err != nil {
+			return nil, err
+		}
+		contentType = detectContentType(req.Filename, head)
+		minioPath = presetDataObjectKey(&s.cfg().MinIO, id, req.Filename)
 		if s.minioClient != nil {
-			_, err := s.minioClient.PutObject(ctx, s.bucketName, minioPath, bytes.NewReader(req.FileData), int64(len(req.FileData)), minio.PutObjectOptions{})
+			_, err := s.minioClient.PutObject(ctx, s.bucketName, minioPath, bytes.NewReader(req.FileData), int64(len(req.FileData)), minio.PutObjectOptions{
+				ContentType: contentType,
+			})
 			if err != nil {
 				fmt.Printf("Failed to upload preset data to MinIO: %v\n", err)
 				return nil, fmt.Errorf("failed to upload file: %v", err)
 			}
 		}
-	} else if req.MinioPath != "" {
-		minioPath = req.MinioPath
 	}
 
 	if minioPath == "" {
@@ -338,27 +717,25 @@ func (s *ManagementService) UploadPresetData(ctx context.Context, req *v1.Upload
 
 	// 数据库只保存路径，不保存完整URL
 	dbPresetData := &models.PresetData{
-		ID:        id,
-		Filename:  req.Filename,
-		Category:  req.Category,
-		MinioPath: minioPath, // 只保存路径，如: preset-data/file.zip
-		CreatedAt: time.Now(),
+		ID:          id,
+		Filename:    req.Filename,
+		Category:    req.Category,
+		MinioPath:   minioPath, // 只保存路径，如: preset-data/data_123/file.zip
+		Checksum:    checksum,
+		Size:        int64(len(req.FileData)),
+		ContentType: contentType,
+		CreatedAt:   time.Now(),
 	}
 
-	if err := s.db.DB().Create(dbPresetData).Error; err != nil {
+	if err := s.db.WithRetry(ctx, func(db *gorm.DB) error { return db.Create(dbPresetData).Error }); err != nil {
 		return nil, fmt.Errorf("failed to create preset data: %w", err)
 	}
 
 	// 返回时拼接完整URL
-	scheme := "http"
-	if s.cfg.MinIO.UseSSL {
-		scheme = "https"
-	}
-	minioURL := fmt.Sprintf("%s://%s/%s/%s", scheme, s.cfg.MinIO.ExternalEndpoint, s.bucketName, minioPath)
-
 	return &v1.UploadDataResponse{
 		FileId:   id,
-		MinioUrl: minioURL,
+		MinioUrl: externalObjectURL(&s.cfg().MinIO, minioPath),
+		Checksum: checksum,
 	}, nil
 }
 
@@ -366,32 +743,67 @@ func (s *ManagementService) ListPresetData(ctx context.Context, req *v1.ListPres
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	scheme := "http"
-	if s.cfg.MinIO.UseSSL {
-		scheme = "https"
-	}
-
-	query := s.db.DB()
+	query := s.db.DB().Model(&models.PresetData{})
 	if req.Category != "" {
 		query = query.Where("category = ?", req.Category)
 	}
 
+	p, err := s.resolvePage(pageEndpointPresetData, req.PageToken, req.PageSize, 0, map[string]string{"category": req.Category})
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count preset data: %w", err)
+	}
+
 	var dbPresetData []models.PresetData
-	if err := query.Order("created_at DESC").Find(&dbPresetData).Error; err != nil {
+	if err := p.apply(query.Order("created_at DESC, id DESC")).Find(&dbPresetData).Error; err != nil {
 		return nil, fmt.Errorf("failed to list preset data: %w", err)
 	}
 
 	files := make([]*v1.PresetData, len(dbPresetData))
 	for i, dbData := range dbPresetData {
-		files[i] = presetDataModelToProto(&dbData, scheme, s.cfg.MinIO.ExternalEndpoint, s.bucketName)
+		files[i] = presetDataModelToProto(&dbData, &s.cfg().MinIO)
 	}
 
 	return &v1.ListPresetDataResponse{
-		Files: files,
-		Total: int32(len(files)),
+		Files:         files,
+		Total:         int32(total),
+		NextPageToken: s.nextPageToken(p, len(files), total),
 	}, nil
 }
 
+// GetPresetData 返回单个预置数据的元信息。旧数据没有记录大小时从 MinIO 读取
+func (s *ManagementService) GetPresetData(ctx context.Context, req *v1.GetPresetDataRequest) (*v1.PresetData, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var dbPresetData models.PresetData
+	if err := s.db.DB().WithContext(ctx).First(&dbPresetData, "id = ?", req.Id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "preset data %s not found", req.Id)
+		}
+		return nil, fmt.Errorf("failed to get preset data: %w", err)
+	}
+
+	if dbPresetData.Size == 0 && s.minioClient != nil {
+		objectPath := presetDataObjectPath(s.bucketName, &dbPresetData)
+		if info, err := s.minioClient.StatObject(ctx, s.bucketName, objectPath, minio.StatObjectOptions{}); err == nil {
+			dbPresetData.Size = info.Size
+		} else {
+			fmt.Printf("Warning: failed to stat preset data %s: %v\n", objectPath, err)
+		}
+	}
+
+	return presetDataModelToProto(&dbPresetData, &s.cfg().MinIO), nil
+}
+
 func (s *ManagementService) DeletePresetData(ctx context.Context, req *v1.DeletePresetDataRequest) (*v1.DeletePresetDataResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -403,14 +815,14 @@ func (s *ManagementService) DeletePresetData(ctx context.Context, req *v1.Delete
 
 	// 从MinIO删除文件
 	if s.minioClient != nil {
-		err := s.minioClient.RemoveObject(ctx, s.bucketName, dbPresetData.MinioPath, minio.RemoveObjectOptions{})
+		err := s.minioClient.RemoveObject(ctx, s.bucketName, presetDataObjectPath(s.bucketName, &dbPresetData), minio.RemoveObjectOptions{})
 		if err != nil {
 			fmt.Printf("Failed to remove object from MinIO: %v\n", err)
 		}
 	}
 
 	// 从数据库删除
-	if err := s.db.DB().Delete(&dbPresetData).Error; err != nil {
+	if err := s.db.WithRetry(ctx, func(db *gorm.DB) error { return db.Delete(&dbPresetData).Error }); err != nil {
 		return nil, fmt.Errorf("failed to delete preset data: %w", err)
 	}
 
@@ -420,71 +832,49 @@ func (s *ManagementService) DeletePresetData(ctx context.Context, req *v1.Delete
 	}, nil
 }
 
+// GetPresetDataDownloadURL 生成预置数据的预签名下载地址
+// 记录、对象或 bucket 不存在时返回 ErrPresetDataNotFound，而不是一个打开即 404 的链接
 func (s *ManagementService) GetPresetDataDownloadURL(ctx context.Context, fileID string) (string, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	var dbPresetData models.PresetData
 	if err := s.db.DB().First(&dbPresetData, "id = ?", fileID).Error; err != nil {
-		return "", fmt.Errorf("file not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", fmt.Errorf("%w: %s", ErrPresetDataNotFound, fileID)
+		}
+		return "", fmt.Errorf("failed to get preset data: %w", err)
 	}
 
 	if s.minioClient == nil {
 		return "", fmt.Errorf("minio client not available")
 	}
 
-	presignedURL, err := s.minioClient.PresignedGetObject(ctx, s.bucketName, dbPresetData.MinioPath, time.Hour*24, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to generate presigned URL: %v", err)
-	}
-
-	return presignedURL.String(), nil
+	return presetDataDownloadURL(ctx, s.minioClient, s.bucketName, &dbPresetData)
 }
 
-func (s *ManagementService) UploadPresetDataFile(ctx context.Context, filename string, category string, originalFilename string, file io.Reader) (*v1.UploadDataResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	id := fmt.Sprintf("data_%d", time.Now().UnixNano())
-	minioPath := fmt.Sprintf("preset-data/%s", originalFilename)
-
-	if s.minioClient != nil {
-		_, err := s.minioClient.PutObject(ctx, s.bucketName, minioPath, file, -1, minio.PutObjectOptions{})
-		if err != nil {
-			fmt.Printf("Failed to upload preset data to MinIO: %v\n", err)
-			return nil, fmt.Errorf("failed to upload file: %v", err)
-		}
-	}
-
-	// 数据库只保存路径，不保存完整URL
-	dbPresetData := &models.PresetData{
-		ID:        id,
-		Filename:  filename,
-		Category:  category,
-		MinioPath: minioPath, // 只保存路径，如: preset-data/file.zip
-		CreatedAt: time.Now(),
-	}
-
-	if err := s.db.DB().Create(dbPresetData).Error; err != nil {
-		return nil, fmt.Errorf("failed to create preset data: %w", err)
+// presetDataDownloadURL 确认对象存在后生成带原始文件名的预签名地址
+func presetDataDownloadURL(ctx context.Context, signer downloadSigner, bucket string, data *models.PresetData) (string, error) {
+	presignedURL, err := presignExistingObject(ctx, signer, bucket, presetDataObjectPath(bucket, data), time.Hour*24,
+		presetDataDownloadName(bucket, data))
+	if errors.Is(err, errObjectMissing) {
+		return "", fmt.Errorf("%w: %v", ErrPresetDataNotFound, err)
 	}
+	return presignedURL, err
+}
 
-	// 返回时拼接完整URL
-	scheme := "http"
-	if s.cfg.MinIO.UseSSL {
-		scheme = "https"
+// UploadPresetDataFile 流式上传并登记预置数据文件，表单字段已知时使用
+func (s *ManagementService) UploadPresetDataFile(ctx context.Context, filename string, category string, originalFilename string, file io.Reader) (*v1.UploadDataResponse, error) {
+	staged, err := s.StagePresetDataFile(ctx, originalFilename, category, file)
+	if err != nil {
+		return nil, err
 	}
-	minioURL := fmt.Sprintf("%s://%s/%s/%s", scheme, s.cfg.MinIO.ExternalEndpoint, s.bucketName, minioPath)
-
-	return &v1.UploadDataResponse{
-		FileId:   id,
-		MinioUrl: minioURL,
-	}, nil
+	return s.CommitPresetDataFile(ctx, staged, filename, category)
 }
 
 func (s *ManagementService) ListJobs(ctx context.Context, req *v1.ListJobsRequest) (*v1.ListJobsResponse, error) {
 	var dbJobs []models.Job
-	query := s.db.DB()
+	query := s.db.DB().Model(&models.Job{})
 
 	if req.AlgorithmId != "" {
 		query = query.Where("algorithm_id = ?", req.AlgorithmId)
@@ -493,7 +883,23 @@ func (s *ManagementService) ListJobs(ctx context.Context, req *v1.ListJobsReques
 		query = query.Where("status = ?", req.Status)
 	}
 
-	if err := query.Order("created_at DESC").Limit(100).Find(&dbJobs).Error; err != nil {
+	p, err := s.resolvePage(pageEndpointJobs, req.PageToken, req.PageSize, defaultJobPageSize, map[string]string{
+		"algorithm_id": req.AlgorithmId,
+		"status":       req.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := p.withLegacyPage(req.PageToken, req.Page); err != nil {
+		return nil, err
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	if err := p.apply(query.Order("created_at DESC, id DESC")).Find(&dbJobs).Error; err != nil {
 		return nil, fmt.Errorf("failed to list jobs: %w", err)
 	}
 
@@ -508,57 +914,170 @@ func (s *ManagementService) ListJobs(ctx context.Context, req *v1.ListJobsReques
 	}
 
 	return &v1.ListJobsResponse{
-		Jobs:  jobs,
-		Total: int32(len(jobs)),
+		Jobs:          jobs,
+		Total:         int32(total),
+		NextPageToken: s.nextPageToken(p, len(jobs), total),
 	}, nil
 }
 
 func (s *ManagementService) GetJobDetail(ctx context.Context, req *v1.GetJobDetailRequest) (*v1.JobDetail, error) {
+	if req.JobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+
 	var dbJob models.Job
-	if err := s.db.DB().First(&dbJob, "id = ?", req.JobId).Error; err != nil {
-		return nil, fmt.Errorf("job not found: %w", err)
-	}
-
-	return &v1.JobDetail{
-		JobId:       dbJob.ID,
-		AlgorithmId: dbJob.AlgorithmID,
-		Mode:        dbJob.Mode,
-		Status:      dbJob.Status,
-		OutputUrl:   dbJob.OutputURL,
-		LogUrl:      dbJob.LogURL,
-		CreatedAt:   timestamppb.New(dbJob.CreatedAt),
-	}, nil
+	if err := s.db.DB().WithContext(ctx).First(&dbJob, "id = ?", req.JobId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "job %s not found", req.JobId)
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	outputURL, expired := resolveJobArtifacts(ctx, s.minioClient, &s.cfg().MinIO, &dbJob)
+
+	detail := &v1.JobDetail{
+		JobId:             dbJob.ID,
+		AlgorithmId:       dbJob.AlgorithmID,
+		Mode:              dbJob.Mode,
+		Status:            dbJob.Status,
+		OutputUrl:         outputURL,
+		LogUrl:            externalObjectURL(&s.cfg().MinIO, objectPathFromURL(s.bucketName, dbJob.LogURL)),
+		CreatedAt:         timestamppb.New(dbJob.CreatedAt),
+		StartedAt:         timestampProto(dbJob.StartedAt),
+		FinishedAt:        timestampProto(dbJob.FinishedAt),
+		ArtifactsExpired:  expired,
+		ArtifactsExpireAt: timestampProto(dbJob.ArtifactsExpireAt),
+		RetriedFrom:       dbJob.RetriedFrom,
+		VersionId:         dbJob.VersionID,
+		Warning:           dbJob.Warning,
+		WebhookStatus:     dbJob.WebhookStatus,
+		WebhookStatusCode: int32(dbJob.WebhookStatusCode),
+		WebhookError:      dbJob.WebhookError,
+		FailureReason:     dbJob.FailureReason,
+	}
+
+	// 附加 Docker 中容器的实际状态，便于与数据库记录对照
+	if s.scheduler != nil {
+		info, err := s.scheduler.InspectJobContainer(ctx, dbJob.ID)
+		if err != nil {
+			fmt.Printf("Warning: failed to inspect container for job %s: %v\n", dbJob.ID, err)
+		} else if info != nil {
+			detail.Container = &v1.JobContainer{
+				ContainerId: info.ID,
+				State:       info.State,
+				ExitCode:    int32(info.ExitCode),
+				StartedAt:   timestampProto(info.StartedAt),
+				FinishedAt:  timestampProto(info.FinishedAt),
+				OomKilled:   info.OOMKilled,
+			}
+			detail.StatusMismatch = containerStatusMismatch(dbJob.Status, info.State)
+		}
+	}
+
+	return detail, nil
+}
+
+// containerStatusMismatch 判断数据库中的任务状态与容器实际状态是否矛盾
+func containerStatusMismatch(jobStatus, containerState string) bool {
+	switch jobStatus {
+	case "running":
+		return containerState == "exited" || containerState == "dead"
+	case "completed", "failed", "timeout", "cancelled":
+		return containerState == "running"
+	}
+	return false
 }
 
 func (s *ManagementService) GetServerInfo(ctx context.Context, req *v1.GetServerInfoRequest) (*v1.GetServerInfoResponse, error) {
 	os := runtime.GOOS
 	arch := runtime.GOARCH
 
-	var platform v1.Platform
-	var platformName string
-
-	switch {
-	case os == "darwin" && arch == "arm64":
-		platform = v1.Platform_PLATFORM_MACOS_ARM64
-		platformName = "macOS ARM64"
-	case os == "windows" && (arch == "amd64" || arch == "386"):
-		platform = v1.Platform_PLATFORM_WINDOWS_X86_64
-		platformName = "Windows x86_64"
-	case os == "linux" && (arch == "amd64" || arch == "386"):
-		platform = v1.Platform_PLATFORM_LINUX_X86_64
-		platformName = "Linux x86_64"
-	case os == "linux" && arch == "arm64":
-		platform = v1.Platform_PLATFORM_LINUX_ARM64
-		platformName = "Linux ARM64"
-	default:
-		platform = v1.Platform_PLATFORM_DOCKER
-		platformName = fmt.Sprintf("%s %s", strings.Title(os), arch)
-	}
+	platform, platformName := hostPlatform(os, arch)
 
 	return &v1.GetServerInfoResponse{
 		Os:           os,
 		Arch:         arch,
 		Platform:     platform,
 		PlatformName: platformName,
+		Maintenance:  s.maintenanceStatus(),
 	}, nil
 }
+
+// SetMaintenanceMode 手动开启或关闭只读维护模式，需要管理员令牌
+func (s *ManagementService) SetMaintenanceMode(ctx context.Context, req *v1.SetMaintenanceModeRequest) (*v1.MaintenanceStatus, error) {
+	if err := requireAdmin(ctx, s.cfg().Server.AdminToken); err != nil {
+		return nil, err
+	}
+	if s.maintenance == nil {
+		return nil, status.Error(codes.Unimplemented, "maintenance mode is not available")
+	}
+
+	s.maintenance.SetReadOnly(req.ReadOnly, req.Reason)
+	fmt.Printf("Maintenance mode set: read_only=%v reason=%q\n", req.ReadOnly, req.Reason)
+
+	return s.maintenanceStatus(), nil
+}
+
+// maintenanceStatus 返回当前维护模式状态
+func (s *ManagementService) maintenanceStatus() *v1.MaintenanceStatus {
+	if s.maintenance == nil {
+		return &v1.MaintenanceStatus{}
+	}
+
+	current := s.maintenance.Status()
+	result := &v1.MaintenanceStatus{
+		ReadOnly: current.ReadOnly,
+		Reason:   current.Reason,
+	}
+	if current.ReadOnly {
+		result.Since = timestamppb.New(current.Since)
+	}
+	return result
+}
+
+// GetOverview 返回仪表盘所需的汇总统计（算法数、预置数据数、各状态任务数）
+func (s *ManagementService) GetOverview(ctx context.Context, req *v1.GetOverviewRequest) (*v1.GetOverviewResponse, error) {
+	s.overviewMu.Lock()
+	defer s.overviewMu.Unlock()
+
+	if s.overviewCache != nil && time.Since(s.overviewCachedAt) < overviewCacheTTL {
+		return s.overviewCache, nil
+	}
+
+	db := s.db.DB().WithContext(ctx)
+
+	var algorithmCount, presetDataCount int64
+	if err := db.Model(&models.Algorithm{}).Count(&algorithmCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count algorithms: %w", err)
+	}
+	if err := db.Model(&models.PresetData{}).Count(&presetDataCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count preset data: %w", err)
+	}
+
+	var statusCounts []struct {
+		Status string
+		Count  int64
+	}
+	if err := db.Model(&models.Job{}).Select("status, COUNT(*) AS count").Group("status").Scan(&statusCounts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	jobsByStatus := make(map[string]int64, len(statusCounts))
+	var jobCount int64
+	for _, sc := range statusCounts {
+		jobsByStatus[sc.Status] = sc.Count
+		jobCount += sc.Count
+	}
+
+	now := time.Now()
+	s.overviewCache = &v1.GetOverviewResponse{
+		AlgorithmCount:  algorithmCount,
+		PresetDataCount: presetDataCount,
+		JobCount:        jobCount,
+		JobsByStatus:    jobsByStatus,
+		GeneratedAt:     timestamppb.New(now),
+	}
+	s.overviewCachedAt = now
+
+	return s.overviewCache, nil
+}
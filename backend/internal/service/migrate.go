@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/keys"
+	"algorithm-platform/internal/models"
+
+	"github.com/minio/minio-go/v7"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// 迁移对象的状态
+const (
+	migrationPlanned = "planned"
+	migrationCopied  = "copied"
+	migrationFailed  = "failed"
+)
+
+// migrationItem 一个待迁移的对象，model 和 column 为空表示对象没有对应的数据库记录（如备份）
+type migrationItem struct {
+	kind      string
+	id        string
+	sourceKey string
+	targetKey string
+	model     interface{}
+	column    string
+}
+
+// MigrateObjects 将算法、预置数据、任务结果和备份对象从旧的 bucket/前缀复制到新的位置，
+// 每个对象复制后校验大小，全部复制完成后在一个事务中更新数据库中的路径。源对象不会被删除
+func (s *ManagementService) MigrateObjects(ctx context.Context, req *v1.MigrateObjectsRequest) (*v1.MigrateObjectsResponse, error) {
+	if err := requireAdmin(ctx, s.cfg().Server.AdminToken); err != nil {
+		return nil, err
+	}
+	if s.minioClient == nil {
+		return nil, status.Error(codes.Unavailable, "MinIO client is not available")
+	}
+
+	sourceBucket := req.SourceBucket
+	if sourceBucket == "" {
+		sourceBucket = s.bucketName
+	}
+	targetBucket := req.TargetBucket
+	if targetBucket == "" {
+		targetBucket = s.bucketName
+	}
+	if sourceBucket == targetBucket && strings.Trim(req.SourcePrefix, "/") == strings.Trim(req.TargetPrefix, "/") {
+		return nil, status.Error(codes.InvalidArgument, "source and target locations are the same")
+	}
+
+	items, err := s.collectMigrationItems(ctx, sourceBucket, req.SourcePrefix, targetBucket, req.TargetPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &v1.MigrateObjectsResponse{DryRun: req.DryRun}
+	if req.DryRun {
+		for _, item := range items {
+			resp.Objects = append(resp.Objects, item.toProto(migrationPlanned, nil))
+		}
+		return resp, nil
+	}
+
+	// 迁移期间拒绝写请求，避免新对象写到旧位置
+	if s.maintenance != nil {
+		release := s.maintenance.Engage("migrating objects")
+		defer release()
+	}
+
+	var copied []migrationItem
+	for i, item := range items {
+		fmt.Printf("[%d/%d] Migrating %s %s: %s/%s -> %s/%s\n", i+1, len(items),
+			item.kind, item.id, sourceBucket, item.sourceKey, targetBucket, item.targetKey)
+
+		if err := s.copyAndVerifyObject(ctx, sourceBucket, item.sourceKey, targetBucket, item.targetKey); err != nil {
+			fmt.Printf("   ❌ %v\n", err)
+			resp.Failed++
+			resp.Objects = append(resp.Objects, item.toProto(migrationFailed, err))
+			continue
+		}
+		resp.Copied++
+		resp.Objects = append(resp.Objects, item.toProto(migrationCopied, nil))
+		copied = append(copied, item)
+	}
+
+	rows, err := updateMigratedPaths(s.db.DB(), copied)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "objects copied but failed to update database paths: %v", err)
+	}
+	resp.RowsUpdated = int32(rows)
+
+	fmt.Printf("Object migration finished: %d copied, %d failed, %d rows updated\n", resp.Copied, resp.Failed, resp.RowsUpdated)
+	return resp, nil
+}
+
+// collectMigrationItems 收集源位置下需要迁移的对象
+func (s *ManagementService) collectMigrationItems(ctx context.Context, sourceBucket, sourcePrefix, targetBucket, targetPrefix string) ([]migrationItem, error) {
+	var items []migrationItem
+	add := func(kind, id, key string, model interface{}, column string) {
+		targetKey, ok := rewriteObjectKey(key, sourcePrefix, targetPrefix)
+		if !ok || (sourceBucket == targetBucket && targetKey == key) {
+			return
+		}
+		items = append(items, migrationItem{kind: kind, id: id, sourceKey: key, targetKey: targetKey, model: model, column: column})
+	}
+
+	var versions []models.Version
+	if err := s.db.DB().Where("minio_path <> ''").Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+	for _, v := range versions {
+		add("version", v.ID, v.MinioPath, &models.Version{}, "minio_path")
+	}
+
+	var presetData []models.PresetData
+	if err := s.db.DB().Find(&presetData).Error; err != nil {
+		return nil, fmt.Errorf("failed to list preset data: %w", err)
+	}
+	for _, d := range presetData {
+		if key := presetDataObjectPath(s.bucketName, &d); key != "" {
+			add("preset_data", d.ID, key, &models.PresetData{}, "minio_path")
+		}
+	}
+
+	var jobs []models.Job
+	if err := s.db.DB().Where("output_url <> '' OR log_url <> ''").Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, j := range jobs {
+		// 旧记录保存的是完整 URL，按对象路径迁移，迁移后改写为对象路径
+		if key := objectPathFromURL(s.bucketName, j.OutputURL); key != "" {
+			add("job_result", j.ID, key, &models.Job{}, "output_url")
+		}
+		if key := objectPathFromURL(s.bucketName, j.LogURL); key != "" {
+			add("job_log", j.ID, key, &models.Job{}, "log_url")
+		}
+	}
+
+	// 备份对象不在数据库中，直接按前缀列出
+	backupPrefix := config.JoinObjectKey(sourcePrefix, keys.BackupDir)
+	for object := range s.minioClient.ListObjects(ctx, sourceBucket, minio.ListObjectsOptions{Prefix: backupPrefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list backups: %w", object.Err)
+		}
+		add("backup", object.Key, object.Key, nil, "")
+	}
+
+	return items, nil
+}
+
+// copyAndVerifyObject 在服务端复制对象，并确认目标对象的大小与源对象一致
+func (s *ManagementService) copyAndVerifyObject(ctx context.Context, sourceBucket, sourceKey, targetBucket, targetKey string) error {
+	srcInfo, err := s.minioClient.StatObject(ctx, sourceBucket, sourceKey, minio.StatObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("source object not found: %w", err)
+	}
+
+	_, err = s.minioClient.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: targetBucket, Object: targetKey},
+		minio.CopySrcOptions{Bucket: sourceBucket, Object: sourceKey},
+	)
+	if err != nil {
+		return fmt.Errorf("copy failed: %w", err)
+	}
+
+	dstInfo, err := s.minioClient.StatObject(ctx, targetBucket, targetKey, minio.StatObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to verify copy: %w", err)
+	}
+	if dstInfo.Size != srcInfo.Size {
+		return fmt.Errorf("copy size mismatch: source %d bytes, target %d bytes", srcInfo.Size, dstInfo.Size)
+	}
+	return nil
+}
+
+// updateMigratedPaths 在一个事务中将已复制对象的数据库路径改为新路径，任何一条失败则全部回滚
+func updateMigratedPaths(db *gorm.DB, items []migrationItem) (int64, error) {
+	var rows int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			if item.model == nil {
+				continue
+			}
+			result := tx.Model(item.model).
+				Where("id = ?", item.id).
+				Update(item.column, item.targetKey)
+			if result.Error != nil {
+				return fmt.Errorf("failed to update %s %s: %w", item.kind, item.id, result.Error)
+			}
+			rows += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return rows, nil
+}
+
+// rewriteObjectKey 将源前缀下的对象路径改写为目标前缀下的路径，不在源前缀下时返回 false
+func rewriteObjectKey(key, sourcePrefix, targetPrefix string) (string, bool) {
+	relative := key
+	if source := strings.Trim(sourcePrefix, "/"); source != "" {
+		var ok bool
+		if relative, ok = strings.CutPrefix(key, source+"/"); !ok {
+			return "", false
+		}
+	} else if target := strings.Trim(targetPrefix, "/"); target != "" && strings.HasPrefix(key, target+"/") {
+		// 源前缀为空时，已经在目标前缀下的对象无需迁移
+		return "", false
+	}
+	return config.JoinObjectKey(targetPrefix, relative), true
+}
+
+func (item migrationItem) toProto(state string, err error) *v1.MigratedObject {
+	obj := &v1.MigratedObject{
+		Kind:      item.kind,
+		Id:        item.id,
+		SourceKey: item.sourceKey,
+		TargetKey: item.targetKey,
+		Status:    state,
+	}
+	if err != nil {
+		obj.Error = err.Error()
+	}
+	return obj
+}
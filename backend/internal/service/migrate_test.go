@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+)
+
+func TestRewriteObjectKey(t *testing.T) {
+	tests := []struct {
+		key, source, target string
+		want                string
+		ok                  bool
+	}{
+		{"algorithms/alg_1/v1.zip", "", "staging", "staging/algorithms/alg_1/v1.zip", true},
+		{"staging/algorithms/alg_1/v1.zip", "", "staging", "", false},
+		{"old/results/job_1/output.json", "old", "new", "new/results/job_1/output.json", true},
+		{"old/results/job_1/output.json", "old/", "", "results/job_1/output.json", true},
+		{"other/results/job_1/output.json", "old", "new", "", false},
+		{"older/results/job_1/output.json", "old", "new", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := rewriteObjectKey(tt.key, tt.source, tt.target)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("rewriteObjectKey(%q, %q, %q) = %q, %v; want %q, %v", tt.key, tt.source, tt.target, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestUpdateMigratedPathsSkipsBackups(t *testing.T) {
+	db := testutil.NewDB(t)
+	job := createJob(t, db, "job_1", models.JobStatusCompleted)
+	db.Model(job).Update("output_url", "results/job_1/output.json")
+
+	rows, err := updateMigratedPaths(db, []migrationItem{
+		{kind: "job_result", id: "job_1", sourceKey: "results/job_1/output.json", targetKey: "new/results/job_1/output.json", model: &models.Job{}, column: "output_url"},
+		{kind: "backup", id: "database-backup/a.db", sourceKey: "database-backup/a.db", targetKey: "new/database-backup/a.db"},
+	})
+	if err != nil {
+		t.Fatalf("updateMigratedPaths failed: %v", err)
+	}
+	if rows != 1 {
+		t.Errorf("rows = %d, want 1", rows)
+	}
+
+	var reloaded models.Job
+	db.First(&reloaded, "id = ?", "job_1")
+	if reloaded.OutputURL != "new/results/job_1/output.json" {
+		t.Errorf("OutputURL = %q, want new key", reloaded.OutputURL)
+	}
+}
+
+func TestCollectMigrationItemsNormalizesLegacyJobURLs(t *testing.T) {
+	db := testutil.NewDB(t)
+	if err := db.AutoMigrate(&models.Version{}, &models.PresetData{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	legacy := createJob(t, db, "job_1", models.JobStatusCompleted)
+	db.Model(legacy).Updates(map[string]interface{}{
+		"output_url": "http://minio:9000/algorithm-platform/results/job_1/output.json",
+		"log_url":    "http://localhost:9000/algorithm-platform/results/job_1/logs.txt",
+	})
+	current := createJob(t, db, "job_2", models.JobStatusCompleted)
+	db.Model(current).Update("output_url", "results/job_2/output.json")
+
+	// 备份前缀下没有对象
+	_, client := testutil.NewS3(t)
+	cfg := &config.Config{}
+	s := &ManagementService{db: database.NewWithDB(db, cfg), cfgStore: config.NewStore(cfg), minioClient: client, bucketName: "algorithm-platform"}
+
+	items, err := s.collectMigrationItems(context.Background(), "algorithm-platform", "", "algorithm-platform", "staging")
+	if err != nil {
+		t.Fatalf("collectMigrationItems failed: %v", err)
+	}
+	want := map[string]string{
+		"job_result job_1": "results/job_1/output.json",
+		"job_log job_1":    "results/job_1/logs.txt",
+		"job_result job_2": "results/job_2/output.json",
+	}
+	if len(items) != len(want) {
+		t.Fatalf("Expected %d items, got %+v", len(want), items)
+	}
+	for _, item := range items {
+		if key, ok := want[item.kind+" "+item.id]; !ok || item.sourceKey != key || item.targetKey != "staging/"+key {
+			t.Errorf("Unexpected item %s %s: %s -> %s", item.kind, item.id, item.sourceKey, item.targetKey)
+		}
+	}
+
+	// 迁移后旧记录的 URL 改写为新前缀下的对象路径
+	if _, err := updateMigratedPaths(db, items); err != nil {
+		t.Fatalf("updateMigratedPaths failed: %v", err)
+	}
+	var reloaded models.Job
+	db.First(&reloaded, "id = ?", "job_1")
+	if reloaded.OutputURL != "staging/results/job_1/output.json" || reloaded.LogURL != "staging/results/job_1/logs.txt" {
+		t.Errorf("Unexpected migrated paths: %q, %q", reloaded.OutputURL, reloaded.LogURL)
+	}
+}
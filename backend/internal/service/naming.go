@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// findAlgorithmByName 按名称查找唯一的算法。开启 database.unique_algorithm_names 时新建和改名会拒绝重名，
+// 未开启或历史数据中已存在同名算法时返回 FailedPrecondition 并列出冲突的 ID
+func findAlgorithmByName(db *gorm.DB, name string) (*models.Algorithm, error) {
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	var matches []models.Algorithm
+	if err := db.Where("name = ?", name).Order("created_at ASC").Limit(10).Find(&matches).Error; err != nil {
+		return nil, fmt.Errorf("failed to look up algorithm: %w", err)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, status.Errorf(codes.NotFound, "algorithm %q not found", name)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, alg := range matches {
+			ids[i] = alg.ID
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "algorithm name %q is ambiguous, matching ids: %s", name, strings.Join(ids, ", "))
+	}
+}
+
+// checkAlgorithmNameAvailable 检查名称是否已被其他算法使用，excludeID 为正在改名的算法。
+// 只在开启 database.unique_algorithm_names 时调用
+func checkAlgorithmNameAvailable(db *gorm.DB, name, excludeID string) error {
+	query := db.Model(&models.Algorithm{}).Where("name = ?", name)
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check algorithm name: %w", err)
+	}
+	if count > 0 {
+		return status.Errorf(codes.AlreadyExists, "algorithm name %q already exists", name)
+	}
+	return nil
+}
+
+// isUniqueViolation 判断是否违反唯一约束（开启 database.unique_algorithm_names 时，并发创建可能越过名称检查）
+func isUniqueViolation(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint failed") || strings.Contains(msg, "duplicate key value")
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func newAlgorithmTestDB(t *testing.T, algorithms ...models.Algorithm) *gorm.DB {
+	t.Helper()
+	db := testutil.NewDB(t)
+	for i := range algorithms {
+		if err := db.Create(&algorithms[i]).Error; err != nil {
+			t.Fatalf("Failed to create algorithm: %v", err)
+		}
+	}
+	return db
+}
+
+func TestFindAlgorithmByName(t *testing.T) {
+	db := newAlgorithmTestDB(t,
+		models.Algorithm{ID: "alg_1", Name: "detector"},
+		models.Algorithm{ID: "alg_2", Name: "legacy"},
+		models.Algorithm{ID: "alg_3", Name: "legacy"},
+	)
+
+	alg, err := findAlgorithmByName(db, "detector")
+	if err != nil || alg.ID != "alg_1" {
+		t.Fatalf("Expected alg_1, got %v, %v", alg, err)
+	}
+
+	if _, err := findAlgorithmByName(db, "missing"); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+	if _, err := findAlgorithmByName(db, "legacy"); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for duplicate names, got %v", err)
+	}
+	if _, err := findAlgorithmByName(db, ""); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for empty name, got %v", err)
+	}
+}
+
+func TestCheckAlgorithmNameAvailable(t *testing.T) {
+	db := newAlgorithmTestDB(t, models.Algorithm{ID: "alg_1", Name: "detector"})
+
+	if err := checkAlgorithmNameAvailable(db, "detector", ""); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists, got %v", err)
+	}
+	if err := checkAlgorithmNameAvailable(db, "detector", "alg_1"); err != nil {
+		t.Errorf("Renaming to its own name should be allowed, got %v", err)
+	}
+	if err := checkAlgorithmNameAvailable(db, "classifier", ""); err != nil {
+		t.Errorf("Expected name to be available, got %v", err)
+	}
+}
+
+func TestDuplicateAlgorithmNamesFollowConfig(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newAlgorithmTestService(t, nil)
+
+	// 未开启 unique_algorithm_names 时允许重名
+	first, err := s.CreateAlgorithm(ctx, &v1.CreateAlgorithmRequest{Name: "detector"})
+	if err != nil {
+		t.Fatalf("CreateAlgorithm failed: %v", err)
+	}
+	second, err := s.CreateAlgorithm(ctx, &v1.CreateAlgorithmRequest{Name: "classifier"})
+	if err != nil {
+		t.Fatalf("CreateAlgorithm failed: %v", err)
+	}
+	if _, err := s.CreateAlgorithm(ctx, &v1.CreateAlgorithmRequest{Name: "detector"}); err != nil {
+		t.Errorf("Duplicate names should be allowed by default, got %v", err)
+	}
+	if _, err := s.UpdateAlgorithm(ctx, &v1.UpdateAlgorithmRequest{Id: second.Id, Name: "detector"}); err != nil {
+		t.Errorf("Renaming to a used name should be allowed by default, got %v", err)
+	}
+
+	cfg := *s.cfg()
+	cfg.Database.UniqueAlgorithmNames = true
+	s.cfgStore = config.NewStore(&cfg)
+	if _, err := s.CreateAlgorithm(ctx, &v1.CreateAlgorithmRequest{Name: first.Name}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists with unique names, got %v", err)
+	}
+	if _, err := s.UpdateAlgorithm(ctx, &v1.UpdateAlgorithmRequest{Id: first.Id, Name: "segmenter"}); err != nil {
+		t.Errorf("Renaming to a free name failed: %v", err)
+	}
+	if _, err := s.UpdateAlgorithm(ctx, &v1.UpdateAlgorithmRequest{Id: first.Id, Name: "detector"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists when renaming with unique names, got %v", err)
+	}
+}
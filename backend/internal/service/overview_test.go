@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+)
+
+func TestGetOverview(t *testing.T) {
+	s := newPresetTestService(t)
+	db := s.db.DB()
+	if err := db.AutoMigrate(&models.Algorithm{}, &models.Job{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if err := db.Create(&[]models.Algorithm{{ID: "alg_1", Name: "one"}, {ID: "alg_2", Name: "two"}}).Error; err != nil {
+		t.Fatalf("Failed to create algorithms: %v", err)
+	}
+	createPresetData(t, s, models.PresetData{ID: "data_1", Filename: "a.csv"}, models.PresetData{ID: "data_2", Filename: "b.csv"}, models.PresetData{ID: "data_3", Filename: "c.csv"})
+	for id, status := range map[string]string{
+		"job_1": models.JobStatusCompleted,
+		"job_2": models.JobStatusCompleted,
+		"job_3": models.JobStatusFailed,
+		"job_4": models.JobStatusRunning,
+		"job_5": models.JobStatusPending,
+		"job_6": models.JobStatusCompleted,
+	} {
+		createJob(t, db, id, status)
+	}
+	ctx := context.Background()
+
+	overview, err := s.GetOverview(ctx, &v1.GetOverviewRequest{})
+	if err != nil {
+		t.Fatalf("GetOverview failed: %v", err)
+	}
+	wantByStatus := map[string]int64{models.JobStatusCompleted: 3, models.JobStatusFailed: 1, models.JobStatusRunning: 1, models.JobStatusPending: 1}
+	if overview.AlgorithmCount != 2 || overview.PresetDataCount != 3 || overview.JobCount != 6 || !reflect.DeepEqual(overview.JobsByStatus, wantByStatus) {
+		t.Errorf("Unexpected overview: algorithms=%d preset_data=%d jobs=%d by_status=%v",
+			overview.AlgorithmCount, overview.PresetDataCount, overview.JobCount, overview.JobsByStatus)
+	}
+
+	// 缓存有效期内新增的数据不计入
+	createJob(t, db, "job_7", models.JobStatusTimeout)
+	if err := db.Create(&models.Algorithm{ID: "alg_3", Name: "three"}).Error; err != nil {
+		t.Fatalf("Failed to create algorithm: %v", err)
+	}
+	cached, err := s.GetOverview(ctx, &v1.GetOverviewRequest{})
+	if err != nil {
+		t.Fatalf("GetOverview failed: %v", err)
+	}
+	if cached.JobCount != 6 || cached.AlgorithmCount != 2 || !cached.GeneratedAt.AsTime().Equal(overview.GeneratedAt.AsTime()) {
+		t.Errorf("Expected cached counts within %v, got jobs=%d algorithms=%d", overviewCacheTTL, cached.JobCount, cached.AlgorithmCount)
+	}
+
+	// 缓存过期后重新统计
+	s.overviewCachedAt = time.Now().Add(-overviewCacheTTL)
+	refreshed, err := s.GetOverview(ctx, &v1.GetOverviewRequest{})
+	if err != nil {
+		t.Fatalf("GetOverview failed: %v", err)
+	}
+	if refreshed.JobCount != 7 || refreshed.AlgorithmCount != 3 || refreshed.JobsByStatus[models.JobStatusTimeout] != 1 {
+		t.Errorf("Expected fresh counts after the cache expired, got jobs=%d algorithms=%d by_status=%v",
+			refreshed.JobCount, refreshed.AlgorithmCount, refreshed.JobsByStatus)
+	}
+}
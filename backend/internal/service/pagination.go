@@ -0,0 +1,87 @@
+package service
+
+import (
+	"math"
+
+	"algorithm-platform/internal/pagination"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// defaultAlgorithmPageSize 算法列表未指定 page_size 时的默认页大小
+const defaultAlgorithmPageSize = 50
+
+// defaultJobPageSize 任务列表未指定 page_size 时的默认页大小，更多任务通过分页令牌或更大的 page_size 获取
+const defaultJobPageSize = 100
+
+// 分页令牌所属的列表接口
+const (
+	pageEndpointAlgorithms = "algorithms"
+	pageEndpointPresetData = "preset_data"
+	pageEndpointJobs       = "jobs"
+)
+
+// page 一次列表查询的分页参数
+type page struct {
+	endpoint string
+	filters  map[string]string
+	offset   int
+	limit    int // 0 表示不分页
+}
+
+// resolvePage 解析分页令牌和页大小，令牌被篡改或与过滤条件不一致时返回 InvalidArgument
+// pageSize 为 0 时使用 defaultSize（0 表示返回全部），超过 server.max_page_size 时按上限处理
+func (s *ManagementService) resolvePage(endpoint, token string, pageSize int32, defaultSize int, filters map[string]string) (*page, error) {
+	p := &page{endpoint: endpoint, filters: filters, limit: defaultSize}
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+	if pageSize > 0 {
+		p.limit = min(int(pageSize), s.cfg().Server.GetMaxPageSize())
+	}
+
+	if token != "" {
+		state, err := s.pageTokens.Decode(token, endpoint, filters)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		p.offset = state.Offset
+	}
+	return p, nil
+}
+
+// maxLegacyPageOffset 旧 page 参数换算出的最大偏移量，超出时拒绝请求，避免偏移量溢出
+const maxLegacyPageOffset = math.MaxInt32
+
+// withLegacyPage 兼容旧客户端的 page 参数（从 1 开始），仅在没有分页令牌时生效；
+// 页码换算的偏移量超过 maxLegacyPageOffset 时返回 InvalidArgument
+func (p *page) withLegacyPage(token string, pageNumber int32) error {
+	if token != "" || pageNumber <= 1 || p.limit <= 0 {
+		return nil
+	}
+	offset := int64(pageNumber-1) * int64(p.limit)
+	if offset > maxLegacyPageOffset {
+		return status.Errorf(codes.InvalidArgument, "page %d is too large for page_size %d", pageNumber, p.limit)
+	}
+	p.offset = int(offset)
+	return nil
+}
+
+// apply 将分页参数应用到查询
+func (p *page) apply(query *gorm.DB) *gorm.DB {
+	if p.limit > 0 {
+		query = query.Limit(p.limit)
+	}
+	return query.Offset(p.offset)
+}
+
+// nextPageToken 还有后续数据时返回下一页的令牌，否则返回空
+func (s *ManagementService) nextPageToken(p *page, returned int, total int64) string {
+	next := p.offset + returned
+	if p.limit == 0 || returned == 0 || int64(next) >= total {
+		return ""
+	}
+	return s.pageTokens.Encode(pagination.State{Endpoint: p.endpoint, Offset: next, Filters: p.filters})
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/pagination"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPageTokensFollowResults(t *testing.T) {
+	s := &ManagementService{cfgStore: config.NewStore(&config.Config{}), pageTokens: pagination.NewCodec("secret")}
+	filters := map[string]string{"status": "failed"}
+
+	p, err := s.resolvePage(pageEndpointJobs, "", 2, 100, filters)
+	if err != nil {
+		t.Fatalf("resolvePage failed: %v", err)
+	}
+	if p.offset != 0 || p.limit != 2 {
+		t.Errorf("Unexpected first page: %+v", p)
+	}
+
+	token := s.nextPageToken(p, 2, 5)
+	if token == "" {
+		t.Fatal("Expected next page token")
+	}
+
+	p, err = s.resolvePage(pageEndpointJobs, token, 2, 100, filters)
+	if err != nil {
+		t.Fatalf("resolvePage with token failed: %v", err)
+	}
+	if p.offset != 2 {
+		t.Errorf("Expected offset 2, got %d", p.offset)
+	}
+
+	// 最后一页不再返回令牌
+	if token := s.nextPageToken(&page{endpoint: pageEndpointJobs, offset: 4, limit: 2}, 1, 5); token != "" {
+		t.Errorf("Expected no token on last page, got %q", token)
+	}
+	// 不分页时不返回令牌
+	if token := s.nextPageToken(&page{endpoint: pageEndpointJobs}, 5, 10); token != "" {
+		t.Errorf("Expected no token without page size, got %q", token)
+	}
+}
+
+func TestResolvePageRejectsInvalidTokens(t *testing.T) {
+	s := &ManagementService{cfgStore: config.NewStore(&config.Config{}), pageTokens: pagination.NewCodec("secret")}
+	token := s.pageTokens.Encode(pagination.State{Endpoint: pageEndpointJobs, Offset: 10, Filters: map[string]string{"status": "failed"}})
+
+	if _, err := s.resolvePage(pageEndpointJobs, token+"x", 10, 100, map[string]string{"status": "failed"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for tampered token, got %v", err)
+	}
+	if _, err := s.resolvePage(pageEndpointJobs, token, 10, 100, map[string]string{"status": "completed"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for changed filters, got %v", err)
+	}
+	if _, err := s.resolvePage(pageEndpointJobs, "", -1, 100, nil); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for negative page size, got %v", err)
+	}
+}
+
+func TestWithLegacyPage(t *testing.T) {
+	tests := []struct {
+		name       string
+		p          page
+		token      string
+		pageNumber int32
+		wantOffset int
+	}{
+		{"first page", page{limit: 50}, "", 1, 0},
+		{"no page", page{limit: 50}, "", 0, 0},
+		{"third page", page{limit: 20}, "", 3, 40},
+		{"token wins", page{limit: 20, offset: 7}, "token", 3, 7},
+		{"unpaged", page{}, "", 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.p.withLegacyPage(tt.token, tt.pageNumber); err != nil || tt.p.offset != tt.wantOffset {
+				t.Errorf("offset = %d, err = %v, want %d", tt.p.offset, err, tt.wantOffset)
+			}
+		})
+	}
+
+	p := page{limit: 1000}
+	if err := p.withLegacyPage("", math.MaxInt32); status.Code(err) != codes.InvalidArgument || p.offset != 0 {
+		t.Errorf("Huge page: offset = %d, err = %v, want InvalidArgument", p.offset, err)
+	}
+}
+
+func TestListPagingWithIdenticalTimestamps(t *testing.T) {
+	s := newPresetTestService(t)
+	s.pageTokens = pagination.NewCodec("secret")
+	if err := s.db.DB().AutoMigrate(&models.Job{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	// 乱序插入，保证结果不依赖插入顺序
+	createdAt := time.Now()
+	ids := []string{"c", "a", "e", "b", "d"}
+	for _, id := range ids {
+		if err := s.db.DB().Create(&models.Job{ID: "job_" + id, Status: models.JobStatusCompleted, CreatedAt: createdAt}).Error; err != nil {
+			t.Fatalf("Failed to create job: %v", err)
+		}
+		if err := s.db.DB().Create(&models.PresetData{ID: "data_" + id, Filename: id, CreatedAt: createdAt}).Error; err != nil {
+			t.Fatalf("Failed to create preset data: %v", err)
+		}
+	}
+	ctx := context.Background()
+
+	var jobIDs []string
+	for token := ""; ; {
+		resp, err := s.ListJobs(ctx, &v1.ListJobsRequest{PageSize: 2, PageToken: token})
+		if err != nil {
+			t.Fatalf("ListJobs failed: %v", err)
+		}
+		for _, job := range resp.Jobs {
+			jobIDs = append(jobIDs, job.JobId)
+		}
+		if token = resp.NextPageToken; token == "" {
+			break
+		}
+	}
+	if want := []string{"job_e", "job_d", "job_c", "job_b", "job_a"}; !reflect.DeepEqual(jobIDs, want) {
+		t.Errorf("Paged jobs = %v, want %v", jobIDs, want)
+	}
+
+	var dataIDs []string
+	for token := ""; ; {
+		resp, err := s.ListPresetData(ctx, &v1.ListPresetDataRequest{PageSize: 2, PageToken: token})
+		if err != nil {
+			t.Fatalf("ListPresetData failed: %v", err)
+		}
+		for _, file := range resp.Files {
+			dataIDs = append(dataIDs, file.Id)
+		}
+		if token = resp.NextPageToken; token == "" {
+			break
+		}
+	}
+	if want := []string{"data_e", "data_d", "data_c", "data_b", "data_a"}; !reflect.DeepEqual(dataIDs, want) {
+		t.Errorf("Paged preset data = %v, want %v", dataIDs, want)
+	}
+}
+
+func TestResolvePageCapsPageSize(t *testing.T) {
+	s := &ManagementService{cfgStore: config.NewStore(&config.Config{}), pageTokens: pagination.NewCodec("secret")}
+
+	tests := []struct {
+		name        string
+		maxPageSize int
+		pageSize    int32
+		wantLimit   int
+	}{
+		{"default page size", 0, 0, defaultJobPageSize},
+		{"larger than default", 0, 300, 300},
+		{"capped at default maximum", 0, 5000, 500},
+		{"configured maximum", 2000, 1500, 1500},
+		{"capped at configured maximum", 2000, 5000, 2000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.cfg().Server.MaxPageSize = tt.maxPageSize
+			p, err := s.resolvePage(pageEndpointJobs, "", tt.pageSize, defaultJobPageSize, nil)
+			if err != nil {
+				t.Fatalf("resolvePage failed: %v", err)
+			}
+			if p.limit != tt.wantLimit {
+				t.Errorf("limit = %d, want %d", p.limit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestListJobsTotalBeyondPage(t *testing.T) {
+	s := newPresetTestService(t)
+	s.pageTokens = pagination.NewCodec("secret")
+	if err := s.db.DB().AutoMigrate(&models.Job{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	for i := 0; i < defaultJobPageSize+20; i++ {
+		createJob(t, s.db.DB(), fmt.Sprintf("job_%03d", i), models.JobStatusCompleted)
+	}
+	ctx := context.Background()
+
+	resp, err := s.ListJobs(ctx, &v1.ListJobsRequest{})
+	if err != nil {
+		t.Fatalf("ListJobs failed: %v", err)
+	}
+	if len(resp.Jobs) != defaultJobPageSize || resp.Total != defaultJobPageSize+20 || resp.NextPageToken == "" {
+		t.Errorf("Default page: %d jobs, total %d, token %q", len(resp.Jobs), resp.Total, resp.NextPageToken)
+	}
+
+	resp, err = s.ListJobs(ctx, &v1.ListJobsRequest{PageSize: 200})
+	if err != nil {
+		t.Fatalf("ListJobs failed: %v", err)
+	}
+	if len(resp.Jobs) != defaultJobPageSize+20 || resp.NextPageToken != "" {
+		t.Errorf("Large page: %d jobs, token %q", len(resp.Jobs), resp.NextPageToken)
+	}
+
+	// 旧客户端的 page 参数
+	resp, err = s.ListJobs(ctx, &v1.ListJobsRequest{Page: 2})
+	if err != nil {
+		t.Fatalf("ListJobs failed: %v", err)
+	}
+	if len(resp.Jobs) != 20 {
+		t.Errorf("Legacy page 2: %d jobs, want 20", len(resp.Jobs))
+	}
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/paramschema"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validateParamsSchema 检查算法声明的参数 schema 是否有效，空字符串表示不校验
+func validateParamsSchema(schema string) error {
+	if schema == "" {
+		return nil
+	}
+	if _, err := paramschema.Parse(schema); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// validateExecutionParams 按算法的参数 schema 校验执行参数，未设置 schema 时跳过
+func validateExecutionParams(alg *models.Algorithm, params map[string]string) error {
+	if alg.ParamsSchema == "" {
+		return nil
+	}
+
+	schema, err := paramschema.Parse(alg.ParamsSchema)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "algorithm %s has an invalid params schema: %v", alg.ID, err)
+	}
+	if errs := schema.Validate(params); len(errs) > 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid params: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// paramsFileName 执行参数在输入目录中的文件名
+const paramsFileName = "params.json"
+
+// encodeParams 将执行参数序列化为 JSON 对象，没有参数时为 {}
+func encodeParams(params map[string]string) string {
+	if params == nil {
+		params = map[string]string{}
+	}
+	data, _ := json.Marshal(params) // map[string]string 的序列化不会失败
+	return string(data)
+}
+
+// writeParamsFile 将执行参数以 JSON 写入输入目录的 params.json，供算法读取
+func writeParamsFile(inputDir string, params map[string]string) error {
+	if err := os.WriteFile(filepath.Join(inputDir, paramsFileName), []byte(encodeParams(params)), 0644); err != nil {
+		return fmt.Errorf("failed to write params file: %w", err)
+	}
+	return nil
+}
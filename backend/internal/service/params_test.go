@@ -0,0 +1,79 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateExecutionParams(t *testing.T) {
+	alg := &models.Algorithm{
+		ID:           "alg_1",
+		ParamsSchema: `{"properties": {"threshold": {"type": "number", "maximum": 1}}, "required": ["threshold"]}`,
+	}
+
+	if err := validateExecutionParams(alg, map[string]string{"threshold": "0.3"}); err != nil {
+		t.Errorf("Expected valid params, got %v", err)
+	}
+
+	err := validateExecutionParams(alg, map[string]string{"threshold": "3"})
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "threshold") {
+		t.Errorf("Expected InvalidArgument mentioning threshold, got %v", err)
+	}
+
+	// 未设置 schema 时不校验
+	if err := validateExecutionParams(&models.Algorithm{ID: "alg_2"}, map[string]string{"anything": "x"}); err != nil {
+		t.Errorf("Expected no validation without schema, got %v", err)
+	}
+}
+
+func TestValidateParamsSchema(t *testing.T) {
+	if err := validateParamsSchema(""); err != nil {
+		t.Errorf("Empty schema should be accepted, got %v", err)
+	}
+	if err := validateParamsSchema(`{"type": "array"}`); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestWriteParamsFileRoundTrip(t *testing.T) {
+	params := map[string]string{
+		"threshold": "0.5",
+		"label":     `say "hi", map[a:b]`,
+		"名称":        "数据 集",
+		"empty":     "",
+	}
+	dir := t.TempDir()
+	if err := writeParamsFile(dir, params); err != nil {
+		t.Fatalf("writeParamsFile failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, paramsFileName))
+	if err != nil {
+		t.Fatalf("Failed to read params file: %v", err)
+	}
+	var got map[string]string
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("params.json is not valid JSON: %v\n%s", err, data)
+	}
+	if !reflect.DeepEqual(got, params) {
+		t.Errorf("Round-tripped params = %v, want %v", got, params)
+	}
+}
+
+func TestEncodeParams(t *testing.T) {
+	if got := encodeParams(nil); got != "{}" {
+		t.Errorf("encodeParams(nil) = %s, want {}", got)
+	}
+	if got := encodeParams(map[string]string{"b": "2", "a": "1"}); got != `{"a":"1","b":"2"}` {
+		t.Errorf("encodeParams = %s", got)
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	v1 "algorithm-platform/api/v1/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// hostPlatform 返回 goos/goarch 对应的平台及显示名称，无法对应到具体平台时为 PLATFORM_DOCKER
+func hostPlatform(goos, goarch string) (v1.Platform, string) {
+	switch {
+	case goos == "darwin" && goarch == "arm64":
+		return v1.Platform_PLATFORM_MACOS_ARM64, "macOS ARM64"
+	case goos == "windows" && (goarch == "amd64" || goarch == "386"):
+		return v1.Platform_PLATFORM_WINDOWS_X86_64, "Windows x86_64"
+	case goos == "linux" && (goarch == "amd64" || goarch == "386"):
+		return v1.Platform_PLATFORM_LINUX_X86_64, "Linux x86_64"
+	case goos == "linux" && goarch == "arm64":
+		return v1.Platform_PLATFORM_LINUX_ARM64, "Linux ARM64"
+	default:
+		return v1.Platform_PLATFORM_DOCKER, fmt.Sprintf("%s %s", strings.Title(goos), goarch)
+	}
+}
+
+// parseAlgorithmPlatform 解析数据库中保存的算法平台，兼容 linux_x86_64 和 platform_linux_x86_64 两种写法，
+// 为空或无法识别时视为 PLATFORM_DOCKER
+func parseAlgorithmPlatform(stored string) v1.Platform {
+	name := strings.ToUpper(strings.TrimSpace(stored))
+	if !strings.HasPrefix(name, "PLATFORM_") {
+		name = "PLATFORM_" + name
+	}
+	return v1.Platform(v1.Platform_value[name])
+}
+
+// checkPlatformConsistency 检查算法声明的平台能否在本机运行，返回本机平台信息
+func (s *AlgorithmService) checkPlatformConsistency(algorithmPlatform string) (*v1.GetServerInfoResponse, error) {
+	return checkPlatformCompatibility(algorithmPlatform, runtime.GOOS, runtime.GOARCH)
+}
+
+// checkPlatformCompatibility 比较算法平台与 goos/goarch 对应的平台，PLATFORM_DOCKER 的算法在任何平台上都可运行
+func checkPlatformCompatibility(algorithmPlatform, goos, goarch string) (*v1.GetServerInfoResponse, error) {
+	platform, platformName := hostPlatform(goos, goarch)
+	server := &v1.GetServerInfoResponse{
+		Os:           goos,
+		Arch:         goarch,
+		Platform:     platform,
+		PlatformName: platformName,
+	}
+
+	required := parseAlgorithmPlatform(algorithmPlatform)
+	if required == v1.Platform_PLATFORM_DOCKER || required == platform {
+		return server, nil
+	}
+	return server, status.Errorf(codes.FailedPrecondition,
+		"algorithm requires %s but server runs on %s (%s/%s)", required, platformName, goos, goarch)
+}
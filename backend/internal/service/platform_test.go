@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckPlatformCompatibility(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		goos      string
+		goarch    string
+		code      codes.Code
+	}{
+		{"platform_linux_x86_64", "linux", "amd64", codes.OK},
+		{"linux_x86_64", "linux", "amd64", codes.OK},
+		{"platform_linux_x86_64", "darwin", "arm64", codes.FailedPrecondition},
+		{"platform_macos_arm64", "darwin", "arm64", codes.OK},
+		{"platform_macos_arm64", "linux", "amd64", codes.FailedPrecondition},
+		{"platform_linux_arm64", "linux", "amd64", codes.FailedPrecondition},
+		{"platform_linux_arm64", "darwin", "arm64", codes.FailedPrecondition},
+		{"platform_windows_x86_64", "linux", "amd64", codes.FailedPrecondition},
+		{"platform_linux_x86_64", "linux", "riscv64", codes.FailedPrecondition},
+		{"platform_docker", "darwin", "arm64", codes.OK},
+		{"platform_docker", "linux", "riscv64", codes.OK},
+		{"", "linux", "amd64", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s on %s/%s", tt.algorithm, tt.goos, tt.goarch), func(t *testing.T) {
+			server, err := checkPlatformCompatibility(tt.algorithm, tt.goos, tt.goarch)
+			if status.Code(err) != tt.code {
+				t.Fatalf("err = %v, want %v", err, tt.code)
+			}
+			if server.Os != tt.goos || server.Arch != tt.goarch {
+				t.Errorf("Server = %s/%s", server.Os, server.Arch)
+			}
+		})
+	}
+}
+
+func TestParseAlgorithmPlatform(t *testing.T) {
+	for stored, want := range map[string]v1.Platform{
+		"platform_macos_arm64": v1.Platform_PLATFORM_MACOS_ARM64,
+		"linux_arm64":          v1.Platform_PLATFORM_LINUX_ARM64,
+		"":                     v1.Platform_PLATFORM_DOCKER,
+		"unknown":              v1.Platform_PLATFORM_DOCKER,
+	} {
+		if got := parseAlgorithmPlatform(stored); got != want {
+			t.Errorf("parseAlgorithmPlatform(%q) = %v, want %v", stored, got, want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"algorithm-platform/internal/config"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sniffLen http.DetectContentType 使用的最大字节数
+const sniffLen = 512
+
+// sniffPresetData 读取文件开头用于检测内容类型，返回的 reader 仍包含完整内容
+func sniffPresetData(file io.Reader) ([]byte, io.Reader, error) {
+	br := bufio.NewReaderSize(file, sniffLen)
+	head, err := br.Peek(sniffLen)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return head, br, nil
+}
+
+// validatePresetDataContent 检查文件扩展名和内容是否符合分类的限制，不符合时返回 InvalidArgument；
+// 分类没有配置限制时只打印警告
+func validatePresetDataContent(cfg *config.ServerConfig, category, filename string, head []byte) error {
+	rule, ok := cfg.GetPresetDataCategory(category)
+	if !ok || (len(rule.Extensions) == 0 && len(rule.MIMETypes) == 0) {
+		if category != "" {
+			fmt.Printf("Warning: preset data category %q has no content constraints, %s not validated\n", category, filename)
+		}
+		return nil
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	if len(rule.Extensions) > 0 && !containsFold(rule.Extensions, ext) {
+		return status.Errorf(codes.InvalidArgument, "file %s does not match category %s: extension must be one of %s",
+			filename, category, strings.Join(rule.Extensions, ", "))
+	}
+
+	if len(rule.MIMETypes) > 0 {
+		detected := presetDataContentType(filename, head)
+		if !matchesMIMEType(rule.MIMETypes, detected) {
+			return status.Errorf(codes.InvalidArgument, "file %s does not match category %s: content is %s, must be one of %s",
+				filename, category, detected, strings.Join(rule.MIMETypes, ", "))
+		}
+	}
+	return nil
+}
+
+// presetDataContentType 检测文件内容的类型。http.DetectContentType 对 CSV、JSON 等文本只返回 text/plain，
+// 内容是文本时按扩展名表细分为对应的文本类型；二进制内容以检测结果为准，避免改扩展名绕过限制
+func presetDataContentType(filename string, head []byte) string {
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if sniffed != "text/plain" {
+		return sniffed
+	}
+	byExtension, _, _ := mime.ParseMediaType(detectContentType(filename, nil))
+	if isTextContentType(byExtension) {
+		return byExtension
+	}
+	return sniffed
+}
+
+// isTextContentType 判断类型是否为文本格式
+func isTextContentType(contentType string) bool {
+	switch contentType {
+	case "application/json", "application/jsonl", "application/yaml":
+		return true
+	}
+	return strings.HasPrefix(contentType, "text/")
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMIMEType 判断内容类型是否在允许列表中，列表项可以是 image/* 形式的通配
+func matchesMIMEType(allowed []string, detected string) bool {
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(detected, prefix+"/") {
+				return true
+			}
+		} else if pattern == detected {
+			return true
+		}
+	}
+	return false
+}
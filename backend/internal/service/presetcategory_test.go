@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/testutil"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pngHeader PNG 文件签名
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestValidatePresetDataContent(t *testing.T) {
+	cfg := &config.ServerConfig{PresetDataCategories: map[string]config.PresetDataCategory{
+		"Images": {Extensions: []string{".png", ".jpg"}, MIMETypes: []string{"image/*"}},
+		"tables": {Extensions: []string{".csv"}},
+		"csv":    {MIMETypes: []string{"text/csv"}},
+		"any":    {},
+	}}
+
+	tests := []struct {
+		name     string
+		category string
+		filename string
+		head     []byte
+		code     codes.Code
+	}{
+		{"image", "images", "photo.PNG", pngHeader, codes.OK},
+		{"wrong extension", "images", "photo.csv", pngHeader, codes.InvalidArgument},
+		{"text renamed to png", "images", "photo.png", []byte("a,b\n1,2\n"), codes.InvalidArgument},
+		{"extension only", "tables", "data.csv", pngHeader, codes.OK},
+		{"csv by extension", "csv", "data.csv", []byte("a,b\n1,2\n"), codes.OK},
+		{"png renamed to csv", "csv", "data.csv", pngHeader, codes.InvalidArgument},
+		{"text without csv extension", "csv", "data.txt", []byte("a,b\n1,2\n"), codes.InvalidArgument},
+		{"empty rule", "any", "data.bin", nil, codes.OK},
+		{"unconfigured category", "other", "data.bin", nil, codes.OK},
+		{"no category", "", "data.bin", nil, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePresetDataContent(cfg, tt.category, tt.filename, tt.head)
+			if status.Code(err) != tt.code {
+				t.Errorf("err = %v, want %v", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestMatchesMIMEType(t *testing.T) {
+	allowed := []string{"image/*", "text/csv"}
+	for detected, want := range map[string]bool{
+		"image/png":   true,
+		"text/csv":    true,
+		"text/plain":  false,
+		"imagex/png":  false,
+		"application": false,
+	} {
+		if got := matchesMIMEType(allowed, detected); got != want {
+			t.Errorf("matchesMIMEType(%q) = %v, want %v", detected, got, want)
+		}
+	}
+}
+
+func TestSniffPresetDataKeepsContent(t *testing.T) {
+	content := strings.Repeat("x", 3*sniffLen)
+	head, r, err := sniffPresetData(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("sniffPresetData failed: %v", err)
+	}
+	if len(head) != sniffLen {
+		t.Errorf("Sniffed %d bytes, want %d", len(head), sniffLen)
+	}
+	if rest, _ := io.ReadAll(r); string(rest) != content {
+		t.Errorf("Read %d bytes after sniffing, want %d", len(rest), len(content))
+	}
+
+	head, _, err = sniffPresetData(strings.NewReader("short"))
+	if err != nil || string(head) != "short" {
+		t.Errorf("Sniffing a short file: head = %q, err = %v", head, err)
+	}
+}
+
+func TestUploadPresetDataRejectsMismatchedCategory(t *testing.T) {
+	s := newPresetTestService(t)
+	s.cfg().Server.PresetDataCategories = map[string]config.PresetDataCategory{
+		"images": {MIMETypes: []string{"image/*"}},
+	}
+	ctx := context.Background()
+
+	if _, err := s.UploadPresetDataFile(ctx, "表格", "images", "data.png", strings.NewReader("a,b\n")); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Multipart upload: err = %v, want InvalidArgument", err)
+	}
+	if _, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "data.png", Category: "images", FileData: []byte("a,b\n")}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Upload: err = %v, want InvalidArgument", err)
+	}
+	if _, err := s.UploadPresetDataFile(ctx, "图片", "images", "photo.png", strings.NewReader(string(pngHeader))); err != nil {
+		t.Errorf("Uploading a PNG failed: %v", err)
+	}
+
+	var count int64
+	s.db.DB().Table("preset_data").Count(&count)
+	if count != 1 {
+		t.Errorf("%d preset data records, want 1", count)
+	}
+}
+
+func TestStagePresetDataFileChecksCategoryBeforeUpload(t *testing.T) {
+	s := newPresetTestService(t)
+	store, client := testutil.NewS3(t)
+	s.minioClient = client
+	s.cfg().Server.PresetDataCategories = map[string]config.PresetDataCategory{
+		"images": {MIMETypes: []string{"image/*"}},
+	}
+
+	if _, err := s.StagePresetDataFile(context.Background(), "data.png", "images", strings.NewReader("a,b\n")); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err = %v, want InvalidArgument", err)
+	}
+	if keys := store.Keys(); len(keys) != 0 || len(store.PartSizes()) != 0 {
+		t.Errorf("Rejected file was written to MinIO: %v", keys)
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+
+	"algorithm-platform/internal/models"
+
+	"github.com/minio/minio-go/v7"
+	"gorm.io/gorm"
+)
+
+// ErrPresetDataNotFound 预置数据记录或其对象不存在
+var ErrPresetDataNotFound = errors.New("preset data not found")
+
+// PresetDataFile 打开的预置数据文件，调用方负责关闭 Body
+type PresetDataFile struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Body        io.ReadCloser
+}
+
+// presetDataDownloadName 下载时使用的文件名，优先使用上传时保存的文件名
+func presetDataDownloadName(bucket string, data *models.PresetData) string {
+	if data.Filename != "" {
+		return data.Filename
+	}
+	if key := presetDataObjectPath(bucket, data); key != "" {
+		return path.Base(key)
+	}
+	return data.ID
+}
+
+// AttachmentDisposition 生成附件形式的 Content-Disposition，非 ASCII 文件名按 RFC 2231 编码
+func AttachmentDisposition(filename string) string {
+	if disposition := mime.FormatMediaType("attachment", map[string]string{"filename": filename}); disposition != "" {
+		return disposition
+	}
+	return "attachment"
+}
+
+// OpenPresetData 打开预置数据对象用于代理下载，返回的文件名为原始文件名而不是对象键
+func (s *ManagementService) OpenPresetData(ctx context.Context, fileID string) (*PresetDataFile, error) {
+	var data models.PresetData
+	if err := s.db.DB().WithContext(ctx).First(&data, "id = ?", fileID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPresetDataNotFound
+		}
+		return nil, fmt.Errorf("failed to get preset data: %w", err)
+	}
+
+	if s.minioClient == nil {
+		return nil, fmt.Errorf("minio client not available")
+	}
+
+	object, err := s.minioClient.GetObject(ctx, s.bucketName, presetDataObjectPath(s.bucketName, &data), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open preset data: %w", err)
+	}
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		if isObjectMissing(err) {
+			return nil, ErrPresetDataNotFound
+		}
+		return nil, fmt.Errorf("failed to stat preset data: %w", err)
+	}
+
+	contentType := data.ContentType
+	if contentType == "" {
+		contentType = info.ContentType
+	}
+	if contentType == "" || contentType == "binary/octet-stream" {
+		contentType = defaultContentType
+	}
+
+	return &PresetDataFile{
+		Filename:    presetDataDownloadName(s.bucketName, &data),
+		ContentType: contentType,
+		Size:        info.Size,
+		Body:        object,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"mime"
+	"testing"
+
+	"algorithm-platform/internal/models"
+)
+
+func TestPresetDataDownloadName(t *testing.T) {
+	tests := []struct {
+		name string
+		data models.PresetData
+		want string
+	}{
+		{"stored filename", models.PresetData{ID: "data_1", Filename: "销售数据.csv", MinioPath: "preset-data/upload.csv"}, "销售数据.csv"},
+		{"falls back to object name", models.PresetData{ID: "data_2", MinioPath: "prod/preset-data/upload.csv"}, "upload.csv"},
+		{"legacy url", models.PresetData{ID: "data_3", MinioURL: "http://minio:9000/bucket/preset-data/old.json"}, "old.json"},
+		{"no path", models.PresetData{ID: "data_4"}, "data_4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := presetDataDownloadName("bucket", &tt.data); got != tt.want {
+				t.Errorf("presetDataDownloadName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachmentDisposition(t *testing.T) {
+	for _, filename := range []string{"result.csv", "销售数据 2024.csv", `quote".txt`} {
+		disposition := AttachmentDisposition(filename)
+		mediaType, params, err := mime.ParseMediaType(disposition)
+		if err != nil {
+			t.Fatalf("AttachmentDisposition(%q) = %q is not parseable: %v", filename, disposition, err)
+		}
+		if mediaType != "attachment" || params["filename"] != filename {
+			t.Errorf("AttachmentDisposition(%q) = %q, parsed as %q %v", filename, disposition, mediaType, params)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/models"
+
+	"github.com/minio/minio-go/v7"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// presetDataUploadURLExpiry 预签名上传地址的有效期
+const presetDataUploadURLExpiry = time.Hour
+
+// presetDataUploadPartSize 流式上传的分片大小。大小未知时 minio-go 按 5 TiB 计算分片，
+// 每次上传会分配约 537 MiB 的缓冲区；固定分片后只缓存一个分片，最多 10000 片即 160 GiB
+const presetDataUploadPartSize = 16 << 20
+
+// checkUploadSize 检查请求中 file_data 的大小，超过 server.upload_max_size_mb 时返回 ResourceExhausted
+func checkUploadSize(cfg *config.ServerConfig, size int) error {
+	if limit := cfg.GetMaxUploadBytes(); int64(size) > limit {
+		return TooLargeError("file is %d bytes, exceeds the maximum upload size of %d bytes", size, limit)
+	}
+	return nil
+}
+
+// tooLargeReason 请求或上传文件超出大小上限的错误原因，与执行槽位耗尽等其他 ResourceExhausted 区分
+const tooLargeReason = "REQUEST_TOO_LARGE"
+
+// TooLargeError 返回请求或上传文件超出大小上限的 ResourceExhausted 错误，HTTP 网关将其映射为 413
+func TooLargeError(format string, args ...interface{}) error {
+	st, err := status.New(codes.ResourceExhausted, fmt.Sprintf(format, args...)).
+		WithDetails(&errdetails.ErrorInfo{Reason: tooLargeReason, Domain: "algorithm-platform"})
+	if err != nil {
+		return status.Errorf(codes.ResourceExhausted, format, args...)
+	}
+	return st.Err()
+}
+
+// IsTooLarge 判断错误是否由 TooLargeError 产生，经过 gRPC 连接后仍可识别
+func IsTooLarge(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		return false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Reason == tooLargeReason {
+			return true
+		}
+	}
+	return false
+}
+
+// StagedPresetData 已写入 MinIO、尚未登记的预置数据文件。
+// multipart 表单中文件可能先于 filename、category 字段到达，文件先流式写入，读完表单后再登记
+type StagedPresetData struct {
+	id               string
+	objectPath       string
+	originalFilename string
+	checksum         string
+	contentType      string
+	size             int64
+	head             []byte // 文件开头，登记时按分类校验内容类型
+	checkedCategory  string // 写入前已校验过的分类
+}
+
+// StagePresetDataFile 将文件流式写入 MinIO，同时计算校验和和大小，不在内存或磁盘中缓存整个文件。
+// category 已知时先按文件开头校验分类允许的类型，不符合时返回 InvalidArgument，不写入 MinIO；
+// 为空（表单中 category 在文件之后）时由 CommitPresetDataFile 校验
+func (s *ManagementService) StagePresetDataFile(ctx context.Context, originalFilename, category string, file io.Reader) (*StagedPresetData, error) {
+	s.mu.RLock()
+	client, bucket := s.minioClient, s.bucketName
+	s.mu.RUnlock()
+
+	head, file, err := sniffPresetData(file)
+	if err != nil {
+		return nil, err
+	}
+	if category != "" {
+		if err := validatePresetDataContent(&s.cfg().Server, category, originalFilename, head); err != nil {
+			return nil, err
+		}
+	}
+
+	id := fmt.Sprintf("data_%d", time.Now().UnixNano())
+	staged := &StagedPresetData{
+		id:               id,
+		objectPath:       presetDataObjectKey(&s.cfg().MinIO, id, originalFilename),
+		originalFilename: originalFilename,
+		contentType:      detectContentType(originalFilename, head),
+		head:             head,
+		checkedCategory:  category,
+	}
+
+	// 上传的同时计算校验和
+	hash := sha256.New()
+	file = io.TeeReader(file, hash)
+	if client != nil {
+		info, err := client.PutObject(ctx, bucket, staged.objectPath, file, -1, minio.PutObjectOptions{
+			ContentType: staged.contentType,
+			PartSize:    presetDataUploadPartSize,
+		})
+		if err != nil {
+			fmt.Printf("Failed to upload preset data to MinIO: %v\n", err)
+			return nil, fmt.Errorf("failed to upload file: %w", err)
+		}
+		staged.size = info.Size
+	} else if staged.size, err = io.Copy(io.Discard, file); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	staged.checksum = hex.EncodeToString(hash.Sum(nil))
+	return staged, nil
+}
+
+// CommitPresetDataFile 按分类校验已写入的文件并登记，校验或登记失败时删除已写入的对象。
+// 写入前已按同一分类校验过的文件不再校验
+func (s *ManagementService) CommitPresetDataFile(ctx context.Context, staged *StagedPresetData, filename, category string) (*v1.UploadDataResponse, error) {
+	if category == "" || category != staged.checkedCategory {
+		if err := validatePresetDataContent(&s.cfg().Server, category, staged.originalFilename, staged.head); err != nil {
+			s.DiscardPresetDataFile(ctx, staged)
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 数据库只保存路径，不保存完整URL
+	dbPresetData := &models.PresetData{
+		ID:          staged.id,
+		Filename:    filename,
+		Category:    category,
+		MinioPath:   staged.objectPath, // 只保存路径，如: preset-data/data_123/file.zip
+		Checksum:    staged.checksum,
+		Size:        staged.size,
+		ContentType: staged.contentType,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.db.WithRetry(ctx, func(db *gorm.DB) error { return db.Create(dbPresetData).Error }); err != nil {
+		s.removeObject(ctx, staged.objectPath)
+		return nil, fmt.Errorf("failed to create preset data: %w", err)
+	}
+
+	// 返回时拼接完整URL
+	return &v1.UploadDataResponse{
+		FileId:   staged.id,
+		MinioUrl: externalObjectURL(&s.cfg().MinIO, staged.objectPath),
+		Checksum: staged.checksum,
+	}, nil
+}
+
+// DiscardPresetDataFile 删除未登记的文件，上传请求在写入文件后失败时调用
+func (s *ManagementService) DiscardPresetDataFile(ctx context.Context, staged *StagedPresetData) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.removeObject(ctx, staged.objectPath)
+}
+
+// removeObject 删除 MinIO 中的对象，失败时只打印日志；调用方需持有 s.mu
+func (s *ManagementService) removeObject(ctx context.Context, objectPath string) {
+	if s.minioClient == nil {
+		return
+	}
+	if err := s.minioClient.RemoveObject(ctx, s.bucketName, objectPath, minio.RemoveObjectOptions{}); err != nil {
+		fmt.Printf("Failed to remove object %s from MinIO: %v\n", objectPath, err)
+	}
+}
+
+// registerUploadedPresetData 登记通过预签名地址上传的对象。路径必须是 CreatePresetDataUploadURL 生成的
+// preset-data/<id>/<文件名>，登记时沿用其中的 ID，读取对象计算校验和、大小和内容类型，并按分类校验内容
+func (s *ManagementService) registerUploadedPresetData(ctx context.Context, req *v1.UploadDataRequest) (*v1.UploadDataResponse, error) {
+	s.mu.RLock()
+	client, bucket := s.minioClient, s.bucketName
+	s.mu.RUnlock()
+	cfg := s.cfg()
+
+	objectPath := objectPathFromURL(bucket, req.MinioPath)
+	id, ok := presetDataIDFromObjectKey(&cfg.MinIO, objectPath)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "minio_path %s is not a preset data upload path, use CreatePresetDataUploadURL", objectPath)
+	}
+	if client == nil {
+		return nil, status.Error(codes.Unavailable, "object storage is not available")
+	}
+
+	var existing int64
+	if err := s.db.DB().WithContext(ctx).Model(&models.PresetData{}).Where("id = ?", id).Count(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to check preset data: %w", err)
+	}
+	if existing > 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "preset data %s is already registered", id)
+	}
+
+	info, err := client.StatObject(ctx, bucket, objectPath, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, status.Errorf(codes.NotFound, "object %s has not been uploaded", objectPath)
+		}
+		return nil, fmt.Errorf("failed to stat uploaded object: %w", err)
+	}
+	if limit := cfg.Server.GetMaxUploadBytes(); info.Size > limit {
+		return nil, TooLargeError("file is %d bytes, exceeds the maximum upload size of %d bytes", info.Size, limit)
+	}
+
+	obj, err := client.GetObject(ctx, bucket, objectPath, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded object: %w", err)
+	}
+	defer obj.Close()
+	head, file, err := sniffPresetData(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	_, originalFilename := path.Split(objectPath)
+	if err := validatePresetDataContent(&cfg.Server, req.Category, originalFilename, head); err != nil {
+		s.mu.RLock()
+		s.removeObject(ctx, objectPath)
+		s.mu.RUnlock()
+		return nil, err
+	}
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded object: %w", err)
+	}
+
+	filename := req.Filename
+	if filename == "" {
+		filename = originalFilename
+	}
+	dbPresetData := &models.PresetData{
+		ID:          id,
+		Filename:    filename,
+		Category:    req.Category,
+		MinioPath:   objectPath,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		Size:        size,
+		ContentType: detectContentType(originalFilename, head),
+		CreatedAt:   time.Now(),
+	}
+	if err := s.db.WithRetry(ctx, func(db *gorm.DB) error { return db.Create(dbPresetData).Error }); err != nil {
+		return nil, fmt.Errorf("failed to create preset data: %w", err)
+	}
+
+	return &v1.UploadDataResponse{
+		FileId:   id,
+		MinioUrl: externalObjectURL(&cfg.MinIO, objectPath),
+		Checksum: dbPresetData.Checksum,
+	}, nil
+}
+
+// CreatePresetDataUploadURL 生成预签名 PUT 地址，客户端直接上传到 MinIO 后用 minio_path 调用 UploadPresetData 登记
+func (s *ManagementService) CreatePresetDataUploadURL(ctx context.Context, req *v1.CreatePresetDataUploadURLRequest) (*v1.CreatePresetDataUploadURLResponse, error) {
+	if req.Filename == "" {
+		return nil, status.Error(codes.InvalidArgument, "filename is required")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.minioClient == nil {
+		return nil, status.Error(codes.Unavailable, "object storage is not available")
+	}
+
+	id := fmt.Sprintf("data_%d", time.Now().UnixNano())
+	objectPath := presetDataObjectKey(&s.cfg().MinIO, id, req.Filename)
+	expiresAt := time.Now().Add(presetDataUploadURLExpiry)
+	u, err := s.minioClient.PresignedPutObject(ctx, s.bucketName, objectPath, presetDataUploadURLExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate presigned upload URL: %w", err)
+	}
+
+	return &v1.CreatePresetDataUploadURLResponse{
+		UploadUrl: u.String(),
+		MinioPath: objectPath,
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, nil
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newPresetTestService(t *testing.T) *ManagementService {
+	t.Helper()
+	db := testutil.NewDB(t)
+	cfg := &config.Config{MinIO: config.MinIOConfig{Bucket: "bucket", ExternalEndpoint: "localhost:9000"}}
+	return &ManagementService{db: database.NewWithDB(db, cfg), cfgStore: config.NewStore(cfg), bucketName: cfg.MinIO.Bucket}
+}
+
+func TestUploadPresetDataWithSameFilename(t *testing.T) {
+	s := newPresetTestService(t)
+	ctx := context.Background()
+
+	first, err := s.UploadPresetDataFile(ctx, "销售数据", "通用", "data.csv", strings.NewReader("a"))
+	if err != nil {
+		t.Fatalf("First upload failed: %v", err)
+	}
+	second, err := s.UploadPresetDataFile(ctx, "销售数据", "通用", "data.csv", strings.NewReader("b"))
+	if err != nil {
+		t.Fatalf("Second upload failed: %v", err)
+	}
+	third, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "data.csv", FileData: []byte("c")})
+	if err != nil {
+		t.Fatalf("Third upload failed: %v", err)
+	}
+
+	paths := make(map[string]string)
+	for _, id := range []string{first.FileId, second.FileId, third.FileId} {
+		var data models.PresetData
+		if err := s.db.DB().First(&data, "id = ?", id).Error; err != nil {
+			t.Fatalf("Preset data %s not found: %v", id, err)
+		}
+		if want := "preset-data/" + id + "/data.csv"; data.MinioPath != want {
+			t.Errorf("MinioPath = %q, want %q", data.MinioPath, want)
+		}
+		if other, ok := paths[data.MinioPath]; ok {
+			t.Errorf("Uploads %s and %s share object %s", other, id, data.MinioPath)
+		}
+		paths[data.MinioPath] = id
+	}
+}
+
+func TestPresetDataObjectKey(t *testing.T) {
+	cfg := &config.MinIOConfig{KeyPrefix: "staging"}
+	if got := presetDataObjectKey(cfg, "data_1", "../../etc/passwd"); got != "staging/preset-data/data_1/passwd" {
+		t.Errorf("presetDataObjectKey() = %q", got)
+	}
+}
+
+func TestListPresetDataReturnsSingleURL(t *testing.T) {
+	s := newPresetTestService(t)
+	store, client := testutil.NewS3(t)
+	s.minioClient = client
+	ctx := context.Background()
+
+	uploaded, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "data.csv", FileData: []byte("a")})
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	// 以完整URL登记的已上传对象同样只保存路径
+	store.Put("preset-data/data_1/old.csv", []byte("b"))
+	if _, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "old.csv", MinioPath: "http://localhost:9000/bucket/preset-data/data_1/old.csv"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	resp, err := s.ListPresetData(ctx, &v1.ListPresetDataRequest{})
+	if err != nil {
+		t.Fatalf("ListPresetData failed: %v", err)
+	}
+	want := map[string]bool{
+		"http://localhost:9000/bucket/preset-data/" + uploaded.FileId + "/data.csv": true,
+		"http://localhost:9000/bucket/preset-data/data_1/old.csv":                   true,
+	}
+	if len(resp.Files) != len(want) {
+		t.Fatalf("Listed %d files, want %d", len(resp.Files), len(want))
+	}
+	for _, file := range resp.Files {
+		if !want[file.MinioUrl] || strings.Count(file.MinioUrl, "://") != 1 {
+			t.Errorf("Unexpected URL for %s: %s", file.Filename, file.MinioUrl)
+		}
+	}
+
+	var stored []string
+	s.db.DB().Model(&models.PresetData{}).Pluck("minio_path", &stored)
+	for _, path := range stored {
+		if strings.Contains(path, "://") {
+			t.Errorf("Stored a full URL instead of an object path: %s", path)
+		}
+	}
+}
+
+func TestGetPresetData(t *testing.T) {
+	s := newPresetTestService(t)
+	ctx := context.Background()
+
+	uploaded, err := s.UploadPresetDataFile(ctx, "销售数据", "通用", "data.csv", strings.NewReader("x,y\n"))
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+
+	got, err := s.GetPresetData(ctx, &v1.GetPresetDataRequest{Id: uploaded.FileId})
+	if err != nil {
+		t.Fatalf("GetPresetData failed: %v", err)
+	}
+	if got.Filename != "销售数据" || got.Category != "通用" || got.Size != 4 || got.Checksum != uploaded.Checksum ||
+		got.MinioUrl != uploaded.MinioUrl || got.CreatedAt == nil {
+		t.Errorf("Unexpected preset data: %+v", got)
+	}
+
+	if _, err := s.GetPresetData(ctx, &v1.GetPresetDataRequest{Id: "data_missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Missing id: err = %v, want NotFound", err)
+	}
+	if _, err := s.GetPresetData(ctx, &v1.GetPresetDataRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Empty id: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestGetPresetDataStatsLegacySize(t *testing.T) {
+	s := newPresetTestService(t)
+	store, client := testutil.NewS3(t)
+	store.Put("preset-data/old.csv", []byte("legacy content"))
+	s.minioClient = client
+	createPresetData(t, s, models.PresetData{ID: "data_old", Filename: "old.csv", MinioPath: "preset-data/old.csv"})
+
+	got, err := s.GetPresetData(context.Background(), &v1.GetPresetDataRequest{Id: "data_old"})
+	if err != nil {
+		t.Fatalf("GetPresetData failed: %v", err)
+	}
+	if got.Size != int64(len("legacy content")) {
+		t.Errorf("Size = %d, want %d", got.Size, len("legacy content"))
+	}
+}
+
+func TestUploadPresetDataFileFieldsAfterFile(t *testing.T) {
+	s := newPresetTestService(t)
+	ctx := context.Background()
+
+	// 文件先写入，表单字段读完后再登记
+	staged, err := s.StagePresetDataFile(ctx, "data.csv", "", strings.NewReader("x,y\n"))
+	if err != nil {
+		t.Fatalf("StagePresetDataFile failed: %v", err)
+	}
+	var count int64
+	s.db.DB().Model(&models.PresetData{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("Staging created %d records, want 0", count)
+	}
+
+	resp, err := s.CommitPresetDataFile(ctx, staged, "销售数据", "通用")
+	if err != nil {
+		t.Fatalf("CommitPresetDataFile failed: %v", err)
+	}
+	got, err := s.GetPresetData(ctx, &v1.GetPresetDataRequest{Id: resp.FileId})
+	if err != nil {
+		t.Fatalf("GetPresetData failed: %v", err)
+	}
+	if got.Filename != "销售数据" || got.Category != "通用" || got.Size != 4 || got.Checksum != resp.Checksum {
+		t.Errorf("Unexpected preset data: %+v", got)
+	}
+}
+
+func TestCreatePresetDataUploadURL(t *testing.T) {
+	s := newPresetTestService(t)
+	ctx := context.Background()
+
+	if _, err := s.CreatePresetDataUploadURL(ctx, &v1.CreatePresetDataUploadURLRequest{Filename: "big.csv"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Without MinIO: err = %v, want Unavailable", err)
+	}
+
+	store, client := testutil.NewS3(t)
+	s.minioClient = client
+	if _, err := s.CreatePresetDataUploadURL(ctx, &v1.CreatePresetDataUploadURLRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Empty filename: err = %v, want InvalidArgument", err)
+	}
+
+	resp, err := s.CreatePresetDataUploadURL(ctx, &v1.CreatePresetDataUploadURLRequest{Filename: "../big.csv"})
+	if err != nil {
+		t.Fatalf("CreatePresetDataUploadURL failed: %v", err)
+	}
+	if !strings.HasPrefix(resp.MinioPath, "preset-data/data_") || strings.Contains(resp.MinioPath, "..") {
+		t.Errorf("Unexpected object path %q", resp.MinioPath)
+	}
+	if !strings.Contains(resp.UploadUrl, "/bucket/"+resp.MinioPath) || !strings.Contains(resp.UploadUrl, "X-Amz-Signature=") {
+		t.Errorf("Unexpected upload URL %q", resp.UploadUrl)
+	}
+	if resp.ExpiresAt.AsTime().Before(time.Now()) {
+		t.Errorf("Upload URL already expired at %v", resp.ExpiresAt.AsTime())
+	}
+
+	// 上传前登记失败
+	if _, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "big.csv", MinioPath: resp.MinioPath}); status.Code(err) != codes.NotFound {
+		t.Errorf("Before upload: err = %v, want NotFound", err)
+	}
+
+	// 客户端直接 PUT 到预签名地址，再以 minio_path 登记
+	put, _ := http.NewRequest(http.MethodPut, resp.UploadUrl, strings.NewReader("x,y\n"))
+	putResp, err := http.DefaultClient.Do(put)
+	if err != nil {
+		t.Fatalf("PUT to the upload URL failed: %v", err)
+	}
+	putResp.Body.Close()
+
+	registered, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "大文件", Category: "通用", MinioPath: resp.MinioPath})
+	if err != nil {
+		t.Fatalf("UploadPresetData failed: %v", err)
+	}
+	if !strings.HasSuffix(registered.MinioUrl, "/bucket/"+resp.MinioPath) {
+		t.Errorf("Registered URL %q does not point at %s", registered.MinioUrl, resp.MinioPath)
+	}
+	if want := strings.Split(resp.MinioPath, "/")[1]; registered.FileId != want {
+		t.Errorf("FileId = %q, want the id from the upload path %q", registered.FileId, want)
+	}
+	got, err := s.GetPresetData(ctx, &v1.GetPresetDataRequest{Id: registered.FileId})
+	if err != nil {
+		t.Fatalf("GetPresetData failed: %v", err)
+	}
+	if got.Filename != "大文件" || got.Size != 4 || got.Checksum != xyChecksum || registered.Checksum != xyChecksum || !strings.HasPrefix(got.ContentType, "text/csv") {
+		t.Errorf("Unexpected preset data: %+v", got)
+	}
+
+	// 同一路径不能重复登记
+	if _, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{MinioPath: resp.MinioPath}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Second registration: err = %v, want AlreadyExists", err)
+	}
+	if _, ok := store.Object(resp.MinioPath); !ok {
+		t.Error("Registered object was removed")
+	}
+}
+
+func TestRegisterPresetDataRejectsForeignKeys(t *testing.T) {
+	s := newPresetTestService(t)
+	store, client := testutil.NewS3(t)
+	s.minioClient = client
+	ctx := context.Background()
+
+	for _, key := range []string{
+		"database-backup/latest.json.gz",
+		"algorithms/alg_1/v1/main.py",
+		"preset-data/old.csv",
+		"preset-data/other/data.csv",
+		"preset-data/data_1/nested/data.csv",
+		"staging/preset-data/data_1/data.csv",
+		"http://localhost:9000/bucket/database-backup/latest.json.gz",
+	} {
+		store.Put(strings.TrimPrefix(key, "http://localhost:9000/bucket/"), []byte("secret"))
+		if _, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "data.csv", MinioPath: key}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: err = %v, want InvalidArgument", key, err)
+		}
+	}
+	var count int64
+	s.db.DB().Model(&models.PresetData{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Registered %d foreign objects", count)
+	}
+}
+
+func TestRegisterPresetDataValidatesCategory(t *testing.T) {
+	s := newPresetTestService(t)
+	s.cfg().Server.PresetDataCategories = map[string]config.PresetDataCategory{
+		"images": {Extensions: []string{".png"}, MIMETypes: []string{"image/*"}},
+	}
+	store, client := testutil.NewS3(t)
+	s.minioClient = client
+
+	// 扩展名正确但内容不是图片，拒绝并删除对象
+	store.Put("preset-data/data_1/photo.png", []byte("#!/bin/sh\nrm -rf /\n"))
+	_, err := s.UploadPresetData(context.Background(), &v1.UploadDataRequest{Category: "images", MinioPath: "preset-data/data_1/photo.png"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err = %v, want InvalidArgument", err)
+	}
+	if _, ok := store.Object("preset-data/data_1/photo.png"); ok {
+		t.Error("Rejected object was not removed")
+	}
+}
+
+func TestStagePresetDataFileUploadsInParts(t *testing.T) {
+	s := newPresetTestService(t)
+	store, client := testutil.NewS3(t)
+	s.minioClient = client
+
+	// 大小未知的流按固定分片上传
+	data := bytes.Repeat([]byte("0123456789abcdef"), (presetDataUploadPartSize+1<<20)/16)
+	staged, err := s.StagePresetDataFile(context.Background(), "big.bin", "", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("StagePresetDataFile failed: %v", err)
+	}
+	if staged.size != int64(len(data)) {
+		t.Errorf("size = %d, want %d", staged.size, len(data))
+	}
+	if stored, _ := store.Object(staged.objectPath); !bytes.Equal(stored, data) {
+		t.Errorf("Stored %d bytes, want %d", len(stored), len(data))
+	}
+	if parts := store.PartSizes(); len(parts) != 2 || parts[0] != presetDataUploadPartSize {
+		t.Errorf("Part sizes = %v, want [%d %d]", parts, presetDataUploadPartSize, len(data)-presetDataUploadPartSize)
+	}
+}
+
+func TestUploadSizeLimit(t *testing.T) {
+	s := newPresetTestService(t)
+	s.cfg().Server.UploadMaxSizeMB = 1
+	const limit = 1 << 20
+	ctx := context.Background()
+
+	if _, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "under.bin", FileData: make([]byte, limit-1)}); err != nil {
+		t.Errorf("One byte under the limit: %v", err)
+	}
+	if _, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "at.bin", FileData: make([]byte, limit)}); err != nil {
+		t.Errorf("At the limit: %v", err)
+	}
+
+	_, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "over.bin", FileData: make([]byte, limit+1)})
+	if status.Code(err) != codes.ResourceExhausted || !strings.Contains(err.Error(), "1048576 bytes") {
+		t.Errorf("One byte over the limit: err = %v, want ResourceExhausted with the limit", err)
+	}
+	if _, err := s.CreateVersion(ctx, &v1.CreateVersionRequest{AlgorithmId: "alg", FileName: "code.zip", FileData: make([]byte, limit+1)}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("CreateVersion over the limit: err = %v, want ResourceExhausted", err)
+	}
+	if _, err := s.CreateAlgorithm(ctx, &v1.CreateAlgorithmRequest{Name: "alg", FileName: "code.zip", FileData: make([]byte, limit+1)}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("CreateAlgorithm over the limit: err = %v, want ResourceExhausted", err)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// 相关算法推荐的默认值和上限
+const (
+	defaultRelatedLimit = 5
+	maxRelatedLimit     = 50
+	relatedCacheTTL     = 30 * time.Second
+)
+
+type relatedCacheKey struct {
+	id    string
+	limit int32
+}
+
+type cachedRelated struct {
+	resp     *v1.GetRelatedAlgorithmsResponse
+	cachedAt time.Time
+}
+
+// GetRelatedAlgorithms 推荐与指定算法标签重叠或分类相同的算法，结果短时缓存
+func (s *ManagementService) GetRelatedAlgorithms(ctx context.Context, req *v1.GetRelatedAlgorithmsRequest) (*v1.GetRelatedAlgorithmsResponse, error) {
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultRelatedLimit
+	}
+	limit = min(limit, maxRelatedLimit)
+
+	key := relatedCacheKey{id: req.Id, limit: limit}
+	s.relatedMu.Lock()
+	defer s.relatedMu.Unlock()
+
+	if cached, ok := s.relatedCache[key]; ok && time.Since(cached.cachedAt) < relatedCacheTTL {
+		return cached.resp, nil
+	}
+
+	related, err := findRelatedAlgorithms(s.db.DB().WithContext(ctx), req.Id, int(limit))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "algorithm %s not found", req.Id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &v1.GetRelatedAlgorithmsResponse{Algorithms: related}
+
+	now := time.Now()
+	for k, cached := range s.relatedCache {
+		if now.Sub(cached.cachedAt) >= relatedCacheTTL {
+			delete(s.relatedCache, k)
+		}
+	}
+	s.relatedCache[key] = cachedRelated{resp: resp, cachedAt: now}
+
+	return resp, nil
+}
+
+// normalizeTags 将逗号分隔的标签转换为去空格、小写、去重后的集合
+func normalizeTags(tags string) map[string]bool {
+	set := make(map[string]bool)
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			set[tag] = true
+		}
+	}
+	return set
+}
+
+// findRelatedAlgorithms 按共同标签数排序，其次是分类相同，最后按名称；不包含自身和已停用的算法
+func findRelatedAlgorithms(db *gorm.DB, algorithmID string, limit int) ([]*v1.RelatedAlgorithm, error) {
+	var target models.Algorithm
+	if err := db.First(&target, "id = ?", algorithmID).Error; err != nil {
+		return nil, err
+	}
+
+	targetTags := normalizeTags(target.Tags)
+	category := strings.TrimSpace(target.Category)
+	if len(targetTags) == 0 && category == "" {
+		return []*v1.RelatedAlgorithm{}, nil
+	}
+
+	var candidates []models.Algorithm
+	if err := db.Where("id <> ?", algorithmID).Find(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to list algorithms: %w", err)
+	}
+
+	type scored struct {
+		algorithm    *models.Algorithm
+		sharedTags   []string
+		sameCategory bool
+	}
+	var matches []scored
+	for i := range candidates {
+		candidate := &candidates[i]
+		if algorithmStatus(candidate) == models.AlgorithmStatusDisabled {
+			continue
+		}
+		var shared []string
+		for tag := range normalizeTags(candidate.Tags) {
+			if targetTags[tag] {
+				shared = append(shared, tag)
+			}
+		}
+		sameCategory := category != "" && strings.EqualFold(strings.TrimSpace(candidate.Category), category)
+		if len(shared) == 0 && !sameCategory {
+			continue
+		}
+		sort.Strings(shared)
+		matches = append(matches, scored{algorithm: candidate, sharedTags: shared, sameCategory: sameCategory})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if len(a.sharedTags) != len(b.sharedTags) {
+			return len(a.sharedTags) > len(b.sharedTags)
+		}
+		if a.sameCategory != b.sameCategory {
+			return a.sameCategory
+		}
+		if a.algorithm.Name != b.algorithm.Name {
+			return a.algorithm.Name < b.algorithm.Name
+		}
+		return a.algorithm.ID < b.algorithm.ID
+	})
+
+	related := make([]*v1.RelatedAlgorithm, 0, min(limit, len(matches)))
+	for _, match := range matches[:min(limit, len(matches))] {
+		related = append(related, &v1.RelatedAlgorithm{
+			Algorithm:    modelToProto(match.algorithm),
+			SharedTags:   match.sharedTags,
+			SameCategory: match.sameCategory,
+		})
+	}
+	return related, nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"algorithm-platform/internal/models"
+
+	"gorm.io/gorm"
+)
+
+func TestFindRelatedAlgorithms(t *testing.T) {
+	db := newAlgorithmTestDB(t,
+		models.Algorithm{ID: "alg_target", Name: "target", Category: "图像", Tags: "OCR, detection,cv", Status: models.AlgorithmStatusReady},
+		models.Algorithm{ID: "alg_two", Name: "two tags", Category: "文本", Tags: "ocr,cv", Status: models.AlgorithmStatusReady},
+		models.Algorithm{ID: "alg_one_cat", Name: "b one tag", Category: "图像", Tags: "cv", Status: models.AlgorithmStatusReady},
+		models.Algorithm{ID: "alg_one", Name: "a one tag", Category: "文本", Tags: "detection", Status: models.AlgorithmStatusReady},
+		models.Algorithm{ID: "alg_cat", Name: "category only", Category: "图像", Status: models.AlgorithmStatusReady},
+		models.Algorithm{ID: "alg_none", Name: "unrelated", Category: "文本", Tags: "nlp", Status: models.AlgorithmStatusReady},
+		models.Algorithm{ID: "alg_disabled", Name: "disabled", Category: "图像", Tags: "ocr,cv,detection", Status: models.AlgorithmStatusDisabled},
+	)
+
+	related, err := findRelatedAlgorithms(db, "alg_target", 10)
+	if err != nil {
+		t.Fatalf("findRelatedAlgorithms failed: %v", err)
+	}
+
+	var ids []string
+	for _, r := range related {
+		ids = append(ids, r.Algorithm.Id)
+	}
+	want := []string{"alg_two", "alg_one_cat", "alg_one", "alg_cat"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Fatalf("Related = %v, want %v", ids, want)
+	}
+	if !reflect.DeepEqual(related[0].SharedTags, []string{"cv", "ocr"}) || related[0].SameCategory {
+		t.Errorf("Unexpected match details: %v", related[0])
+	}
+	if !related[3].SameCategory || len(related[3].SharedTags) != 0 {
+		t.Errorf("Unexpected match details: %v", related[3])
+	}
+
+	limited, err := findRelatedAlgorithms(db, "alg_target", 2)
+	if err != nil || len(limited) != 2 {
+		t.Errorf("Limit not applied: %d results, err %v", len(limited), err)
+	}
+
+	if _, err := findRelatedAlgorithms(db, "missing", 5); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("Missing algorithm: err = %v", err)
+	}
+}
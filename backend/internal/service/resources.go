@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/scheduler"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// resolveResourceConfig 计算任务实际使用的资源限制
+// 优先级：请求参数 > 算法默认值 > 平台默认值，CPU 和内存分别解析
+func resolveResourceConfig(req *v1.ResourceConfig, algorithm *models.Algorithm, dockerCfg *config.DockerConfig) (scheduler.ResourceConfig, error) {
+	resources := scheduler.ResourceConfig{
+		CPULimit: dockerCfg.DefaultCPULimit,
+		MemoryMB: dockerCfg.DefaultMemoryMB,
+	}
+
+	if algorithm.DefaultCPULimit > 0 {
+		resources.CPULimit = algorithm.DefaultCPULimit
+	}
+	if algorithm.DefaultMemoryMB > 0 {
+		resources.MemoryMB = algorithm.DefaultMemoryMB
+	}
+
+	if req.GetCpuLimit() > 0 {
+		resources.CPULimit = float64(req.GetCpuLimit())
+	}
+	if req.GetMemoryLimit() != "" {
+		memoryMB, err := parseMemoryLimitMB(req.GetMemoryLimit())
+		if err != nil {
+			return resources, err
+		}
+		resources.MemoryMB = memoryMB
+	}
+
+	// 0 表示不限制，配置了上限时改为按上限运行，否则默认值为 0 时可以绕过上限
+	if resources.CPULimit <= 0 && dockerCfg.MaxCPULimit > 0 {
+		resources.CPULimit = dockerCfg.MaxCPULimit
+	}
+	if resources.MemoryMB <= 0 && dockerCfg.MaxMemoryMB > 0 {
+		resources.MemoryMB = dockerCfg.MaxMemoryMB
+	}
+
+	return resources, nil
+}
+
+// validateResourceLimits 检查资源申请是否超过配置的上限，超出时返回 InvalidArgument 并说明允许的最大值
+func validateResourceLimits(cpuLimit float64, memoryMB int, dockerCfg *config.DockerConfig) error {
+	if dockerCfg.MaxCPULimit > 0 && cpuLimit > dockerCfg.MaxCPULimit {
+		return status.Errorf(codes.InvalidArgument, "cpu limit %.2f exceeds maximum allowed %.2f", cpuLimit, dockerCfg.MaxCPULimit)
+	}
+	if dockerCfg.MaxMemoryMB > 0 && memoryMB > dockerCfg.MaxMemoryMB {
+		return status.Errorf(codes.InvalidArgument, "memory limit %dMB exceeds maximum allowed %dMB", memoryMB, dockerCfg.MaxMemoryMB)
+	}
+	return nil
+}
+
+// parseMemoryLimitMB 解析内存限制为 MB，支持 "512"、"512m"、"512Mi"、"2g"、"2Gi" 等写法，无单位时按 MB 处理。
+// 不足整 MB 的部分向上取整，"512k" 为 1MB 而不是表示不限制的 0
+func parseMemoryLimitMB(limit string) (int, error) {
+	value := strings.ToLower(strings.TrimSpace(limit))
+	value = strings.TrimSuffix(strings.TrimSuffix(value, "b"), "i")
+
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(value, "g"):
+		multiplier = 1024
+		value = strings.TrimSuffix(value, "g")
+	case strings.HasSuffix(value, "m"):
+		value = strings.TrimSuffix(value, "m")
+	case strings.HasSuffix(value, "k"):
+		multiplier = 1.0 / 1024
+		value = strings.TrimSuffix(value, "k")
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount <= 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, fmt.Errorf("invalid memory limit %q", limit)
+	}
+
+	memoryMB := math.Ceil(amount * multiplier)
+	if memoryMB > math.MaxInt32 {
+		return 0, fmt.Errorf("invalid memory limit %q", limit)
+	}
+	return int(memoryMB), nil
+}
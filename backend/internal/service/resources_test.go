@@ -0,0 +1,119 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResolveResourceConfigPrecedence(t *testing.T) {
+	dockerCfg := &config.DockerConfig{DefaultCPULimit: 1, DefaultMemoryMB: 512}
+
+	// 都未指定时使用平台默认值
+	resources, err := resolveResourceConfig(nil, &models.Algorithm{}, dockerCfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resources.CPULimit != 1 || resources.MemoryMB != 512 {
+		t.Errorf("Expected platform defaults, got %+v", resources)
+	}
+
+	// 算法默认值覆盖平台默认值
+	algorithm := &models.Algorithm{DefaultCPULimit: 2, DefaultMemoryMB: 2048}
+	resources, _ = resolveResourceConfig(nil, algorithm, dockerCfg)
+	if resources.CPULimit != 2 || resources.MemoryMB != 2048 {
+		t.Errorf("Expected algorithm defaults, got %+v", resources)
+	}
+
+	// 请求参数优先，未指定的项仍使用算法默认值
+	resources, _ = resolveResourceConfig(&v1.ResourceConfig{CpuLimit: 4}, algorithm, dockerCfg)
+	if resources.CPULimit != 4 || resources.MemoryMB != 2048 {
+		t.Errorf("Expected request CPU with algorithm memory, got %+v", resources)
+	}
+
+	resources, _ = resolveResourceConfig(&v1.ResourceConfig{MemoryLimit: "1g"}, algorithm, dockerCfg)
+	if resources.CPULimit != 2 || resources.MemoryMB != 1024 {
+		t.Errorf("Expected algorithm CPU with request memory, got %+v", resources)
+	}
+
+	if _, err := resolveResourceConfig(&v1.ResourceConfig{MemoryLimit: "lots"}, algorithm, dockerCfg); err == nil {
+		t.Error("Expected error for invalid memory limit")
+	}
+}
+
+func TestParseMemoryLimitMB(t *testing.T) {
+	cases := map[string]int{
+		"512":   512,
+		"512m":  512,
+		"512Mi": 512,
+		"512MB": 512,
+		"2g":    2048,
+		"2Gi":   2048,
+		"1.5G":  1536,
+		"512k":  1, // 不足 1MB 向上取整，不能变成表示不限制的 0
+		"1025k": 2,
+	}
+
+	for input, want := range cases {
+		got, err := parseMemoryLimitMB(input)
+		if err != nil {
+			t.Errorf("parseMemoryLimitMB(%q) returned error: %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseMemoryLimitMB(%q) = %d, want %d", input, got, want)
+		}
+	}
+
+	for _, input := range []string{"0", "-1g", "nan", "inf", "1e30g"} {
+		if _, err := parseMemoryLimitMB(input); err == nil {
+			t.Errorf("parseMemoryLimitMB(%q) should fail", input)
+		}
+	}
+}
+
+func TestResolveResourceConfigUnlimitedUsesMaximum(t *testing.T) {
+	// 默认值为 0（不限制）但配置了上限时按上限运行
+	dockerCfg := &config.DockerConfig{MaxCPULimit: 4, MaxMemoryMB: 8192}
+	resources, err := resolveResourceConfig(nil, &models.Algorithm{}, dockerCfg)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resources.CPULimit != 4 || resources.MemoryMB != 8192 {
+		t.Errorf("Expected the maximums, got %+v", resources)
+	}
+
+	resources, _ = resolveResourceConfig(nil, &models.Algorithm{}, &config.DockerConfig{})
+	if resources.CPULimit != 0 || resources.MemoryMB != 0 {
+		t.Errorf("Expected unlimited without maximums, got %+v", resources)
+	}
+}
+
+func TestValidateResourceLimits(t *testing.T) {
+	dockerCfg := &config.DockerConfig{MaxCPULimit: 4, MaxMemoryMB: 8192}
+
+	if err := validateResourceLimits(4, 8192, dockerCfg); err != nil {
+		t.Errorf("Expected limits at the maximum to pass, got %v", err)
+	}
+
+	err := validateResourceLimits(8, 1024, dockerCfg)
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "4.00") {
+		t.Errorf("Expected InvalidArgument mentioning the CPU maximum, got %v", err)
+	}
+
+	err = validateResourceLimits(1, 16384, dockerCfg)
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "8192MB") {
+		t.Errorf("Expected InvalidArgument mentioning the memory maximum, got %v", err)
+	}
+
+	// 上限为 0 表示不限制
+	if err := validateResourceLimits(64, 1<<20, &config.DockerConfig{}); err != nil {
+		t.Errorf("Expected no error without configured maximums, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// resultCacheTimeout 单次缓存读写的超时时间，Redis 不可用时不拖慢执行请求
+const resultCacheTimeout = 500 * time.Millisecond
+
+// ResultCache 同步执行结果的缓存，由 cache.Cache 实现
+type ResultCache interface {
+	GenerateKey(algorithmID string, params map[string]string, inputURL string) string
+	GetJSON(ctx context.Context, key string, dest interface{}) error
+	SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// cachedResult 缓存中保存的内容，结果地址在命中时从任务记录读取，任务被清理后缓存随之失效
+type cachedResult struct {
+	JobID string `json:"job_id"`
+}
+
+// resultCacheKey 计算缓存键，算法部分包含当前版本，发布新版本后旧结果不再命中；
+// 按镜像标签运行时镜像可能已变化，与按摘要运行的结果分开缓存
+func resultCacheKey(c ResultCache, algorithm *models.Algorithm, req *v1.ExecuteRequest) string {
+	id := algorithm.ID + "@" + algorithm.CurrentVersionID
+	if req.UseImageTag {
+		id += "+image_tag"
+	}
+	return c.GenerateKey(id, req.Params, joinInputRefs(req))
+}
+
+// lookupCachedResult 查找缓存的已完成任务，未命中或缓存不可用时返回 nil
+func lookupCachedResult(ctx context.Context, c ResultCache, db *gorm.DB, key string) *models.Job {
+	cacheCtx, cancel := context.WithTimeout(ctx, resultCacheTimeout)
+	defer cancel()
+
+	var cached cachedResult
+	if err := c.GetJSON(cacheCtx, key, &cached); err != nil {
+		if !errors.Is(err, redis.Nil) {
+			fmt.Printf("Warning: failed to read result cache: %v\n", err)
+		}
+		return nil
+	}
+
+	var job models.Job
+	err := db.First(&job, "id = ?", cached.JobID).Error
+	if err == nil && job.Status == models.JobStatusCompleted && job.OutputURL != "" &&
+		(job.ArtifactsExpireAt == nil || time.Now().Before(*job.ArtifactsExpireAt)) {
+		return &job
+	}
+
+	// 任务已被清理或结果已过期
+	if err := c.Delete(cacheCtx, key); err != nil {
+		fmt.Printf("Warning: failed to delete stale result cache entry: %v\n", err)
+	}
+	return nil
+}
+
+// storeCachedResult 缓存已完成的任务，缓存时长不超过结果保留时长
+func storeCachedResult(ctx context.Context, c ResultCache, key, jobID string, ttl, retention time.Duration) {
+	if retention > 0 && ttl > retention {
+		ttl = retention
+	}
+
+	cacheCtx, cancel := context.WithTimeout(ctx, resultCacheTimeout)
+	defer cancel()
+
+	if err := c.SetJSON(cacheCtx, key, cachedResult{JobID: jobID}, ttl); err != nil {
+		fmt.Printf("Warning: failed to write result cache for job %s: %v\n", jobID, err)
+	}
+}
+
+// executeWithCache 同步执行时先查缓存，命中则直接返回之前任务的结果，否则执行并写入缓存。
+// 引用了密钥的请求不使用缓存：结果取决于密钥的值，也不能返回给没有该密钥的请求
+func (s *AlgorithmService) executeWithCache(ctx context.Context, req *v1.ExecuteRequest) (*v1.ExecuteResponse, error) {
+	ttl := s.cfg().Redis.GetResultCacheTTL()
+	if s.resultCache == nil || ttl <= 0 || req.Mode != models.ExecutionModeSync || req.NoCache || len(req.Secrets) > 0 {
+		return s.execute(ctx, req, "", "")
+	}
+
+	algorithm := &models.Algorithm{}
+	if err := s.db.DB().First(algorithm, "id = ?", req.AlgorithmId).Error; err != nil {
+		return nil, fmt.Errorf("algorithm not found: %w", err)
+	}
+	if err := checkAlgorithmRunnable(algorithm); err != nil {
+		return nil, err
+	}
+
+	key := resultCacheKey(s.resultCache, algorithm, req)
+	if !req.ForceRefresh {
+		if job := lookupCachedResult(ctx, s.resultCache, s.db.DB(), key); job != nil {
+			return &v1.ExecuteResponse{
+				JobId:     job.ID,
+				Status:    job.Status,
+				ResultUrl: externalObjectURL(&s.cfg().MinIO, objectPathFromURL(s.cfg().MinIO.Bucket, job.OutputURL)),
+				Message:   fmt.Sprintf("Cached result of job %s", job.ID),
+				Cached:    true,
+			}, nil
+		}
+	}
+
+	resp, err := s.execute(ctx, req, "", "")
+	if err == nil && resp.Status == models.JobStatusCompleted {
+		storeCachedResult(ctx, s.resultCache, key, resp.JobId, ttl, s.cfg().MinIO.GetResultRetention())
+	}
+	return resp, err
+}
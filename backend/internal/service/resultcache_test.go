@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type fakeResultCache struct {
+	entries map[string][]byte
+	ttls    map[string]time.Duration
+}
+
+func newFakeResultCache() *fakeResultCache {
+	return &fakeResultCache{entries: make(map[string][]byte), ttls: make(map[string]time.Duration)}
+}
+
+func (f *fakeResultCache) GenerateKey(algorithmID string, params map[string]string, inputURL string) string {
+	return fmt.Sprintf("%s|%v|%s", algorithmID, params, inputURL)
+}
+
+func (f *fakeResultCache) GetJSON(ctx context.Context, key string, dest interface{}) error {
+	data, ok := f.entries[key]
+	if !ok {
+		return redis.Nil
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (f *fakeResultCache) SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	f.entries[key] = data
+	f.ttls[key] = expiration
+	return nil
+}
+
+func (f *fakeResultCache) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(f.entries, key)
+	}
+	return nil
+}
+
+func TestResultCacheKeyIncludesVersion(t *testing.T) {
+	c := newFakeResultCache()
+	req := &v1.ExecuteRequest{Params: map[string]string{"k": "v"}, InputSource: &v1.InputSource{Url: "preset-data/a.csv"}}
+
+	v1Key := resultCacheKey(c, &models.Algorithm{ID: "alg_1", CurrentVersionID: "ver_1"}, req)
+	v2Key := resultCacheKey(c, &models.Algorithm{ID: "alg_1", CurrentVersionID: "ver_2"}, req)
+	if v1Key == v2Key {
+		t.Error("Results of different versions should not share a cache key")
+	}
+}
+
+func TestResultCacheKeySeparatesImageTagRuns(t *testing.T) {
+	c := newFakeResultCache()
+	algorithm := &models.Algorithm{ID: "alg_1", CurrentVersionID: "ver_1"}
+
+	pinned := resultCacheKey(c, algorithm, &v1.ExecuteRequest{Params: map[string]string{"k": "v"}})
+	tagged := resultCacheKey(c, algorithm, &v1.ExecuteRequest{Params: map[string]string{"k": "v"}, UseImageTag: true})
+	if pinned == tagged {
+		t.Error("Runs on the image tag should not share a cache key with runs on the pinned digest")
+	}
+}
+
+func TestExecuteWithCacheSkipsRequestsWithSecrets(t *testing.T) {
+	s, _ := newExecutorTestService(t, map[string][]byte{"ver_1": []byte("print('v1')")})
+	c := newFakeResultCache()
+	s.resultCache = c
+	cfg := *s.cfg()
+	cfg.Redis.ResultCacheTTLStr = "1h"
+	cfg.Docker.Secrets = map[string]config.SecretEntry{"api_key": {Value: "s3cr3t", AllowedAlgorithms: []string{"*"}}}
+	s.cfgStore = config.NewStore(&cfg)
+
+	req := &v1.ExecuteRequest{AlgorithmId: "alg_1", Mode: models.ExecutionModeSync, UseImageTag: true, Secrets: map[string]string{"API_KEY": "api_key"}}
+	for i := 0; i < 2; i++ {
+		resp, err := s.executeWithCache(context.Background(), req)
+		if err != nil {
+			t.Fatalf("executeWithCache failed: %v", err)
+		}
+		if resp.Cached {
+			t.Fatal("Requests with secrets should not be served from the cache")
+		}
+	}
+	if len(c.entries) != 0 {
+		t.Errorf("Results of requests with secrets should not be cached, got %d entries", len(c.entries))
+	}
+}
+
+func TestLookupCachedResult(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	c := newFakeResultCache()
+
+	expired := time.Now().Add(-time.Minute)
+	jobs := []models.Job{
+		{ID: "job_ok", Status: models.JobStatusCompleted, OutputURL: "results/job_ok"},
+		{ID: "job_expired", Status: models.JobStatusCompleted, OutputURL: "results/job_expired", ArtifactsExpireAt: &expired},
+	}
+	if err := db.Create(&jobs).Error; err != nil {
+		t.Fatalf("Failed to create jobs: %v", err)
+	}
+
+	if job := lookupCachedResult(ctx, c, db, "missing"); job != nil {
+		t.Errorf("Expected miss, got %s", job.ID)
+	}
+
+	storeCachedResult(ctx, c, "hit", "job_ok", time.Hour, 24*time.Hour)
+	if job := lookupCachedResult(ctx, c, db, "hit"); job == nil || job.ID != "job_ok" {
+		t.Errorf("Expected job_ok, got %v", job)
+	}
+
+	// 任务被清理或结果过期后缓存条目失效并被删除
+	storeCachedResult(ctx, c, "trimmed", "job_gone", time.Hour, 0)
+	storeCachedResult(ctx, c, "expired", "job_expired", time.Hour, 0)
+	for _, key := range []string{"trimmed", "expired"} {
+		if job := lookupCachedResult(ctx, c, db, key); job != nil {
+			t.Errorf("%s: expected miss, got %s", key, job.ID)
+		}
+		if _, ok := c.entries[key]; ok {
+			t.Errorf("%s: stale entry should be deleted", key)
+		}
+	}
+}
+
+func TestStoreCachedResultCapsTTLAtRetention(t *testing.T) {
+	c := newFakeResultCache()
+	storeCachedResult(context.Background(), c, "key", "job_1", 48*time.Hour, 24*time.Hour)
+	if c.ttls["key"] != 24*time.Hour {
+		t.Errorf("TTL = %v, want result retention 24h", c.ttls["key"])
+	}
+}
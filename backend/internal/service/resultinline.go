@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// inlineResultTimeout 读取内嵌结果的超时时间，超时后 webhook 只带 result_url
+const inlineResultTimeout = 30 * time.Second
+
+// readInlineResult 结果对象不超过 limit 字节时读取其内容，超过时返回 false
+func readInlineResult(ctx context.Context, client *minio.Client, bucket, key string, limit int64) ([]byte, bool, error) {
+	info, err := client.StatObject(ctx, bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to stat result: %w", err)
+	}
+	if info.Size > limit {
+		return nil, false, nil
+	}
+
+	obj, err := client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get result: %w", err)
+	}
+	defer obj.Close()
+
+	// 对象可能在 stat 之后被覆盖，读取时同样限制大小
+	data, err := io.ReadAll(io.LimitReader(obj, limit+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read result: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, false, nil
+	}
+	return data, true, nil
+}
+
+// addInlineResult 已完成任务的结果足够小时，将其以 base64 写入 webhook 的 result_inline 字段
+func (s *AlgorithmService) addInlineResult(ctx context.Context, webhookData map[string]interface{}, jobID string, result *v1.ExecuteResponse) {
+	limit := s.cfg().Server.WebhookInlineMaxBytes
+	if limit <= 0 || s.minioClient == nil || result.GetStatus() != models.JobStatusCompleted || result.GetResultUrl() == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, inlineResultTimeout)
+	defer cancel()
+
+	data, ok, err := readInlineResult(ctx, s.minioClient, s.cfg().MinIO.Bucket, resultObjectPath(&s.cfg().MinIO, jobID), limit)
+	if err != nil {
+		fmt.Printf("Warning: failed to inline result of job %s in webhook: %v\n", jobID, err)
+		return
+	}
+	if ok {
+		webhookData["result_inline"] = base64.StdEncoding.EncodeToString(data)
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+)
+
+func TestSendWebhookInlinesSmallResults(t *testing.T) {
+	const result = `{"score":0.9}`
+	tests := []struct {
+		name   string
+		limit  int64
+		status string
+		inline bool
+	}{
+		{"small result", 1024, models.JobStatusCompleted, true},
+		{"exactly at limit", int64(len(result)), models.JobStatusCompleted, true},
+		{"larger than limit", 4, models.JobStatusCompleted, false},
+		{"disabled", 0, models.JobStatusCompleted, false},
+		{"failed job", 1024, models.JobStatusFailed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, client := testutil.NewS3(t)
+			var payload map[string]interface{}
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				json.Unmarshal(body, &payload)
+			}))
+			defer receiver.Close()
+
+			s := newJobContextTestService(t)
+			s.minioClient = client
+			s.cfg().MinIO.Bucket = "bucket"
+			s.cfg().Server.WebhookInlineMaxBytes = tt.limit
+			createJob(t, s.db.DB(), "job_1", tt.status)
+			store.Put(resultObjectPath(&s.cfg().MinIO, "job_1"), []byte(result))
+
+			s.sendWebhook(context.Background(), receiver.URL, "job_1", &v1.ExecuteResponse{
+				JobId: "job_1", Status: tt.status, ResultUrl: "http://localhost:9000/bucket/results/job_1",
+			}, nil)
+
+			inline, ok := payload["result_inline"].(string)
+			if ok != tt.inline {
+				t.Fatalf("result_inline present = %v, want %v (payload %v)", ok, tt.inline, payload)
+			}
+			if !ok {
+				return
+			}
+			if decoded, _ := base64.StdEncoding.DecodeString(inline); string(decoded) != result {
+				t.Errorf("result_inline = %q, want %q", decoded, result)
+			}
+		})
+	}
+}
+
+func TestAsyncWebhookCarriesServerResult(t *testing.T) {
+	s, _ := newExecutorTestService(t, map[string][]byte{"ver_1": []byte("print('v1')")})
+	s.cfg().MinIO.ExternalEndpoint = "localhost:9000"
+	s.cfg().Server.WebhookInlineMaxBytes = 1024
+
+	payloads := make(chan map[string]interface{}, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&payload)
+		payloads <- payload
+	}))
+	defer receiver.Close()
+
+	resp, err := s.ExecuteAlgorithm(context.Background(), &v1.ExecuteRequest{
+		AlgorithmId: "alg_1", Mode: models.ExecutionModeAsync, WebhookUrl: receiver.URL, UseImageTag: true,
+	})
+	if err != nil {
+		t.Fatalf("ExecuteAlgorithm failed: %v", err)
+	}
+
+	var payload map[string]interface{}
+	select {
+	case payload = <-payloads:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Webhook was not delivered")
+	}
+
+	jobStatus, err := s.GetJobStatus(context.Background(), &v1.GetJobStatusRequest{JobId: resp.JobId})
+	if err != nil {
+		t.Fatalf("GetJobStatus failed: %v", err)
+	}
+	if jobStatus.ResultUrl == "" || payload["result_url"] != jobStatus.ResultUrl {
+		t.Errorf("Webhook result_url = %v, want the URL reported by the server %q", payload["result_url"], jobStatus.ResultUrl)
+	}
+	inline, _ := payload["result_inline"].(string)
+	if decoded, _ := base64.StdEncoding.DecodeString(inline); string(decoded) != "print('v1')" {
+		t.Errorf("result_inline = %q, want the stored result", decoded)
+	}
+
+	// 等待后台任务记录投递结果后再结束，避免关闭数据库时仍在写入
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		var job models.Job
+		if s.db.DB().First(&job, "id = ?", resp.JobId).Error == nil && job.WebhookStatus != "" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Webhook delivery was not recorded")
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/redact"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// envNamePattern 可注入的环境变量名
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reservedEnv 平台设置的环境变量，密钥不能覆盖
+var reservedEnv = map[string]bool{"JOB_ID": true, "ALGORITHM_ID": true, "INPUT_DIR": true, "OUTPUT_DIR": true}
+
+// resolveSecretEnv 按请求中的 环境变量名 → 密钥名 查找密钥值，密钥不存在或变量名无效时返回 InvalidArgument，
+// 密钥的 allowed_algorithms 不包含该算法时返回 PermissionDenied
+func resolveSecretEnv(cfg *config.DockerConfig, algorithm *models.Algorithm, refs map[string]string) (map[string]string, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	secrets, err := cfg.LoadSecrets()
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "secrets are not available: %v", err)
+	}
+
+	env := make(map[string]string, len(refs))
+	for name, secret := range refs {
+		if !envNamePattern.MatchString(name) || reservedEnv[name] {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid secret environment variable %q", name)
+		}
+		entry, ok := secrets[secret]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "secret %q not found", secret)
+		}
+		if !entry.Allows(algorithm.ID, algorithm.Name) {
+			return nil, status.Errorf(codes.PermissionDenied, "algorithm %s is not allowed to use secret %q", algorithm.ID, secret)
+		}
+		env[name] = entry.Value
+	}
+	return env, nil
+}
+
+// jobSecretRedactor 按任务保存的请求重新解析密钥，用于脱敏任务的实时日志。密钥已不存在时只脱敏仍能找到的值
+func jobSecretRedactor(cfg *config.DockerConfig, job *models.Job) *redact.Values {
+	req, err := decodeExecuteRequest(job.Request)
+	if err != nil || len(req.Secrets) == 0 {
+		return nil
+	}
+	secrets, err := cfg.LoadSecrets()
+	if err != nil {
+		fmt.Printf("Warning: failed to load secrets for redacting logs of job %s: %v\n", job.ID, err)
+		return nil
+	}
+	var values []string
+	for _, secret := range req.Secrets {
+		if entry, ok := secrets[secret]; ok {
+			values = append(values, entry.Value)
+		}
+	}
+	return redact.NewValues(values)
+}
+
+// envRedactor 为注入的密钥环境变量创建脱敏器
+func envRedactor(env map[string]string) *redact.Values {
+	values := make([]string, 0, len(env))
+	for _, value := range env {
+		values = append(values, value)
+	}
+	return redact.NewValues(values)
+}
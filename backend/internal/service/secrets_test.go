@@ -0,0 +1,65 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/redact"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResolveSecretEnv(t *testing.T) {
+	cfg := &config.DockerConfig{Secrets: map[string]config.SecretEntry{
+		"weather_key": {Value: "s3cr3t", AllowedAlgorithms: []string{"weather"}},
+		"db_password": {Value: "pw"},
+	}}
+	algorithm := &models.Algorithm{ID: "alg_1", Name: "weather"}
+
+	tests := []struct {
+		name string
+		refs map[string]string
+		code codes.Code
+	}{
+		{"no secrets", nil, codes.OK},
+		{"known secret", map[string]string{"WEATHER_API_KEY": "weather_key"}, codes.OK},
+		{"missing secret", map[string]string{"API_KEY": "missing"}, codes.InvalidArgument},
+		{"invalid env name", map[string]string{"API-KEY": "weather_key"}, codes.InvalidArgument},
+		{"reserved env name", map[string]string{"JOB_ID": "weather_key"}, codes.InvalidArgument},
+		{"secret not allowed", map[string]string{"DB_PASSWORD": "db_password"}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := resolveSecretEnv(cfg, algorithm, tt.refs)
+			if status.Code(err) != tt.code {
+				t.Fatalf("err = %v, want %v", err, tt.code)
+			}
+			if err == nil && len(tt.refs) > 0 && env["WEATHER_API_KEY"] != "s3cr3t" {
+				t.Errorf("env = %v", env)
+			}
+			if err != nil && strings.Contains(err.Error(), "s3cr3t") {
+				t.Errorf("Error leaks the secret value: %v", err)
+			}
+		})
+	}
+}
+
+func TestJobSecretRedactor(t *testing.T) {
+	cfg := &config.DockerConfig{Secrets: map[string]config.SecretEntry{"weather_key": {Value: "s3cr3t"}}}
+	req := &v1.ExecuteRequest{AlgorithmId: "alg_1", Secrets: map[string]string{"API_KEY": "weather_key"}}
+	job := &models.Job{ID: "job_1", Request: encodeExecuteRequest(req)}
+
+	if strings.Contains(job.Request, "s3cr3t") {
+		t.Fatalf("Stored request contains the secret value: %s", job.Request)
+	}
+	if got := jobSecretRedactor(cfg, job).String("key s3cr3t"); got != "key "+redact.Placeholder {
+		t.Errorf("redact = %q", got)
+	}
+	if jobSecretRedactor(cfg, &models.Job{ID: "job_2"}) != nil {
+		t.Error("Jobs without secrets should not get a redactor")
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/keys"
+	"algorithm-platform/pkg/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// storageCheckTimeout 存储自检的总超时时间
+const storageCheckTimeout = 30 * time.Second
+
+// EnsureStorage 重新创建 bucket 并验证读写，MinIO 在服务启动后才就绪或 bucket 被删除时无需重启即可恢复
+func (s *ManagementService) EnsureStorage(ctx context.Context, req *v1.EnsureStorageRequest) (*v1.EnsureStorageResponse, error) {
+	if err := requireAdmin(ctx, s.cfg().Server.AdminToken); err != nil {
+		return nil, err
+	}
+	if s.minioClient == nil {
+		return nil, status.Error(codes.Unavailable, "MinIO client is not available")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, storageCheckTimeout)
+	defer cancel()
+
+	probeKey := s.cfg().MinIO.ObjectKey(keys.StorageProbe(fmt.Sprintf("%d", time.Now().UnixNano())))
+	checks := storage.VerifyStorage(ctx, s.minioClient, s.bucketName, probeKey)
+
+	return storageCheckResponse(s.bucketName, checks), nil
+}
+
+// storageCheckResponse 将自检结果转换为 proto，所有步骤都完成且成功时 ok 为 true
+func storageCheckResponse(bucket string, checks []storage.StorageCheck) *v1.EnsureStorageResponse {
+	resp := &v1.EnsureStorageResponse{Bucket: bucket, Ok: len(checks) > 0}
+	for _, check := range checks {
+		resp.Steps = append(resp.Steps, &v1.StorageCheckStep{
+			Name:       check.Name,
+			Ok:         check.OK,
+			Message:    check.Message,
+			DurationMs: check.Duration.Milliseconds(),
+		})
+		if !check.OK {
+			resp.Ok = false
+		}
+	}
+	return resp
+}
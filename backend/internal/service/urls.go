@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/keys"
+	"algorithm-platform/internal/models"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// externalObjectURL 根据当前配置拼接对象的外部访问URL
+// 数据库只保存对象路径，URL 在读取时生成，避免 endpoint 或证书变更后返回失效地址
+func externalObjectURL(cfg *config.MinIOConfig, objectPath string) string {
+	if objectPath == "" {
+		return ""
+	}
+
+	scheme := "http"
+	if cfg.UseSSL {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s/%s/%s", scheme, cfg.ExternalEndpoint, cfg.Bucket, objectPath)
+}
+
+// objectPathFromURL 将历史数据中保存的完整URL还原为对象路径，已经是路径时原样返回
+func objectPathFromURL(bucket, stored string) string {
+	u, err := url.Parse(stored)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return strings.TrimPrefix(stored, "/")
+	}
+
+	objectPath := strings.TrimPrefix(u.Path, "/")
+	return strings.TrimPrefix(objectPath, bucket+"/")
+}
+
+// presetDataObjectKey 新上传的预置数据的对象路径，按数据 ID 分目录，同名文件不会互相覆盖
+func presetDataObjectKey(cfg *config.MinIOConfig, id, filename string) string {
+	return cfg.ObjectKey(keys.PresetData(id, filename))
+}
+
+// presetDataIDFromObjectKey 返回 presetDataObjectKey 生成的对象路径中的数据 ID，其他路径返回 false
+func presetDataIDFromObjectKey(cfg *config.MinIOConfig, objectPath string) (string, bool) {
+	dir, filename := path.Split(objectPath)
+	id := path.Base(dir)
+	if !strings.HasPrefix(id, "data_") || filename == "" {
+		return "", false
+	}
+	return id, presetDataObjectKey(cfg, id, filename) == objectPath
+}
+
+// presetDataObjectPath 返回预置数据的对象路径，兼容只保存了 MinioURL 的旧记录
+func presetDataObjectPath(bucket string, data *models.PresetData) string {
+	if data.MinioPath != "" {
+		return data.MinioPath
+	}
+	return objectPathFromURL(bucket, data.MinioURL)
+}
+
+// presignedDownloadParams 预签名下载地址的响应头参数，让浏览器按原始文件名和类型保存
+// contentType 为空时按文件扩展名推断
+func presignedDownloadParams(filename, contentType string) url.Values {
+	if contentType == "" || contentType == "binary/octet-stream" {
+		contentType = mime.TypeByExtension(path.Ext(filename))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	params := url.Values{}
+	params.Set("response-content-disposition", AttachmentDisposition(filename))
+	params.Set("response-content-type", contentType)
+	return params
+}
+
+// downloadSigner 生成下载地址所需的对象存储操作，由 *minio.Client 实现
+type downloadSigner interface {
+	StatObject(ctx context.Context, bucketName, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error)
+	PresignedGetObject(ctx context.Context, bucketName, objectName string, expires time.Duration, reqParams url.Values) (*url.URL, error)
+}
+
+// errObjectMissing 对象或 bucket 不存在
+var errObjectMissing = errors.New("object does not exist")
+
+// isObjectMissing 判断 MinIO 错误是否表示对象或 bucket 不存在
+func isObjectMissing(err error) bool {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey", "NoSuchBucket":
+		return true
+	}
+	return false
+}
+
+// presignDownload 生成带文件名和类型的预签名下载地址
+func presignDownload(ctx context.Context, signer downloadSigner, bucket, objectPath string, expiry time.Duration, filename, contentType string) (*url.URL, error) {
+	return signer.PresignedGetObject(ctx, bucket, objectPath, expiry, presignedDownloadParams(filename, contentType))
+}
+
+// presignExistingObject 确认对象存在后再生成预签名下载地址，避免返回打开即 404 的链接
+// 对象或 bucket 不存在时返回 errObjectMissing
+func presignExistingObject(ctx context.Context, signer downloadSigner, bucket, objectPath string, expiry time.Duration, filename string) (string, error) {
+	info, err := signer.StatObject(ctx, bucket, objectPath, minio.StatObjectOptions{})
+	if err != nil {
+		if isObjectMissing(err) {
+			return "", fmt.Errorf("%w: %s", errObjectMissing, objectPath)
+		}
+		return "", fmt.Errorf("failed to stat %s: %w", objectPath, err)
+	}
+
+	u, err := presignDownload(ctx, signer, bucket, objectPath, expiry, filename, info.ContentType)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate presigned URL: %w", err)
+	}
+	return u.String(), nil
+}
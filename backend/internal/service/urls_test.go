@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestExternalObjectURLReflectsCurrentConfig(t *testing.T) {
+	objectPath := "results/job_1"
+
+	localCfg := &config.MinIOConfig{ExternalEndpoint: "localhost:9000", Bucket: "algorithm-platform"}
+	if got := externalObjectURL(localCfg, objectPath); got != "http://localhost:9000/algorithm-platform/results/job_1" {
+		t.Errorf("Unexpected URL for local config: %s", got)
+	}
+
+	// 修改 endpoint 和 SSL 后，同一个对象路径应生成新的地址
+	prodCfg := &config.MinIOConfig{ExternalEndpoint: "files.example.com", Bucket: "algorithm-platform", UseSSL: true}
+	if got := externalObjectURL(prodCfg, objectPath); got != "https://files.example.com/algorithm-platform/results/job_1" {
+		t.Errorf("Unexpected URL for prod config: %s", got)
+	}
+
+	if got := externalObjectURL(prodCfg, ""); got != "" {
+		t.Errorf("Expected empty URL for empty path, got %s", got)
+	}
+}
+
+func TestObjectPathFromURL(t *testing.T) {
+	cases := []struct {
+		stored string
+		want   string
+	}{
+		{"preset-data/a.csv", "preset-data/a.csv"},
+		{"/preset-data/a.csv", "preset-data/a.csv"},
+		{"http://old-host:9000/algorithm-platform/preset-data/a.csv", "preset-data/a.csv"},
+		{"https://cdn.example.com/algorithm-platform/results/job_1", "results/job_1"},
+		{"", ""},
+	}
+
+	for _, c := range cases {
+		if got := objectPathFromURL("algorithm-platform", c.stored); got != c.want {
+			t.Errorf("objectPathFromURL(%q) = %q, want %q", c.stored, got, c.want)
+		}
+	}
+}
+
+func TestResolveJobArtifactsUsesCurrentConfig(t *testing.T) {
+	cfg := &config.MinIOConfig{ExternalEndpoint: "new-host:9000", Bucket: "algorithm-platform"}
+	expireAt := time.Now().Add(time.Hour)
+
+	// 旧记录中保存的是完整URL，读取时应按当前配置重新生成
+	job := &models.Job{
+		ID:                "job_1",
+		OutputURL:         "http://old-host:9000/algorithm-platform/results/job_1",
+		ArtifactsExpireAt: &expireAt,
+	}
+
+	url, expired := resolveJobArtifacts(context.Background(), nil, cfg, job)
+	if expired {
+		t.Fatal("Expected artifacts not to be expired")
+	}
+	if url != "http://new-host:9000/algorithm-platform/results/job_1" {
+		t.Errorf("Expected URL built from current config, got %s", url)
+	}
+
+	// 过期且无法确认对象存在时应返回过期状态
+	expiredAt := time.Now().Add(-time.Hour)
+	job.ArtifactsExpireAt = &expiredAt
+	url, expired = resolveJobArtifacts(context.Background(), nil, cfg, job)
+	if !expired || url != "" {
+		t.Errorf("Expected expired artifacts with empty URL, got expired=%v url=%s", expired, url)
+	}
+}
+
+func TestResolveJobArtifactsAfterExpiry(t *testing.T) {
+	store, client := testutil.NewS3(t)
+	cfg := &config.MinIOConfig{ExternalEndpoint: "localhost:9000", Bucket: "bucket"}
+	expiredAt := time.Now().Add(-time.Hour)
+	job := &models.Job{ID: "job_1", OutputURL: "results/job_1", ArtifactsExpireAt: &expiredAt}
+
+	// 过期后对象已被清理，应报告过期且不返回地址
+	url, expired := resolveJobArtifacts(context.Background(), client, cfg, job)
+	if !expired || url != "" {
+		t.Errorf("Missing object: expired=%v url=%q, want expired with empty URL", expired, url)
+	}
+
+	// 过期后对象仍存在，应重新生成预签名地址
+	store.Put("results/job_1", []byte("{}"))
+	url, expired = resolveJobArtifacts(context.Background(), client, cfg, job)
+	if expired {
+		t.Error("Expected artifacts that still exist not to be reported as expired")
+	}
+	if !strings.Contains(url, "/bucket/results/job_1?") || !strings.Contains(url, "X-Amz-Signature=") {
+		t.Errorf("Expected a presigned URL for the result, got %q", url)
+	}
+}
+
+func TestPresignDownloadSetsResponseHeaders(t *testing.T) {
+	// 指定 Region 后生成预签名地址不需要访问 MinIO
+	client, err := minio.New("localhost:9000", &minio.Options{
+		Creds:  credentials.NewStaticV4("access", "secret", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	u, err := presignDownload(context.Background(), client, "bucket", "preset-data/upload_1", time.Hour, "销售数据.csv", "")
+	if err != nil {
+		t.Fatalf("presignDownload failed: %v", err)
+	}
+
+	query := u.Query()
+	_, params, err := mime.ParseMediaType(query.Get("response-content-disposition"))
+	if err != nil || params["filename"] != "销售数据.csv" {
+		t.Errorf("response-content-disposition = %q, parsed filename %q", query.Get("response-content-disposition"), params["filename"])
+	}
+	if got := query.Get("response-content-type"); got != "text/csv; charset=utf-8" {
+		t.Errorf("response-content-type = %q", got)
+	}
+	if query.Get("X-Amz-Signature") == "" {
+		t.Error("Expected a signed URL")
+	}
+}
+
+func TestPresignedDownloadParamsContentType(t *testing.T) {
+	tests := []struct {
+		filename, contentType, want string
+	}{
+		{"result", "application/json", "application/json"},
+		{"result", "", "application/octet-stream"},
+		{"data.json", "binary/octet-stream", "application/json"},
+	}
+	for _, tt := range tests {
+		if got := presignedDownloadParams(tt.filename, tt.contentType).Get("response-content-type"); got != tt.want {
+			t.Errorf("presignedDownloadParams(%q, %q) content type = %q, want %q", tt.filename, tt.contentType, got, tt.want)
+		}
+	}
+}
+
+// fakeSigner 记录生成预签名地址的调用，statErr 为 StatObject 返回的错误
+type fakeSigner struct {
+	statErr error
+	signed  []string
+}
+
+func (f *fakeSigner) StatObject(ctx context.Context, bucketName, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
+	if f.statErr != nil {
+		return minio.ObjectInfo{}, f.statErr
+	}
+	return minio.ObjectInfo{Key: objectName, ContentType: "text/csv"}, nil
+}
+
+func (f *fakeSigner) PresignedGetObject(ctx context.Context, bucketName, objectName string, expires time.Duration, reqParams url.Values) (*url.URL, error) {
+	f.signed = append(f.signed, objectName)
+	return &url.URL{Scheme: "http", Host: "minio:9000", Path: "/" + bucketName + "/" + objectName, RawQuery: reqParams.Encode()}, nil
+}
+
+func TestPresetDataDownloadURLMissingObject(t *testing.T) {
+	data := &models.PresetData{ID: "data_1", Filename: "a.csv", MinioPath: "preset-data/data_1/a.csv"}
+
+	for _, code := range []string{"NoSuchKey", "NoSuchBucket"} {
+		signer := &fakeSigner{statErr: minio.ErrorResponse{Code: code}}
+		if _, err := presetDataDownloadURL(context.Background(), signer, "bucket", data); !errors.Is(err, ErrPresetDataNotFound) {
+			t.Errorf("%s: err = %v, want ErrPresetDataNotFound", code, err)
+		}
+		if len(signer.signed) != 0 {
+			t.Errorf("%s: presigned a URL for a missing object", code)
+		}
+	}
+
+	signer := &fakeSigner{statErr: errors.New("connection refused")}
+	if _, err := presetDataDownloadURL(context.Background(), signer, "bucket", data); err == nil || errors.Is(err, ErrPresetDataNotFound) {
+		t.Errorf("Unavailable MinIO: err = %v, want a non-NotFound error", err)
+	}
+
+	signer = &fakeSigner{}
+	got, err := presetDataDownloadURL(context.Background(), signer, "bucket", data)
+	if err != nil || !strings.Contains(got, "/bucket/preset-data/data_1/a.csv?") {
+		t.Errorf("presetDataDownloadURL() = %q, %v", got, err)
+	}
+}
+
+func TestGetPresetDataDownloadURLUnknownFile(t *testing.T) {
+	s := newPresetTestService(t)
+	if _, err := s.GetPresetDataDownloadURL(context.Background(), "missing"); !errors.Is(err, ErrPresetDataNotFound) {
+		t.Errorf("err = %v, want ErrPresetDataNotFound", err)
+	}
+}
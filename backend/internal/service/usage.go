@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// 使用统计的默认值和上限
+const (
+	defaultUsageWindowHours = 7 * 24
+	maxUsageWindowHours     = 90 * 24
+	defaultUsageLimit       = 10
+	maxUsageLimit           = 100
+	usageCacheTTL           = 30 * time.Second
+)
+
+type usageCacheKey struct {
+	windowHours int32
+	limit       int32
+}
+
+type cachedUsage struct {
+	resp     *v1.GetUsageStatsResponse
+	cachedAt time.Time
+}
+
+// GetUsageStats 返回时间窗口内任务数最多的算法及其成功率，结果按参数短时缓存
+func (s *ManagementService) GetUsageStats(ctx context.Context, req *v1.GetUsageStatsRequest) (*v1.GetUsageStatsResponse, error) {
+	windowHours, limit := req.WindowHours, req.Limit
+	if windowHours < 0 || limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "window_hours and limit must not be negative")
+	}
+	if windowHours == 0 {
+		windowHours = defaultUsageWindowHours
+	}
+	if windowHours > maxUsageWindowHours {
+		return nil, status.Errorf(codes.InvalidArgument, "window_hours must be at most %d", maxUsageWindowHours)
+	}
+	if limit == 0 {
+		limit = defaultUsageLimit
+	}
+	limit = min(limit, maxUsageLimit)
+
+	key := usageCacheKey{windowHours: windowHours, limit: limit}
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+
+	if cached, ok := s.usageCache[key]; ok && time.Since(cached.cachedAt) < usageCacheTTL {
+		return cached.resp, nil
+	}
+
+	now := time.Now()
+	since := now.Add(-time.Duration(windowHours) * time.Hour)
+	usage, err := queryAlgorithmUsage(s.db.DB().WithContext(ctx), since, int(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &v1.GetUsageStatsResponse{
+		Algorithms:  usage,
+		WindowStart: timestamppb.New(since),
+		GeneratedAt: timestamppb.New(now),
+	}
+
+	for k, cached := range s.usageCache {
+		if time.Since(cached.cachedAt) >= usageCacheTTL {
+			delete(s.usageCache, k)
+		}
+	}
+	s.usageCache[key] = cachedUsage{resp: resp, cachedAt: now}
+
+	return resp, nil
+}
+
+// queryAlgorithmUsage 按算法分组统计 since 之后创建的任务，返回任务数最多的 limit 个算法
+func queryAlgorithmUsage(db *gorm.DB, since time.Time, limit int) ([]*v1.AlgorithmUsage, error) {
+	var rows []struct {
+		AlgorithmID    string
+		AlgorithmName  string
+		JobCount       int64
+		CompletedCount int64
+		FailedCount    int64
+	}
+	err := db.Model(&models.Job{}).
+		Select("algorithm_id, MAX(algorithm_name) AS algorithm_name, COUNT(*) AS job_count, "+
+			"SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS completed_count, "+
+			"SUM(CASE WHEN status IN ? THEN 1 ELSE 0 END) AS failed_count",
+			models.JobStatusCompleted, []string{models.JobStatusFailed, models.JobStatusTimeout}).
+		Where("created_at >= ?", since).
+		Group("algorithm_id").
+		Order("job_count DESC, algorithm_id ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query algorithm usage: %w", err)
+	}
+
+	usage := make([]*v1.AlgorithmUsage, len(rows))
+	for i, row := range rows {
+		usage[i] = &v1.AlgorithmUsage{
+			AlgorithmId:    row.AlgorithmID,
+			AlgorithmName:  row.AlgorithmName,
+			JobCount:       row.JobCount,
+			CompletedCount: row.CompletedCount,
+			FailedCount:    row.FailedCount,
+		}
+		if finished := row.CompletedCount + row.FailedCount; finished > 0 {
+			usage[i].SuccessRate = float64(row.CompletedCount) / float64(finished)
+		}
+	}
+	return usage, nil
+}
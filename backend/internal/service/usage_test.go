@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+)
+
+func TestQueryAlgorithmUsage(t *testing.T) {
+	db := testutil.NewDB(t)
+	now := time.Now()
+	jobs := []models.Job{
+		{ID: "j1", AlgorithmID: "alg_a", AlgorithmName: "detector", Status: models.JobStatusCompleted, CreatedAt: now},
+		{ID: "j2", AlgorithmID: "alg_a", AlgorithmName: "detector", Status: models.JobStatusCompleted, CreatedAt: now},
+		{ID: "j3", AlgorithmID: "alg_a", AlgorithmName: "detector", Status: models.JobStatusTimeout, CreatedAt: now},
+		{ID: "j4", AlgorithmID: "alg_a", AlgorithmName: "detector", Status: models.JobStatusRunning, CreatedAt: now},
+		{ID: "j5", AlgorithmID: "alg_b", AlgorithmName: "classifier", Status: models.JobStatusPending, CreatedAt: now},
+		{ID: "j6", AlgorithmID: "alg_c", AlgorithmName: "ranker", Status: models.JobStatusFailed, CreatedAt: now},
+		{ID: "j7", AlgorithmID: "alg_c", AlgorithmName: "ranker", Status: models.JobStatusFailed, CreatedAt: now},
+		// 窗口之外的任务不计入
+		{ID: "j8", AlgorithmID: "alg_b", AlgorithmName: "classifier", Status: models.JobStatusCompleted, CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "j9", AlgorithmID: "alg_b", AlgorithmName: "classifier", Status: models.JobStatusCompleted, CreatedAt: now.Add(-48 * time.Hour)},
+	}
+	if err := db.Create(&jobs).Error; err != nil {
+		t.Fatalf("Failed to create jobs: %v", err)
+	}
+
+	usage, err := queryAlgorithmUsage(db, now.Add(-24*time.Hour), 2)
+	if err != nil {
+		t.Fatalf("queryAlgorithmUsage failed: %v", err)
+	}
+	if len(usage) != 2 || usage[0].AlgorithmId != "alg_a" || usage[1].AlgorithmId != "alg_c" {
+		t.Fatalf("Unexpected ranking: %v", usage)
+	}
+
+	a := usage[0]
+	if a.AlgorithmName != "detector" || a.JobCount != 4 || a.CompletedCount != 2 || a.FailedCount != 1 {
+		t.Errorf("Unexpected stats for alg_a: %v", a)
+	}
+	if a.SuccessRate < 0.66 || a.SuccessRate > 0.67 {
+		t.Errorf("SuccessRate = %v, want 2/3", a.SuccessRate)
+	}
+	if usage[1].SuccessRate != 0 {
+		t.Errorf("SuccessRate for alg_c = %v, want 0", usage[1].SuccessRate)
+	}
+
+	usage, err = queryAlgorithmUsage(db, now.Add(-24*time.Hour), 10)
+	if err != nil || len(usage) != 3 {
+		t.Fatalf("Expected 3 algorithms, got %v, %v", usage, err)
+	}
+	if b := usage[2]; b.AlgorithmId != "alg_b" || b.JobCount != 1 || b.SuccessRate != 0 {
+		t.Errorf("Unfinished jobs should not affect the success rate: %v", b)
+	}
+}
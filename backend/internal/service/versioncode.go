@@ -0,0 +1,166 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"algorithm-platform/internal/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// resolveJobVersion 返回任务要运行的代码版本，versionID 为空时使用算法的当前版本
+func resolveJobVersion(db *gorm.DB, algorithm *models.Algorithm, versionID string) (*models.Version, error) {
+	if versionID == "" {
+		versionID = algorithm.CurrentVersionID
+	}
+	if versionID == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "algorithm %s has no code version", algorithm.ID)
+	}
+
+	version := &models.Version{}
+	err := db.First(version, "id = ? AND algorithm_id = ?", versionID, algorithm.ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.FailedPrecondition, "version %s of algorithm %s not found", versionID, algorithm.ID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load version %s: %w", versionID, err)
+	}
+	if version.MinioPath == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "version %s has no code", version.ID)
+	}
+	return version, nil
+}
+
+// stageVersionCode 下载版本的代码到 codeDir，作为容器的工作目录挂载。
+// zip 包解压到目录中（只有一个顶层目录时去掉这一层），其他文件按上传时的文件名保存
+func (s *AlgorithmService) stageVersionCode(ctx context.Context, version *models.Version, codeDir string) error {
+	if s.minioClient == nil {
+		return fmt.Errorf("MinIO client is not available")
+	}
+	if err := os.MkdirAll(codeDir, 0755); err != nil {
+		return fmt.Errorf("failed to create code directory: %w", err)
+	}
+
+	archive := codeDir + ".download"
+	defer os.Remove(archive)
+	if _, err := s.downloadObject(ctx, s.cfg().MinIO.Bucket, version.MinioPath, archive); err != nil {
+		return fmt.Errorf("failed to download code of version %s: %w", version.ID, err)
+	}
+
+	if isZipFile(archive) {
+		if err := extractZip(archive, codeDir); err != nil {
+			return fmt.Errorf("failed to extract code of version %s: %w", version.ID, err)
+		}
+		return nil
+	}
+
+	name := filepath.Base(version.SourceCodeFile)
+	if name == "." || name == string(filepath.Separator) || name == "" {
+		name = filepath.Base(version.MinioPath)
+	}
+	if err := os.Rename(archive, filepath.Join(codeDir, name)); err != nil {
+		return fmt.Errorf("failed to stage code of version %s: %w", version.ID, err)
+	}
+	return os.Chmod(filepath.Join(codeDir, name), 0755)
+}
+
+// isZipFile 按文件头判断是否为 zip 包
+func isZipFile(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(file, magic); err != nil {
+		return false
+	}
+	return string(magic) == "PK\x03\x04"
+}
+
+// extractZip 将 zip 包解压到 dir，拒绝指向 dir 之外的条目
+func extractZip(archive, dir string) error {
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		if !filepath.IsLocal(file.Name) {
+			return fmt.Errorf("invalid path %q in archive", file.Name)
+		}
+	}
+
+	strip := zipCommonRoot(reader.File)
+	for _, file := range reader.File {
+		name := strings.TrimPrefix(file.Name, strip)
+		if name == "" {
+			continue
+		}
+
+		target := filepath.Join(dir, name)
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := extractZipFile(file, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipFile(file *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// 保留可执行权限，编译好的算法需要直接运行
+	mode := file.Mode().Perm() | 0644
+	dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// zipCommonRoot 所有条目都位于同一个顶层目录时返回 "<目录>/"，否则返回空
+func zipCommonRoot(files []*zip.File) string {
+	root := ""
+	for _, file := range files {
+		first, _, nested := strings.Cut(file.Name, "/")
+		if !nested && !file.FileInfo().IsDir() {
+			return ""
+		}
+		if root == "" {
+			root = first
+		} else if first != root {
+			return ""
+		}
+	}
+	if root == "" {
+		return ""
+	}
+	return root + "/"
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/models"
+	"algorithm-platform/internal/testutil"
+	"algorithm-platform/internal/webhook"
+)
+
+func TestPostWebhookSignsBody(t *testing.T) {
+	body := []byte(`{"job_id":"job_1","status":"completed"}`)
+	var gotSignature, gotType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ := io.ReadAll(r.Body)
+		gotType = r.Header.Get("Content-Type")
+		gotSignature = r.Header.Get(webhook.SignatureHeader)
+		if !webhook.Verify("secret", received, gotSignature) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	code, err := postWebhook(t.Context(), server.URL, body, "secret")
+	if err != nil || code != http.StatusAccepted {
+		t.Fatalf("postWebhook() = %d, %v (signature %q)", code, err, gotSignature)
+	}
+	if gotType != "application/json" {
+		t.Errorf("Content-Type = %q", gotType)
+	}
+
+	if _, err := postWebhook(t.Context(), server.URL, body, ""); err == nil {
+		t.Error("Expected unsigned request to be rejected by the receiver")
+	}
+}
+
+func TestPostWebhookReportsStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	code, err := postWebhook(t.Context(), server.URL, []byte(`{}`), "")
+	var statusErr *webhookStatusError
+	if code != http.StatusServiceUnavailable || !errors.As(err, &statusErr) {
+		t.Fatalf("postWebhook() = %d, %v", code, err)
+	}
+	if !isRetryableWebhookError(err) {
+		t.Error("503 should be retryable")
+	}
+}
+
+func TestPostWebhookHidesURLTokenInErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	addr := server.URL
+	server.Close() // 连接被拒绝，触发 *url.Error
+
+	_, err := postWebhook(t.Context(), addr+"/hook?token=s3cr3t", []byte(`{}`), "")
+	if err == nil {
+		t.Fatal("Expected an error for a closed server")
+	}
+	if strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("Webhook error leaks the token: %v", err)
+	}
+	if !isRetryableWebhookError(err) {
+		t.Error("Network errors should stay retryable")
+	}
+}
+
+func TestRecordWebhookDelivery(t *testing.T) {
+	db := testutil.NewDB(t)
+	createJob(t, db, "job_ok", models.JobStatusCompleted)
+	createJob(t, db, "job_bad", models.JobStatusCompleted)
+
+	if err := recordWebhookDelivery(db, "job_ok", http.StatusOK, nil); err != nil {
+		t.Fatalf("recordWebhookDelivery failed: %v", err)
+	}
+	if err := recordWebhookDelivery(db, "job_bad", http.StatusBadRequest, &webhookStatusError{StatusCode: http.StatusBadRequest}); err != nil {
+		t.Fatalf("recordWebhookDelivery failed: %v", err)
+	}
+
+	var ok, bad models.Job
+	db.First(&ok, "id = ?", "job_ok")
+	db.First(&bad, "id = ?", "job_bad")
+	if ok.WebhookStatus != models.WebhookStatusDelivered || ok.WebhookStatusCode != http.StatusOK || ok.WebhookError != "" {
+		t.Errorf("Unexpected delivery record: %+v", ok)
+	}
+	if bad.WebhookStatus != models.WebhookStatusFailed || bad.WebhookStatusCode != http.StatusBadRequest || bad.WebhookError == "" {
+		t.Errorf("Unexpected failure record: %+v", bad)
+	}
+}
+
+func TestSendWebhookStopsRetryingWhenContextEnds(t *testing.T) {
+	var attempts atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	s := newJobContextTestService(t)
+	createJob(t, s.db.DB(), "job_1", models.JobStatusCompleted)
+
+	// 第一次失败后等待 1s 再重试，context 在此之前结束
+	ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	s.sendWebhook(ctx, receiver.URL, "job_1", &v1.ExecuteResponse{JobId: "job_1", Status: models.JobStatusCompleted}, nil)
+
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("sendWebhook kept retrying for %v after the context ended", elapsed)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Expected a single attempt, got %d", attempts.Load())
+	}
+	var job models.Job
+	s.db.DB().First(&job, "id = ?", "job_1")
+	if job.WebhookStatus != models.WebhookStatusFailed {
+		t.Errorf("Expected the delivery to be recorded as failed, got %+v", job)
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "algorithm-platform/api/v1/proto"
+	"algorithm-platform/internal/config"
+	"algorithm-platform/internal/database"
+	"algorithm-platform/internal/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openContendedDB 打开同一个 SQLite 文件的独立连接，busy_timeout 为 0，写锁冲突时立即返回 SQLITE_BUSY
+func openContendedDB(t *testing.T, path string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(path+"?_journal_mode=WAL&_busy_timeout=0"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get sql.DB: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+func TestConcurrentWritesRetryOnBusy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contended.db")
+	locker := openContendedDB(t, path)
+	if err := locker.AutoMigrate(&models.Algorithm{}, &models.Version{}, &models.PresetData{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	// 每个服务使用自己的连接，模拟多个实例同时写同一个数据库
+	const services, writesPerService = 4, 5
+	cfg := &config.Config{}
+	var all []*ManagementService
+	for i := 0; i < services; i++ {
+		all = append(all, &ManagementService{db: database.NewWithDB(openContendedDB(t, path), cfg), cfgStore: config.NewStore(cfg)})
+	}
+
+	// 另一个连接在写入期间几次短暂持有写锁，保证写入会遇到 SQLITE_BUSY
+	lockerDone := make(chan struct{})
+	go func() {
+		defer close(lockerDone)
+		for i := 0; i < 3; i++ {
+			tx := locker.Begin()
+			tx.Exec("UPDATE algorithms SET updated_at = updated_at")
+			time.Sleep(30 * time.Millisecond)
+			tx.Commit()
+			time.Sleep(30 * time.Millisecond)
+		}
+	}()
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	errs := make(chan error, services*writesPerService*2)
+	for i, s := range all {
+		for j := 0; j < writesPerService; j++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, err := s.CreateAlgorithm(ctx, &v1.CreateAlgorithmRequest{Name: fmt.Sprintf("alg-%d-%d", i, j), Language: "python"})
+				errs <- err
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := s.UploadPresetData(ctx, &v1.UploadDataRequest{Filename: "data.csv", FileData: []byte(fmt.Sprintf("%d-%d", i, j))})
+				errs <- err
+			}()
+		}
+	}
+	wg.Wait()
+	<-lockerDone
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Concurrent write failed: %v", err)
+		}
+	}
+
+	var algorithms, presetData int64
+	locker.Model(&models.Algorithm{}).Count(&algorithms)
+	locker.Model(&models.PresetData{}).Count(&presetData)
+	if algorithms != services*writesPerService || presetData != services*writesPerService {
+		t.Errorf("Expected %d algorithms and preset data, got %d and %d", services*writesPerService, algorithms, presetData)
+	}
+}
@@ -0,0 +1,57 @@
+package startup
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"algorithm-platform/internal/retry"
+)
+
+// Dependency 服务启动前需要就绪的外部依赖
+type Dependency struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// checkTimeout 单次依赖检查的超时时间
+const checkTimeout = 5 * time.Second
+
+// BackoffPolicy 等待依赖时的重试间隔：1s 起步，最长 15s
+func BackoffPolicy() retry.Policy {
+	return retry.Policy{
+		MaxAttempts: math.MaxInt32,
+		BaseDelay:   time.Second,
+		MaxDelay:    15 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// WaitForDependencies 依次等待每个依赖检查通过，失败时按 policy 退避重试，直到 ctx 结束。
+// 返回的错误包含未就绪的依赖名称和最后一次检查的错误
+func WaitForDependencies(ctx context.Context, deps []Dependency, policy retry.Policy) error {
+	for _, dep := range deps {
+		dep := dep
+		var lastErr error
+		policy.OnRetry = func(attempt int, delay time.Duration, err error) {
+			log.Printf("Waiting for %s (attempt %d, retrying in %v): %v", dep.Name, attempt, delay.Round(time.Millisecond), err)
+		}
+
+		err := retry.Do(ctx, policy, func() error {
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+			lastErr = dep.Check(checkCtx)
+			return lastErr
+		})
+		if err != nil {
+			if lastErr != nil {
+				err = lastErr
+			}
+			return fmt.Errorf("%s is not ready: %w", dep.Name, err)
+		}
+		log.Printf("%s is ready", dep.Name)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"algorithm-platform/internal/retry"
+)
+
+func testPolicy() retry.Policy {
+	policy := BackoffPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+	return policy
+}
+
+func TestWaitForDependenciesRetriesUntilReady(t *testing.T) {
+	attempts := 0
+	var order []string
+	deps := []Dependency{
+		{Name: "database", Check: func(ctx context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("connection refused")
+			}
+			order = append(order, "database")
+			return nil
+		}},
+		{Name: "minio", Check: func(ctx context.Context) error {
+			order = append(order, "minio")
+			return nil
+		}},
+	}
+
+	if err := WaitForDependencies(context.Background(), deps, testPolicy()); err != nil {
+		t.Fatalf("WaitForDependencies failed: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Database checked %d times, want 3", attempts)
+	}
+	if strings.Join(order, ",") != "database,minio" {
+		t.Errorf("Ready order = %v", order)
+	}
+}
+
+func TestWaitForDependenciesTimesOut(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	minioChecked := false
+	deps := []Dependency{
+		{Name: "redis", Check: func(ctx context.Context) error { return errors.New("connection refused") }},
+		{Name: "minio", Check: func(ctx context.Context) error { minioChecked = true; return nil }},
+	}
+
+	err := WaitForDependencies(ctx, deps, testPolicy())
+	if err == nil || !strings.Contains(err.Error(), "redis is not ready: connection refused") {
+		t.Fatalf("err = %v, want redis not ready", err)
+	}
+	if minioChecked {
+		t.Error("Later dependencies should not be checked after a failure")
+	}
+}
@@ -0,0 +1,35 @@
+// Package testutil 提供各包测试共用的内存数据库和内存 S3 服务，只在测试中使用
+package testutil
+
+import (
+	"testing"
+
+	"algorithm-platform/internal/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// OpenDB 打开一个空的内存 SQLite 数据库，queryLogger 为 nil 时不输出日志
+func OpenDB(t testing.TB, queryLogger logger.Interface) *gorm.DB {
+	t.Helper()
+	if queryLogger == nil {
+		queryLogger = logger.Default.LogMode(logger.Silent)
+	}
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: queryLogger})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	return db
+}
+
+// NewDB 打开内存 SQLite 数据库并创建所有表
+func NewDB(t testing.TB) *gorm.DB {
+	t.Helper()
+	db := OpenDB(t, nil)
+	if err := models.AutoMigrate(db); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	return db
+}
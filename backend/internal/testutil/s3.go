@@ -0,0 +1,329 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3 内存中的 S3 服务，支持上传（包括分片上传）、列出、读取、HEAD 和删除，
+// 上传时的内容类型和用户元数据在读取时原样返回。路径为 /<bucket>/<key>，不区分 bucket
+type S3 struct {
+	mu        sync.Mutex
+	objects   map[string][]byte
+	metadata  map[string]http.Header // 上传时的 Content-Type 和 X-Amz-Meta-* 请求头
+	uploads   map[string]map[int][]byte
+	partSizes []int
+	gets      int // 读取对象内容的次数
+	started   chan struct{}
+	release   chan struct{}
+
+	noBucket bool // bucket 尚未创建，HEAD bucket 返回 404，客户端创建后存在
+	failPuts bool // 对象上传返回 AccessDenied
+}
+
+// NewS3 启动内存 S3 服务，返回连接它的 MinIO 客户端
+func NewS3(t testing.TB) (*S3, *minio.Client) {
+	t.Helper()
+	s := &S3{objects: make(map[string][]byte), metadata: make(map[string]http.Header), uploads: make(map[string]map[int][]byte)}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	endpoint, _ := url.Parse(server.URL)
+	client, err := minio.New(endpoint.Host, &minio.Options{
+		Creds:      credentials.NewStaticV4("key", "secret", ""),
+		Region:     "us-east-1",
+		MaxRetries: 1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create MinIO client: %v", err)
+	}
+	return s, client
+}
+
+// BlockPuts 让之后的上传在写入前阻塞，直到调用 release；每次上传开始时向 started 发送一次（不会阻塞发送方）
+func (s *S3) BlockPuts() (started <-chan struct{}, release func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started = make(chan struct{}, 1)
+	s.release = make(chan struct{})
+	var once sync.Once
+	return s.started, func() { once.Do(func() { close(s.release) }) }
+}
+
+// RemoveBucket 让 bucket 不存在，直到客户端创建
+func (s *S3) RemoveBucket() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.noBucket = true
+}
+
+// BucketExists 返回 bucket 是否存在
+func (s *S3) BucketExists() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.noBucket
+}
+
+// FailPuts 让之后的对象上传返回 AccessDenied
+func (s *S3) FailPuts() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failPuts = true
+}
+
+// Put 直接写入对象
+func (s *S3) Put(key string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = data
+}
+
+// Object 返回对象内容
+func (s *S3) Object(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.objects[key]
+	return data, ok
+}
+
+// Keys 返回所有对象的路径，按字典序排列
+func (s *S3) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ObjectGets 返回读取对象内容的次数
+func (s *S3) ObjectGets() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gets
+}
+
+// PartSizes 返回分片上传的各片大小
+func (s *S3) PartSizes() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int(nil), s.partSizes...)
+}
+
+func (s *S3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+
+	switch {
+	case query.Has("location"):
+		// 客户端未指定 region 时会先查询 bucket 所在区域
+		io.WriteString(w, `<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`)
+	case key == "":
+		s.serveBucket(w, r)
+	case (r.Method == http.MethodPut || r.Method == http.MethodPost && query.Has("uploads")) && s.putsFail():
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`)
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		s.mu.Lock()
+		uploadID := fmt.Sprintf("upload-%d", len(s.uploads)+1)
+		s.uploads[uploadID] = make(map[int][]byte)
+		s.metadata[key] = userMetadata(r)
+		s.mu.Unlock()
+		fmt.Fprintf(w, `<InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>`, key, uploadID)
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		part, _ := strconv.Atoi(query.Get("partNumber"))
+		data := readBody(r)
+		s.mu.Lock()
+		s.uploads[query.Get("uploadId")][part] = data
+		s.partSizes = append(s.partSizes, len(data))
+		s.mu.Unlock()
+		w.Header().Set("ETag", fmt.Sprintf(`"part-%d"`, part))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		s.mu.Lock()
+		parts := s.uploads[query.Get("uploadId")]
+		numbers := make([]int, 0, len(parts))
+		for number := range parts {
+			numbers = append(numbers, number)
+		}
+		sort.Ints(numbers)
+		var data []byte
+		for _, number := range numbers {
+			data = append(data, parts[number]...)
+		}
+		s.objects[key] = data
+		s.mu.Unlock()
+		fmt.Fprintf(w, `<CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>%s</Key><ETag>"complete"</ETag></CompleteMultipartUploadResult>`, key)
+	case r.Method == http.MethodPut:
+		data := readBody(r)
+		s.waitForRelease()
+		s.mu.Lock()
+		s.objects[key] = data
+		s.metadata[key] = userMetadata(r)
+		s.mu.Unlock()
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodDelete:
+		s.mu.Lock()
+		delete(s.objects, key)
+		delete(s.metadata, key)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.serveObject(w, r, key)
+	}
+}
+
+// serveBucket 响应 bucket 的 HEAD（是否存在）、PUT（创建）、GET（列出对象）和 POST ?delete（批量删除）
+func (s *S3) serveBucket(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && r.URL.Query().Has("delete") {
+		s.deleteObjects(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	missing := s.noBucket
+	if r.Method == http.MethodPut {
+		s.noBucket = false
+	}
+	s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet:
+		s.list(w, r.URL.Query().Get("prefix"))
+	case r.Method == http.MethodHead && missing:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// deleteObjects 删除请求中列出的对象，不存在的对象也按删除成功处理
+func (s *S3) deleteObjects(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Objects []struct{ Key string } `xml:"Object"`
+	}
+	xml.NewDecoder(bytes.NewReader(readBody(r))).Decode(&req)
+
+	s.mu.Lock()
+	for _, object := range req.Objects {
+		delete(s.objects, object.Key)
+		delete(s.metadata, object.Key)
+	}
+	s.mu.Unlock()
+	io.WriteString(w, `<DeleteResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></DeleteResult>`)
+}
+
+func (s *S3) putsFail() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failPuts
+}
+
+// waitForRelease BlockPuts 之后的上传在这里等待
+func (s *S3) waitForRelease() {
+	s.mu.Lock()
+	started, release := s.started, s.release
+	s.mu.Unlock()
+	if started == nil {
+		return
+	}
+	select {
+	case started <- struct{}{}:
+	default:
+	}
+	<-release
+}
+
+// serveObject 响应对象的 HEAD 和 GET 请求，未上传的对象返回 NoSuchKey
+func (s *S3) serveObject(w http.ResponseWriter, r *http.Request, key string) {
+	s.mu.Lock()
+	data, ok := s.objects[key]
+	for name, values := range s.metadata[key] {
+		w.Header()[name] = values
+	}
+	if ok && r.Method == http.MethodGet {
+		s.gets++
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusNotFound)
+		if r.Method == http.MethodGet {
+			xml.NewEncoder(w).Encode(struct {
+				XMLName xml.Name `xml:"Error"`
+				Code    string
+				Message string
+			}{Code: "NoSuchKey", Message: "The specified key does not exist."})
+		}
+		return
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	w.Header().Set("ETag", `"etag"`)
+	if r.Method == http.MethodGet {
+		w.Write(data)
+	}
+}
+
+// list 返回 prefix 下的对象列表（ListObjectsV2）
+func (s *S3) list(w http.ResponseWriter, prefix string) {
+	var contents strings.Builder
+	for _, key := range s.Keys() {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		data, _ := s.Object(key)
+		fmt.Fprintf(&contents, "<Contents><Key>%s</Key><Size>%d</Size><LastModified>%s</LastModified><ETag>\"etag\"</ETag></Contents>",
+			key, len(data), time.Now().UTC().Format(time.RFC3339))
+	}
+	fmt.Fprintf(w, `<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>bucket</Name><Prefix>%s</Prefix><IsTruncated>false</IsTruncated>%s</ListBucketResult>`,
+		prefix, contents.String())
+}
+
+func userMetadata(r *http.Request) http.Header {
+	meta := http.Header{}
+	for name, values := range r.Header {
+		if name == "Content-Type" || strings.HasPrefix(name, "X-Amz-Meta-") {
+			meta[name] = values
+		}
+	}
+	return meta
+}
+
+// readBody 读取上传内容，解析 aws-chunked 编码（"<size>;chunk-signature=...\r\n<data>\r\n"）
+func readBody(r *http.Request) []byte {
+	body, _ := io.ReadAll(r.Body)
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return body
+	}
+	var out []byte
+	for len(body) > 0 {
+		header, rest, ok := bytes.Cut(body, []byte("\r\n"))
+		if !ok {
+			break
+		}
+		sizeHex, _, _ := bytes.Cut(header, []byte(";"))
+		size, err := strconv.ParseInt(string(sizeHex), 16, 64)
+		if err != nil || size == 0 || int64(len(rest)) < size {
+			break
+		}
+		out = append(out, rest[:size]...)
+		body = bytes.TrimPrefix(rest[size:], []byte("\r\n"))
+	}
+	return out
+}
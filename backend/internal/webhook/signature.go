@@ -0,0 +1,22 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SignatureHeader 携带请求体签名的请求头，接收方用相同的密钥重新计算并比较
+const SignatureHeader = "X-Webhook-Signature"
+
+// Sign 返回请求体的 HMAC-SHA256 签名，格式为 "sha256=<hex>"
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 校验签名，比较耗时与内容无关
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
@@ -0,0 +1,26 @@
+package webhook
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	// echo -n '{"job_id":"job_1"}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=1e4055322c8f1cbe5bf23cdfe379c022b3188588ebafb2710e8c2e5de8fcd914"
+	if got := Sign("secret", []byte(`{"job_id":"job_1"}`)); got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"status":"completed"}`)
+	signature := Sign("secret", body)
+
+	if !Verify("secret", body, signature) {
+		t.Error("Expected signature to verify")
+	}
+	if Verify("other", body, signature) {
+		t.Error("Signature with a different secret should not verify")
+	}
+	if Verify("secret", []byte(`{"status":"failed"}`), signature) {
+		t.Error("Signature of a different body should not verify")
+	}
+}
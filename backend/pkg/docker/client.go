@@ -2,9 +2,12 @@ package docker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 
+	"github.com/distribution/reference"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
@@ -28,6 +31,7 @@ func New(host string) (*Client, error) {
 
 type ContainerConfig struct {
 	Image      string
+	Entrypoint []string
 	Cmd        []string
 	Env        []string
 	WorkingDir string
@@ -45,6 +49,9 @@ type Mount struct {
 	ReadOnly bool
 }
 
+// ErrImageNotFound 创建容器时本地没有该镜像
+var ErrImageNotFound = errors.New("image not found")
+
 func (c *Client) CreateContainer(ctx context.Context, name string, cfg ContainerConfig) (string, error) {
 	hostConfig := &container.HostConfig{
 		Mounts: make([]mount.Mount, len(cfg.Mounts)),
@@ -69,6 +76,7 @@ func (c *Client) CreateContainer(ctx context.Context, name string, cfg Container
 
 	resp, err := c.cli.ContainerCreate(ctx, &container.Config{
 		Image:      cfg.Image,
+		Entrypoint: cfg.Entrypoint,
 		Cmd:        cfg.Cmd,
 		Env:        cfg.Env,
 		WorkingDir: cfg.WorkingDir,
@@ -76,6 +84,10 @@ func (c *Client) CreateContainer(ctx context.Context, name string, cfg Container
 		Tty:        false,
 	}, hostConfig, nil, nil, name)
 	if err != nil {
+		// 创建容器只在镜像不存在时返回 404
+		if client.IsErrNotFound(err) {
+			return "", fmt.Errorf("%w: %s: %v", ErrImageNotFound, cfg.Image, err)
+		}
 		return "", err
 	}
 
@@ -116,7 +128,7 @@ func (c *Client) ListContainers(ctx context.Context, filterLabels map[string][]s
 		}
 	}
 
-	return c.cli.ContainerList(ctx, container.ListOptions{Filters: f})
+	return c.cli.ContainerList(ctx, container.ListOptions{All: true, Filters: f})
 }
 
 func (c *Client) PullImage(ctx context.Context, imageRef string) error {
@@ -130,6 +142,62 @@ func (c *Client) PullImage(ctx context.Context, imageRef string) error {
 	return err
 }
 
+func (c *Client) ImageExists(ctx context.Context, imageRef string) (bool, error) {
+	if _, err := c.cli.ImageInspect(ctx, imageRef); err != nil {
+		if client.IsErrNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ImageDigest 返回本地镜像在同一仓库下的内容摘要引用（repo@sha256:...），用于按摘要固定镜像，已经是摘要引用时原样返回。
+// 镜像没有该仓库的摘要时返回错误：其他仓库的摘要不一定能从配置的仓库拉取，本地镜像 ID 在其他主机上不存在
+func (c *Client) ImageDigest(ctx context.Context, imageRef string) (string, error) {
+	if strings.Contains(imageRef, "@") {
+		return imageRef, nil
+	}
+	inspect, err := c.cli.ImageInspect(ctx, imageRef)
+	if err != nil {
+		return "", err
+	}
+	digest, ok := pickRepoDigest(imageRef, inspect.RepoDigests)
+	if !ok {
+		return "", fmt.Errorf("image %s has no repository digest for its own repository", imageRef)
+	}
+	return digest, nil
+}
+
+// pickRepoDigest 选择与镜像引用同一仓库的摘要，一个镜像可能以多个仓库名推送过
+func pickRepoDigest(imageRef string, repoDigests []string) (string, bool) {
+	for _, digest := range repoDigests {
+		if SameRepository(imageRef, digest) {
+			return digest, true
+		}
+	}
+	return "", false
+}
+
+// SameRepository 两个镜像引用是否属于同一仓库，仓库名按 Docker 的规则规范化后比较（python 与 docker.io/library/python 相同）
+func SameRepository(a, b string) bool {
+	namedA, err := reference.ParseNormalizedNamed(a)
+	if err != nil {
+		return false
+	}
+	namedB, err := reference.ParseNormalizedNamed(b)
+	return err == nil && namedA.Name() == namedB.Name()
+}
+
+// ServerVersion 返回 Docker 守护进程的版本和 API 版本，用于检查守护进程是否可达
+func (c *Client) ServerVersion(ctx context.Context) (string, string, error) {
+	version, err := c.cli.ServerVersion(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	return version.Version, version.APIVersion, nil
+}
+
 func (c *Client) WaitContainer(ctx context.Context, id string) (int64, error) {
 	statusCh, errCh := c.cli.ContainerWait(ctx, id, container.WaitConditionNotRunning)
 
@@ -0,0 +1,34 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+)
+
+// repoDigest 构造仓库摘要引用，摘要为 64 个 c
+func repoDigest(repo, c string) string {
+	return repo + "@sha256:" + strings.Repeat(c, 64)
+}
+
+func TestPickRepoDigest(t *testing.T) {
+	tests := []struct {
+		name        string
+		imageRef    string
+		repoDigests []string
+		want        string
+	}{
+		{"same repository", "python:3.11", []string{repoDigest("mirror/python", "a"), repoDigest("python", "b")}, repoDigest("python", "b")},
+		{"registry with port", "registry:5000/algo/python:3.11", []string{repoDigest("registry:5000/algo/python", "c")}, repoDigest("registry:5000/algo/python", "c")},
+		{"normalized name", "docker.io/library/python:3.11", []string{repoDigest("python", "d")}, repoDigest("python", "d")},
+		{"other repository", "python:3.11", []string{repoDigest("mirror/python", "a")}, ""},
+		{"local image", "python:3.11", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := pickRepoDigest(tt.imageRef, tt.repoDigests)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("pickRepoDigest() = %q, %v, want %q", got, ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// StreamContainerLogs 返回容器 stdout 和 stderr 合并后的日志流，每次写出的都是完整的行。
+// follow 为 true 时持续读取直到容器退出；timestamps 为 true 时每行以 Docker 记录的时间戳开头。
+// ctx 取消或调用 Close 时底层连接会被关闭
+func (c *Client) StreamContainerLogs(ctx context.Context, id string, follow, timestamps bool) (io.ReadCloser, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	raw, err := c.cli.ContainerLogs(ctx, id, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     follow,
+		Timestamps: timestamps,
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return newLogStream(ctx, cancel, raw), nil
+}
+
+// logStream 在后台拆分 Docker 的多路复用日志流，通过管道输出完整的行
+type logStream struct {
+	pr     *io.PipeReader
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newLogStream(ctx context.Context, cancel context.CancelFunc, raw io.ReadCloser) *logStream {
+	pr, pw := io.Pipe()
+	s := &logStream{pr: pr, cancel: cancel, done: make(chan struct{})}
+
+	// ctx 取消后关闭连接，使阻塞中的读取立即返回
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-s.done:
+		}
+		raw.Close()
+	}()
+
+	go func() {
+		defer close(s.done)
+		err := demuxLines(pw, raw)
+		if ctx.Err() != nil {
+			// 连接是被主动关闭的，返回取消原因而不是读取错误
+			err = ctx.Err()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return s
+}
+
+func (s *logStream) Read(p []byte) (int, error) {
+	return s.pr.Read(p)
+}
+
+func (s *logStream) Close() error {
+	s.cancel()
+	err := s.pr.Close()
+	<-s.done
+	return err
+}
+
+// demuxLines 将 Docker 多路复用的日志流拆分为 stdout 和 stderr，按行写入 dst。
+// 两个流的内容各自缓冲到换行再写出，避免交错产生半行；流结束时补齐最后一行的换行
+func demuxLines(dst io.Writer, src io.Reader) error {
+	var mu sync.Mutex
+	stdout := &lineWriter{dst: dst, mu: &mu}
+	stderr := &lineWriter{dst: dst, mu: &mu}
+
+	_, err := stdcopy.StdCopy(stdout, stderr, src)
+	if flushErr := stdout.flush(); err == nil {
+		err = flushErr
+	}
+	if flushErr := stderr.flush(); err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// lineWriter 缓冲不完整的行，只向 dst 写出以换行结尾的内容
+type lineWriter struct {
+	dst io.Writer
+	mu  *sync.Mutex
+	buf []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	end := bytes.LastIndexByte(w.buf, '\n')
+	if end < 0 {
+		return len(p), nil
+	}
+
+	w.mu.Lock()
+	_, err := w.dst.Write(w.buf[:end+1])
+	w.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	w.buf = w.buf[:copy(w.buf, w.buf[end+1:])]
+	return len(p), nil
+}
+
+func (w *lineWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := w.dst.Write(append(w.buf, '\n'))
+	w.buf = nil
+	return err
+}
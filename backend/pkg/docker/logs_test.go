@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+func TestDemuxLines(t *testing.T) {
+	var raw bytes.Buffer
+	stdout := stdcopy.NewStdWriter(&raw, stdcopy.Stdout)
+	stderr := stdcopy.NewStdWriter(&raw, stdcopy.Stderr)
+	stdout.Write([]byte("loading "))
+	stderr.Write([]byte("warning: slow\n"))
+	stdout.Write([]byte("data\nstep 1\nstep"))
+	stdout.Write([]byte(" 2"))
+
+	var out bytes.Buffer
+	if err := demuxLines(&out, &raw); err != nil {
+		t.Fatalf("demuxLines failed: %v", err)
+	}
+
+	want := "warning: slow\nloading data\nstep 1\nstep 2\n"
+	if out.String() != want {
+		t.Errorf("Got %q, want %q", out.String(), want)
+	}
+}
+
+func TestLogStreamStopsOnCancel(t *testing.T) {
+	rawReader, rawWriter := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := newLogStream(ctx, cancel, rawReader)
+	defer stream.Close()
+
+	go stdcopy.NewStdWriter(rawWriter, stdcopy.Stdout).Write([]byte("started\n"))
+
+	buf := make([]byte, 64)
+	n, err := stream.Read(buf)
+	if err != nil || string(buf[:n]) != "started\n" {
+		t.Fatalf("Read() = %q, %v", buf[:n], err)
+	}
+
+	cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.ReadAll(stream)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Stream did not stop after cancel")
+	}
+	if _, err := rawWriter.Write([]byte("late")); err == nil || !strings.Contains(err.Error(), "closed") {
+		t.Errorf("Expected underlying reader to be closed, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// DeleteBatchSize 每次批量删除提交的最大对象数，与 S3 DeleteObjects 单次请求上限一致
+const DeleteBatchSize = 1000
+
+// ObjectRemover 批量删除对象的接口，*minio.Client 实现了该接口
+type ObjectRemover interface {
+	RemoveObjects(ctx context.Context, bucketName string, objectsCh <-chan minio.ObjectInfo, opts minio.RemoveObjectsOptions) <-chan minio.RemoveObjectError
+}
+
+// RemoveObjects 按批次删除对象，返回删除失败的对象及其错误，全部成功时返回空 map
+func RemoveObjects(ctx context.Context, remover ObjectRemover, bucketName string, keys []string) map[string]error {
+	failed := make(map[string]error)
+	for start := 0; start < len(keys); start += DeleteBatchSize {
+		end := min(start+DeleteBatchSize, len(keys))
+
+		objectsCh := make(chan minio.ObjectInfo)
+		go func(batch []string) {
+			defer close(objectsCh)
+			for _, key := range batch {
+				select {
+				case objectsCh <- minio.ObjectInfo{Key: key}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(keys[start:end])
+
+		for removeErr := range remover.RemoveObjects(ctx, bucketName, objectsCh, minio.RemoveObjectsOptions{}) {
+			failed[removeErr.ObjectName] = removeErr.Err
+		}
+	}
+	return failed
+}
+
+func (m *MinIO) DeleteFiles(ctx context.Context, bucketName string, objectNames []string) map[string]error {
+	return RemoveObjects(ctx, m.client, bucketName, objectNames)
+}
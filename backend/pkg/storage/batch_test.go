@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// fakeRemover 记录每次批量删除收到的对象，并对 failKeys 中的对象返回错误
+type fakeRemover struct {
+	batches  [][]string
+	failKeys map[string]bool
+}
+
+func (f *fakeRemover) RemoveObjects(ctx context.Context, bucketName string, objectsCh <-chan minio.ObjectInfo, opts minio.RemoveObjectsOptions) <-chan minio.RemoveObjectError {
+	errCh := make(chan minio.RemoveObjectError)
+	go func() {
+		defer close(errCh)
+		var batch []string
+		for object := range objectsCh {
+			batch = append(batch, object.Key)
+		}
+		f.batches = append(f.batches, batch)
+		for _, key := range batch {
+			if f.failKeys[key] {
+				errCh <- minio.RemoveObjectError{ObjectName: key, Err: errors.New("access denied")}
+			}
+		}
+	}()
+	return errCh
+}
+
+func TestRemoveObjectsBatches(t *testing.T) {
+	keys := make([]string, 2*DeleteBatchSize+1)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("results/job_%d/output.json", i)
+	}
+	remover := &fakeRemover{failKeys: map[string]bool{keys[3]: true, keys[DeleteBatchSize+7]: true}}
+
+	failed := RemoveObjects(context.Background(), remover, "algorithms", keys)
+
+	if len(remover.batches) != 3 {
+		t.Fatalf("batched delete calls = %d, want 3", len(remover.batches))
+	}
+	if len(remover.batches[0]) != DeleteBatchSize || len(remover.batches[2]) != 1 {
+		t.Errorf("batch sizes = %d, %d, %d", len(remover.batches[0]), len(remover.batches[1]), len(remover.batches[2]))
+	}
+	if len(failed) != 2 || failed[keys[3]] == nil || failed[keys[DeleteBatchSize+7]] == nil {
+		t.Errorf("failed = %v, want errors for the two denied keys", failed)
+	}
+}
+
+func TestRemoveObjectsEmpty(t *testing.T) {
+	remover := &fakeRemover{}
+	if failed := RemoveObjects(context.Background(), remover, "algorithms", nil); len(failed) != 0 {
+		t.Errorf("failed = %v, want none", failed)
+	}
+	if len(remover.batches) != 0 {
+		t.Errorf("batched delete calls = %d, want 0", len(remover.batches))
+	}
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// BucketClient 创建 bucket 所需的操作，由 *minio.Client 实现
+type BucketClient interface {
+	BucketExists(ctx context.Context, bucketName string) (bool, error)
+	MakeBucket(ctx context.Context, bucketName string, opts minio.MakeBucketOptions) error
+}
+
+// EnsureBucket bucket 不存在时创建，返回是否新建。其他实例同时创建时视为已存在
+func EnsureBucket(ctx context.Context, client BucketClient, bucketName string) (bool, error) {
+	exists, err := client.BucketExists(ctx, bucketName)
+	if err != nil {
+		return false, fmt.Errorf("failed to check bucket %s: %w", bucketName, err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	if err := client.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{}); err != nil {
+		switch minio.ToErrorResponse(err).Code {
+		case "BucketAlreadyOwnedByYou", "BucketAlreadyExists":
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create bucket %s: %w", bucketName, err)
+	}
+	return true, nil
+}
+
+// StorageCheck 存储自检中一个步骤的结果
+type StorageCheck struct {
+	Name     string
+	OK       bool
+	Message  string
+	Duration time.Duration
+}
+
+// 存储自检的步骤
+const (
+	CheckBucket = "bucket"
+	CheckWrite  = "write"
+	CheckRead   = "read"
+	CheckDelete = "delete"
+)
+
+// VerifyStorage 确保 bucket 存在，再写入、读回并删除探测对象 probeKey，返回每一步的结果。
+// 某一步失败后后续步骤不再执行，但写入成功后总会尝试删除探测对象
+func VerifyStorage(ctx context.Context, client *minio.Client, bucketName, probeKey string) []StorageCheck {
+	var checks []StorageCheck
+	run := func(name string, fn func() (string, error)) bool {
+		start := time.Now()
+		message, err := fn()
+		check := StorageCheck{Name: name, OK: err == nil, Message: message, Duration: time.Since(start)}
+		if err != nil {
+			check.Message = err.Error()
+		}
+		checks = append(checks, check)
+		return err == nil
+	}
+
+	if !run(CheckBucket, func() (string, error) {
+		created, err := EnsureBucket(ctx, client, bucketName)
+		if created {
+			return fmt.Sprintf("created bucket %s", bucketName), err
+		}
+		return fmt.Sprintf("bucket %s exists", bucketName), err
+	}) {
+		return checks
+	}
+
+	payload := []byte(fmt.Sprintf("storage check %s", time.Now().Format(time.RFC3339Nano)))
+	if !run(CheckWrite, func() (string, error) {
+		_, err := client.PutObject(ctx, bucketName, probeKey, bytes.NewReader(payload), int64(len(payload)), minio.PutObjectOptions{
+			ContentType: "text/plain",
+		})
+		return fmt.Sprintf("wrote %s", probeKey), err
+	}) {
+		return checks
+	}
+
+	run(CheckRead, func() (string, error) {
+		object, err := client.GetObject(ctx, bucketName, probeKey, minio.GetObjectOptions{})
+		if err != nil {
+			return "", err
+		}
+		defer object.Close()
+
+		data, err := io.ReadAll(object)
+		if err != nil {
+			return "", err
+		}
+		if !bytes.Equal(data, payload) {
+			return "", fmt.Errorf("read back %d bytes that differ from the %d bytes written", len(data), len(payload))
+		}
+		return fmt.Sprintf("read back %d bytes", len(data)), nil
+	})
+
+	run(CheckDelete, func() (string, error) {
+		return fmt.Sprintf("deleted %s", probeKey), client.RemoveObject(ctx, bucketName, probeKey, minio.RemoveObjectOptions{})
+	})
+	return checks
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"algorithm-platform/internal/testutil"
+
+	"github.com/minio/minio-go/v7"
+)
+
+type fakeBucketClient struct {
+	exists  bool
+	makeErr error
+	made    int
+}
+
+func (f *fakeBucketClient) BucketExists(ctx context.Context, bucketName string) (bool, error) {
+	return f.exists, nil
+}
+
+func (f *fakeBucketClient) MakeBucket(ctx context.Context, bucketName string, opts minio.MakeBucketOptions) error {
+	f.made++
+	return f.makeErr
+}
+
+func TestEnsureBucket(t *testing.T) {
+	tests := []struct {
+		name        string
+		client      *fakeBucketClient
+		wantCreated bool
+		wantErr     bool
+	}{
+		{"exists", &fakeBucketClient{exists: true}, false, false},
+		{"created", &fakeBucketClient{}, true, false},
+		{"created concurrently", &fakeBucketClient{makeErr: minio.ErrorResponse{Code: "BucketAlreadyOwnedByYou"}}, false, false},
+		{"create fails", &fakeBucketClient{makeErr: errors.New("access denied")}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created, err := EnsureBucket(context.Background(), tt.client, "bucket")
+			if created != tt.wantCreated || (err != nil) != tt.wantErr {
+				t.Errorf("EnsureBucket() = %v, %v", created, err)
+			}
+			if tt.client.exists && tt.client.made != 0 {
+				t.Error("MakeBucket called for an existing bucket")
+			}
+		})
+	}
+}
+
+func checkNames(checks []StorageCheck) []string {
+	names := make([]string, len(checks))
+	for i, check := range checks {
+		names[i] = check.Name
+		if !check.OK {
+			names[i] += "!"
+		}
+	}
+	return names
+}
+
+func TestVerifyStorageCreatesBucket(t *testing.T) {
+	s3, client := testutil.NewS3(t)
+	s3.RemoveBucket()
+
+	checks := VerifyStorage(context.Background(), client, "bucket", "storage-check/probe")
+	if got := strings.Join(checkNames(checks), ","); got != "bucket,write,read,delete" {
+		t.Fatalf("Checks = %s, messages %+v", got, checks)
+	}
+	if !strings.Contains(checks[0].Message, "created") {
+		t.Errorf("Bucket step message = %q", checks[0].Message)
+	}
+	if !s3.BucketExists() || len(s3.Keys()) != 0 {
+		t.Errorf("Bucket exists %v, leftover objects %v", s3.BucketExists(), s3.Keys())
+	}
+}
+
+func TestVerifyStorageStopsAfterFailedWrite(t *testing.T) {
+	s3, client := testutil.NewS3(t)
+	s3.FailPuts()
+
+	checks := VerifyStorage(context.Background(), client, "bucket", "storage-check/probe")
+	if got := strings.Join(checkNames(checks), ","); got != "bucket,write!" {
+		t.Errorf("Checks = %s", got)
+	}
+}
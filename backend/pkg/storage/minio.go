@@ -86,12 +86,6 @@ type FileInfo struct {
 }
 
 func (m *MinIO) CreateBucket(ctx context.Context, bucketName string) error {
-	exists, err := m.client.BucketExists(ctx, bucketName)
-	if err != nil {
-		return err
-	}
-	if !exists {
-		return m.client.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{})
-	}
-	return nil
+	_, err := EnsureBucket(ctx, m.client, bucketName)
+	return err
 }
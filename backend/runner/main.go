@@ -1,14 +1,22 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"algorithm-platform/internal/retry"
+	"algorithm-platform/internal/webhook"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
@@ -88,22 +96,68 @@ func main() {
 	}
 }
 
-func downloadFile(client *minio.Client, url, destPath string) error {
-	bucket, object := getBucketAndObject(url)
-	reader, err := client.GetObject(context.Background(), bucket, object, minio.GetObjectOptions{})
-	if err != nil {
-		return err
+// retryPolicy 输入输出传输和 webhook 的重试策略
+func retryPolicy(action string) retry.Policy {
+	policy := retry.Default()
+	policy.MaxAttempts = 5
+	policy.BaseDelay = 500 * time.Millisecond
+	policy.MaxDelay = 10 * time.Second
+	policy.Retryable = isRetryable
+	policy.OnRetry = func(attempt int, delay time.Duration, err error) {
+		log.Printf("%s failed (attempt %d), retrying in %v: %v", action, attempt, delay.Round(time.Millisecond), err)
 	}
-	defer reader.Close()
+	return policy
+}
 
-	file, err := os.Create(destPath)
-	if err != nil {
-		return err
+// permanentMinIOErrors 重试也不会成功的 MinIO 错误码
+var permanentMinIOErrors = map[string]bool{
+	"NoSuchKey":    true,
+	"NoSuchBucket": true,
+	"AccessDenied": true,
+}
+
+// webhookStatusError webhook 返回了非 2xx 状态码
+type webhookStatusError struct {
+	code int
+}
+
+func (e *webhookStatusError) Error() string {
+	return fmt.Sprintf("webhook returned status %d", e.code)
+}
+
+// isRetryable 对象或桶不存在、无权访问，以及 webhook 的 4xx 响应（408、429 除外）直接失败，不再重试
+func isRetryable(err error) bool {
+	var statusErr *webhookStatusError
+	if errors.As(err, &statusErr) {
+		code := statusErr.code
+		return code < 400 || code >= 500 || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+	}
+	var minioErr minio.ErrorResponse
+	if errors.As(err, &minioErr) {
+		return !permanentMinIOErrors[minioErr.Code]
 	}
-	defer file.Close()
+	return true
+}
+
+func downloadFile(client *minio.Client, url, destPath string) error {
+	bucket, object := getBucketAndObject(url)
+
+	return retry.Do(context.Background(), retryPolicy("Download"), func() error {
+		reader, err := client.GetObject(context.Background(), bucket, object, minio.GetObjectOptions{})
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		file, err := os.Create(destPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
 
-	_, err = io.Copy(file, reader)
-	return err
+		_, err = io.Copy(file, reader)
+		return err
+	})
 }
 
 func uploadFile(client *minio.Client, url string, file *os.File) error {
@@ -113,14 +167,57 @@ func uploadFile(client *minio.Client, url string, file *os.File) error {
 	}
 
 	bucket, object := getBucketAndObject(url)
-	_, err = client.PutObject(context.Background(), bucket, object, file, stat.Size(), minio.PutObjectOptions{
-		ContentType: "application/octet-stream",
+	return retry.Do(context.Background(), retryPolicy("Upload"), func() error {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		_, err := client.PutObject(context.Background(), bucket, object, file, stat.Size(), minio.PutObjectOptions{
+			ContentType: "application/octet-stream",
+		})
+		return err
 	})
-	return err
 }
 
 func sendWebhook(url, status, resultURL string) {
 	log.Printf("Sending webhook to %s: status=%s, result=%s", url, status, resultURL)
+
+	body, _ := json.Marshal(map[string]string{
+		"status":     status,
+		"result_url": resultURL,
+	})
+
+	// 与平台使用相同的签名方式，密钥通过 WEBHOOK_SECRET 传入
+	secret := os.Getenv("WEBHOOK_SECRET")
+
+	policy := retryPolicy("Webhook")
+	policy.MaxAttempts = 3
+	err := retry.Do(context.Background(), policy, func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if secret != "" {
+			req.Header.Set(webhook.SignatureHeader, webhook.Sign(secret, body))
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 300 {
+			return &webhookStatusError{code: resp.StatusCode}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to send webhook: %v", err)
+	}
 }
 
 func getBucketAndObject(url string) (string, string) {
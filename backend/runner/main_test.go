@@ -0,0 +1,35 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network error", errors.New("connection refused"), true},
+		{"missing object", minio.ErrorResponse{Code: "NoSuchKey", StatusCode: http.StatusNotFound}, false},
+		{"missing bucket", minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound}, false},
+		{"access denied", fmt.Errorf("get: %w", minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden}), false},
+		{"slow down", minio.ErrorResponse{Code: "SlowDown", StatusCode: http.StatusServiceUnavailable}, true},
+		{"webhook 404", &webhookStatusError{code: http.StatusNotFound}, false},
+		{"webhook 400", &webhookStatusError{code: http.StatusBadRequest}, false},
+		{"webhook 408", &webhookStatusError{code: http.StatusRequestTimeout}, true},
+		{"webhook 429", &webhookStatusError{code: http.StatusTooManyRequests}, true},
+		{"webhook 502", &webhookStatusError{code: http.StatusBadGateway}, true},
+	}
+
+	for _, tt := range tests {
+		if got := isRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: isRetryable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}